@@ -1,7 +1,12 @@
 package main
 
 import (
+	"flag"
+	"fmt"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/cors"
@@ -9,11 +14,14 @@ import (
 	"github.com/gofiber/fiber/v2/middleware/recover"
 
 	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
 	"github.com/redhat-data-and-ai/naysayer/internal/logging"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules"
 	"github.com/redhat-data-and-ai/naysayer/internal/webhook"
 )
 
-func setupRoutes(app *fiber.App, cfg *config.Config) {
+// setupRoutes registers every route and returns the work to finish before the process exits
+func setupRoutes(app *fiber.App, cfg *config.Config) []drainFunc {
 	// Core middleware
 	app.Use(recover.New())
 	app.Use(logger.New(logger.Config{
@@ -26,22 +34,116 @@ func setupRoutes(app *fiber.App, cfg *config.Config) {
 	healthHandler := webhook.NewHealthHandler(cfg)
 	autoRebaseHandler := webhook.NewAutoRebaseHandler(cfg)
 	staleMRCleanupHandler := webhook.NewStaleMRCleanupHandler(cfg)
+	noteCommandHandler := webhook.NewNoteCommandHandler(cfg)
+	namedReviewHandlers := newNamedReviewHandlers(cfg)
+	rulesReloadHandler := webhook.NewRulesReloadHandler(rulesReloaders(dataProductConfigMrReviewHandler, noteCommandHandler, namedReviewHandlers)...)
+	rulesConfigHandler := webhook.NewRulesConfigHandler(dataProductConfigMrReviewHandler)
+	rulesCoverageHandler := webhook.NewRulesCoverageHandler(dataProductConfigMrReviewHandler)
+	bulkReevaluateHandler := webhook.NewBulkReevaluateHandler(dataProductConfigMrReviewHandler)
+	configHandler := webhook.NewConfigHandler(cfg)
+	eventDeduplicator := webhook.NewEventDeduplicator(cfg)
+	webhookCapturer := webhook.NewWebhookCapturer(cfg)
+
+	// Post-decision hooks apply to webhook and `/naysayer recheck` decisions alike
+	for _, name := range cfg.Webhook.DecisionHooks {
+		hook, err := webhook.NewDecisionHook(name)
+		if err != nil {
+			logging.Warn("Skipping decision hook: %v", err)
+			continue
+		}
+		dataProductConfigMrReviewHandler.AddDecisionHook(hook)
+		noteCommandHandler.AddDecisionHook(hook)
+		for _, named := range namedReviewHandlers {
+			named.handler.AddDecisionHook(hook)
+		}
+		logging.Info("Decision hook enabled: %s", hook.Name())
+	}
+	if cfg.Webhook.DecisionCallbackURL != "" {
+		hook := webhook.NewCallbackDecisionHook(cfg)
+		dataProductConfigMrReviewHandler.AddDecisionHook(hook)
+		noteCommandHandler.AddDecisionHook(hook)
+		for _, named := range namedReviewHandlers {
+			named.handler.AddDecisionHook(hook)
+		}
+		logging.Info("Decision callback enabled: %s", cfg.Webhook.DecisionCallbackURL)
+	}
 
 	// Health and monitoring routes
 	app.Get("/health", healthHandler.HandleHealth)
 	app.Get("/ready", healthHandler.HandleReady)
 
-	// Webhook routes
-	app.Post("/dataverse-product-config-review", dataProductConfigMrReviewHandler.HandleWebhook)
+	// Webhook routes; deliveries are captured before deduplication when WEBHOOK_CAPTURE_DIR is set
+	app.Post("/dataverse-product-config-review", webhookCapturer.Handle, eventDeduplicator.Handle, dataProductConfigMrReviewHandler.HandleWebhook)
+
+	// Review routes bound to named rule configurations (REVIEW_RULE_CONFIGS)
+	for _, named := range namedReviewHandlers {
+		app.Post("/review/"+named.name, webhookCapturer.Handle, eventDeduplicator.Handle, named.handler.HandleWebhook)
+		logging.Info("Review route enabled: /review/%s", named.name)
+	}
 
 	// Auto-rebase route (generic, reusable)
-	app.Post("/auto-rebase", autoRebaseHandler.HandleWebhook)
+	app.Post("/auto-rebase", webhookCapturer.Handle, eventDeduplicator.Handle, autoRebaseHandler.HandleWebhook)
 
 	// Stale MR cleanup route
-	app.Post("/stale-mr-cleanup", staleMRCleanupHandler.HandleWebhook)
+	app.Post("/stale-mr-cleanup", webhookCapturer.Handle, eventDeduplicator.Handle, staleMRCleanupHandler.HandleWebhook)
+
+	// MR comment slash commands (/naysayer recheck|approve|hold)
+	app.Post("/naysayer-commands", webhookCapturer.Handle, eventDeduplicator.Handle, noteCommandHandler.HandleWebhook)
+
+	// Management routes
+	app.Post("/api/rules/reload", webhook.RequireAdminToken(cfg), rulesReloadHandler.HandleReload)
+	app.Get("/api/rules/config", webhook.RequireAdminToken(cfg), rulesConfigHandler.HandleConfig)
+	app.Get("/api/rules/coverage", webhook.RequireAdminToken(cfg), rulesCoverageHandler.HandleCoverage)
+	app.Post("/api/projects/:id/reevaluate", webhook.RequireAdminToken(cfg), bulkReevaluateHandler.HandleReevaluate)
+	app.Get("/api/config", webhook.RequireAdminToken(cfg), configHandler.HandleConfig)
+
+	// Debounced evaluations still waiting at shutdown run before the process exits
+	drains := []drainFunc{dataProductConfigMrReviewHandler.DrainScheduledEvaluations}
+	for _, named := range namedReviewHandlers {
+		drains = append(drains, named.handler.DrainScheduledEvaluations)
+	}
+	return drains
+}
+
+// namedReviewHandler is a review handler served at POST /review/<name>
+type namedReviewHandler struct {
+	name    string
+	handler *webhook.DataProductConfigMrReviewHandler
+}
+
+// newNamedReviewHandlers creates a review handler, with its own rule manager, for every named
+// rule configuration. Like rules.yaml, a named configuration that fails to load stops startup.
+func newNamedReviewHandlers(cfg *config.Config) []namedReviewHandler {
+	handlers := make([]namedReviewHandler, 0, len(cfg.Rules.NamedConfigs))
+	for _, named := range cfg.Rules.NamedConfigs {
+		handler, err := webhook.NewNamedConfigReviewHandler(cfg, named.Path)
+		if err != nil {
+			logging.Error("Failed to load rule config %s for /review/%s: %v", named.Path, named.Name, err)
+			panic(fmt.Sprintf("Critical error: cannot start without rule config %s: %v", named.Name, err))
+		}
+		handlers = append(handlers, namedReviewHandler{name: named.Name, handler: handler})
+	}
+	return handlers
+}
+
+// rulesReloaders lists every handler whose rules POST /api/rules/reload re-reads, named by route
+func rulesReloaders(review *webhook.DataProductConfigMrReviewHandler, commands *webhook.NoteCommandHandler, named []namedReviewHandler) []webhook.NamedRulesReloader {
+	reloaders := []webhook.NamedRulesReloader{
+		{Name: "dataverse-product-config-review", Reloader: review},
+		{Name: "naysayer-commands", Reloader: commands},
+	}
+	for _, n := range named {
+		reloaders = append(reloaders, webhook.NamedRulesReloader{Name: "review/" + n.name, Reloader: n.handler})
+	}
+	return reloaders
 }
 
 func main() {
+	validateConfig := flag.Bool("validate-config", false, "Validate the rules configuration and exit without starting the server")
+	rulesConfigPath := flag.String("rules-config", "", "Rules file or directory to validate (default: RULES_CONFIG_DIR, then rules.yaml)")
+	replayFile := flag.String("replay", "", "Replay a captured webhook through the MR review handler without writing to GitLab, then exit")
+	flag.Parse()
+
 	// Initialize configuration
 	cfg := config.Load()
 
@@ -52,24 +154,55 @@ func main() {
 	}
 	logging.InitLogger(logLevel, "NAYSAYER")
 
+	// Lint mode for CI: check the rules configuration and exit
+	if *validateConfig {
+		path := *rulesConfigPath
+		if path == "" {
+			path = cfg.Rules.ConfigDir
+		}
+		if path == "" {
+			path = rules.DataverseRuleConfigPath
+		}
+		os.Exit(validateRuleConfig(path, os.Stdout))
+	}
+
+	// Debug mode: re-evaluate a captured webhook as a dry run and print the decision
+	if *replayFile != "" {
+		handler := webhook.NewDataProductConfigMrReviewHandler(cfg)
+		handler.EnableDryRun()
+		os.Exit(replayWebhook(*replayFile, handler, os.Stdout))
+	}
+
 	// Validate GitLab configuration
-	if !cfg.HasGitLabToken() {
-		logging.Warn("GITLAB_TOKEN not set - file analysis will be limited")
+	if err := cfg.CheckGitLabToken(); err != nil {
+		logging.Error("Refusing to start: %v", err)
+		os.Exit(1)
+	}
+	if cfg.SimulatesWithoutToken() {
+		logging.Warn("GITLAB_TOKEN not set - decisions are simulated as manual review (NO_TOKEN_MODE=%s)", cfg.GitLab.NoTokenMode)
+	}
+	if err := gitlab.ValidateTLSConfig(cfg.GitLab); err != nil {
+		logging.Error("Invalid GitLab TLS configuration: %v", err)
+		os.Exit(1)
 	}
 
 	// Create Fiber app
-	app := fiber.New(fiber.Config{
-		DisableStartupMessage: true,
-		ErrorHandler: func(c *fiber.Ctx, err error) error {
-			logging.Error("Fiber error: %v", err)
-			return c.Status(500).JSON(fiber.Map{
-				"error": "Internal server error",
-			})
-		},
-	})
-
-	// Add routes
-	setupRoutes(app, cfg)
+	app := newApp(cfg)
+
+	// Track in-flight requests so shutdown can drain them, then add routes
+	inFlight := &inFlightRequests{}
+	app.Use(inFlight.middleware)
+	drains := setupRoutes(app, cfg)
+
+	// Drain in-flight webhooks on pod termination instead of killing them mid-approval
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGTERM, syscall.SIGINT)
+	grace := time.Duration(cfg.Server.ShutdownGracePeriodSeconds) * time.Second
+	shutdownDone := make(chan struct{})
+	go func() {
+		defer close(shutdownDone)
+		_ = shutdownOnSignal(app, inFlight, signals, grace, drains...)
+	}()
 
 	// Start server
 	port := cfg.Server.Port
@@ -81,4 +214,7 @@ func main() {
 		logging.Error("Failed to start server: %v", err)
 		os.Exit(1)
 	}
+
+	// Listen returns as soon as the listener closes; wait for in-flight requests to drain
+	<-shutdownDone
 }
@@ -89,6 +89,7 @@ func createTestApplication() *fiber.App {
 	healthHandler := webhook.NewHealthHandler(cfg)
 	autoRebaseHandler := webhook.NewAutoRebaseHandler(cfg)
 	staleMRCleanupHandler := webhook.NewStaleMRCleanupHandler(cfg)
+	noteCommandHandler := webhook.NewNoteCommandHandler(cfg)
 
 	// Create Fiber app with same config as main
 	app := fiber.New(fiber.Config{
@@ -115,6 +116,7 @@ func createTestApplication() *fiber.App {
 	app.Post("/dataverse-product-config-review", webhookHandler.HandleWebhook)
 	app.Post("/auto-rebase", autoRebaseHandler.HandleWebhook)
 	app.Post("/stale-mr-cleanup", staleMRCleanupHandler.HandleWebhook)
+	app.Post("/naysayer-commands", noteCommandHandler.HandleWebhook)
 
 	return app
 }
@@ -203,7 +205,7 @@ func TestApplication_WebhookEndpoint(t *testing.T) {
 			path:         "/dataverse-product-config-review",
 			body:         `{"object_kind":"push","commits":[]}`,
 			contentType:  "application/json",
-			expectedCode: 400,
+			expectedCode: 200,
 		},
 	}
 
@@ -369,6 +371,7 @@ func TestApplication_RouteConfiguration(t *testing.T) {
 		"POST:/dataverse-product-config-review": "200",     // Will return 200 even with API failure
 		"POST:/auto-rebase":                     "200|500", // Route exists (500 = API failure, not 404 = route missing)
 		"POST:/stale-mr-cleanup":                "200|500", // Route exists (500 = API failure, not 404 = route missing)
+		"POST:/naysayer-commands":               "200",     // Non-command notes are acknowledged and ignored
 	}
 
 	for route, expectedStatus := range expectedRoutes {
@@ -388,6 +391,8 @@ func TestApplication_RouteConfiguration(t *testing.T) {
 					payload = `{"object_kind":"push","ref":"refs/heads/main","project":{"id":456}}`
 				case "/stale-mr-cleanup":
 					payload = `{"project_id":456}`
+				case "/naysayer-commands":
+					payload = `{"object_kind":"note","object_attributes":{"noteable_type":"MergeRequest","note":"LGTM"}}`
 				default:
 					payload = `{}`
 				}
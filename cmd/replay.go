@@ -0,0 +1,43 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/webhook"
+)
+
+// replayRoute is the only route whose handler supports dry runs
+const replayRoute = "/dataverse-product-config-review"
+
+// replayWebhook feeds the capture at capturePath through handler, which must be in dry-run
+// mode, and writes the handler's response to out. It returns the process exit code.
+func replayWebhook(capturePath string, handler *webhook.DataProductConfigMrReviewHandler, out io.Writer) int {
+	capture, err := webhook.LoadWebhookCapture(capturePath)
+	if err != nil {
+		_, _ = fmt.Fprintf(out, "❌ %v\n", err)
+		return 1
+	}
+	if capture.Path != replayRoute {
+		_, _ = fmt.Fprintf(out, "❌ only %s captures can be replayed, %s was captured from %s\n", replayRoute, capturePath, capture.Path)
+		return 1
+	}
+
+	status, body, err := webhook.ReplayCapture(handler.HandleWebhook, capture)
+	if err != nil {
+		_, _ = fmt.Fprintf(out, "❌ replay failed: %v\n", err)
+		return 1
+	}
+
+	var pretty bytes.Buffer
+	if json.Indent(&pretty, body, "", "  ") == nil {
+		body = pretty.Bytes()
+	}
+	_, _ = fmt.Fprintf(out, "Replayed %s captured at %s (HTTP %d):\n%s\n", capture.Path, capture.CapturedAt.Format("2006-01-02 15:04:05 MST"), status, body)
+	if status >= 400 {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReplayWebhook_RejectsUnsupportedCaptures(t *testing.T) {
+	dir := t.TempDir()
+	otherRoute := filepath.Join(dir, "auto-rebase.json")
+	_ = os.WriteFile(otherRoute, []byte(`{"path":"/auto-rebase","headers":{},"body":{"object_kind":"push"}}`), 0600)
+
+	tests := []struct {
+		name         string
+		path         string
+		expectedText string
+	}{
+		{"missing file", filepath.Join(dir, "missing.json"), "failed to read capture"},
+		{"other route", otherRoute, "only /dataverse-product-config-review captures can be replayed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			code := replayWebhook(tt.path, nil, &out)
+
+			assert.Equal(t, 1, code)
+			assert.Contains(t, out.String(), tt.expectedText)
+		})
+	}
+}
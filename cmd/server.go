@@ -0,0 +1,38 @@
+package main
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/compress"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/logging"
+)
+
+// newApp creates the Fiber app with the request body limit and optional response compression.
+// Requests over the limit are rejected with 413 before any handler reads them.
+func newApp(cfg *config.Config) *fiber.App {
+	app := fiber.New(fiber.Config{
+		DisableStartupMessage: true,
+		BodyLimit:             cfg.Server.MaxRequestBodyBytes,
+		ErrorHandler: func(c *fiber.Ctx, err error) error {
+			var fiberErr *fiber.Error
+			if errors.As(err, &fiberErr) && fiberErr.Code == fiber.StatusRequestEntityTooLarge {
+				logging.Warn("Rejected %s %s: request body exceeds %d bytes", c.Method(), c.Path(), cfg.Server.MaxRequestBodyBytes)
+				return c.Status(fiber.StatusRequestEntityTooLarge).JSON(fiber.Map{
+					"error": "Request body too large",
+				})
+			}
+			logging.Error("Fiber error: %v", err)
+			return c.Status(500).JSON(fiber.Map{
+				"error": "Internal server error",
+			})
+		},
+	})
+
+	if cfg.Server.CompressResponses {
+		app.Use(compress.New())
+	}
+	return app
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/webhook"
+)
+
+// serveApp serves app on a local port and returns its base URL. The body limit is enforced
+// while reading the request, so it can't be exercised through app.Test.
+func serveApp(t *testing.T, app *fiber.App) string {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go func() { _ = app.Listener(ln) }()
+	t.Cleanup(func() { _ = app.Shutdown() })
+	return "http://" + ln.Addr().String()
+}
+
+func TestNewApp_BodyLimit(t *testing.T) {
+	setupTestRulesFile()
+	t.Cleanup(cleanupTestRulesFile)
+
+	cfg := &config.Config{
+		GitLab: config.GitLabConfig{BaseURL: "https://gitlab.example.com", Token: "test-token"},
+		Server: config.ServerConfig{MaxRequestBodyBytes: 1024},
+	}
+	app := newApp(cfg)
+	app.Post("/dataverse-product-config-review", webhook.NewDataProductConfigMrReviewHandler(cfg).HandleWebhook)
+	baseURL := serveApp(t, app)
+
+	tests := []struct {
+		name         string
+		body         string
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name:         "normal payload is parsed",
+			body:         `{"object_kind":"merge_request","object_attributes":{"iid":123},"project":{"id":456},"user":{"username":"testuser"}}`,
+			expectedCode: 200,
+		},
+		{
+			name:         "over-limit payload is rejected",
+			body:         `{"object_kind":"merge_request","padding":"` + strings.Repeat("x", 2048) + `"}`,
+			expectedCode: 413,
+			expectedBody: "Request body too large",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := http.Post(baseURL+"/dataverse-product-config-review", "application/json", strings.NewReader(tt.body))
+			require.NoError(t, err)
+			defer func() { _ = resp.Body.Close() }()
+
+			assert.Equal(t, tt.expectedCode, resp.StatusCode)
+			body, _ := io.ReadAll(resp.Body)
+			assert.Contains(t, string(body), tt.expectedBody)
+		})
+	}
+}
+
+func TestNewApp_CompressResponses(t *testing.T) {
+	tests := []struct {
+		name             string
+		compress         bool
+		expectedEncoding string
+	}{
+		{name: "enabled", compress: true, expectedEncoding: "gzip"},
+		{name: "disabled", compress: false, expectedEncoding: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := newApp(&config.Config{Server: config.ServerConfig{CompressResponses: tt.compress}})
+			app.Get("/verbose", func(c *fiber.Ctx) error {
+				return c.SendString(strings.Repeat("naysayer ", 100))
+			})
+
+			req, _ := http.NewRequest("GET", "/verbose", nil)
+			req.Header.Set("Accept-Encoding", "gzip")
+			resp, err := app.Test(req)
+			require.NoError(t, err)
+
+			assert.Equal(t, 200, resp.StatusCode)
+			assert.Equal(t, tt.expectedEncoding, resp.Header.Get("Content-Encoding"))
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sync/atomic"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/logging"
+)
+
+// inFlightRequests counts requests currently being handled so shutdown can report what it drained
+type inFlightRequests struct {
+	count atomic.Int64
+}
+
+// middleware tracks the request for as long as the rest of the handler chain runs
+func (r *inFlightRequests) middleware(c *fiber.Ctx) error {
+	r.count.Add(1)
+	defer r.count.Add(-1)
+	return c.Next()
+}
+
+// drainFunc finishes background work, such as debounced evaluations, and returns once it is done
+type drainFunc func()
+
+// shutdownOnSignal blocks until a signal arrives, then stops accepting connections and
+// waits up to grace for in-flight requests (and any pending approvals) to finish, then
+// for the background work of drains
+func shutdownOnSignal(app *fiber.App, inFlight *inFlightRequests, signals <-chan os.Signal, grace time.Duration, drains ...drainFunc) error {
+	sig := <-signals
+	start := time.Now()
+	pending := inFlight.count.Load()
+	logging.Info("Received %s, draining %d in-flight request(s) (grace period %s)", sig, pending, grace)
+
+	err := app.ShutdownWithTimeout(grace)
+	remaining := inFlight.count.Load()
+	if err != nil {
+		logging.Error("Shutdown grace period expired with %d request(s) still in flight: %v", remaining, err)
+		return err
+	}
+
+	// No requests can schedule more work now, so the drains see everything that is left
+	drained := make(chan struct{})
+	go func() {
+		defer close(drained)
+		for _, drain := range drains {
+			drain()
+		}
+	}()
+	select {
+	case <-drained:
+	case <-time.After(grace - time.Since(start)):
+		logging.Error("Shutdown grace period expired with background work still running")
+		return fmt.Errorf("background work still running after %s", grace)
+	}
+
+	logging.Info("Shutdown complete, drained %d in-flight request(s)", pending-remaining)
+	return nil
+}
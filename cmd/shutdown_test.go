@@ -0,0 +1,141 @@
+package main
+
+import (
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// startSlowServer serves a handler that sleeps for delay and returns the app, its address,
+// a channel closed once the handler starts and a flag set once it finishes
+func startSlowServer(t *testing.T, inFlight *inFlightRequests, delay time.Duration) (*fiber.App, string, chan struct{}, *atomic.Bool) {
+	t.Helper()
+	app := fiber.New(fiber.Config{DisableStartupMessage: true})
+	app.Use(inFlight.middleware)
+
+	started := make(chan struct{})
+	finished := &atomic.Bool{}
+	app.Get("/slow", func(c *fiber.Ctx) error {
+		close(started)
+		time.Sleep(delay)
+		finished.Store(true)
+		return c.SendString("done")
+	})
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go func() { _ = app.Listener(ln) }()
+
+	return app, "http://" + ln.Addr().String(), started, finished
+}
+
+func TestShutdownOnSignal_DrainsInFlightRequests(t *testing.T) {
+	inFlight := &inFlightRequests{}
+	app, baseURL, started, finished := startSlowServer(t, inFlight, 200*time.Millisecond)
+
+	// Shutdown hooks run once the server has stopped; the slow handler must be done by then
+	var finishedBeforeHook atomic.Bool
+	app.Hooks().OnShutdown(func() error {
+		finishedBeforeHook.Store(finished.Load())
+		return nil
+	})
+
+	type result struct {
+		body string
+		err  error
+	}
+	responses := make(chan result, 1)
+	go func() {
+		resp, err := http.Get(baseURL + "/slow")
+		if err != nil {
+			responses <- result{err: err}
+			return
+		}
+		defer func() { _ = resp.Body.Close() }()
+		body, err := io.ReadAll(resp.Body)
+		responses <- result{body: string(body), err: err}
+	}()
+
+	<-started
+	assert.Equal(t, int64(1), inFlight.count.Load())
+
+	signals := make(chan os.Signal, 1)
+	signals <- syscall.SIGTERM
+	err := shutdownOnSignal(app, inFlight, signals, 5*time.Second)
+
+	require.NoError(t, err)
+	assert.True(t, finished.Load(), "shutdown returned before the in-flight request finished")
+	assert.True(t, finishedBeforeHook.Load())
+	assert.Equal(t, int64(0), inFlight.count.Load())
+
+	response := <-responses
+	require.NoError(t, response.err)
+	assert.Equal(t, "done", response.body)
+}
+
+func TestShutdownOnSignal_GracePeriodExpires(t *testing.T) {
+	inFlight := &inFlightRequests{}
+	app, baseURL, started, finished := startSlowServer(t, inFlight, 500*time.Millisecond)
+
+	go func() {
+		if resp, err := http.Get(baseURL + "/slow"); err == nil {
+			_ = resp.Body.Close()
+		}
+	}()
+	<-started
+
+	signals := make(chan os.Signal, 1)
+	signals <- syscall.SIGINT
+	err := shutdownOnSignal(app, inFlight, signals, 50*time.Millisecond)
+
+	assert.Error(t, err)
+	assert.False(t, finished.Load())
+}
+
+func TestShutdownOnSignal_RunsDrainsAfterRequests(t *testing.T) {
+	inFlight := &inFlightRequests{}
+	app, baseURL, started, finished := startSlowServer(t, inFlight, 100*time.Millisecond)
+
+	go func() {
+		if resp, err := http.Get(baseURL + "/slow"); err == nil {
+			_ = resp.Body.Close()
+		}
+	}()
+	<-started
+
+	var drainedAfterRequest atomic.Bool
+	drain := func() {
+		time.Sleep(50 * time.Millisecond)
+		drainedAfterRequest.Store(finished.Load())
+	}
+
+	signals := make(chan os.Signal, 1)
+	signals <- syscall.SIGTERM
+	err := shutdownOnSignal(app, inFlight, signals, 5*time.Second, drain)
+
+	require.NoError(t, err)
+	assert.True(t, drainedAfterRequest.Load(), "drains run once in-flight requests have finished")
+}
+
+func TestShutdownOnSignal_DrainExceedsGracePeriod(t *testing.T) {
+	inFlight := &inFlightRequests{}
+	app, _, _, _ := startSlowServer(t, inFlight, 0)
+
+	release := make(chan struct{})
+	defer close(release)
+
+	signals := make(chan os.Signal, 1)
+	signals <- syscall.SIGTERM
+	err := shutdownOnSignal(app, inFlight, signals, 50*time.Millisecond, func() { <-release })
+
+	assert.Error(t, err)
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules"
+)
+
+// validateRuleConfig loads the rules configuration at configPath, checks it against the
+// rule registry and writes diagnostics to out. It returns the process exit code.
+func validateRuleConfig(configPath string, out io.Writer) int {
+	ruleConfig, err := config.LoadRuleConfig(configPath)
+	if err != nil {
+		_, _ = fmt.Fprintf(out, "❌ %v\n", err)
+		return 1
+	}
+
+	problems := rules.GetGlobalRegistry().CheckRuleConfig(ruleConfig)
+	if len(problems) > 0 {
+		_, _ = fmt.Fprintf(out, "❌ %s has %d problem(s):\n", ruleConfig.Source.Path, len(problems))
+		for _, problem := range problems {
+			_, _ = fmt.Fprintf(out, "  - %s\n", problem)
+		}
+		return 1
+	}
+
+	_, _ = fmt.Fprintf(out, "✅ %s is valid (%d file configurations)\n", ruleConfig.Source.Path, len(ruleConfig.Files))
+	return 0
+}
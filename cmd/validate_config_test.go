@@ -0,0 +1,98 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeRulesConfig(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "rules.yaml")
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("failed to write rules config: %v", err)
+	}
+	return path
+}
+
+func TestValidateRuleConfig_Valid(t *testing.T) {
+	path := writeRulesConfig(t, `enabled: true
+always_manual_review:
+  - "CODEOWNERS"
+global_rules:
+  - name: secret_scan_rule
+    enabled: true
+files:
+  - name: "product_configs"
+    path: "dataproducts/**/"
+    filename: "product.{yaml,yml}"
+    parser_type: yaml
+    enabled: true
+    sections:
+      - name: tags
+        yaml_path: tags
+        rule_configs:
+          - name: tags_rule
+            enabled: true
+        auto_approve: true
+`)
+
+	var out bytes.Buffer
+	code := validateRuleConfig(path, &out)
+
+	assert.Equal(t, 0, code)
+	assert.Contains(t, out.String(), "is valid (1 file configurations)")
+}
+
+func TestValidateRuleConfig_Invalid(t *testing.T) {
+	path := writeRulesConfig(t, `enabled: true
+safe_paths:
+  - "docs/**/guides/**"
+global_rules:
+  - name: secrets_rule
+    enabled: true
+files:
+  - name: "product_configs"
+    path: "dataproducts/**/"
+    filename: "product.{yaml,yml"
+    parser_type: json
+    enabled: true
+    sections:
+      - name: tags
+        yaml_path: spec..tags
+        rule_configs:
+          - name: tag_rules
+            enabled: true
+        auto_approve: true
+`)
+
+	var out bytes.Buffer
+	code := validateRuleConfig(path, &out)
+
+	assert.Equal(t, 1, code)
+	assert.Contains(t, out.String(), "has 6 problem(s)")
+	assert.Contains(t, out.String(), "safe_paths: \"docs/**/guides/**\" uses ** more than once")
+	assert.Contains(t, out.String(), "global_rules: unknown rule 'secrets_rule'")
+	assert.Contains(t, out.String(), "file product_configs: unsupported parser_type 'json'")
+	assert.Contains(t, out.String(), "file product_configs: unbalanced braces")
+	assert.Contains(t, out.String(), "file product_configs, section tags: malformed yaml_path 'spec..tags'")
+	assert.Contains(t, out.String(), "file product_configs, section tags: unknown rule 'tag_rules'")
+}
+
+func TestValidateRuleConfig_LoadError(t *testing.T) {
+	var out bytes.Buffer
+	code := validateRuleConfig(filepath.Join(t.TempDir(), "missing.yaml"), &out)
+
+	assert.Equal(t, 1, code)
+	assert.Contains(t, out.String(), "rule config file not found")
+}
+
+func TestValidateRuleConfig_RepositoryRules(t *testing.T) {
+	var out bytes.Buffer
+	code := validateRuleConfig(filepath.Join("..", "rules.yaml"), &out)
+
+	assert.Equal(t, 0, code, out.String())
+}
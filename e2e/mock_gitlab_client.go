@@ -30,6 +30,7 @@ type MockGitLabClient struct {
 	// Captured interactions for validation
 	CapturedComments  []CapturedComment
 	CapturedApprovals []CapturedApproval
+	CapturedStatuses  []CapturedStatus
 	FetchedFiles      []string
 
 	// Optional: for auto-rebase E2E tests. When set, ListOpenMRs/ListOpenMRsWithDetails return these MRs.
@@ -57,6 +58,14 @@ type CapturedApproval struct {
 	Message   string
 }
 
+// CapturedStatus represents a commit status that would be posted to GitLab
+type CapturedStatus struct {
+	ProjectID   int
+	SHA         string
+	State       string
+	Description string
+}
+
 // NewMockGitLabClient creates a new mock GitLab client
 // beforeDir should point to the before/ directory (represents target branch)
 // afterDir should point to the after/ directory (represents source branch)
@@ -141,6 +150,10 @@ func (m *MockGitLabClient) AddMRComment(projectID, mrID int, comment string) err
 	return nil
 }
 
+func (m *MockGitLabClient) AddIssueComment(projectID, issueIID int, comment string) error {
+	return nil
+}
+
 // AddOrUpdateMRComment captures the comment with a tag
 func (m *MockGitLabClient) AddOrUpdateMRComment(projectID, mrID int, comment string, tag string) error {
 	m.CapturedComments = append(m.CapturedComments, CapturedComment{
@@ -152,6 +165,11 @@ func (m *MockGitLabClient) AddOrUpdateMRComment(projectID, mrID int, comment str
 	return nil
 }
 
+// AddMRDiffNote accepts inline notes without recording them
+func (m *MockGitLabClient) AddMRDiffNote(projectID, mrIID int, sha, filePath string, line int, body string) error {
+	return nil
+}
+
 // ApproveMR captures the approval request
 func (m *MockGitLabClient) ApproveMR(projectID, mrID int) error {
 	m.CapturedApprovals = append(m.CapturedApprovals, CapturedApproval{
@@ -172,6 +190,27 @@ func (m *MockGitLabClient) ApproveMRWithMessage(projectID, mrID int, message str
 	return nil
 }
 
+// SetCommitStatus captures the commit status
+func (m *MockGitLabClient) SetCommitStatus(projectID int, sha, state, description string) error {
+	m.CapturedStatuses = append(m.CapturedStatuses, CapturedStatus{
+		ProjectID:   projectID,
+		SHA:         sha,
+		State:       state,
+		Description: description,
+	})
+	return nil
+}
+
+// GetMRHeadPipelineStatus reports a passing pipeline for mock client
+func (m *MockGitLabClient) GetMRHeadPipelineStatus(projectID, mrIID int) (string, error) {
+	return "success", nil
+}
+
+// GetProjectIDByPath returns a fixed project ID for mock client
+func (m *MockGitLabClient) GetProjectIDByPath(pathWithNamespace string) (int, error) {
+	return 123, nil
+}
+
 // ResetNaysayerApproval is a no-op for mock client
 func (m *MockGitLabClient) ResetNaysayerApproval(projectID, mrID int) error {
 	// In tests, we don't need to reset approvals
@@ -179,6 +218,10 @@ func (m *MockGitLabClient) ResetNaysayerApproval(projectID, mrID int) error {
 	return nil
 }
 
+func (m *MockGitLabClient) GetMRApprovals(projectID, mrIID int) (*gitlab.MRApprovals, error) {
+	return nil, nil
+}
+
 // GetLatestCommentByTag retrieves the most recent comment with a specific tag
 func (m *MockGitLabClient) GetLatestCommentByTag(tag string) (string, bool) {
 	// Search in reverse to get the latest
@@ -313,6 +356,15 @@ func (m *MockGitLabClient) UpdateMRComment(projectID, mrIID, commentID int, newB
 	return m.AddMRComment(projectID, mrIID, newBody)
 }
 
+// DeleteMRComment removes a captured comment
+func (m *MockGitLabClient) DeleteMRComment(projectID, mrIID, commentID int) error {
+	if commentID < 1 || commentID > len(m.CapturedComments) {
+		return fmt.Errorf("delete comment failed: comment or MR not found")
+	}
+	m.CapturedComments = append(m.CapturedComments[:commentID-1], m.CapturedComments[commentID:]...)
+	return nil
+}
+
 // FindLatestNaysayerComment finds the latest comment by type
 func (m *MockGitLabClient) FindLatestNaysayerComment(projectID, mrIID int, commentType ...string) (*gitlab.MRComment, error) {
 	// Search in reverse for latest comment
@@ -364,6 +416,14 @@ func (m *MockGitLabClient) CompareBranches(sourceProjectID int, sourceBranch str
 func (m *MockGitLabClient) GetBranchCommit(projectID int, branch string) (string, error) {
 	return "e2e-main-sha", nil
 }
+func (m *MockGitLabClient) IsBranchProtected(projectID int, branch string) (bool, error) {
+	return true, nil
+}
+
+// GetMergeRefCommit reports no merge ref so E2E scenarios validate the source branch.
+func (m *MockGitLabClient) GetMergeRefCommit(projectID, mrIID int) (string, error) {
+	return "", gitlab.ErrNotFound
+}
 
 // CompareCommits returns behind count for E2E (fork MR path).
 func (m *MockGitLabClient) CompareCommits(projectID int, fromSHA, toSHA string) (*gitlab.CompareResult, error) {
@@ -429,6 +489,16 @@ func (m *MockGitLabClient) CloseMR(projectID, mrIID int) error {
 	return nil
 }
 
+// AddMRLabels adds labels to a merge request (mock implementation)
+func (m *MockGitLabClient) AddMRLabels(projectID, mrIID int, labels []string) error {
+	return nil
+}
+
+// RemoveMRLabels removes labels from a merge request (mock implementation)
+func (m *MockGitLabClient) RemoveMRLabels(projectID, mrIID int, labels []string) error {
+	return nil
+}
+
 // FindCommentByPattern checks if a comment with the pattern exists (mock implementation)
 func (m *MockGitLabClient) FindCommentByPattern(projectID, mrIID int, pattern string) (bool, error) {
 	// Mock implementation - check captured comments
@@ -1,6 +1,7 @@
 package config
 
 import (
+	"fmt"
 	"os"
 	"strconv"
 	"strings"
@@ -8,53 +9,113 @@ import (
 
 // Config holds application configuration
 type Config struct {
-	GitLab     GitLabConfig
-	Server     ServerConfig
-	Webhook    WebhookConfig
-	Comments   CommentsConfig
-	Rules      RulesConfig
-	Approval   ApprovalConfig
-	AutoRebase AutoRebaseConfig
-	StaleMR    StaleMRConfig
+	GitLab       GitLabConfig
+	Server       ServerConfig
+	Webhook      WebhookConfig
+	Comments     CommentsConfig
+	Rules        RulesConfig
+	Approval     ApprovalConfig
+	AutoRebase   AutoRebaseConfig
+	StaleMR      StaleMRConfig
+	Commands     CommandsConfig
+	CommitStatus CommitStatusConfig
+	QuietHours   QuietHoursConfig
+	ReviewLabel  ReviewLabelConfig
 }
 
 // GitLabConfig holds GitLab API configuration
 type GitLabConfig struct {
 	BaseURL                       string
 	Token                         string
-	GitlabFivetranRepositoryToken string // Optional: separate token for fivetran_terraform rebase
-	GitlabStaleMRToken            string // Optional: dedicated token for stale MR cleanup
-	InsecureTLS                   bool   // Skip TLS certificate verification
-	CACertPath                    string // Path to custom CA certificate file
+	GitlabFivetranRepositoryToken string   // Optional: separate token for fivetran_terraform rebase
+	GitlabStaleMRToken            string   // Optional: dedicated token for stale MR cleanup
+	ApprovalToken                 string   // Optional: narrowly-scoped token for approve/unapprove calls
+	InsecureTLS                   bool     // Skip TLS certificate verification
+	CACertPaths                   []string // Paths to custom CA certificate files added to the trusted pool
+	ClientCertPath                string   // Path to client certificate for mTLS (requires ClientKeyPath)
+	ClientKeyPath                 string   // Path to client private key for mTLS
+	RateLimitRPS                  float64  // Max outbound GitLab API requests per second (0 disables)
+	RateLimitBurst                int      // Max requests allowed in a burst above the steady rate
+	RequestTimeoutSeconds         int      // Max time for a whole GitLab API call, including reading the body (0 disables)
+	DialTimeoutSeconds            int      // Max time to open a TCP connection to GitLab (0 disables)
+	TLSHandshakeTimeoutSeconds    int      // Max time for the TLS handshake with GitLab (0 disables)
+	NoTokenMode                   string   // Behaviour without GITLAB_TOKEN: "simulate" or "fail"
 }
 
+// No-token modes (NO_TOKEN_MODE)
+const (
+	NoTokenModeSimulate = "simulate" // Start, and report every decision as a simulated manual review without calling GitLab
+	NoTokenModeFail     = "fail"     // Refuse to start
+)
+
 // ServerConfig holds server configuration
 type ServerConfig struct {
-	Port string
+	Port                       string
+	ShutdownGracePeriodSeconds int    // Time to drain in-flight requests on SIGTERM; keep below the pod's termination grace period
+	AdminToken                 string // Bearer token for admin endpoints such as bulk re-evaluation (endpoints are disabled when empty)
+	ReevaluateConcurrency      int    // MRs whose decisions are applied in parallel during bulk re-evaluation
+	MaxRequestBodyBytes        int    // Requests with larger bodies are rejected with 413
+	CompressResponses          bool   // Gzip/deflate/brotli responses for clients that accept it
 }
 
 // WebhookConfig holds webhook security configuration
 type WebhookConfig struct {
-	Secret     string   // GitLab webhook secret token
-	AllowedIPs []string // Optional: restrict webhook calls to specific IPs
+	Secret          string   // GitLab webhook secret token
+	AllowedIPs      []string // Optional: restrict webhook calls to specific IPs
+	DedupCacheSize  int      // Recently processed X-Gitlab-Event-UUIDs remembered per route (0 disables)
+	DedupTTLSeconds int      // How long a processed event UUID is treated as a duplicate
+	DecisionHooks   []string // Built-in hooks run after every decision (e.g. "log")
+	CaptureDir      string   // Optional: directory raw webhook deliveries are written to for replay
+	TriggerActions  []string // MR webhook actions (object_attributes.action) that trigger evaluation
+
+	UpdateDebounceSeconds int  // Delay evaluation of MR update events, coalescing bursts of pushes (0 disables)
+	DecisionCacheSeconds  int  // Reuse an MR's rule evaluation for this long while its head commit is unchanged (0 disables)
+	CleanupOnClose        bool // Remove the review label and naysayer's approval from MRs closed without merging
+
+	ProtectedTargetsOnly bool     // Skip evaluation of MRs whose target branch is not protected
+	ProtectedBranches    []string // Optional: target branch globs treated as protected instead of asking GitLab
+
+	DecisionCallbackURL    string // Optional: URL every decision is POSTed to as JSON
+	DecisionCallbackSecret string // Optional: key for the callback's HMAC-SHA256 signature header
 }
 
 // CommentsConfig holds MR comments and messages configuration
 type CommentsConfig struct {
 	EnableMRComments       bool   // Enable/disable MR commenting
-	CommentVerbosity       string // Comment verbosity level (basic, detailed, debug)
+	CommentVerbosity       string // Comment verbosity level (basic, detailed, summary, debug)
+	ApprovalVerbosity      string // Optional: verbosity for approval comments (defaults to CommentVerbosity)
+	ReviewVerbosity        string // Optional: verbosity for manual review comments (defaults to CommentVerbosity)
 	UpdateExistingComments bool   // Update existing comments instead of creating new ones
+	TemplatePath           string // Optional: text/template file overriding built-in comment formatting
+	RuleDisplayTextPath    string // Optional: YAML file with friendly names and approval explanations by rule name
+	Locale                 string // Language of comment headers and approval messages (e.g. "de"; default "en")
+	MessageCatalogPath     string // Optional: YAML file with comment messages by locale and message key
+	InlineDiffNotes        bool   // Post a diff note on the first uncovered line of each manual-review file
+	MaxCommentBytes        int    // Comments longer than this are truncated (0 disables the limit)
+	ArchiveOnMerge         bool   // Replace naysayer's decision comment with a short note once the MR merges
+	CommentOnChangeOnly    bool   // Only comment when the decision differs from naysayer's latest decision comment
+	ReviewerMentionsPath   string // Optional: YAML file mapping path globs to reviewers @-mentioned on manual review comments
+}
+
+// NamedRuleConfig binds a review route name to its rule configuration
+type NamedRuleConfig struct {
+	Name string // Route name, served at POST /review/<name>
+	Path string // Rules file, or directory of *.yaml fragments
 }
 
 // RulesConfig holds rule-specific configuration
 type RulesConfig struct {
 	EnabledRules            []string                      // List of enabled rule names
 	DisabledRules           []string                      // List of disabled rule names
+	ConfigDir               string                        // Directory of *.yaml fragments merged instead of rules.yaml (e.g. a mounted ConfigMap)
+	NamedConfigs            []NamedRuleConfig             // Rule configurations served at POST /review/<name> next to the dataverse route
 	DataProductConsumerRule DataProductConsumerRuleConfig // Consumer access rule configuration
 	MigrationsRule          MigrationsRuleConfig          // Migrations validation configuration
 	NamingRule              NamingRuleConfig              // Naming conventions configuration
 	ServiceAccountRule      ServiceAccountRuleConfig      // Service account rule configuration
 	TOCApprovalRule         TOCApprovalRuleConfig         // TOC approval rule configuration
+	TagsRule                TagsRuleConfig                // Tags vocabulary rule configuration
+	ClassificationRule      ClassificationRuleConfig      // Classification downgrade rule configuration
 	WarehouseRule           WarehouseRuleConfig           // Warehouse rule configuration
 	SandboxPersonalRule     SandboxPersonalRuleConfig     // Sandbox personal unstructured data product rule configuration
 }
@@ -64,6 +125,8 @@ type WarehouseRuleConfig struct {
 	AllowTOCBypass       bool     // Allow bypassing TOC approval for specific cases
 	PlatformEnvironments []string // Environments requiring platform approval
 	AutoApproveEnvs      []string // Environments allowing auto-approval
+	MaxAutoSuspend       int      // Highest auto_suspend (seconds) allowed without manual review
+	MaxNewSize           string   // Largest size a newly added warehouse may have without manual review
 }
 
 // SandboxPersonalRuleConfig holds sandbox personal unstructured data product rule configuration
@@ -78,16 +141,37 @@ type ServiceAccountRuleConfig struct {
 	AllowedDomains           []string // Allowed email domains
 	AstroEnvironmentsOnly    []string // Environments where Astro service accounts are allowed
 	EnforceNamingConventions bool     // Enforce naming conventions
+	NamePatterns             []string // Regexes with a (?P<name>...) capture deriving the expected 'name' field from the file path
+	PrivilegedScopes         []string // Scopes/roles that require manual review in product.yaml service_account sections
 }
 
 // TOCApprovalRuleConfig holds TOC approval rule configuration
 type TOCApprovalRuleConfig struct {
-	CriticalEnvironments []string // Environments requiring TOC approval for new products
+	CriticalEnvironments  []string // Environments requiring TOC approval for new products
+	WarehouseEnvironments []string // Environments where warehouse size increases need a TOC member's approval
+	Approvers             []string // GitLab usernames of TOC members whose approval satisfies the requirement
+}
+
+// TagsRuleConfig holds tags vocabulary rule configuration
+type TagsRuleConfig struct {
+	AllowedValues []string // Tag values allowed in product.yaml tags (empty disables enforcement)
+}
+
+// ClassificationRuleConfig holds classification downgrade rule configuration
+type ClassificationRuleConfig struct {
+	Levels []string // Classification values ordered from least to most restrictive
 }
 
 // DataProductConsumerRuleConfig holds data product consumer rule configuration
 type DataProductConsumerRuleConfig struct {
 	AllowedEnvironments []string // Environments where consumer access is allowed (preprod, prod)
+	AllowedGroups       []string // Groups added rover_group/consumer_group consumers may reference
+	AllowedGroupsFile   string   // File listing allowed groups, one per line
+}
+
+// EnforcesAllowedGroups reports whether added consumer groups are checked against an allowlist
+func (c DataProductConsumerRuleConfig) EnforcesAllowedGroups() bool {
+	return len(c.AllowedGroups) > 0 || c.AllowedGroupsFile != ""
 }
 
 // MigrationsRuleConfig holds migrations validation configuration
@@ -109,6 +193,10 @@ type ApprovalConfig struct {
 	EnablePlatformWorkflow bool   // Enable platform approval workflow
 	TOCGroupID             string // GitLab group ID for TOC team
 	PlatformGroupID        string // GitLab group ID for platform team
+	RequirePassingPipeline bool   // Only auto-approve once the MR's head pipeline has succeeded
+	MessageSuffixEnabled   bool   // Append [naysayer:<decision code>:<correlation id>] to approval messages
+
+	IndependentApprovalPaths []string // Path globs naysayer only approves once a human has approved the MR
 }
 
 // AutoRebaseConfig holds auto-rebase configuration
@@ -116,6 +204,8 @@ type AutoRebaseConfig struct {
 	Enabled               bool   // Enable/disable auto-rebase feature
 	CheckAtlantisComments bool   // Check atlantis comments for plan failures (default: false)
 	RepositoryToken       string // Optional: repository-specific token (for backward compat with Fivetran)
+	SummaryMRIID          int    // Post one rebase summary to this MR instead of commenting on each rebased MR (0 disables)
+	SummaryIssueIID       int    // Post one rebase summary to this issue instead (used when SummaryMRIID is 0)
 }
 
 // StaleMRConfig holds stale MR cleanup configuration
@@ -123,6 +213,32 @@ type StaleMRConfig struct {
 	ClosureDays int // Days before closure (default: 30)
 }
 
+// CommandsConfig holds configuration for /naysayer slash commands in MR comments
+type CommandsConfig struct {
+	Enabled      bool     // Enable/disable handling of /naysayer commands
+	AllowedUsers []string // GitLab usernames permitted to run commands (empty permits nobody)
+}
+
+// CommitStatusConfig holds configuration for reporting decisions as GitLab commit statuses
+type CommitStatusConfig struct {
+	Enabled           bool   // Post the decision as a commit status on the MR's last commit
+	ManualReviewState string // Status for manual review decisions: "pending" (default) or "failed"
+}
+
+// QuietHoursConfig holds the change-freeze schedule outside of which naysayer never approves
+type QuietHoursConfig struct {
+	Enabled      bool     // Restrict auto-approval to the allowed window
+	Timezone     string   // IANA timezone the window is evaluated in (e.g. "Europe/Prague")
+	AllowedDays  []string // Weekdays or ranges when approval is allowed (e.g. "mon-fri")
+	AllowedHours []string // Hour ranges when approval is allowed, end exclusive (e.g. "09:00-17:00")
+}
+
+// ReviewLabelConfig holds the label that marks MRs waiting on manual review
+type ReviewLabelConfig struct {
+	Enabled bool   // Add the label on manual review and remove it on approval
+	Name    string // Label name (e.g. "naysayer:needs-review")
+}
+
 // Load loads configuration from environment variables
 func Load() *Config {
 	return &Config{
@@ -131,26 +247,70 @@ func Load() *Config {
 			Token:                         getEnv("GITLAB_TOKEN", ""),
 			GitlabFivetranRepositoryToken: getEnv("GITLAB_TOKEN_FIVETRAN", ""), // Dedicated token for fivetran_terraform rebase
 			GitlabStaleMRToken:            getEnv("GITLAB_TOKEN_STALE_MR", ""), // Dedicated token for stale MR cleanup
+			ApprovalToken:                 getEnv("GITLAB_TOKEN_APPROVAL", ""), // Dedicated token for approvals
 			InsecureTLS:                   getEnv("GITLAB_INSECURE_TLS", "false") == "true",
-			CACertPath:                    getEnv("GITLAB_CA_CERT_PATH", ""),
+			CACertPaths:                   parseStringList(getEnv("GITLAB_CA_CERT_PATH", "")), // Comma-separated for multiple bundles
+			ClientCertPath:                getEnv("GITLAB_CLIENT_CERT_PATH", ""),
+			ClientKeyPath:                 getEnv("GITLAB_CLIENT_KEY_PATH", ""),
+			RateLimitRPS:                  getEnvFloat("GITLAB_RATE_LIMIT_RPS", 10),
+			RateLimitBurst:                getEnvInt("GITLAB_RATE_LIMIT_BURST", 20),
+			RequestTimeoutSeconds:         getEnvInt("GITLAB_REQUEST_TIMEOUT_SECONDS", 30),
+			DialTimeoutSeconds:            getEnvInt("GITLAB_DIAL_TIMEOUT_SECONDS", 10),
+			TLSHandshakeTimeoutSeconds:    getEnvInt("GITLAB_TLS_HANDSHAKE_TIMEOUT_SECONDS", 10),
+			NoTokenMode:                   strings.ToLower(getEnv("NO_TOKEN_MODE", NoTokenModeSimulate)),
 		},
 		Server: ServerConfig{
-			Port: getEnv("PORT", "3000"),
+			Port:                       getEnv("PORT", "3000"),
+			ShutdownGracePeriodSeconds: getEnvInt("SHUTDOWN_GRACE_PERIOD_SECONDS", 25),
+			AdminToken:                 getEnv("ADMIN_API_TOKEN", ""),
+			ReevaluateConcurrency:      getEnvInt("REEVALUATE_CONCURRENCY", 4),
+			MaxRequestBodyBytes:        getEnvInt("MAX_REQUEST_BODY_BYTES", 4*1024*1024),
+			CompressResponses:          getEnv("COMPRESS_RESPONSES", "false") == "true",
 		},
 		Webhook: WebhookConfig{
-			Secret:     getEnv("WEBHOOK_SECRET", ""),
-			AllowedIPs: parseIPList(getEnv("WEBHOOK_ALLOWED_IPS", "")),
+			Secret:          getEnv("WEBHOOK_SECRET", ""),
+			AllowedIPs:      parseIPList(getEnv("WEBHOOK_ALLOWED_IPS", "")),
+			DedupCacheSize:  getEnvInt("WEBHOOK_DEDUP_CACHE_SIZE", 1000),
+			DedupTTLSeconds: getEnvInt("WEBHOOK_DEDUP_TTL_SECONDS", 3600),
+			DecisionHooks:   parseStringList(getEnv("DECISION_HOOKS", "")),
+			CaptureDir:      getEnv("WEBHOOK_CAPTURE_DIR", ""),
+			TriggerActions:  parseStringList(getEnv("MR_TRIGGER_ACTIONS", "open,reopen,update")),
+
+			UpdateDebounceSeconds: getEnvInt("UPDATE_DEBOUNCE_SECONDS", 0),
+			DecisionCacheSeconds:  getEnvInt("DECISION_CACHE_TTL_SECONDS", 0),
+			CleanupOnClose:        getEnv("CLEANUP_ON_CLOSE", "false") == "true",
+
+			ProtectedTargetsOnly: getEnv("PROTECTED_TARGETS_ONLY", "false") == "true",
+			ProtectedBranches:    parseStringList(getEnv("PROTECTED_BRANCHES", "")),
+
+			DecisionCallbackURL:    getEnv("DECISION_CALLBACK_URL", ""),
+			DecisionCallbackSecret: getEnv("DECISION_CALLBACK_SECRET", ""),
 		},
 		Comments: CommentsConfig{
 			EnableMRComments:       getEnv("ENABLE_MR_COMMENTS", "true") == "true",
 			CommentVerbosity:       getEnv("COMMENT_VERBOSITY", "detailed"),
+			ApprovalVerbosity:      getEnv("APPROVAL_COMMENT_VERBOSITY", ""),
+			ReviewVerbosity:        getEnv("REVIEW_COMMENT_VERBOSITY", ""),
 			UpdateExistingComments: getEnv("UPDATE_EXISTING_COMMENTS", "true") == "true",
+			TemplatePath:           getEnv("COMMENT_TEMPLATE_PATH", ""),
+			RuleDisplayTextPath:    getEnv("RULE_DISPLAY_TEXT_PATH", ""),
+			Locale:                 getEnv("COMMENT_LOCALE", "en"),
+			MessageCatalogPath:     getEnv("MESSAGE_CATALOG_PATH", ""),
+			ReviewerMentionsPath:   getEnv("ESCALATION_REVIEWERS_PATH", ""),
+			InlineDiffNotes:        getEnv("INLINE_DIFF_NOTES", "false") == "true",
+			MaxCommentBytes:        getEnvInt("MAX_COMMENT_BYTES", 1000000),
+			ArchiveOnMerge:         getEnv("ARCHIVE_COMMENTS_ON_MERGE", "false") == "true",
+			CommentOnChangeOnly:    getEnv("COMMENT_ON_CHANGE_ONLY", "false") == "true",
 		},
 		Rules: RulesConfig{
 			EnabledRules:  parseStringList(getEnv("ENABLED_RULES", "")),
 			DisabledRules: parseStringList(getEnv("DISABLED_RULES", "")),
+			ConfigDir:     getEnv("RULES_CONFIG_DIR", ""),
+			NamedConfigs:  parseNamedRuleConfigs(getEnv("REVIEW_RULE_CONFIGS", "")),
 			DataProductConsumerRule: DataProductConsumerRuleConfig{
 				AllowedEnvironments: parseStringList(getEnv("DATAPRODUCT_CONSUMER_ENVS", "preprod,prod")),
+				AllowedGroups:       parseStringList(getEnv("DATAPRODUCT_CONSUMER_ALLOWED_GROUPS", "")),
+				AllowedGroupsFile:   getEnv("DATAPRODUCT_CONSUMER_ALLOWED_GROUPS_FILE", ""),
 			},
 			MigrationsRule: MigrationsRuleConfig{
 				RequirePlatformApproval: getEnv("MIGRATIONS_REQUIRE_PLATFORM", "true") == "true",
@@ -166,14 +326,26 @@ func Load() *Config {
 				AllowedDomains:           parseStringList(getEnv("SA_ALLOWED_DOMAINS", "redhat.com")),
 				AstroEnvironmentsOnly:    parseStringList(getEnv("SA_ASTRO_ENVS", "preprod,prod")),
 				EnforceNamingConventions: getEnv("SA_ENFORCE_NAMING", "true") == "true",
+				NamePatterns:             parseStringList(getEnv("SA_NAME_PATTERNS", "")),
+				PrivilegedScopes:         parseStringList(getEnv("SA_PRIVILEGED_SCOPES", "ACCOUNTADMIN,ORGADMIN,SECURITYADMIN,SYSADMIN,USERADMIN")),
 			},
 			TOCApprovalRule: TOCApprovalRuleConfig{
-				CriticalEnvironments: parseStringList(getEnv("TOC_APPROVAL_ENVS", "preprod,prod")),
+				CriticalEnvironments:  parseStringList(getEnv("TOC_APPROVAL_ENVS", "preprod,prod")),
+				WarehouseEnvironments: parseStringList(getEnv("TOC_WAREHOUSE_ENVS", "prod")),
+				Approvers:             parseStringList(getEnv("TOC_APPROVERS", "")),
+			},
+			TagsRule: TagsRuleConfig{
+				AllowedValues: parseStringList(getEnv("TAGS_ALLOWED_VALUES", "")),
+			},
+			ClassificationRule: ClassificationRuleConfig{
+				Levels: parseStringList(getEnv("CLASSIFICATION_LEVELS", "public,internal,confidential")),
 			},
 			WarehouseRule: WarehouseRuleConfig{
 				AllowTOCBypass:       getEnv("WAREHOUSE_ALLOW_TOC_BYPASS", "false") == "true",
 				PlatformEnvironments: parseStringList(getEnv("WAREHOUSE_PLATFORM_ENVS", "preprod,prod")),
 				AutoApproveEnvs:      parseStringList(getEnv("WAREHOUSE_AUTO_APPROVE_ENVS", "dev,sandbox")),
+				MaxAutoSuspend:       getEnvInt("WAREHOUSE_MAX_AUTO_SUSPEND_SECONDS", 600),
+				MaxNewSize:           getEnv("WAREHOUSE_MAX_NEW_SIZE", "MEDIUM"),
 			},
 			SandboxPersonalRule: SandboxPersonalRuleConfig{
 				ServiceAccountName: getEnv("SANDBOX_SERVICE_ACCOUNT_NAME", ""),
@@ -185,16 +357,40 @@ func Load() *Config {
 			EnablePlatformWorkflow: getEnv("ENABLE_PLATFORM_WORKFLOW", "true") == "true",
 			TOCGroupID:             getEnv("TOC_GROUP_ID", ""),
 			PlatformGroupID:        getEnv("PLATFORM_GROUP_ID", ""),
+			RequirePassingPipeline: getEnv("REQUIRE_PASSING_PIPELINE", "false") == "true",
+			MessageSuffixEnabled:   getEnv("APPROVAL_MESSAGE_SUFFIX_ENABLED", "false") == "true",
+
+			IndependentApprovalPaths: parseStringList(getEnv("INDEPENDENT_APPROVAL_PATHS", "")),
 		},
 		AutoRebase: AutoRebaseConfig{
 			Enabled:               getEnv("AUTO_REBASE_ENABLED", "true") == "true",
 			CheckAtlantisComments: getEnv("AUTO_REBASE_CHECK_ATLANTIS_COMMENTS", "true") == "true",
 			// Support both new and old env var names for backward compatibility
 			RepositoryToken: getEnv("AUTO_REBASE_REPOSITORY_TOKEN", getEnv("GITLAB_TOKEN_FIVETRAN", "")),
+			SummaryMRIID:    getEnvInt("AUTO_REBASE_SUMMARY_MR_IID", 0),
+			SummaryIssueIID: getEnvInt("AUTO_REBASE_SUMMARY_ISSUE_IID", 0),
 		},
 		StaleMR: StaleMRConfig{
 			ClosureDays: getEnvInt("STALE_MR_CLOSURE_DAYS", 30),
 		},
+		Commands: CommandsConfig{
+			Enabled:      getEnv("NAYSAYER_COMMANDS_ENABLED", "true") == "true",
+			AllowedUsers: parseStringList(getEnv("NAYSAYER_COMMAND_USERS", "")),
+		},
+		CommitStatus: CommitStatusConfig{
+			Enabled:           getEnv("COMMIT_STATUS_ENABLED", "false") == "true",
+			ManualReviewState: getEnv("COMMIT_STATUS_MANUAL_REVIEW_STATE", "pending"),
+		},
+		QuietHours: QuietHoursConfig{
+			Enabled:      getEnv("QUIET_HOURS_ENABLED", "false") == "true",
+			Timezone:     getEnv("QUIET_HOURS_TIMEZONE", "UTC"),
+			AllowedDays:  parseStringList(getEnv("QUIET_HOURS_ALLOWED_DAYS", "mon-fri")),
+			AllowedHours: parseStringList(getEnv("QUIET_HOURS_ALLOWED_HOURS", "09:00-17:00")),
+		},
+		ReviewLabel: ReviewLabelConfig{
+			Enabled: getEnv("REVIEW_LABEL_ENABLED", "false") == "true",
+			Name:    getEnv("REVIEW_LABEL", "naysayer:needs-review"),
+		},
 	}
 }
 
@@ -203,6 +399,25 @@ func (c *Config) HasGitLabToken() bool {
 	return c.GitLab.Token != ""
 }
 
+// CheckGitLabToken returns an error when naysayer must not start: GITLAB_TOKEN is missing and
+// NO_TOKEN_MODE is "fail", or NO_TOKEN_MODE is not a known mode
+func (c *Config) CheckGitLabToken() error {
+	switch c.GitLab.NoTokenMode {
+	case NoTokenModeSimulate, NoTokenModeFail:
+	default:
+		return fmt.Errorf("invalid NO_TOKEN_MODE '%s' (expected %s or %s)", c.GitLab.NoTokenMode, NoTokenModeSimulate, NoTokenModeFail)
+	}
+	if !c.HasGitLabToken() && c.GitLab.NoTokenMode == NoTokenModeFail {
+		return fmt.Errorf("GITLAB_TOKEN not set and NO_TOKEN_MODE is %s", NoTokenModeFail)
+	}
+	return nil
+}
+
+// SimulatesWithoutToken returns true if decisions are simulated because GITLAB_TOKEN is missing
+func (c *Config) SimulatesWithoutToken() bool {
+	return !c.HasGitLabToken() && c.GitLab.NoTokenMode == NoTokenModeSimulate
+}
+
 // AnalysisMode returns a description of the current analysis mode
 func (c *Config) AnalysisMode() string {
 	if c.HasGitLabToken() {
@@ -224,6 +439,54 @@ func (c *Config) WebhookSecurityMode() string {
 	return "No secret configured"
 }
 
+// secretFields returns every secret setting keyed by its environment variable
+func (c *Config) secretFields() map[string]*string {
+	return map[string]*string{
+		"GITLAB_TOKEN":                 &c.GitLab.Token,
+		"GITLAB_TOKEN_FIVETRAN":        &c.GitLab.GitlabFivetranRepositoryToken,
+		"GITLAB_TOKEN_STALE_MR":        &c.GitLab.GitlabStaleMRToken,
+		"GITLAB_TOKEN_APPROVAL":        &c.GitLab.ApprovalToken,
+		"WEBHOOK_SECRET":               &c.Webhook.Secret,
+		"DECISION_CALLBACK_SECRET":     &c.Webhook.DecisionCallbackSecret,
+		"ADMIN_API_TOKEN":              &c.Server.AdminToken,
+		"AUTO_REBASE_REPOSITORY_TOKEN": &c.AutoRebase.RepositoryToken,
+	}
+}
+
+// SecretsSet reports which secrets are configured, keyed by environment variable
+func (c *Config) SecretsSet() map[string]bool {
+	set := make(map[string]bool)
+	for name, value := range c.secretFields() {
+		set[name] = *value != ""
+	}
+	return set
+}
+
+// Redacted returns a copy of the configuration with every secret cleared, safe to log or expose
+func (c *Config) Redacted() Config {
+	redacted := *c
+	for _, value := range redacted.secretFields() {
+		*value = ""
+	}
+	return redacted
+}
+
+// ApprovalCommentVerbosity returns the verbosity used for approval comments
+func (c CommentsConfig) ApprovalCommentVerbosity() string {
+	if c.ApprovalVerbosity != "" {
+		return c.ApprovalVerbosity
+	}
+	return c.CommentVerbosity
+}
+
+// ReviewCommentVerbosity returns the verbosity used for manual review comments
+func (c CommentsConfig) ReviewCommentVerbosity() string {
+	if c.ReviewVerbosity != "" {
+		return c.ReviewVerbosity
+	}
+	return c.CommentVerbosity
+}
+
 func getEnv(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
 		return value
@@ -240,6 +503,15 @@ func getEnvInt(key string, defaultValue int) int {
 	return defaultValue
 }
 
+func getEnvFloat(key string, defaultValue float64) float64 {
+	if value := os.Getenv(key); value != "" {
+		if floatValue, err := strconv.ParseFloat(value, 64); err == nil {
+			return floatValue
+		}
+	}
+	return defaultValue
+}
+
 // parseIPList parses a comma-separated list of IP addresses
 func parseIPList(ipString string) []string {
 	if ipString == "" {
@@ -269,3 +541,18 @@ func parseStringList(s string) []string {
 	}
 	return result
 }
+
+// parseNamedRuleConfigs parses a comma-separated list of name=path rule configurations,
+// skipping entries without a name or path
+func parseNamedRuleConfigs(s string) []NamedRuleConfig {
+	result := make([]NamedRuleConfig, 0)
+	for _, item := range parseStringList(s) {
+		name, path, ok := strings.Cut(item, "=")
+		name, path = strings.TrimSpace(name), strings.TrimSpace(path)
+		if !ok || name == "" || path == "" {
+			continue
+		}
+		result = append(result, NamedRuleConfig{Name: name, Path: path})
+	}
+	return result
+}
@@ -12,6 +12,24 @@ func TestLoad_DefaultValues(t *testing.T) {
 	envVars := []string{
 		"GITLAB_BASE_URL", "GITLAB_TOKEN", "PORT",
 		"WEBHOOK_SECRET", "WEBHOOK_ALLOWED_IPS",
+		"GITLAB_RATE_LIMIT_RPS", "GITLAB_RATE_LIMIT_BURST",
+		"GITLAB_REQUEST_TIMEOUT_SECONDS", "GITLAB_DIAL_TIMEOUT_SECONDS", "GITLAB_TLS_HANDSHAKE_TIMEOUT_SECONDS",
+		"COMMIT_STATUS_ENABLED", "COMMIT_STATUS_MANUAL_REVIEW_STATE",
+		"QUIET_HOURS_ENABLED", "QUIET_HOURS_TIMEZONE", "QUIET_HOURS_ALLOWED_DAYS", "QUIET_HOURS_ALLOWED_HOURS",
+		"REQUIRE_PASSING_PIPELINE", "GITLAB_TOKEN_APPROVAL",
+		"WEBHOOK_DEDUP_CACHE_SIZE", "WEBHOOK_DEDUP_TTL_SECONDS", "SHUTDOWN_GRACE_PERIOD_SECONDS",
+		"COMMENT_VERBOSITY", "APPROVAL_COMMENT_VERBOSITY", "REVIEW_COMMENT_VERBOSITY", "APPROVAL_MESSAGE_SUFFIX_ENABLED",
+		"TAGS_ALLOWED_VALUES", "INLINE_DIFF_NOTES", "MAX_COMMENT_BYTES", "ARCHIVE_COMMENTS_ON_MERGE", "DECISION_HOOKS", "WAREHOUSE_MAX_NEW_SIZE",
+		"RULES_CONFIG_DIR", "REVIEW_LABEL_ENABLED", "REVIEW_LABEL", "SA_NAME_PATTERNS",
+		"ADMIN_API_TOKEN", "REEVALUATE_CONCURRENCY", "COMMENT_ON_CHANGE_ONLY", "SA_PRIVILEGED_SCOPES",
+		"AUTO_REBASE_SUMMARY_MR_IID", "AUTO_REBASE_SUMMARY_ISSUE_IID", "CLASSIFICATION_LEVELS",
+		"WEBHOOK_CAPTURE_DIR", "MR_TRIGGER_ACTIONS", "RULE_DISPLAY_TEXT_PATH",
+		"TOC_WAREHOUSE_ENVS", "TOC_APPROVERS", "MAX_REQUEST_BODY_BYTES", "COMPRESS_RESPONSES",
+		"DECISION_CALLBACK_URL", "DECISION_CALLBACK_SECRET", "PROTECTED_TARGETS_ONLY", "PROTECTED_BRANCHES",
+		"DATAPRODUCT_CONSUMER_ALLOWED_GROUPS", "DATAPRODUCT_CONSUMER_ALLOWED_GROUPS_FILE", "UPDATE_DEBOUNCE_SECONDS",
+		"CLEANUP_ON_CLOSE", "COMMENT_LOCALE", "MESSAGE_CATALOG_PATH", "NO_TOKEN_MODE",
+		"REVIEW_RULE_CONFIGS", "INDEPENDENT_APPROVAL_PATHS", "ESCALATION_REVIEWERS_PATH",
+		"DECISION_CACHE_TTL_SECONDS",
 	}
 
 	originalValues := make(map[string]string)
@@ -35,9 +53,66 @@ func TestLoad_DefaultValues(t *testing.T) {
 	// Test default values
 	assert.Equal(t, "https://gitlab.com", config.GitLab.BaseURL)
 	assert.Equal(t, "", config.GitLab.Token)
+	assert.Equal(t, "", config.GitLab.ApprovalToken)
 	assert.Equal(t, "3000", config.Server.Port)
+	assert.Equal(t, 25, config.Server.ShutdownGracePeriodSeconds)
 	assert.Equal(t, "", config.Webhook.Secret)
 	assert.Empty(t, config.Webhook.AllowedIPs)
+	assert.Equal(t, 1000, config.Webhook.DedupCacheSize)
+	assert.Equal(t, 3600, config.Webhook.DedupTTLSeconds)
+	assert.Equal(t, 10.0, config.GitLab.RateLimitRPS)
+	assert.Equal(t, 20, config.GitLab.RateLimitBurst)
+	assert.Zero(t, config.AutoRebase.SummaryMRIID)
+	assert.Zero(t, config.AutoRebase.SummaryIssueIID)
+	assert.Equal(t, 30, config.GitLab.RequestTimeoutSeconds)
+	assert.Equal(t, 10, config.GitLab.DialTimeoutSeconds)
+	assert.Equal(t, 10, config.GitLab.TLSHandshakeTimeoutSeconds)
+	assert.False(t, config.CommitStatus.Enabled)
+	assert.Equal(t, "pending", config.CommitStatus.ManualReviewState)
+	assert.False(t, config.QuietHours.Enabled)
+	assert.Equal(t, "UTC", config.QuietHours.Timezone)
+	assert.Equal(t, []string{"mon-fri"}, config.QuietHours.AllowedDays)
+	assert.Equal(t, []string{"09:00-17:00"}, config.QuietHours.AllowedHours)
+	assert.False(t, config.Approval.RequirePassingPipeline)
+	assert.False(t, config.Approval.MessageSuffixEnabled)
+	assert.Empty(t, config.Rules.TagsRule.AllowedValues)
+	assert.Empty(t, config.Webhook.CaptureDir)
+	assert.Empty(t, config.Comments.RuleDisplayTextPath)
+	assert.Equal(t, []string{"open", "reopen", "update"}, config.Webhook.TriggerActions)
+	assert.Equal(t, []string{"public", "internal", "confidential"}, config.Rules.ClassificationRule.Levels)
+	assert.Equal(t, []string{"prod"}, config.Rules.TOCApprovalRule.WarehouseEnvironments)
+	assert.Empty(t, config.Rules.TOCApprovalRule.Approvers)
+	assert.Equal(t, 4*1024*1024, config.Server.MaxRequestBodyBytes)
+	assert.False(t, config.Server.CompressResponses)
+	assert.Empty(t, config.Webhook.DecisionCallbackURL)
+	assert.Empty(t, config.Webhook.DecisionCallbackSecret)
+	assert.False(t, config.Rules.DataProductConsumerRule.EnforcesAllowedGroups())
+	assert.Equal(t, 0, config.Webhook.UpdateDebounceSeconds)
+	assert.False(t, config.Webhook.CleanupOnClose)
+	assert.Equal(t, "en", config.Comments.Locale)
+	assert.Empty(t, config.Comments.MessageCatalogPath)
+	assert.Equal(t, NoTokenModeSimulate, config.GitLab.NoTokenMode)
+	assert.Empty(t, config.Rules.NamedConfigs)
+	assert.Empty(t, config.Approval.IndependentApprovalPaths)
+	assert.Empty(t, config.Comments.ReviewerMentionsPath)
+	assert.Equal(t, 0, config.Webhook.DecisionCacheSeconds)
+	assert.False(t, config.Webhook.ProtectedTargetsOnly)
+	assert.Empty(t, config.Webhook.ProtectedBranches)
+	assert.False(t, config.Comments.InlineDiffNotes)
+	assert.Equal(t, 1000000, config.Comments.MaxCommentBytes)
+	assert.False(t, config.Comments.ArchiveOnMerge)
+	assert.False(t, config.Comments.CommentOnChangeOnly)
+	assert.Empty(t, config.Rules.ConfigDir)
+	assert.False(t, config.ReviewLabel.Enabled)
+	assert.Equal(t, "naysayer:needs-review", config.ReviewLabel.Name)
+	assert.Empty(t, config.Rules.ServiceAccountRule.NamePatterns)
+	assert.Equal(t, []string{"ACCOUNTADMIN", "ORGADMIN", "SECURITYADMIN", "SYSADMIN", "USERADMIN"}, config.Rules.ServiceAccountRule.PrivilegedScopes)
+	assert.Empty(t, config.Server.AdminToken)
+	assert.Equal(t, 4, config.Server.ReevaluateConcurrency)
+	assert.Empty(t, config.Webhook.DecisionHooks)
+	assert.Equal(t, "MEDIUM", config.Rules.WarehouseRule.MaxNewSize)
+	assert.Equal(t, "detailed", config.Comments.ApprovalCommentVerbosity())
+	assert.Equal(t, "detailed", config.Comments.ReviewCommentVerbosity())
 }
 
 func TestLoad_EnvironmentOverrides(t *testing.T) {
@@ -147,6 +222,36 @@ func TestAnalysisMode(t *testing.T) {
 	}
 }
 
+func TestCheckGitLabToken(t *testing.T) {
+	tests := []struct {
+		name      string
+		token     string
+		mode      string
+		wantErr   string
+		simulated bool
+	}{
+		{name: "token set, simulate", token: "glpat-mock", mode: NoTokenModeSimulate},
+		{name: "token set, fail", token: "glpat-mock", mode: NoTokenModeFail},
+		{name: "no token, simulate", mode: NoTokenModeSimulate, simulated: true},
+		{name: "no token, fail", mode: NoTokenModeFail, wantErr: "GITLAB_TOKEN not set"},
+		{name: "invalid mode", token: "glpat-mock", mode: "warn", wantErr: "invalid NO_TOKEN_MODE 'warn'"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &Config{GitLab: GitLabConfig{Token: tt.token, NoTokenMode: tt.mode}}
+
+			err := config.CheckGitLabToken()
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.simulated, config.SimulatesWithoutToken())
+		})
+	}
+}
+
 func TestHasWebhookSecret(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -216,6 +321,29 @@ func TestWebhookSecurityMode(t *testing.T) {
 	}
 }
 
+func TestRedacted(t *testing.T) {
+	config := &Config{
+		GitLab:  GitLabConfig{BaseURL: "https://gitlab.example.com", Token: "glpat-secret"},
+		Webhook: WebhookConfig{Secret: "webhook-secret-123"},
+		Server:  ServerConfig{Port: "3000", AdminToken: "admin-token"},
+	}
+
+	redacted := config.Redacted()
+	assert.Empty(t, redacted.GitLab.Token)
+	assert.Empty(t, redacted.Webhook.Secret)
+	assert.Empty(t, redacted.Server.AdminToken)
+	assert.Equal(t, "https://gitlab.example.com", redacted.GitLab.BaseURL)
+	assert.Equal(t, "3000", redacted.Server.Port)
+	assert.Equal(t, "glpat-secret", config.GitLab.Token, "original is unchanged")
+
+	set := config.SecretsSet()
+	assert.True(t, set["GITLAB_TOKEN"])
+	assert.True(t, set["WEBHOOK_SECRET"])
+	assert.True(t, set["ADMIN_API_TOKEN"])
+	assert.False(t, set["GITLAB_TOKEN_APPROVAL"])
+	assert.False(t, set["DECISION_CALLBACK_SECRET"])
+}
+
 func TestGetEnv(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -344,6 +472,39 @@ func TestParseIPList(t *testing.T) {
 	}
 }
 
+func TestParseNamedRuleConfigs(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected []NamedRuleConfig
+	}{
+		{
+			name:     "empty string",
+			input:    "",
+			expected: []NamedRuleConfig{},
+		},
+		{
+			name:  "multiple configs in order",
+			input: "terraform=/etc/naysayer/terraform.yaml, dbt = rules/dbt",
+			expected: []NamedRuleConfig{
+				{Name: "terraform", Path: "/etc/naysayer/terraform.yaml"},
+				{Name: "dbt", Path: "rules/dbt"},
+			},
+		},
+		{
+			name:     "entries without name or path are skipped",
+			input:    "terraform,=rules.yaml,dbt=,docs=docs.yaml",
+			expected: []NamedRuleConfig{{Name: "docs", Path: "docs.yaml"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, parseNamedRuleConfigs(tt.input))
+		})
+	}
+}
+
 func TestConfigStructs_FieldsExist(t *testing.T) {
 	// Test that all expected fields exist and have correct types
 	config := &Config{}
@@ -1,9 +1,14 @@
 package config
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/redhat-data-and-ai/naysayer/internal/utils"
 	"gopkg.in/yaml.v3"
@@ -11,8 +16,29 @@ import (
 
 // RuleConfig defines a rule with its enabled state
 type RuleConfig struct {
-	Name    string `yaml:"name"`    // Rule name (e.g., "warehouse_rule")
-	Enabled bool   `yaml:"enabled"` // Whether this rule should be executed
+	Name         string   `yaml:"name"`         // Rule name (e.g., "warehouse_rule")
+	Enabled      bool     `yaml:"enabled"`      // Whether this rule should be executed
+	Severity     string   `yaml:"severity"`     // "blocking" (default) or "advisory"
+	Environments []string `yaml:"environments"` // Environments the rule applies to (empty = all files)
+}
+
+// IsAdvisory reports whether failures of this rule are warnings that don't block auto-approval
+func (rc RuleConfig) IsAdvisory() bool {
+	return rc.Severity == utils.RuleSeverityAdvisory
+}
+
+// AppliesToEnvironment reports whether the rule runs for files in the given environment.
+// Unscoped rules run everywhere; scoped rules never run for files without a recognizable environment.
+func (rc RuleConfig) AppliesToEnvironment(environment string) bool {
+	if len(rc.Environments) == 0 {
+		return true
+	}
+	for _, env := range rc.Environments {
+		if strings.EqualFold(env, environment) {
+			return true
+		}
+	}
+	return false
 }
 
 // SectionDefinition defines how to identify and parse a section within a file
@@ -23,6 +49,12 @@ type SectionDefinition struct {
 	RuleConfigs []RuleConfig `yaml:"rule_configs"` // Rules with enable/disable control
 	AutoApprove bool         `yaml:"auto_approve"` // Auto-approve this section if rules pass (or no rules)
 	Description string       `yaml:"description"`  // Human-readable description
+	Treatment   string       `yaml:"treatment"`    // "structured" (default) or "raw" to pass free-form content through as text
+}
+
+// IsRaw reports whether the section is passed to its rules as text instead of parsed YAML
+func (sd SectionDefinition) IsRaw() bool {
+	return sd.Treatment == utils.SectionTreatmentRaw
 }
 
 // FileRuleConfig defines sections and rules for a specific file type
@@ -35,22 +67,90 @@ type FileRuleConfig struct {
 	Enabled       bool                `yaml:"enabled"`        // Enable/disable this file type
 	DefaultAction string              `yaml:"default_action"` // Default action for unconfigured sections (manual_review, auto_approve)
 	Sections      []SectionDefinition `yaml:"sections"`       // Sections within this file type
+	MultiDocument bool                `yaml:"multi_document"` // Extract sections from every `---` separated YAML document, not just the first
+}
+
+// UnmatchedFileFallback sets the decision for files that match Pattern but no file configuration
+type UnmatchedFileFallback struct {
+	Pattern string `yaml:"pattern"` // Path glob (e.g., "**/*.{md,txt}")
+	Action  string `yaml:"action"`  // manual_review or auto_approve
 }
 
 // GlobalRuleConfig holds the complete rule configuration for all file types
 type GlobalRuleConfig struct {
-	Enabled bool             `yaml:"enabled"`
-	Files   []FileRuleConfig `yaml:"files"` // Array of file configurations
+	Enabled              bool                    `yaml:"enabled"`
+	Files                []FileRuleConfig        `yaml:"files"`                  // Array of file configurations
+	AlwaysManualReview   []string                `yaml:"always_manual_review"`   // Path globs that always require manual review
+	SafePaths            []string                `yaml:"safe_paths"`             // Path globs whose changes are always safe; MRs touching only these are approved
+	DeltaOnlyValidation  bool                    `yaml:"delta_only_validation"`  // Validate only sections touched by the MR diff
+	MergeRefValidation   bool                    `yaml:"merge_ref_validation"`   // Validate the MR's merge result instead of the source branch
+	FailOnNoRules        bool                    `yaml:"fail_on_no_rules"`       // Refuse to load a configuration that enables no rules
+	ReuseUnchangedFiles  bool                    `yaml:"reuse_unchanged_files"`  // Reuse a file's previous validation while its blob and diff are unchanged
+	ConcurrentEditCheck  bool                    `yaml:"concurrent_edit_check"`  // Require manual review when another open MR modifies the same section
+	ApproveEmptyFiles    bool                    `yaml:"approve_empty_files"`    // Approve empty or whitespace-only files instead of requiring manual review
+	IgnoreCommentChanges bool                    `yaml:"ignore_comment_changes"` // Diff hunks that only change YAML comments don't count as changed lines
+	DiffContextLines     *int                    `yaml:"diff_context_lines"`     // Padding for changed ranges that touch no section (nil uses DefaultDiffContextLines)
+	NewFilePolicy        string                  `yaml:"new_file_policy"`        // How new data product files are decided: validate (default) or manual_review
+	GlobalRules          []RuleConfig            `yaml:"global_rules"`           // Rules run on every changed file; they can only require manual review
+	UnmatchedFiles       []UnmatchedFileFallback `yaml:"unmatched_files"`        // Decisions for files no file configuration matches, by path glob (first match wins)
+	GeneratedFileMarkers []string                `yaml:"generated_file_markers"` // Header markers (e.g. "# DO NOT EDIT") whose files are approved as generated
+	Source               RuleConfigSource        `yaml:"-"`                      // File the configuration was loaded from
+}
+
+// RuleConfigSource records which file a rule configuration was loaded from
+type RuleConfigSource struct {
+	Path    string    // Absolute path of the rule config file
+	ModTime time.Time // Last-modified time of the file when it was loaded
+	SHA256  string    // Hex-encoded SHA-256 of the file contents
+}
+
+// DefaultDiffContextLines matches the context lines of a typical unified diff
+const DefaultDiffContextLines = 3
+
+// DiffContext returns how many lines a changed range that touches no section may be padded by
+// to find the section it belongs to
+func (c *GlobalRuleConfig) DiffContext() int {
+	if c.DiffContextLines == nil {
+		return DefaultDiffContextLines
+	}
+	if *c.DiffContextLines < 0 {
+		return 0
+	}
+	return *c.DiffContextLines
+}
+
+// SectionCount returns the number of section definitions across all file configurations
+func (c *GlobalRuleConfig) SectionCount() int {
+	count := 0
+	for _, fileConfig := range c.Files {
+		count += len(fileConfig.Sections)
+	}
+	return count
 }
 
 // RuleBasedConfig is the external YAML format for rule configuration
 type RuleBasedConfig struct {
-	Enabled bool             `yaml:"enabled"`
-	Files   []FileRuleConfig `yaml:"files"` // Array of file configurations
+	Enabled              bool                    `yaml:"enabled"`
+	Files                []FileRuleConfig        `yaml:"files"`                  // Array of file configurations
+	AlwaysManualReview   []string                `yaml:"always_manual_review"`   // Path globs that always require manual review
+	SafePaths            []string                `yaml:"safe_paths"`             // Path globs whose changes are always safe; MRs touching only these are approved
+	DeltaOnlyValidation  bool                    `yaml:"delta_only_validation"`  // Validate only sections touched by the MR diff
+	MergeRefValidation   bool                    `yaml:"merge_ref_validation"`   // Validate the MR's merge result instead of the source branch
+	FailOnNoRules        bool                    `yaml:"fail_on_no_rules"`       // Refuse to load a configuration that enables no rules
+	ReuseUnchangedFiles  bool                    `yaml:"reuse_unchanged_files"`  // Reuse a file's previous validation while its blob and diff are unchanged
+	ConcurrentEditCheck  bool                    `yaml:"concurrent_edit_check"`  // Require manual review when another open MR modifies the same section
+	ApproveEmptyFiles    bool                    `yaml:"approve_empty_files"`    // Approve empty or whitespace-only files instead of requiring manual review
+	IgnoreCommentChanges bool                    `yaml:"ignore_comment_changes"` // Diff hunks that only change YAML comments don't count as changed lines
+	DiffContextLines     *int                    `yaml:"diff_context_lines"`     // Padding for changed ranges that touch no section (nil uses DefaultDiffContextLines)
+	NewFilePolicy        string                  `yaml:"new_file_policy"`        // How new data product files are decided: validate (default) or manual_review
+	GlobalRules          []RuleConfig            `yaml:"global_rules"`           // Rules run on every changed file; they can only require manual review
+	UnmatchedFiles       []UnmatchedFileFallback `yaml:"unmatched_files"`        // Decisions for files no file configuration matches, by path glob (first match wins)
+	GeneratedFileMarkers []string                `yaml:"generated_file_markers"` // Header markers (e.g. "# DO NOT EDIT") whose files are approved as generated
 }
 
-// LoadRuleConfig loads rule-based validation configuration from YAML
-// The YAML file must exist and be valid - no fallbacks or defaults
+// LoadRuleConfig loads rule-based validation configuration from YAML.
+// configPath may be a single file or a directory of fragments (see loadRuleConfigDir).
+// The configuration must exist and be valid - no fallbacks or defaults
 func LoadRuleConfig(configPath string) (*GlobalRuleConfig, error) {
 	// If no config path provided, use default
 	if configPath == "" {
@@ -58,13 +158,39 @@ func LoadRuleConfig(configPath string) (*GlobalRuleConfig, error) {
 	}
 
 	// Check if YAML config file exists
-	if _, err := os.Stat(configPath); os.IsNotExist(err) {
+	info, err := os.Stat(configPath)
+	if os.IsNotExist(err) {
 		return nil, fmt.Errorf("rule config file not found: %s (create this file to define validation rules)", configPath)
 	}
 
-	// Read YAML config file (sanitize path to prevent traversal)
+	// Sanitize path to prevent traversal
 	cleanPath := filepath.Clean(configPath)
-	data, err := os.ReadFile(cleanPath)
+	var config *GlobalRuleConfig
+	if info != nil && info.IsDir() {
+		config, err = loadRuleConfigDir(cleanPath)
+	} else {
+		config, err = loadRuleConfigFile(cleanPath, info)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Record where the configuration came from so it can be reported at runtime
+	if absPath, err := filepath.Abs(cleanPath); err == nil {
+		config.Source.Path = absPath
+	}
+
+	// Validate the configuration
+	if err := ValidateRuleConfig(config); err != nil {
+		return nil, fmt.Errorf("invalid rule configuration in %s: %w", configPath, err)
+	}
+
+	return config, nil
+}
+
+// loadRuleConfigFile reads a single rules.yaml file
+func loadRuleConfigFile(configPath string, info os.FileInfo) (*GlobalRuleConfig, error) {
+	data, err := os.ReadFile(configPath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read rule config file %s: %w", configPath, err)
 	}
@@ -77,24 +203,134 @@ func LoadRuleConfig(configPath string) (*GlobalRuleConfig, error) {
 
 	// Convert YAML config to internal format
 	config := &GlobalRuleConfig{
-		Enabled: yamlConfig.Enabled,
-		Files:   yamlConfig.Files,
+		Enabled:              yamlConfig.Enabled,
+		Files:                yamlConfig.Files,
+		AlwaysManualReview:   yamlConfig.AlwaysManualReview,
+		SafePaths:            yamlConfig.SafePaths,
+		DeltaOnlyValidation:  yamlConfig.DeltaOnlyValidation,
+		MergeRefValidation:   yamlConfig.MergeRefValidation,
+		FailOnNoRules:        yamlConfig.FailOnNoRules,
+		ReuseUnchangedFiles:  yamlConfig.ReuseUnchangedFiles,
+		ConcurrentEditCheck:  yamlConfig.ConcurrentEditCheck,
+		ApproveEmptyFiles:    yamlConfig.ApproveEmptyFiles,
+		IgnoreCommentChanges: yamlConfig.IgnoreCommentChanges,
+		DiffContextLines:     yamlConfig.DiffContextLines,
+		NewFilePolicy:        yamlConfig.NewFilePolicy,
+		GlobalRules:          yamlConfig.GlobalRules,
+		UnmatchedFiles:       yamlConfig.UnmatchedFiles,
+		GeneratedFileMarkers: yamlConfig.GeneratedFileMarkers,
 	}
 
-	// Validate the configuration
-	if err := ValidateRuleConfig(config); err != nil {
-		return nil, fmt.Errorf("invalid rule configuration in %s: %w", configPath, err)
+	checksum := sha256.Sum256(data)
+	config.Source = RuleConfigSource{Path: configPath, SHA256: hex.EncodeToString(checksum[:])}
+	if info != nil {
+		config.Source.ModTime = info.ModTime()
 	}
 
 	return config, nil
 }
 
+// loadRuleConfigDir merges every *.yaml fragment in dir (e.g. a mounted ConfigMap) into one
+// configuration. Fragments are read in filename order: file configurations,
+// always_manual_review, safe_paths, unmatched_files and generated_file_markers entries are concatenated, boolean options
+// are enabled when any fragment enables them, and the last fragment setting diff_context_lines or
+// new_file_policy wins. A file configuration name defined in two fragments is an error.
+func loadRuleConfigDir(dir string) (*GlobalRuleConfig, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.yaml"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list rule config fragments in %s: %w", dir, err)
+	}
+	sort.Strings(paths)
+
+	config := &GlobalRuleConfig{}
+	definedIn := make(map[string]string) // File configuration name -> fragment that defines it
+	checksum := sha256.New()
+	fragments := 0
+
+	for _, path := range paths {
+		name := filepath.Base(path)
+		if strings.HasPrefix(name, ".") {
+			continue // Hidden files, including Kubernetes' ..data bookkeeping entries
+		}
+
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to stat rule config fragment %s: %w", path, err)
+		}
+		if info.IsDir() {
+			continue
+		}
+		fragment, err := loadRuleConfigFile(path, info)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, fileConfig := range fragment.Files {
+			if previous, exists := definedIn[fileConfig.Name]; exists {
+				return nil, fmt.Errorf("duplicate file configuration %q in rule config fragment %s (already defined in %s)", fileConfig.Name, name, previous)
+			}
+			definedIn[fileConfig.Name] = name
+		}
+
+		config.Enabled = config.Enabled || fragment.Enabled
+		config.Files = append(config.Files, fragment.Files...)
+		config.AlwaysManualReview = append(config.AlwaysManualReview, fragment.AlwaysManualReview...)
+		config.SafePaths = append(config.SafePaths, fragment.SafePaths...)
+		config.DeltaOnlyValidation = config.DeltaOnlyValidation || fragment.DeltaOnlyValidation
+		config.MergeRefValidation = config.MergeRefValidation || fragment.MergeRefValidation
+		config.FailOnNoRules = config.FailOnNoRules || fragment.FailOnNoRules
+		config.ReuseUnchangedFiles = config.ReuseUnchangedFiles || fragment.ReuseUnchangedFiles
+		config.ConcurrentEditCheck = config.ConcurrentEditCheck || fragment.ConcurrentEditCheck
+		config.ApproveEmptyFiles = config.ApproveEmptyFiles || fragment.ApproveEmptyFiles
+		config.IgnoreCommentChanges = config.IgnoreCommentChanges || fragment.IgnoreCommentChanges
+		config.GlobalRules = append(config.GlobalRules, fragment.GlobalRules...)
+		config.UnmatchedFiles = append(config.UnmatchedFiles, fragment.UnmatchedFiles...)
+		config.GeneratedFileMarkers = append(config.GeneratedFileMarkers, fragment.GeneratedFileMarkers...)
+		if fragment.DiffContextLines != nil {
+			// Later fragments override the padding of earlier ones
+			config.DiffContextLines = fragment.DiffContextLines
+		}
+		if fragment.NewFilePolicy != "" {
+			// Later fragments override the policy of earlier ones
+			config.NewFilePolicy = fragment.NewFilePolicy
+		}
+
+		_, _ = fmt.Fprintf(checksum, "%s\n%s\n", name, fragment.Source.SHA256)
+		if info.ModTime().After(config.Source.ModTime) {
+			config.Source.ModTime = info.ModTime()
+		}
+		fragments++
+	}
+
+	if fragments == 0 {
+		return nil, fmt.Errorf("no *.yaml rule config fragments found in %s", dir)
+	}
+
+	config.Source.Path = dir
+	config.Source.SHA256 = hex.EncodeToString(checksum.Sum(nil))
+	return config, nil
+}
+
 // SaveRuleConfig saves rule configuration to file (for custom configs)
 func SaveRuleConfig(config *GlobalRuleConfig, configPath string) error {
 	// Convert internal config to external format
 	externalConfig := RuleBasedConfig{
-		Enabled: config.Enabled,
-		Files:   config.Files,
+		Enabled:              config.Enabled,
+		Files:                config.Files,
+		AlwaysManualReview:   config.AlwaysManualReview,
+		SafePaths:            config.SafePaths,
+		DeltaOnlyValidation:  config.DeltaOnlyValidation,
+		MergeRefValidation:   config.MergeRefValidation,
+		FailOnNoRules:        config.FailOnNoRules,
+		ReuseUnchangedFiles:  config.ReuseUnchangedFiles,
+		ConcurrentEditCheck:  config.ConcurrentEditCheck,
+		ApproveEmptyFiles:    config.ApproveEmptyFiles,
+		IgnoreCommentChanges: config.IgnoreCommentChanges,
+		DiffContextLines:     config.DiffContextLines,
+		NewFilePolicy:        config.NewFilePolicy,
+		GlobalRules:          config.GlobalRules,
+		UnmatchedFiles:       config.UnmatchedFiles,
+		GeneratedFileMarkers: config.GeneratedFileMarkers,
 	}
 
 	// Marshal to YAML
@@ -162,12 +398,29 @@ func ValidateRuleConfig(config *GlobalRuleConfig) error {
 			if section.YAMLPath == "" {
 				return fmt.Errorf("section %s missing YAML path in file configuration %s", section.Name, fileConfig.Name)
 			}
+			if section.Treatment != "" &&
+				section.Treatment != utils.SectionTreatmentStructured &&
+				section.Treatment != utils.SectionTreatmentRaw {
+				return fmt.Errorf("invalid treatment '%s' for section %s in file configuration %s. Must be '%s' or '%s'",
+					section.Treatment, section.Name, fileConfig.Name, utils.SectionTreatmentStructured, utils.SectionTreatmentRaw)
+			}
 
 			// Validate rule configs
 			for _, ruleConfig := range section.RuleConfigs {
 				if ruleConfig.Name == "" {
 					return fmt.Errorf("rule config missing name in section %s of file configuration %s", section.Name, fileConfig.Name)
 				}
+				if ruleConfig.Severity != "" &&
+					ruleConfig.Severity != utils.RuleSeverityBlocking &&
+					ruleConfig.Severity != utils.RuleSeverityAdvisory {
+					return fmt.Errorf("invalid severity '%s' for rule %s in section %s. Must be '%s' or '%s'",
+						ruleConfig.Severity, ruleConfig.Name, section.Name, utils.RuleSeverityBlocking, utils.RuleSeverityAdvisory)
+				}
+				for _, env := range ruleConfig.Environments {
+					if strings.TrimSpace(env) == "" {
+						return fmt.Errorf("empty environment for rule %s in section %s of file configuration %s", ruleConfig.Name, section.Name, fileConfig.Name)
+					}
+				}
 			}
 
 			// Auto-approve sections can have no rules, but warn if auto_approve is set with no rules
@@ -177,6 +430,29 @@ func ValidateRuleConfig(config *GlobalRuleConfig) error {
 		}
 	}
 
+	if config.NewFilePolicy != "" &&
+		config.NewFilePolicy != utils.NewFilePolicyValidate &&
+		config.NewFilePolicy != utils.NewFilePolicyManualReview {
+		return fmt.Errorf("invalid new_file_policy '%s'. Must be '%s' or '%s'",
+			config.NewFilePolicy, utils.NewFilePolicyValidate, utils.NewFilePolicyManualReview)
+	}
+
+	for i, fallback := range config.UnmatchedFiles {
+		if fallback.Pattern == "" {
+			return fmt.Errorf("unmatched_files entry at index %d missing pattern", i)
+		}
+		if fallback.Action != utils.DefaultActionManualReview && fallback.Action != utils.DefaultActionAutoApprove {
+			return fmt.Errorf("invalid action '%s' for unmatched_files pattern '%s'. Must be '%s' or '%s'",
+				fallback.Action, fallback.Pattern, utils.DefaultActionManualReview, utils.DefaultActionAutoApprove)
+		}
+	}
+
+	for i, marker := range config.GeneratedFileMarkers {
+		if strings.TrimSpace(marker) == "" {
+			return fmt.Errorf("generated_file_markers entry at index %d is empty", i)
+		}
+	}
+
 	return nil
 }
 
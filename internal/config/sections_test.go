@@ -0,0 +1,169 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const warehouseFragmentYAML = `enabled: true
+files:
+  - name: "warehouses"
+    path: "**/"
+    filename: "product.{yaml,yml}"
+    parser_type: yaml
+    enabled: true
+    sections:
+      - name: warehouses
+        yaml_path: warehouses
+        rule_configs:
+          - name: warehouse_rule
+            enabled: true
+`
+
+const docsFragmentYAML = `files:
+  - name: "documentation_files"
+    path: "**/"
+    filename: "*.md"
+    parser_type: yaml
+    enabled: true
+    sections:
+      - name: full_file
+        yaml_path: .
+        auto_approve: true
+always_manual_review:
+  - "**/secrets/**"
+safe_paths:
+  - "docs/**"
+delta_only_validation: true
+`
+
+func writeRuleFragments(t *testing.T, fragments map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range fragments {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0644))
+	}
+	return dir
+}
+
+func TestLoadRuleConfig_MergesDirectoryFragments(t *testing.T) {
+	dir := writeRuleFragments(t, map[string]string{
+		"20-docs.yaml":      docsFragmentYAML,
+		"10-warehouse.yaml": warehouseFragmentYAML,
+		"notes.txt":         "not a rule fragment",
+	})
+
+	ruleConfig, err := LoadRuleConfig(dir)
+
+	require.NoError(t, err)
+	assert.True(t, ruleConfig.Enabled)
+	assert.True(t, ruleConfig.DeltaOnlyValidation)
+	require.Len(t, ruleConfig.Files, 2)
+	assert.Equal(t, "warehouses", ruleConfig.Files[0].Name, "fragments are merged in filename order")
+	assert.Equal(t, "documentation_files", ruleConfig.Files[1].Name)
+	assert.Equal(t, []string{"**/secrets/**"}, ruleConfig.AlwaysManualReview)
+	assert.Equal(t, []string{"docs/**"}, ruleConfig.SafePaths)
+	assert.NotEmpty(t, ruleConfig.Source.SHA256)
+	assert.True(t, filepath.IsAbs(ruleConfig.Source.Path))
+
+	again, err := LoadRuleConfig(dir)
+	require.NoError(t, err)
+	assert.Equal(t, ruleConfig.Source.SHA256, again.Source.SHA256)
+}
+
+func TestLoadRuleConfig_DuplicateFileConfigAcrossFragments(t *testing.T) {
+	dir := writeRuleFragments(t, map[string]string{
+		"a.yaml": warehouseFragmentYAML,
+		"b.yaml": warehouseFragmentYAML,
+	})
+
+	_, err := LoadRuleConfig(dir)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `duplicate file configuration "warehouses"`)
+	assert.Contains(t, err.Error(), "b.yaml")
+	assert.Contains(t, err.Error(), "a.yaml")
+}
+
+func TestLoadRuleConfig_EmptyDirectory(t *testing.T) {
+	_, err := LoadRuleConfig(t.TempDir())
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no *.yaml rule config fragments")
+}
+
+func TestLoadRuleConfig_DiffContextLines(t *testing.T) {
+	dir := writeRuleFragments(t, map[string]string{"rules.yaml": warehouseFragmentYAML})
+	ruleConfig, err := LoadRuleConfig(filepath.Join(dir, "rules.yaml"))
+	require.NoError(t, err)
+	assert.Nil(t, ruleConfig.DiffContextLines)
+	assert.Equal(t, DefaultDiffContextLines, ruleConfig.DiffContext())
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "rules.yaml"), []byte(warehouseFragmentYAML+"diff_context_lines: 0\n"), 0644))
+	ruleConfig, err = LoadRuleConfig(filepath.Join(dir, "rules.yaml"))
+	require.NoError(t, err)
+	assert.Equal(t, 0, ruleConfig.DiffContext(), "zero disables padding rather than falling back to the default")
+}
+
+func TestLoadRuleConfig_UnmatchedFiles(t *testing.T) {
+	dir := writeRuleFragments(t, map[string]string{"rules.yaml": warehouseFragmentYAML + `unmatched_files:
+  - pattern: "**/*.{md,txt}"
+    action: auto_approve
+`})
+	ruleConfig, err := LoadRuleConfig(filepath.Join(dir, "rules.yaml"))
+	require.NoError(t, err)
+	assert.Equal(t, []UnmatchedFileFallback{{Pattern: "**/*.{md,txt}", Action: "auto_approve"}}, ruleConfig.UnmatchedFiles)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "rules.yaml"), []byte(warehouseFragmentYAML+`unmatched_files:
+  - pattern: "**/*.md"
+    action: approve
+`), 0644))
+	_, err = LoadRuleConfig(filepath.Join(dir, "rules.yaml"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid action 'approve' for unmatched_files pattern '**/*.md'")
+}
+
+func TestLoadRuleConfig_NewFilePolicy(t *testing.T) {
+	dir := writeRuleFragments(t, map[string]string{"rules.yaml": warehouseFragmentYAML + "new_file_policy: manual_review\n"})
+	ruleConfig, err := LoadRuleConfig(filepath.Join(dir, "rules.yaml"))
+	require.NoError(t, err)
+	assert.Equal(t, "manual_review", ruleConfig.NewFilePolicy)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "rules.yaml"), []byte(warehouseFragmentYAML+"new_file_policy: deny\n"), 0644))
+	_, err = LoadRuleConfig(filepath.Join(dir, "rules.yaml"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid new_file_policy 'deny'")
+}
+
+func TestLoadRuleConfig_SectionTreatment(t *testing.T) {
+	notesSection := "      - name: notes\n        yaml_path: notes\n        auto_approve: true\n        treatment: %s\n"
+	dir := writeRuleFragments(t, map[string]string{"rules.yaml": warehouseFragmentYAML + fmt.Sprintf(notesSection, "raw")})
+	ruleConfig, err := LoadRuleConfig(filepath.Join(dir, "rules.yaml"))
+	require.NoError(t, err)
+	sections := ruleConfig.Files[0].Sections
+	require.Len(t, sections, 2)
+	assert.False(t, sections[0].IsRaw(), "sections are structured by default")
+	assert.True(t, sections[1].IsRaw())
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "rules.yaml"), []byte(warehouseFragmentYAML+fmt.Sprintf(notesSection, "markdown")), 0644))
+	_, err = LoadRuleConfig(filepath.Join(dir, "rules.yaml"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid treatment 'markdown' for section notes")
+}
+
+func TestLoadRuleConfig_GeneratedFileMarkers(t *testing.T) {
+	dir := writeRuleFragments(t, map[string]string{"rules.yaml": "generated_file_markers: [\"# DO NOT EDIT\"]\n" + warehouseFragmentYAML})
+	ruleConfig, err := LoadRuleConfig(filepath.Join(dir, "rules.yaml"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"# DO NOT EDIT"}, ruleConfig.GeneratedFileMarkers)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "rules.yaml"), []byte("generated_file_markers: [\"  \"]\n"+warehouseFragmentYAML), 0644))
+	_, err = LoadRuleConfig(filepath.Join(dir, "rules.yaml"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "generated_file_markers entry at index 0 is empty")
+}
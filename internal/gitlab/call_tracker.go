@@ -0,0 +1,55 @@
+package gitlab
+
+import (
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// callTracker records when a GitLab instance last answered a request successfully
+type callTracker struct {
+	lastSuccess atomic.Int64 // Unix nanoseconds; 0 until the first success
+}
+
+var (
+	sharedCallTrackersMu sync.Mutex
+	sharedCallTrackers   = make(map[string]*callTracker)
+)
+
+// sharedCallTracker returns the call tracker for a GitLab instance, creating it on first use.
+// All clients talking to the same base URL report the same last success.
+func sharedCallTracker(baseURL string) *callTracker {
+	sharedCallTrackersMu.Lock()
+	defer sharedCallTrackersMu.Unlock()
+
+	if tracker, ok := sharedCallTrackers[baseURL]; ok {
+		return tracker
+	}
+	tracker := &callTracker{}
+	sharedCallTrackers[baseURL] = tracker
+	return tracker
+}
+
+// LastSuccess returns when a request last succeeded, or the zero time if none has
+func (t *callTracker) LastSuccess() time.Time {
+	nanos := t.lastSuccess.Load()
+	if nanos == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, nanos)
+}
+
+// Observe records a response below 400 as a successful call; errors, rejected tokens and
+// GitLab outages leave the last success unchanged
+func (t *callTracker) Observe(resp *http.Response) {
+	if resp.StatusCode < http.StatusBadRequest {
+		t.lastSuccess.Store(time.Now().UnixNano())
+	}
+}
+
+// LastSuccessfulCall returns when clients of this GitLab instance last made a successful
+// API call, or the zero time if none has succeeded since startup
+func (c *Client) LastSuccessfulCall() time.Time {
+	return sharedCallTracker(c.config.BaseURL).LastSuccess()
+}
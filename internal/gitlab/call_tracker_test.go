@@ -0,0 +1,40 @@
+package gitlab
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_LastSuccessfulCall(t *testing.T) {
+	status := http.StatusInternalServerError
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(`{"changes": []}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(config.GitLabConfig{BaseURL: server.URL, Token: "test-token"})
+	assert.True(t, client.LastSuccessfulCall().IsZero(), "no call has succeeded yet")
+
+	_, err := client.FetchMRChanges(123, 456)
+	require.Error(t, err)
+	assert.True(t, client.LastSuccessfulCall().IsZero(), "failed calls don't count")
+
+	status = http.StatusOK
+	before := time.Now()
+	_, err = client.FetchMRChanges(123, 456)
+	require.NoError(t, err)
+
+	lastSuccess := client.LastSuccessfulCall()
+	assert.False(t, lastSuccess.Before(before))
+	assert.False(t, lastSuccess.After(time.Now()))
+
+	other := NewClient(config.GitLabConfig{BaseURL: server.URL, Token: "other-token"})
+	assert.Equal(t, lastSuccess, other.LastSuccessfulCall(), "clients of the same instance share the timestamp")
+}
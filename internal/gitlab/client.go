@@ -5,13 +5,16 @@ import (
 	"crypto/tls"
 	"crypto/x509"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"net/url"
 	"os"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/redhat-data-and-ai/naysayer/internal/config"
@@ -21,13 +24,17 @@ import (
 
 // Client handles GitLab API operations
 type Client struct {
-	config config.GitLabConfig
-	http   *http.Client
+	config     config.GitLabConfig
+	http       *http.Client
+	projectIDs sync.Map // path_with_namespace -> numeric project ID
 }
 
 // createHTTPClient creates an HTTP client with custom TLS configuration
 func createHTTPClient(cfg config.GitLabConfig) (*http.Client, error) {
-	transport := &http.Transport{}
+	transport := &http.Transport{
+		DialContext:         (&net.Dialer{Timeout: time.Duration(cfg.DialTimeoutSeconds) * time.Second}).DialContext,
+		TLSHandshakeTimeout: time.Duration(cfg.TLSHandshakeTimeoutSeconds) * time.Second,
+	}
 
 	// Configure TLS settings
 	tlsConfig := &tls.Config{
@@ -39,34 +46,71 @@ func createHTTPClient(cfg config.GitLabConfig) (*http.Client, error) {
 		tlsConfig.InsecureSkipVerify = true
 	}
 
-	// Handle custom CA certificate
-	if cfg.CACertPath != "" {
-		caCert, err := os.ReadFile(cfg.CACertPath)
-		if err != nil {
-			return nil, fmt.Errorf("failed to read CA certificate from %s: %w", cfg.CACertPath, err)
-		}
-
+	// Handle custom CA certificates - every bundle is added to the same pool
+	if len(cfg.CACertPaths) > 0 {
 		caCertPool := x509.NewCertPool()
-		if !caCertPool.AppendCertsFromPEM(caCert) {
-			return nil, fmt.Errorf("failed to parse CA certificate from %s", cfg.CACertPath)
+		for _, caCertPath := range cfg.CACertPaths {
+			caCert, err := os.ReadFile(caCertPath)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read CA certificate from %s: %w", caCertPath, err)
+			}
+			if !caCertPool.AppendCertsFromPEM(caCert) {
+				return nil, fmt.Errorf("failed to parse CA certificate from %s", caCertPath)
+			}
 		}
 
 		tlsConfig.RootCAs = caCertPool
 	}
 
+	// Handle client certificate for mTLS gateways
+	if cfg.ClientCertPath != "" || cfg.ClientKeyPath != "" {
+		if cfg.ClientCertPath == "" || cfg.ClientKeyPath == "" {
+			return nil, fmt.Errorf("both client certificate and key paths are required for mTLS")
+		}
+		clientCert, err := tls.LoadX509KeyPair(cfg.ClientCertPath, cfg.ClientKeyPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate from %s: %w", cfg.ClientCertPath, err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{clientCert}
+	}
+
 	transport.TLSClientConfig = tlsConfig
 
+	// Track GitLab's RateLimit-* headers so requests pause while the quota is exhausted, and
+	// when GitLab last answered, so /health can tell a GitLab-blind instance apart
+	var roundTripper http.RoundTripper = &rateLimitHeaderTransport{
+		base:    transport,
+		tracker: sharedRateLimitTracker(cfg.BaseURL),
+		calls:   sharedCallTracker(cfg.BaseURL),
+	}
+
+	// Throttle outbound calls with a limiter shared by all clients for this GitLab instance
+	if cfg.RateLimitRPS > 0 {
+		roundTripper = &rateLimitedTransport{
+			base:    roundTripper,
+			limiter: sharedRateLimiter(cfg.BaseURL, cfg.RateLimitRPS, cfg.RateLimitBurst),
+		}
+	}
+
 	return &http.Client{
-		Transport: transport,
+		Transport: roundTripper,
+		Timeout:   time.Duration(cfg.RequestTimeoutSeconds) * time.Second, // A hung GitLab must not block a webhook forever
 	}, nil
 }
 
+// ValidateTLSConfig checks that the configured CA bundles and client certificate can be loaded
+func ValidateTLSConfig(cfg config.GitLabConfig) error {
+	_, err := createHTTPClient(cfg)
+	return err
+}
+
 // NewClient creates a new GitLab API client
 func NewClient(cfg config.GitLabConfig) *Client {
 	httpClient, err := createHTTPClient(cfg)
 	if err != nil {
 		// Fallback to default client if TLS configuration fails
-		httpClient = &http.Client{}
+		logging.Error("GitLab TLS configuration failed, falling back to default HTTP client: %v", err)
+		httpClient = &http.Client{Timeout: time.Duration(cfg.RequestTimeoutSeconds) * time.Second}
 	}
 
 	return &Client{
@@ -80,7 +124,8 @@ func NewClientWithConfig(cfg *config.Config) *Client {
 	httpClient, err := createHTTPClient(cfg.GitLab)
 	if err != nil {
 		// Fallback to default client if TLS configuration fails
-		httpClient = &http.Client{}
+		logging.Error("GitLab TLS configuration failed, falling back to default HTTP client: %v", err)
+		httpClient = &http.Client{Timeout: time.Duration(cfg.GitLab.RequestTimeoutSeconds) * time.Second}
 	}
 
 	return &Client{
@@ -136,10 +181,13 @@ func (c *Client) FetchMRChanges(projectID, mrIID int) ([]FileChange, error) {
 	return fileChanges, nil
 }
 
-// ExtractMRInfo extracts merge request information from webhook payload
-func ExtractMRInfo(payload map[string]interface{}) (*MRInfo, error) {
-	var projectID, mrIID int
-	var title, author, sourceBranch, targetBranch, state string
+// ExtractMRInfo extracts merge request information from webhook payload.
+// If the payload has no numeric project ID, project.path_with_namespace is resolved
+// through resolver; a nil resolver disables the lookup.
+func ExtractMRInfo(payload map[string]interface{}, resolver ProjectIDResolver) (*MRInfo, error) {
+	var projectID, mrIID, sourceProjectID int
+	var title, author, sourceBranch, targetBranch, state, lastCommit, action string
+	var draft bool
 
 	// Extract from object_attributes
 	if objectAttrs, ok := payload["object_attributes"].(map[string]interface{}); ok {
@@ -154,6 +202,11 @@ func ExtractMRInfo(payload map[string]interface{}) (*MRInfo, error) {
 			}
 		}
 
+		// Fork MRs carry the fork's project ID, where source-branch files live
+		if sourceProject, ok := objectAttrs["source_project_id"].(float64); ok {
+			sourceProjectID = int(sourceProject)
+		}
+
 		if titleVal, ok := objectAttrs["title"].(string); ok {
 			title = titleVal
 		}
@@ -169,9 +222,27 @@ func ExtractMRInfo(payload map[string]interface{}) (*MRInfo, error) {
 		if stateVal, ok := objectAttrs["state"].(string); ok {
 			state = stateVal
 		}
+
+		if actionVal, ok := objectAttrs["action"].(string); ok {
+			action = actionVal
+		}
+
+		if commit, ok := objectAttrs["last_commit"].(map[string]interface{}); ok {
+			if sha, ok := commit["id"].(string); ok {
+				lastCommit = sha
+			}
+		}
+
+		// GitLab sends both "draft" and the deprecated "work_in_progress" flag
+		if draftVal, ok := objectAttrs["draft"].(bool); ok && draftVal {
+			draft = true
+		}
+		if wipVal, ok := objectAttrs["work_in_progress"].(bool); ok && wipVal {
+			draft = true
+		}
 	}
 
-	// Extract project ID
+	// Extract project ID, falling back to a lookup by path when the payload has no numeric ID
 	if project, ok := payload["project"].(map[string]interface{}); ok {
 		if id, ok := project["id"]; ok {
 			switch v := id.(type) {
@@ -183,6 +254,14 @@ func ExtractMRInfo(payload map[string]interface{}) (*MRInfo, error) {
 				projectID, _ = strconv.Atoi(v)
 			}
 		}
+
+		if pathWithNamespace, ok := project["path_with_namespace"].(string); ok && projectID == 0 && pathWithNamespace != "" && resolver != nil {
+			resolvedID, err := resolver.GetProjectIDByPath(pathWithNamespace)
+			if err != nil {
+				return nil, fmt.Errorf("failed to resolve project ID for %s: %w", pathWithNamespace, err)
+			}
+			projectID = resolvedID
+		}
 	}
 
 	// Extract author from user
@@ -197,13 +276,17 @@ func ExtractMRInfo(payload map[string]interface{}) (*MRInfo, error) {
 	}
 
 	return &MRInfo{
-		ProjectID:    projectID,
-		MRIID:        mrIID,
-		Title:        title,
-		Author:       author,
-		SourceBranch: sourceBranch,
-		TargetBranch: targetBranch,
-		State:        state,
+		ProjectID:       projectID,
+		MRIID:           mrIID,
+		Title:           title,
+		Author:          author,
+		SourceBranch:    sourceBranch,
+		TargetBranch:    targetBranch,
+		State:           state,
+		LastCommit:      lastCommit,
+		Draft:           draft,
+		Action:          action,
+		SourceProjectID: sourceProjectID,
 	}, nil
 }
 
@@ -211,7 +294,18 @@ func ExtractMRInfo(payload map[string]interface{}) (*MRInfo, error) {
 func (c *Client) AddMRComment(projectID, mrIID int, comment string) error {
 	url := fmt.Sprintf("%s/api/v4/projects/%d/merge_requests/%d/notes",
 		strings.TrimRight(c.config.BaseURL, "/"), projectID, mrIID)
+	return c.addNote(url, comment, "MR")
+}
+
+// AddIssueComment adds a comment to an issue
+func (c *Client) AddIssueComment(projectID, issueIID int, comment string) error {
+	url := fmt.Sprintf("%s/api/v4/projects/%d/issues/%d/notes",
+		strings.TrimRight(c.config.BaseURL, "/"), projectID, issueIID)
+	return c.addNote(url, comment, "issue")
+}
 
+// addNote posts a note to a notes endpoint; target names the noteable in not-found errors
+func (c *Client) addNote(url, comment, target string) error {
 	payload := map[string]string{
 		"body": comment,
 	}
@@ -239,15 +333,106 @@ func (c *Client) AddMRComment(projectID, mrIID int, comment string) error {
 	case 201:
 		return nil // Success
 	case 401:
-		return fmt.Errorf("comment failed: insufficient permissions")
+		return fmt.Errorf("comment failed: %w", ErrInsufficientPermissions)
 	case 404:
-		return fmt.Errorf("comment failed: MR not found")
+		return fmt.Errorf("comment failed: %s %w", target, ErrNotFound)
 	default:
 		body, _ := io.ReadAll(resp.Body)
 		return fmt.Errorf("comment failed with status %d: %s", resp.StatusCode, string(body))
 	}
 }
 
+// DiffNotePosition anchors a discussion to a line of the MR diff
+type DiffNotePosition struct {
+	PositionType string `json:"position_type"`
+	BaseSHA      string `json:"base_sha"`
+	StartSHA     string `json:"start_sha"`
+	HeadSHA      string `json:"head_sha"`
+	OldPath      string `json:"old_path"`
+	NewPath      string `json:"new_path"`
+	NewLine      int    `json:"new_line"`
+}
+
+// newDiffNotePosition builds the position for a note on an added line of filePath at headSHA
+func newDiffNotePosition(diffRefs *DiffRefs, headSHA, filePath string, line int) DiffNotePosition {
+	if headSHA == "" {
+		headSHA = diffRefs.HeadSHA
+	}
+	return DiffNotePosition{
+		PositionType: "text",
+		BaseSHA:      diffRefs.BaseSHA,
+		StartSHA:     diffRefs.StartSHA,
+		HeadSHA:      headSHA,
+		OldPath:      filePath,
+		NewPath:      filePath,
+		NewLine:      line,
+	}
+}
+
+// AddMRDiffNote starts a discussion on a line of the MR diff.
+// sha is the MR head commit the line refers to (empty uses the latest diff version);
+// line must be an added line in the new version of filePath.
+func (c *Client) AddMRDiffNote(projectID, mrIID int, sha, filePath string, line int, body string) error {
+	details, err := c.GetMRDetails(projectID, mrIID)
+	if err != nil {
+		return fmt.Errorf("failed to get MR diff refs: %w", err)
+	}
+	if details.DiffRefs == nil {
+		return fmt.Errorf("MR %d has no diff refs yet", mrIID)
+	}
+
+	url := fmt.Sprintf("%s/api/v4/projects/%d/merge_requests/%d/discussions",
+		strings.TrimRight(c.config.BaseURL, "/"), projectID, mrIID)
+
+	payload := struct {
+		Body     string           `json:"body"`
+		Position DiffNotePosition `json:"position"`
+	}{
+		Body:     body,
+		Position: newDiffNotePosition(details.DiffRefs, sha, filePath, line),
+	}
+
+	jsonPayload, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal diff note payload: %w", err)
+	}
+
+	req, err := http.NewRequest("POST", url, bytes.NewBuffer(jsonPayload))
+	if err != nil {
+		return fmt.Errorf("failed to create diff note request: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+c.config.Token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to add diff note: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	switch resp.StatusCode {
+	case 201:
+		return nil // Success
+	case 401, 403:
+		return fmt.Errorf("diff note failed: %w", ErrInsufficientPermissions)
+	case 404:
+		return fmt.Errorf("diff note failed: MR %w", ErrNotFound)
+	default:
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("diff note on %s:%d failed with status %d: %s", filePath, line, resp.StatusCode, string(body))
+	}
+}
+
+// approvalToken returns the token used to approve and unapprove MRs, falling back
+// to the primary token when no dedicated approval token is configured
+func (c *Client) approvalToken() string {
+	if c.config.ApprovalToken != "" {
+		return c.config.ApprovalToken
+	}
+	return c.config.Token
+}
+
 // ApproveMR approves a merge request (simple approval without message)
 func (c *Client) ApproveMR(projectID, mrIID int) error {
 	return c.ApproveMRWithMessage(projectID, mrIID, "")
@@ -279,7 +464,7 @@ func (c *Client) ApproveMRWithMessage(projectID, mrIID int, message string) erro
 		return fmt.Errorf("failed to create approval request: %w", err)
 	}
 
-	req.Header.Set("Authorization", "Bearer "+c.config.Token)
+	req.Header.Set("Authorization", "Bearer "+c.approvalToken())
 	req.Header.Set("Content-Type", "application/json")
 
 	resp, err := c.http.Do(req)
@@ -292,17 +477,60 @@ func (c *Client) ApproveMRWithMessage(projectID, mrIID int, message string) erro
 	case 201:
 		return nil // Success
 	case 401:
-		return fmt.Errorf("approval failed: insufficient permissions")
+		return fmt.Errorf("approval failed: %w", ErrInsufficientPermissions)
 	case 404:
-		return fmt.Errorf("approval failed: MR not found")
+		return fmt.Errorf("approval failed: MR %w", ErrNotFound)
 	case 405:
-		return fmt.Errorf("approval failed: MR already approved or cannot be approved")
+		return fmt.Errorf("approval failed: MR %w", ErrAlreadyApproved)
 	default:
 		body, _ := io.ReadAll(resp.Body)
 		return fmt.Errorf("approval failed with status %d: %s", resp.StatusCode, string(body))
 	}
 }
 
+// SetCommitStatus posts a commit status for the given SHA so the naysayer
+// decision can be used as a required merge check
+func (c *Client) SetCommitStatus(projectID int, sha, state, description string) error {
+	url := fmt.Sprintf("%s/api/v4/projects/%d/statuses/%s",
+		strings.TrimRight(c.config.BaseURL, "/"), projectID, sha)
+
+	payload := map[string]string{
+		"state":       state,
+		"name":        CommitStatusName,
+		"description": description,
+	}
+	jsonPayload, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal commit status payload: %w", err)
+	}
+
+	req, err := http.NewRequest("POST", url, bytes.NewBuffer(jsonPayload))
+	if err != nil {
+		return fmt.Errorf("failed to create commit status request: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+c.config.Token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to set commit status: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	switch resp.StatusCode {
+	case 200, 201:
+		return nil // Success
+	case 401, 403:
+		return fmt.Errorf("commit status failed: %w", ErrInsufficientPermissions)
+	case 404:
+		return fmt.Errorf("commit status failed: commit %s %w", sha, ErrNotFound)
+	default:
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("commit status failed with status %d: %s", resp.StatusCode, string(body))
+	}
+}
+
 // ResetNaysayerApproval revokes naysayer's approval for a merge request
 // This is called when naysayer changes its decision from approve to manual review
 func (c *Client) ResetNaysayerApproval(projectID, mrIID int) error {
@@ -314,7 +542,7 @@ func (c *Client) ResetNaysayerApproval(projectID, mrIID int) error {
 		return fmt.Errorf("failed to create reset approval request: %w", err)
 	}
 
-	req.Header.Set("Authorization", "Bearer "+c.config.Token)
+	req.Header.Set("Authorization", "Bearer "+c.approvalToken())
 	req.Header.Set("Content-Type", "application/json")
 
 	resp, err := c.http.Do(req)
@@ -327,9 +555,9 @@ func (c *Client) ResetNaysayerApproval(projectID, mrIID int) error {
 	case 201:
 		return nil // Success
 	case 401:
-		return fmt.Errorf("reset approval failed: insufficient permissions")
+		return fmt.Errorf("reset approval failed: %w", ErrInsufficientPermissions)
 	case 404:
-		return fmt.Errorf("reset approval failed: MR not found")
+		return fmt.Errorf("reset approval failed: MR %w", ErrNotFound)
 	case 405:
 		return fmt.Errorf("reset approval failed: MR not approved or cannot be reset")
 	default:
@@ -338,6 +566,54 @@ func (c *Client) ResetNaysayerApproval(projectID, mrIID int) error {
 	}
 }
 
+// MRApprovals lists who currently approves a merge request
+type MRApprovals struct {
+	Approved   bool         `json:"approved"`
+	ApprovedBy []MRApprover `json:"approved_by"`
+}
+
+// MRApprover is a single approval of a merge request
+type MRApprover struct {
+	User map[string]interface{} `json:"user"`
+}
+
+// GetMRApprovals retrieves the current approvals of a merge request
+func (c *Client) GetMRApprovals(projectID, mrIID int) (*MRApprovals, error) {
+	url := fmt.Sprintf("%s/api/v4/projects/%d/merge_requests/%d/approvals",
+		strings.TrimRight(c.config.BaseURL, "/"), projectID, mrIID)
+
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create approvals request: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+c.config.Token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get MR approvals: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	switch resp.StatusCode {
+	case 200:
+	case 401, 403:
+		return nil, fmt.Errorf("get MR approvals failed: %w", ErrInsufficientPermissions)
+	case 404:
+		return nil, fmt.Errorf("get MR approvals failed: MR %w", ErrNotFound)
+	default:
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("get MR approvals failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var approvals MRApprovals
+	if err := json.NewDecoder(resp.Body).Decode(&approvals); err != nil {
+		return nil, fmt.Errorf("failed to decode MR approvals response: %w", err)
+	}
+	return &approvals, nil
+}
+
 // parseNextLink extracts the "next" page URL from GitLab's Link header
 // GitLab follows RFC 5988 format: <URL>; rel="next", <URL>; rel="prev"
 // Returns empty string if no next link exists
@@ -436,10 +712,10 @@ func (c *Client) ListMRComments(projectID, mrIID int) ([]MRComment, error) {
 
 		case 401:
 			_ = resp.Body.Close()
-			return nil, fmt.Errorf("list comments failed: insufficient permissions")
+			return nil, fmt.Errorf("list comments failed: %w", ErrInsufficientPermissions)
 		case 404:
 			_ = resp.Body.Close()
-			return nil, fmt.Errorf("list comments failed: MR not found")
+			return nil, fmt.Errorf("list comments failed: MR %w", ErrNotFound)
 		default:
 			// For first page, return error. For subsequent pages, gracefully degrade
 			if pageCount == 1 {
@@ -493,17 +769,50 @@ func (c *Client) UpdateMRComment(projectID, mrIID, commentID int, newBody string
 	case 200:
 		return nil // Success
 	case 401:
-		return fmt.Errorf("update comment failed: insufficient permissions")
+		return fmt.Errorf("update comment failed: %w", ErrInsufficientPermissions)
 	case 404:
-		return fmt.Errorf("update comment failed: comment or MR not found")
+		return fmt.Errorf("update comment failed: comment or MR %w", ErrNotFound)
 	case 403:
-		return fmt.Errorf("update comment failed: cannot edit this comment")
+		return fmt.Errorf("update comment failed: %w", ErrCannotEditComment)
 	default:
 		body, _ := io.ReadAll(resp.Body)
 		return fmt.Errorf("update comment failed with status %d: %s", resp.StatusCode, string(body))
 	}
 }
 
+// DeleteMRComment deletes a comment from a merge request
+func (c *Client) DeleteMRComment(projectID, mrIID, commentID int) error {
+	url := fmt.Sprintf("%s/api/v4/projects/%d/merge_requests/%d/notes/%d",
+		strings.TrimRight(c.config.BaseURL, "/"), projectID, mrIID, commentID)
+
+	req, err := http.NewRequest("DELETE", url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create delete comment request: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+c.config.Token)
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to delete comment: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	switch resp.StatusCode {
+	case 200, 204:
+		return nil // Success
+	case 401:
+		return fmt.Errorf("delete comment failed: %w", ErrInsufficientPermissions)
+	case 403:
+		return fmt.Errorf("delete comment failed: %w", ErrCannotDeleteComment)
+	case 404:
+		return fmt.Errorf("delete comment failed: comment or MR %w", ErrNotFound)
+	default:
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("delete comment failed with status %d: %s", resp.StatusCode, string(body))
+	}
+}
+
 // FindLatestNaysayerComment searches for the most recent comment from the current naysayer bot instance
 // If commentType is provided, only returns comments of that type. If empty, returns any naysayer comment.
 func (c *Client) FindLatestNaysayerComment(projectID, mrIID int, commentType ...string) (*MRComment, error) {
@@ -545,6 +854,8 @@ func (c *Client) matchesCommentType(body, commentType string) bool {
 		return strings.Contains(body, "<!-- naysayer-comment-id: approval -->")
 	case "manual-review":
 		return strings.Contains(body, "<!-- naysayer-comment-id: manual-review -->")
+	case "hold":
+		return strings.Contains(body, "<!-- naysayer-comment-id: hold -->")
 	default:
 		// For unknown types, match any naysayer comment
 		return strings.Contains(body, "<!-- naysayer-comment-id:")
@@ -601,8 +912,12 @@ func (c *Client) IsNaysayerBotAuthor(author map[string]interface{}) bool {
 	return false
 }
 
-// AddOrUpdateMRComment adds a new comment or updates the latest existing naysayer comment of the same type
+// AddOrUpdateMRComment adds a new comment or updates the latest existing naysayer comment of the same type.
+// Concurrent calls for the same MR are serialized, so they can't each create a comment.
 func (c *Client) AddOrUpdateMRComment(projectID, mrIID int, commentBody, commentType string) error {
+	unlock := lockMRComments(c.config.BaseURL, projectID, mrIID)
+	defer unlock()
+
 	// Find the latest naysayer comment of the same type
 	existingComment, err := c.FindLatestNaysayerComment(projectID, mrIID, commentType)
 	if err != nil {
@@ -613,8 +928,7 @@ func (c *Client) AddOrUpdateMRComment(projectID, mrIID int, commentBody, comment
 	if existingComment != nil {
 		if err := c.UpdateMRComment(projectID, mrIID, existingComment.ID, commentBody); err != nil {
 			// If update fails due to permissions, fallback to creating new comment
-			if strings.Contains(err.Error(), "cannot edit this comment") ||
-				strings.Contains(err.Error(), "insufficient permissions") {
+			if errors.Is(err, ErrCannotEditComment) || errors.Is(err, ErrInsufficientPermissions) {
 				return c.AddMRComment(projectID, mrIID, commentBody)
 			}
 			return err
@@ -666,9 +980,9 @@ func (c *Client) RebaseMR(projectID, mrIID int) (bool, error) {
 		}
 		return true, nil
 	case 403:
-		return false, fmt.Errorf("rebase failed: insufficient permissions or rebase not allowed: %s", bodyStr)
+		return false, fmt.Errorf("rebase failed: %w or rebase not allowed: %s", ErrInsufficientPermissions, bodyStr)
 	case 404:
-		return false, fmt.Errorf("rebase failed: MR not found")
+		return false, fmt.Errorf("rebase failed: MR %w", ErrNotFound)
 	case 409:
 		return false, fmt.Errorf("rebase failed: rebase already in progress or conflicts detected: %s", bodyStr)
 	default:
@@ -722,6 +1036,76 @@ func (c *Client) GetBranchCommit(projectID int, branch string) (string, error) {
 	return branchInfo.Commit.ID, nil
 }
 
+// IsBranchProtected reports whether a branch is protected, including by wildcard rules such as release/*.
+// GET /projects/:id/repository/branches/:branch
+func (c *Client) IsBranchProtected(projectID int, branch string) (bool, error) {
+	apiURL := fmt.Sprintf("%s/api/v4/projects/%d/repository/branches/%s",
+		strings.TrimRight(c.config.BaseURL, "/"), projectID, url.QueryEscape(branch))
+	req, err := http.NewRequest("GET", apiURL, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to create get branch request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+c.config.Token)
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("failed to get branch: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return false, fmt.Errorf("get branch failed with status %d: %s", resp.StatusCode, string(body))
+	}
+	var branchInfo struct {
+		Protected bool `json:"protected"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&branchInfo); err != nil {
+		return false, fmt.Errorf("failed to decode branch response: %w", err)
+	}
+	return branchInfo.Protected, nil
+}
+
+// GetMergeRefCommit returns the commit SHA of refs/merge-requests/:iid/merge, the result of merging
+// the MR's source branch into its current target branch.
+// GET /projects/:id/merge_requests/:iid/merge_ref (fails with 400 when the MR cannot be merged cleanly)
+func (c *Client) GetMergeRefCommit(projectID, mrIID int) (string, error) {
+	apiURL := fmt.Sprintf("%s/api/v4/projects/%d/merge_requests/%d/merge_ref",
+		strings.TrimRight(c.config.BaseURL, "/"), projectID, mrIID)
+	req, err := http.NewRequest("GET", apiURL, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create merge ref request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+c.config.Token)
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to get merge ref: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return "", fmt.Errorf("get merge ref failed: %w", ErrInsufficientPermissions)
+	case http.StatusNotFound:
+		return "", fmt.Errorf("get merge ref failed: MR %d %w", mrIID, ErrNotFound)
+	default:
+		body, _ := io.ReadAll(resp.Body)
+		return "", fmt.Errorf("get merge ref failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var mergeRef struct {
+		CommitID string `json:"commit_id"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&mergeRef); err != nil {
+		return "", fmt.Errorf("failed to decode merge ref response: %w", err)
+	}
+	if mergeRef.CommitID == "" {
+		return "", fmt.Errorf("merge ref for MR %d has no commit", mrIID)
+	}
+	return mergeRef.CommitID, nil
+}
+
 // CompareCommits compares two commits by SHA in one project.
 // Used for fork MRs: GitLab cannot compare across projects by branch; use MR.Sha (source HEAD) and target branch SHA.
 // GET /projects/:id/repository/compare?from=<source_sha>&to=<target_sha>
@@ -946,6 +1330,50 @@ func (c *Client) CloseMR(projectID, mrIID int) error {
 	return nil
 }
 
+// AddMRLabels adds labels to a merge request, keeping its existing labels
+func (c *Client) AddMRLabels(projectID, mrIID int, labels []string) error {
+	return c.updateMRLabels(projectID, mrIID, "add_labels", labels)
+}
+
+// RemoveMRLabels removes labels from a merge request; labels not on the MR are ignored
+func (c *Client) RemoveMRLabels(projectID, mrIID int, labels []string) error {
+	return c.updateMRLabels(projectID, mrIID, "remove_labels", labels)
+}
+
+// updateMRLabels sends an add_labels or remove_labels update for a merge request
+func (c *Client) updateMRLabels(projectID, mrIID int, field string, labels []string) error {
+	url := fmt.Sprintf("%s/api/v4/projects/%d/merge_requests/%d",
+		strings.TrimRight(c.config.BaseURL, "/"), projectID, mrIID)
+
+	payloadBytes, err := json.Marshal(map[string]string{
+		field: strings.Join(labels, ","),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal MR labels payload: %w", err)
+	}
+
+	req, err := http.NewRequest("PUT", url, bytes.NewBuffer(payloadBytes))
+	if err != nil {
+		return fmt.Errorf("failed to create MR labels request: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+c.config.Token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to update MR labels: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != 200 {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("update MR labels failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	return nil
+}
+
 // GetPipelineJobs retrieves all jobs for a pipeline
 func (c *Client) GetPipelineJobs(projectID, pipelineID int) ([]PipelineJob, error) {
 	url := fmt.Sprintf("%s/api/v4/projects/%d/pipelines/%d/jobs",
@@ -978,11 +1406,107 @@ func (c *Client) GetPipelineJobs(projectID, pipelineID int) ([]PipelineJob, erro
 	return jobs, nil
 }
 
+// GetProjectIDByPath returns the numeric ID of the project at pathWithNamespace (e.g. "group/subgroup/project").
+// Lookups are cached for the lifetime of the client since project IDs never change.
+func (c *Client) GetProjectIDByPath(pathWithNamespace string) (int, error) {
+	if cached, ok := c.projectIDs.Load(pathWithNamespace); ok {
+		return cached.(int), nil
+	}
+
+	encodedPath := url.QueryEscape(pathWithNamespace)
+	url := fmt.Sprintf("%s/api/v4/projects/%s",
+		strings.TrimRight(c.config.BaseURL, "/"), encodedPath)
+
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create project lookup request: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+c.config.Token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to look up project: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	switch resp.StatusCode {
+	case 200:
+	case 401, 403:
+		return 0, fmt.Errorf("project lookup failed: %w", ErrInsufficientPermissions)
+	case 404:
+		return 0, fmt.Errorf("project lookup failed: project %s %w", pathWithNamespace, ErrNotFound)
+	default:
+		body, _ := io.ReadAll(resp.Body)
+		return 0, fmt.Errorf("project lookup failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var project struct {
+		ID int `json:"id"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&project); err != nil {
+		return 0, fmt.Errorf("failed to decode project response: %w", err)
+	}
+	if project.ID == 0 {
+		return 0, fmt.Errorf("project lookup for %s returned no ID", pathWithNamespace)
+	}
+
+	c.projectIDs.Store(pathWithNamespace, project.ID)
+	return project.ID, nil
+}
+
 // JobTrace represents the trace content from a GitLab job
 type JobTrace struct {
 	Content string `json:"content"`
 }
 
+// GetMRHeadPipelineStatus returns the status of the MR's head pipeline
+// (e.g. "success", "failed", "running"), or an empty string if the MR has no pipeline
+func (c *Client) GetMRHeadPipelineStatus(projectID, mrIID int) (string, error) {
+	url := fmt.Sprintf("%s/api/v4/projects/%d/merge_requests/%d",
+		strings.TrimRight(c.config.BaseURL, "/"), projectID, mrIID)
+
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create head pipeline request: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+c.config.Token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to get head pipeline: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	switch resp.StatusCode {
+	case 200:
+	case 401, 403:
+		return "", fmt.Errorf("get head pipeline failed: %w", ErrInsufficientPermissions)
+	case 404:
+		return "", fmt.Errorf("get head pipeline failed: MR %w", ErrNotFound)
+	default:
+		body, _ := io.ReadAll(resp.Body)
+		return "", fmt.Errorf("get head pipeline failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var mr struct {
+		HeadPipeline *struct {
+			Status string `json:"status"`
+		} `json:"head_pipeline"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&mr); err != nil {
+		return "", fmt.Errorf("failed to decode head pipeline response: %w", err)
+	}
+
+	if mr.HeadPipeline == nil {
+		return "", nil
+	}
+	return mr.HeadPipeline.Status, nil
+}
+
 // GetJobTrace retrieves the trace/logs for a specific job
 func (c *Client) GetJobTrace(projectID, jobID int) (string, error) {
 	url := fmt.Sprintf("%s/api/v4/projects/%d/jobs/%d/trace",
@@ -62,6 +62,29 @@ func TestAddMRComment_Success(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestAddIssueComment(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		if r.URL.Path != "/api/v4/projects/123/issues/7/notes" {
+			w.WriteHeader(404)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		var payload map[string]string
+		_ = json.Unmarshal(body, &payload)
+		assert.Equal(t, "Rebase summary", payload["body"])
+		w.WriteHeader(201)
+	}))
+	defer server.Close()
+
+	client := NewClient(config.GitLabConfig{BaseURL: server.URL, Token: "test-token"})
+
+	assert.NoError(t, client.AddIssueComment(123, 7, "Rebase summary"))
+	err := client.AddIssueComment(123, 8, "Rebase summary")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "comment failed: issue not found")
+}
+
 func TestAddMRComment_UnauthorizedError(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(401)
@@ -402,3 +425,51 @@ func TestListMRComments_Pagination(t *testing.T) {
 	assert.Len(t, comments, 150)
 	assert.Equal(t, 2, requestCount)
 }
+
+func TestDeleteMRComment_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "DELETE", r.Method)
+		assert.Equal(t, "/api/v4/projects/123/merge_requests/456/notes/789", r.URL.Path)
+		assert.Equal(t, "Bearer test-token", r.Header.Get("Authorization"))
+		w.WriteHeader(204)
+	}))
+	defer server.Close()
+
+	client := NewClientWithConfig(&config.Config{
+		GitLab: config.GitLabConfig{BaseURL: server.URL, Token: "test-token"},
+	})
+
+	err := client.DeleteMRComment(123, 456, 789)
+
+	assert.NoError(t, err)
+}
+
+func TestDeleteMRComment_Errors(t *testing.T) {
+	tests := []struct {
+		status      int
+		expectedErr string
+	}{
+		{401, "insufficient permissions"},
+		{403, "cannot delete this comment"},
+		{404, "comment or MR not found"},
+		{500, "delete comment failed with status 500"},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("status %d", tt.status), func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			client := NewClientWithConfig(&config.Config{
+				GitLab: config.GitLabConfig{BaseURL: server.URL, Token: "test-token"},
+			})
+
+			err := client.DeleteMRComment(123, 456, 789)
+
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), tt.expectedErr)
+		})
+	}
+}
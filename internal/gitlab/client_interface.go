@@ -10,20 +10,31 @@ type GitLabClient interface {
 	// ListDirectoryFiles lists files in a directory using GitLab Repository Tree API
 	ListDirectoryFiles(projectID int, dirPath, ref string) ([]RepositoryFile, error)
 
+	// Project lookup
+	GetProjectIDByPath(pathWithNamespace string) (int, error)
+
 	// MR changes
 	FetchMRChanges(projectID, mrIID int) ([]FileChange, error)
 
 	// Comments
 	AddMRComment(projectID, mrIID int, comment string) error
+	AddIssueComment(projectID, issueIID int, comment string) error
 	AddOrUpdateMRComment(projectID, mrIID int, commentBody, commentType string) error
+	// AddMRDiffNote starts a discussion on an added line of filePath in the MR diff at head commit sha
+	AddMRDiffNote(projectID, mrIID int, sha, filePath string, line int, body string) error
 	ListMRComments(projectID, mrIID int) ([]MRComment, error)
 	UpdateMRComment(projectID, mrIID, commentID int, newBody string) error
+	DeleteMRComment(projectID, mrIID, commentID int) error
 	FindLatestNaysayerComment(projectID, mrIID int, commentType ...string) (*MRComment, error)
 
 	// Approvals
 	ApproveMR(projectID, mrIID int) error
 	ApproveMRWithMessage(projectID, mrIID int, message string) error
 	ResetNaysayerApproval(projectID, mrIID int) error
+	GetMRApprovals(projectID, mrIID int) (*MRApprovals, error)
+
+	// Commit statuses
+	SetCommitStatus(projectID int, sha, state, description string) error
 
 	// Bot identity
 	GetCurrentBotUsername() (string, error)
@@ -34,13 +45,18 @@ type GitLabClient interface {
 	CompareBranches(sourceProjectID int, sourceBranch string, targetProjectID int, targetBranch string) (*CompareResult, error)
 	// GetBranchCommit returns the commit SHA of the branch HEAD (for fork MR SHA-based compare)
 	GetBranchCommit(projectID int, branch string) (string, error)
+	// IsBranchProtected reports whether branch matches one of the project's protected branch rules
+	IsBranchProtected(projectID int, branch string) (bool, error)
 	// CompareCommits compares two commits by SHA in one project (used for fork MRs; GitLab cannot compare across projects by branch)
 	CompareCommits(projectID int, fromSHA, toSHA string) (*CompareResult, error)
+	// GetMergeRefCommit returns the commit SHA of the MR's merge ref (source merged into the current target)
+	GetMergeRefCommit(projectID, mrIID int) (string, error)
 	ListOpenMRs(projectID int) ([]int, error)
 	ListOpenMRsWithDetails(projectID int) ([]MRDetails, error)
 
 	// Pipeline and job operations
 	GetPipelineJobs(projectID, pipelineID int) ([]PipelineJob, error)
+	GetMRHeadPipelineStatus(projectID, mrIID int) (string, error)
 	GetJobTrace(projectID, jobID int) (string, error)
 	FindLatestAtlantisComment(projectID, mrIID int) (*MRComment, error)
 	AreAllPipelineJobsSucceeded(projectID, pipelineID int) (bool, error)
@@ -49,6 +65,15 @@ type GitLabClient interface {
 	ListAllOpenMRsWithDetails(projectID int) ([]MRDetails, error)
 	CloseMR(projectID, mrIID int) error
 	FindCommentByPattern(projectID, mrIID int, pattern string) (bool, error)
+
+	// Label operations
+	AddMRLabels(projectID, mrIID int, labels []string) error
+	RemoveMRLabels(projectID, mrIID int, labels []string) error
+}
+
+// ProjectIDResolver resolves a project's numeric ID from its full path
+type ProjectIDResolver interface {
+	GetProjectIDByPath(pathWithNamespace string) (int, error)
 }
 
 // Verify that Client implements GitLabClient interface
@@ -4,10 +4,12 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"testing"
 
 	"github.com/redhat-data-and-ai/naysayer/internal/config"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNewClient(t *testing.T) {
@@ -284,6 +286,90 @@ func TestExtractMRInfo_Success(t *testing.T) {
 				TargetBranch: "main",
 			},
 		},
+		{
+			name: "merge action",
+			payload: map[string]interface{}{
+				"object_attributes": map[string]interface{}{
+					"iid":    float64(123),
+					"state":  "merged",
+					"action": "merge",
+				},
+				"project": map[string]interface{}{
+					"id": float64(456),
+				},
+			},
+			expected: &MRInfo{
+				ProjectID: 456,
+				MRIID:     123,
+				State:     "merged",
+				Action:    "merge",
+			},
+		},
+		{
+			name: "payload with last commit",
+			payload: map[string]interface{}{
+				"object_attributes": map[string]interface{}{
+					"iid":           float64(123),
+					"title":         "Update warehouse configuration",
+					"source_branch": "feature/update-warehouse",
+					"target_branch": "main",
+					"state":         "opened",
+					"last_commit": map[string]interface{}{
+						"id": "da1560886d4f094c3e6c9ef40349f7d38b5d27d7",
+					},
+				},
+				"project": map[string]interface{}{
+					"id": float64(456),
+				},
+			},
+			expected: &MRInfo{
+				ProjectID:    456,
+				MRIID:        123,
+				Title:        "Update warehouse configuration",
+				SourceBranch: "feature/update-warehouse",
+				TargetBranch: "main",
+				State:        "opened",
+				LastCommit:   "da1560886d4f094c3e6c9ef40349f7d38b5d27d7",
+			},
+		},
+		{
+			name: "payload with draft flag",
+			payload: map[string]interface{}{
+				"object_attributes": map[string]interface{}{
+					"iid":   float64(123),
+					"title": "Update warehouse configuration",
+					"draft": true,
+				},
+				"project": map[string]interface{}{
+					"id": float64(456),
+				},
+			},
+			expected: &MRInfo{
+				ProjectID: 456,
+				MRIID:     123,
+				Title:     "Update warehouse configuration",
+				Draft:     true,
+			},
+		},
+		{
+			name: "payload with legacy work_in_progress flag",
+			payload: map[string]interface{}{
+				"object_attributes": map[string]interface{}{
+					"iid":              float64(123),
+					"title":            "Update warehouse configuration",
+					"work_in_progress": true,
+				},
+				"project": map[string]interface{}{
+					"id": float64(456),
+				},
+			},
+			expected: &MRInfo{
+				ProjectID: 456,
+				MRIID:     123,
+				Title:     "Update warehouse configuration",
+				Draft:     true,
+			},
+		},
 		{
 			name: "payload with integer types",
 			payload: map[string]interface{}{
@@ -357,7 +443,7 @@ func TestExtractMRInfo_Success(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result, err := ExtractMRInfo(tt.payload)
+			result, err := ExtractMRInfo(tt.payload, nil)
 
 			assert.NoError(t, err)
 			assert.Equal(t, tt.expected, result)
@@ -437,7 +523,7 @@ func TestExtractMRInfo_Errors(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result, err := ExtractMRInfo(tt.payload)
+			result, err := ExtractMRInfo(tt.payload, nil)
 
 			assert.Error(t, err)
 			assert.Nil(t, result)
@@ -490,7 +576,7 @@ func TestExtractMRInfo_TypeConversion(t *testing.T) {
 				},
 			}
 
-			result, err := ExtractMRInfo(payload)
+			result, err := ExtractMRInfo(payload, nil)
 
 			assert.NoError(t, err)
 			assert.Equal(t, tt.expected.ProjectID, result.ProjectID)
@@ -513,7 +599,7 @@ func TestExtractMRInfo_PartialData(t *testing.T) {
 		// Missing user section
 	}
 
-	result, err := ExtractMRInfo(payload)
+	result, err := ExtractMRInfo(payload, nil)
 
 	assert.NoError(t, err)
 	assert.Equal(t, 456, result.ProjectID)
@@ -522,6 +608,246 @@ func TestExtractMRInfo_PartialData(t *testing.T) {
 	assert.Equal(t, "", result.Author)
 	assert.Equal(t, "", result.SourceBranch)
 	assert.Equal(t, "", result.TargetBranch)
+	assert.Equal(t, 0, result.SourceProjectID)
+}
+
+func TestExtractMRInfo_SourceProjectID(t *testing.T) {
+	payload := map[string]interface{}{
+		"object_attributes": map[string]interface{}{
+			"iid":               float64(123),
+			"source_branch":     "feature",
+			"target_branch":     "main",
+			"source_project_id": float64(789),
+		},
+		"project": map[string]interface{}{
+			"id": float64(456),
+		},
+	}
+
+	result, err := ExtractMRInfo(payload, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 456, result.ProjectID)
+	assert.Equal(t, 789, result.SourceProjectID, "fork MRs keep the fork's project")
+}
+
+type stubProjectIDResolver struct {
+	ids   map[string]int
+	calls []string
+}
+
+func (r *stubProjectIDResolver) GetProjectIDByPath(pathWithNamespace string) (int, error) {
+	r.calls = append(r.calls, pathWithNamespace)
+	if id, ok := r.ids[pathWithNamespace]; ok {
+		return id, nil
+	}
+	return 0, ErrNotFound
+}
+
+func TestExtractMRInfo_ProjectPathFallback(t *testing.T) {
+	tests := []struct {
+		name          string
+		project       map[string]interface{}
+		expectedID    int
+		expectedCalls []string
+		expectError   bool
+	}{
+		{
+			name:       "numeric ID only",
+			project:    map[string]interface{}{"id": float64(456)},
+			expectedID: 456,
+		},
+		{
+			name:          "path only resolves through GitLab",
+			project:       map[string]interface{}{"path_with_namespace": "dataverse/configs/dataverse-config"},
+			expectedID:    789,
+			expectedCalls: []string{"dataverse/configs/dataverse-config"},
+		},
+		{
+			name:       "numeric ID wins over path",
+			project:    map[string]interface{}{"id": float64(456), "path_with_namespace": "dataverse/configs/dataverse-config"},
+			expectedID: 456,
+		},
+		{
+			name:          "unknown path",
+			project:       map[string]interface{}{"path_with_namespace": "dataverse/missing"},
+			expectedCalls: []string{"dataverse/missing"},
+			expectError:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resolver := &stubProjectIDResolver{ids: map[string]int{"dataverse/configs/dataverse-config": 789}}
+			payload := map[string]interface{}{
+				"object_attributes": map[string]interface{}{"iid": float64(123)},
+				"project":           tt.project,
+			}
+
+			result, err := ExtractMRInfo(payload, resolver)
+
+			assert.Equal(t, tt.expectedCalls, resolver.calls)
+			if tt.expectError {
+				assert.ErrorIs(t, err, ErrNotFound)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedID, result.ProjectID)
+			assert.Equal(t, 123, result.MRIID)
+		})
+	}
+}
+
+func TestExtractMRInfo_ProjectPathWithoutResolver(t *testing.T) {
+	payload := map[string]interface{}{
+		"object_attributes": map[string]interface{}{"iid": float64(123)},
+		"project":           map[string]interface{}{"path_with_namespace": "dataverse/configs/dataverse-config"},
+	}
+
+	_, err := ExtractMRInfo(payload, nil)
+
+	assert.ErrorContains(t, err, "missing project ID")
+}
+
+func TestClient_GetProjectIDByPath(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		assert.Equal(t, "/api/v4/projects/dataverse%2Fconfigs%2Fdataverse-config", r.URL.EscapedPath())
+		assert.Equal(t, "Bearer test-token", r.Header.Get("Authorization"))
+		_, _ = w.Write([]byte(`{"id": 789, "path_with_namespace": "dataverse/configs/dataverse-config"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(config.GitLabConfig{BaseURL: server.URL, Token: "test-token"})
+
+	for i := 0; i < 2; i++ {
+		projectID, err := client.GetProjectIDByPath("dataverse/configs/dataverse-config")
+		require.NoError(t, err)
+		assert.Equal(t, 789, projectID)
+	}
+	assert.Equal(t, 1, requests, "the path to ID mapping should be cached")
+}
+
+func TestClient_GetMergeRefCommit(t *testing.T) {
+	t.Run("merge ref available", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/api/v4/projects/123/merge_requests/456/merge_ref", r.URL.Path)
+			assert.Equal(t, "Bearer test-token", r.Header.Get("Authorization"))
+			_, _ = w.Write([]byte(`{"commit_id": "f00dcafe"}`))
+		}))
+		defer server.Close()
+
+		client := NewClient(config.GitLabConfig{BaseURL: server.URL, Token: "test-token"})
+		commit, err := client.GetMergeRefCommit(123, 456)
+
+		require.NoError(t, err)
+		assert.Equal(t, "f00dcafe", commit)
+	})
+
+	t.Run("merge conflicts", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"message": "Merge request is not mergeable"}`))
+		}))
+		defer server.Close()
+
+		client := NewClient(config.GitLabConfig{BaseURL: server.URL, Token: "test-token"})
+		_, err := client.GetMergeRefCommit(123, 456)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "status 400")
+	})
+}
+
+func TestNewDiffNotePosition(t *testing.T) {
+	diffRefs := &DiffRefs{BaseSHA: "base111", StartSHA: "start222", HeadSHA: "head333"}
+
+	position := newDiffNotePosition(diffRefs, "commit444", "dataproducts/agg/product.yaml", 12)
+
+	assert.Equal(t, DiffNotePosition{
+		PositionType: "text",
+		BaseSHA:      "base111",
+		StartSHA:     "start222",
+		HeadSHA:      "commit444",
+		OldPath:      "dataproducts/agg/product.yaml",
+		NewPath:      "dataproducts/agg/product.yaml",
+		NewLine:      12,
+	}, position)
+
+	assert.Equal(t, "head333", newDiffNotePosition(diffRefs, "", "product.yaml", 1).HeadSHA, "empty sha uses the latest diff version")
+}
+
+func TestClient_AddMRDiffNote(t *testing.T) {
+	var posted map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v4/projects/123/merge_requests/456":
+			_, _ = w.Write([]byte(`{"iid": 456, "diff_refs": {"base_sha": "base111", "start_sha": "start222", "head_sha": "head333"}}`))
+		case "/api/v4/projects/123/merge_requests/456/discussions":
+			assert.Equal(t, "POST", r.Method)
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&posted))
+			w.WriteHeader(http.StatusCreated)
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(config.GitLabConfig{BaseURL: server.URL, Token: "test-token"})
+	err := client.AddMRDiffNote(123, 456, "head333", "product.yaml", 7, "Check this line")
+
+	require.NoError(t, err)
+	assert.Equal(t, "Check this line", posted["body"])
+	assert.Equal(t, map[string]interface{}{
+		"position_type": "text",
+		"base_sha":      "base111",
+		"start_sha":     "start222",
+		"head_sha":      "head333",
+		"old_path":      "product.yaml",
+		"new_path":      "product.yaml",
+		"new_line":      float64(7),
+	}, posted["position"])
+}
+
+func TestClient_UpdateMRLabels(t *testing.T) {
+	var requests []map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "PUT", r.Method)
+		assert.Equal(t, "/api/v4/projects/123/merge_requests/456", r.URL.Path)
+		var payload map[string]string
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		requests = append(requests, payload)
+		_, _ = w.Write([]byte(`{"iid": 456}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(config.GitLabConfig{BaseURL: server.URL, Token: "test-token"})
+	require.NoError(t, client.AddMRLabels(123, 456, []string{"naysayer:needs-review", "data"}))
+	require.NoError(t, client.RemoveMRLabels(123, 456, []string{"naysayer:needs-review"}))
+
+	assert.Equal(t, []map[string]string{
+		{"add_labels": "naysayer:needs-review,data"},
+		{"remove_labels": "naysayer:needs-review"},
+	}, requests)
+}
+
+func TestClient_GetMRApprovals(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		assert.Equal(t, "/api/v4/projects/123/merge_requests/456/approvals", r.URL.Path)
+		_, _ = w.Write([]byte(`{"approved": true, "approved_by": [{"user": {"username": "alice"}}, {"user": {"username": "naysayer-bot"}}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(config.GitLabConfig{BaseURL: server.URL, Token: "test-token"})
+	approvals, err := client.GetMRApprovals(123, 456)
+
+	require.NoError(t, err)
+	assert.True(t, approvals.Approved)
+	require.Len(t, approvals.ApprovedBy, 2)
+	assert.Equal(t, "alice", approvals.ApprovedBy[0].User["username"])
+	assert.True(t, client.IsNaysayerBotAuthor(approvals.ApprovedBy[1].User))
 }
 
 func TestClient_FetchMRChanges_EmptyResponse(t *testing.T) {
@@ -582,3 +908,176 @@ func TestClient_RequestHeaders(t *testing.T) {
 	assert.Equal(t, "Bearer test-token-xyz", capturedHeaders.Get("Authorization"))
 	assert.Equal(t, "application/json", capturedHeaders.Get("Content-Type"))
 }
+
+func TestClient_SetCommitStatus_Success(t *testing.T) {
+	var capturedBody map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "/api/v4/projects/123/statuses/abc123", r.URL.Path)
+		assert.Equal(t, "Bearer test-token", r.Header.Get("Authorization"))
+		_ = json.NewDecoder(r.Body).Decode(&capturedBody)
+		w.WriteHeader(201)
+	}))
+	defer server.Close()
+
+	client := NewClient(config.GitLabConfig{BaseURL: server.URL, Token: "test-token"})
+
+	err := client.SetCommitStatus(123, "abc123", CommitStatusSuccess, "All files passed validation")
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"state":       "success",
+		"name":        "naysayer",
+		"description": "All files passed validation",
+	}, capturedBody)
+}
+
+func TestClient_SetCommitStatus_Errors(t *testing.T) {
+	tests := []struct {
+		status      int
+		expectedErr string
+	}{
+		{401, "insufficient permissions"},
+		{403, "insufficient permissions"},
+		{404, "commit abc123 not found"},
+		{400, "commit status failed with status 400"},
+	}
+
+	for _, tt := range tests {
+		t.Run(http.StatusText(tt.status), func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			client := NewClient(config.GitLabConfig{BaseURL: server.URL, Token: "test-token"})
+
+			err := client.SetCommitStatus(123, "abc123", CommitStatusPending, "Manual review required")
+
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), tt.expectedErr)
+		})
+	}
+}
+
+func TestClient_GetMRHeadPipelineStatus(t *testing.T) {
+	tests := []struct {
+		name         string
+		responseBody string
+		expected     string
+	}{
+		{"success", `{"iid": 456, "head_pipeline": {"id": 1, "status": "success"}}`, "success"},
+		{"failed", `{"iid": 456, "head_pipeline": {"id": 1, "status": "failed"}}`, "failed"},
+		{"running", `{"iid": 456, "head_pipeline": {"id": 1, "status": "running"}}`, "running"},
+		{"no pipeline", `{"iid": 456, "head_pipeline": null}`, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "GET", r.Method)
+				assert.Equal(t, "/api/v4/projects/123/merge_requests/456", r.URL.Path)
+				assert.Equal(t, "Bearer test-token", r.Header.Get("Authorization"))
+				_, _ = w.Write([]byte(tt.responseBody))
+			}))
+			defer server.Close()
+
+			client := NewClient(config.GitLabConfig{BaseURL: server.URL, Token: "test-token"})
+
+			status, err := client.GetMRHeadPipelineStatus(123, 456)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, status)
+		})
+	}
+}
+
+func TestClient_IsBranchProtected(t *testing.T) {
+	tests := []struct {
+		name         string
+		branch       string
+		status       int
+		responseBody string
+		expected     bool
+		expectError  bool
+	}{
+		{"protected", "main", 200, `{"name": "main", "protected": true}`, true, false},
+		{"protected by wildcard", "release/2024.1", 200, `{"name": "release/2024.1", "protected": true}`, true, false},
+		{"not protected", "scratch", 200, `{"name": "scratch", "protected": false}`, false, false},
+		{"missing branch", "gone", 404, `{"message": "404 Branch Not Found"}`, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "GET", r.Method)
+				assert.Equal(t, "/api/v4/projects/123/repository/branches/"+url.QueryEscape(tt.branch), r.URL.EscapedPath())
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.responseBody))
+			}))
+			defer server.Close()
+
+			client := NewClient(config.GitLabConfig{BaseURL: server.URL, Token: "test-token"})
+
+			protected, err := client.IsBranchProtected(123, tt.branch)
+
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expected, protected)
+		})
+	}
+}
+
+func TestClient_AuthorizationHeaderPerOperation(t *testing.T) {
+	operations := []struct {
+		name       string
+		isApproval bool
+		call       func(c *Client) error
+	}{
+		{"approve", true, func(c *Client) error { return c.ApproveMR(1, 2) }},
+		{"approve with message", true, func(c *Client) error { return c.ApproveMRWithMessage(1, 2, "lgtm") }},
+		{"reset approval", true, func(c *Client) error { return c.ResetNaysayerApproval(1, 2) }},
+		{"add comment", false, func(c *Client) error { return c.AddMRComment(1, 2, "hi") }},
+		{"fetch changes", false, func(c *Client) error { _, err := c.FetchMRChanges(1, 2); return err }},
+		{"list comments", false, func(c *Client) error { _, err := c.ListMRComments(1, 2); return err }},
+	}
+
+	tests := []struct {
+		name             string
+		approvalToken    string
+		expectedApproval string
+	}{
+		{"dedicated approval token", "approval-token", "Bearer approval-token"},
+		{"primary token fallback", "", "Bearer read-token"},
+	}
+
+	for _, tt := range tests {
+		for _, op := range operations {
+			t.Run(tt.name+"/"+op.name, func(t *testing.T) {
+				var authHeader string
+				server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					authHeader = r.Header.Get("Authorization")
+					if r.Method == "POST" {
+						w.WriteHeader(201)
+						return
+					}
+					_, _ = w.Write([]byte(`{"changes": []}`))
+				}))
+				defer server.Close()
+
+				client := NewClient(config.GitLabConfig{BaseURL: server.URL, Token: "read-token", ApprovalToken: tt.approvalToken})
+
+				_ = op.call(client)
+
+				if op.isApproval {
+					assert.Equal(t, tt.expectedApproval, authHeader)
+				} else {
+					assert.Equal(t, "Bearer read-token", authHeader)
+				}
+			})
+		}
+	}
+}
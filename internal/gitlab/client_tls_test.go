@@ -0,0 +1,155 @@
+package gitlab
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeTestCertificate writes a self-signed certificate and its key as PEM files
+// and returns their paths along with the parsed certificate
+func writeTestCertificate(t *testing.T, commonName string) (certPath, keyPath string, cert *x509.Certificate) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err = x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	certPath = filepath.Join(dir, commonName+".crt")
+	keyPath = filepath.Join(dir, commonName+".key")
+	require.NoError(t, os.WriteFile(certPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	require.NoError(t, os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600))
+	return certPath, keyPath, cert
+}
+
+func transportOf(t *testing.T, client *http.Client) *http.Transport {
+	t.Helper()
+	headerTransport, ok := client.Transport.(*rateLimitHeaderTransport)
+	require.True(t, ok, "expected an unthrottled client")
+	transport, ok := headerTransport.base.(*http.Transport)
+	require.True(t, ok, "expected an *http.Transport below the rate limit header tracking")
+	require.NotNil(t, transport.TLSClientConfig)
+	return transport
+}
+
+func TestCreateHTTPClient_MultipleCACertificates(t *testing.T) {
+	firstPath, _, first := writeTestCertificate(t, "gitlab-ca")
+	secondPath, _, second := writeTestCertificate(t, "gateway-ca")
+
+	client, err := createHTTPClient(config.GitLabConfig{CACertPaths: []string{firstPath, secondPath}})
+	require.NoError(t, err)
+
+	expected := x509.NewCertPool()
+	expected.AddCert(first)
+	expected.AddCert(second)
+
+	rootCAs := transportOf(t, client).TLSClientConfig.RootCAs
+	require.NotNil(t, rootCAs)
+	assert.True(t, expected.Equal(rootCAs), "both CA bundles should be in the trusted pool")
+}
+
+func TestCreateHTTPClient_ClientCertificate(t *testing.T) {
+	certPath, keyPath, cert := writeTestCertificate(t, "naysayer")
+
+	client, err := createHTTPClient(config.GitLabConfig{ClientCertPath: certPath, ClientKeyPath: keyPath})
+	require.NoError(t, err)
+
+	certificates := transportOf(t, client).TLSClientConfig.Certificates
+	require.Len(t, certificates, 1)
+	require.NotEmpty(t, certificates[0].Certificate)
+	assert.Equal(t, cert.Raw, certificates[0].Certificate[0])
+}
+
+func TestCreateHTTPClient_TLSErrors(t *testing.T) {
+	certPath, keyPath, _ := writeTestCertificate(t, "naysayer")
+	garbagePath := filepath.Join(t.TempDir(), "garbage.pem")
+	require.NoError(t, os.WriteFile(garbagePath, []byte("not a certificate"), 0o600))
+
+	tests := []struct {
+		name string
+		cfg  config.GitLabConfig
+	}{
+		{"missing CA file", config.GitLabConfig{CACertPaths: []string{certPath, "/nonexistent/ca.pem"}}},
+		{"unparseable CA file", config.GitLabConfig{CACertPaths: []string{garbagePath}}},
+		{"client cert without key", config.GitLabConfig{ClientCertPath: certPath}},
+		{"client key without cert", config.GitLabConfig{ClientKeyPath: keyPath}},
+		{"unparseable key", config.GitLabConfig{ClientCertPath: certPath, ClientKeyPath: garbagePath}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := createHTTPClient(tt.cfg)
+			assert.Error(t, err)
+			assert.Error(t, ValidateTLSConfig(tt.cfg))
+		})
+	}
+}
+
+func TestCreateHTTPClient_Timeouts(t *testing.T) {
+	client, err := createHTTPClient(config.GitLabConfig{RequestTimeoutSeconds: 30, DialTimeoutSeconds: 10, TLSHandshakeTimeoutSeconds: 5})
+	require.NoError(t, err)
+	assert.Equal(t, 30*time.Second, client.Timeout)
+	assert.Equal(t, 5*time.Second, transportOf(t, client).TLSHandshakeTimeout)
+	assert.NotNil(t, transportOf(t, client).DialContext)
+
+	unbounded, err := createHTTPClient(config.GitLabConfig{})
+	require.NoError(t, err)
+	assert.Zero(t, unbounded.Timeout)
+}
+
+func TestClient_RequestTimeout(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-time.After(10 * time.Second):
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client := NewClient(config.GitLabConfig{BaseURL: server.URL, Token: "test-token", RequestTimeoutSeconds: 1})
+
+	start := time.Now()
+	_, err := client.FetchMRChanges(123, 456)
+
+	require.Error(t, err)
+	var netErr net.Error
+	require.True(t, errors.As(err, &netErr), "expected a network error, got %v", err)
+	assert.True(t, netErr.Timeout())
+	assert.Less(t, time.Since(start), 5*time.Second)
+}
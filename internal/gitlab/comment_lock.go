@@ -0,0 +1,51 @@
+package gitlab
+
+import "sync"
+
+// mrCommentKey identifies an MR on a GitLab instance
+type mrCommentKey struct {
+	baseURL   string
+	projectID int
+	mrIID     int
+}
+
+// mrCommentLock serializes comment operations on one MR; holders counts the callers holding or
+// waiting for it, so it can be dropped once nobody needs it
+type mrCommentLock struct {
+	mu      sync.Mutex
+	holders int
+}
+
+var (
+	sharedCommentLocksMu sync.Mutex
+	sharedCommentLocks   = make(map[mrCommentKey]*mrCommentLock)
+)
+
+// lockMRComments blocks until no other caller in the process is posting or updating naysayer
+// comments on the MR, and returns the function that releases the lock. Without it, two
+// evaluations of the same MR (e.g. a retried webhook) can both find no existing comment and
+// each post one. All clients talking to the same base URL share the locks.
+func lockMRComments(baseURL string, projectID, mrIID int) func() {
+	key := mrCommentKey{baseURL: baseURL, projectID: projectID, mrIID: mrIID}
+
+	sharedCommentLocksMu.Lock()
+	lock, ok := sharedCommentLocks[key]
+	if !ok {
+		lock = &mrCommentLock{}
+		sharedCommentLocks[key] = lock
+	}
+	lock.holders++
+	sharedCommentLocksMu.Unlock()
+
+	lock.mu.Lock()
+	return func() {
+		lock.mu.Unlock()
+
+		sharedCommentLocksMu.Lock()
+		defer sharedCommentLocksMu.Unlock()
+		lock.holders--
+		if lock.holders == 0 {
+			delete(sharedCommentLocks, key)
+		}
+	}
+}
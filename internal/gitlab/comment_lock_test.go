@@ -0,0 +1,106 @@
+package gitlab
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddOrUpdateMRComment_ConcurrentEvaluationsPostOneComment(t *testing.T) {
+	var mu sync.Mutex
+	var notes []string
+	var posts, puts int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/api/v4/user":
+			_, _ = fmt.Fprint(w, `{"username": "naysayer-bot"}`)
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/notes"):
+			mu.Lock()
+			defer mu.Unlock()
+			var body strings.Builder
+			body.WriteString("[")
+			for i, note := range notes {
+				if i > 0 {
+					body.WriteString(",")
+				}
+				encoded, _ := json.Marshal(note)
+				_, _ = fmt.Fprintf(&body, `{"id": %d, "body": %s, "author": {"username": "naysayer-bot"}}`, i+1, encoded)
+			}
+			body.WriteString("]")
+			_, _ = fmt.Fprint(w, body.String())
+		case r.Method == http.MethodPost:
+			var payload map[string]string
+			_ = json.NewDecoder(r.Body).Decode(&payload)
+			// A slow create widens the window in which an unserialized caller sees no comment
+			time.Sleep(50 * time.Millisecond)
+			mu.Lock()
+			defer mu.Unlock()
+			posts++
+			notes = append(notes, payload["body"])
+			w.WriteHeader(http.StatusCreated)
+		case r.Method == http.MethodPut:
+			mu.Lock()
+			defer mu.Unlock()
+			puts++
+			_, _ = fmt.Fprint(w, `{}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	// Two handlers evaluating the same MR each have their own client
+	clients := []*Client{
+		NewClient(config.GitLabConfig{BaseURL: server.URL, Token: "test-token"}),
+		NewClient(config.GitLabConfig{BaseURL: server.URL, Token: "test-token"}),
+	}
+
+	var wg sync.WaitGroup
+	errs := make([]error, len(clients))
+	for i, client := range clients {
+		wg.Add(1)
+		go func(i int, client *Client) {
+			defer wg.Done()
+			errs[i] = client.AddOrUpdateMRComment(1, 2, "<!-- naysayer-comment-id: approval -->\nApproved", "approval")
+		}(i, client)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 1, posts, "only one evaluation creates the comment")
+	assert.Equal(t, 1, puts, "the other evaluation updates it")
+	assert.Len(t, notes, 1)
+
+	sharedCommentLocksMu.Lock()
+	defer sharedCommentLocksMu.Unlock()
+	assert.Empty(t, sharedCommentLocks, "locks are dropped once released")
+}
+
+func TestLockMRComments_DifferentMRsDoNotBlock(t *testing.T) {
+	unlock := lockMRComments("https://gitlab.example.com", 1, 2)
+	defer unlock()
+
+	done := make(chan struct{})
+	go func() {
+		lockMRComments("https://gitlab.example.com", 1, 3)()
+		lockMRComments("https://other.example.com", 1, 2)()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("locking another MR blocked on a held lock")
+	}
+}
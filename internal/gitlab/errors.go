@@ -0,0 +1,18 @@
+package gitlab
+
+import "errors"
+
+// Sentinel errors returned (wrapped) by Client methods so callers can branch
+// on failure causes with errors.Is instead of matching message text.
+var (
+	// ErrInsufficientPermissions indicates the token cannot perform the request (401/403)
+	ErrInsufficientPermissions = errors.New("insufficient permissions")
+	// ErrNotFound indicates the MR, comment, commit or file does not exist (404)
+	ErrNotFound = errors.New("not found")
+	// ErrAlreadyApproved indicates the MR is already approved or cannot be approved (405)
+	ErrAlreadyApproved = errors.New("already approved or cannot be approved")
+	// ErrCannotEditComment indicates the comment belongs to another user or is locked (403)
+	ErrCannotEditComment = errors.New("cannot edit this comment")
+	// ErrCannotDeleteComment indicates the comment belongs to another user or is locked (403)
+	ErrCannotDeleteComment = errors.New("cannot delete this comment")
+)
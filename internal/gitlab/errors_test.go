@@ -0,0 +1,125 @@
+package gitlab
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_SentinelErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      int
+		call        func(c *Client) error
+		expectedErr error
+	}{
+		{"add comment 401", 401, func(c *Client) error { return c.AddMRComment(1, 2, "hi") }, ErrInsufficientPermissions},
+		{"add comment 404", 404, func(c *Client) error { return c.AddMRComment(1, 2, "hi") }, ErrNotFound},
+		{"approve 401", 401, func(c *Client) error { return c.ApproveMR(1, 2) }, ErrInsufficientPermissions},
+		{"approve 404", 404, func(c *Client) error { return c.ApproveMR(1, 2) }, ErrNotFound},
+		{"approve 405", 405, func(c *Client) error { return c.ApproveMR(1, 2) }, ErrAlreadyApproved},
+		{"commit status 401", 401, func(c *Client) error { return c.SetCommitStatus(1, "abc", CommitStatusSuccess, "ok") }, ErrInsufficientPermissions},
+		{"commit status 403", 403, func(c *Client) error { return c.SetCommitStatus(1, "abc", CommitStatusSuccess, "ok") }, ErrInsufficientPermissions},
+		{"commit status 404", 404, func(c *Client) error { return c.SetCommitStatus(1, "abc", CommitStatusSuccess, "ok") }, ErrNotFound},
+		{"reset approval 401", 401, func(c *Client) error { return c.ResetNaysayerApproval(1, 2) }, ErrInsufficientPermissions},
+		{"reset approval 404", 404, func(c *Client) error { return c.ResetNaysayerApproval(1, 2) }, ErrNotFound},
+		{"list comments 401", 401, func(c *Client) error { _, err := c.ListMRComments(1, 2); return err }, ErrInsufficientPermissions},
+		{"list comments 404", 404, func(c *Client) error { _, err := c.ListMRComments(1, 2); return err }, ErrNotFound},
+		{"update comment 401", 401, func(c *Client) error { return c.UpdateMRComment(1, 2, 3, "hi") }, ErrInsufficientPermissions},
+		{"update comment 403", 403, func(c *Client) error { return c.UpdateMRComment(1, 2, 3, "hi") }, ErrCannotEditComment},
+		{"update comment 404", 404, func(c *Client) error { return c.UpdateMRComment(1, 2, 3, "hi") }, ErrNotFound},
+		{"delete comment 401", 401, func(c *Client) error { return c.DeleteMRComment(1, 2, 3) }, ErrInsufficientPermissions},
+		{"delete comment 403", 403, func(c *Client) error { return c.DeleteMRComment(1, 2, 3) }, ErrCannotDeleteComment},
+		{"delete comment 404", 404, func(c *Client) error { return c.DeleteMRComment(1, 2, 3) }, ErrNotFound},
+		{"head pipeline 403", 403, func(c *Client) error { _, err := c.GetMRHeadPipelineStatus(1, 2); return err }, ErrInsufficientPermissions},
+		{"head pipeline 404", 404, func(c *Client) error { _, err := c.GetMRHeadPipelineStatus(1, 2); return err }, ErrNotFound},
+		{"project lookup 401", 401, func(c *Client) error { _, err := c.GetProjectIDByPath("group/project"); return err }, ErrInsufficientPermissions},
+		{"project lookup 404", 404, func(c *Client) error { _, err := c.GetProjectIDByPath("group/project"); return err }, ErrNotFound},
+		{"merge ref 403", 403, func(c *Client) error { _, err := c.GetMergeRefCommit(1, 2); return err }, ErrInsufficientPermissions},
+		{"merge ref 404", 404, func(c *Client) error { _, err := c.GetMergeRefCommit(1, 2); return err }, ErrNotFound},
+		{"fetch file 404", 404, func(c *Client) error { _, err := c.FetchFileContent(1, "product.yaml", "main"); return err }, ErrNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			client := NewClient(config.GitLabConfig{BaseURL: server.URL, Token: "test-token"})
+
+			err := tt.call(client)
+
+			require.Error(t, err)
+			assert.True(t, errors.Is(err, tt.expectedErr), "expected %v to wrap %v", err, tt.expectedErr)
+		})
+	}
+}
+
+func TestClient_SentinelErrors_UnmappedStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	client := NewClient(config.GitLabConfig{BaseURL: server.URL, Token: "test-token"})
+
+	err := client.ApproveMR(1, 2)
+
+	require.Error(t, err)
+	for _, sentinel := range []error{ErrInsufficientPermissions, ErrNotFound, ErrAlreadyApproved, ErrCannotEditComment, ErrCannotDeleteComment} {
+		assert.False(t, errors.Is(err, sentinel))
+	}
+	assert.Contains(t, err.Error(), "approval failed with status 500")
+}
+
+func TestClient_SentinelErrors_KeepReadableMessages(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	client := NewClient(config.GitLabConfig{BaseURL: server.URL, Token: "test-token"})
+
+	err := client.UpdateMRComment(1, 2, 3, "hi")
+
+	require.Error(t, err)
+	assert.Equal(t, "update comment failed: cannot edit this comment", err.Error())
+}
+
+func TestAddOrUpdateMRComment_FallsBackToNewCommentWhenEditForbidden(t *testing.T) {
+	var created bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/api/v4/user":
+			w.WriteHeader(http.StatusOK)
+			_, _ = fmt.Fprint(w, `{"username": "naysayer-bot"}`)
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/notes"):
+			w.WriteHeader(http.StatusOK)
+			_, _ = fmt.Fprint(w, `[{"id": 7, "body": "<!-- naysayer-comment-id: approval -->", "author": {"username": "naysayer-bot"}}]`)
+		case r.Method == http.MethodPut:
+			w.WriteHeader(http.StatusForbidden)
+		case r.Method == http.MethodPost:
+			created = true
+			w.WriteHeader(http.StatusCreated)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(config.GitLabConfig{BaseURL: server.URL, Token: "test-token"})
+
+	err := client.AddOrUpdateMRComment(1, 2, "updated", "approval")
+
+	require.NoError(t, err)
+	assert.True(t, created)
+}
@@ -49,7 +49,7 @@ func (c *Client) FetchFileContent(projectID int, filePath, ref string) (*FileCon
 	defer func() { _ = resp.Body.Close() }()
 
 	if resp.StatusCode == http.StatusNotFound {
-		return nil, fmt.Errorf("file not found: %s", filePath)
+		return nil, fmt.Errorf("file %w: %s", ErrNotFound, filePath)
 	}
 
 	if resp.StatusCode != http.StatusOK {
@@ -112,6 +112,10 @@ func (c *Client) GetMRTargetBranch(projectID, mrIID int) (string, error) {
 
 // MRDetails represents merge request details
 type MRDetails struct {
+	Title                string      `json:"title"`
+	State                string      `json:"state"`
+	Draft                bool        `json:"draft"`
+	Author               MRAuthor    `json:"author"`
 	TargetBranch         string      `json:"target_branch"`
 	SourceBranch         string      `json:"source_branch"`
 	Sha                  string      `json:"sha"` // HEAD of source branch (used for fork MR compare)
@@ -127,6 +131,19 @@ type MRDetails struct {
 	MergeStatus          string      `json:"merge_status"`           // "can_be_merged", "cannot_be_merged", "checking", "unchecked"
 	RebaseInProgress     bool        `json:"rebase_in_progress"`     // True if rebase is currently in progress
 	HasConflicts         bool        `json:"has_conflicts"`          // True if MR has merge conflicts
+	DiffRefs             *DiffRefs   `json:"diff_refs"`              // SHAs of the latest MR diff version (nil if not computed yet)
+}
+
+// MRAuthor identifies the user who opened a merge request
+type MRAuthor struct {
+	Username string `json:"username"`
+}
+
+// DiffRefs identifies the latest diff version of an MR; diff note positions must reference it
+type DiffRefs struct {
+	BaseSHA  string `json:"base_sha"`
+	StartSHA string `json:"start_sha"`
+	HeadSHA  string `json:"head_sha"`
 }
 
 // MRPipeline represents pipeline information for an MR
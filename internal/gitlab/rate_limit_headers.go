@@ -0,0 +1,165 @@
+package gitlab
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/logging"
+)
+
+// rateLimitWarnFraction is the share of the quota left at which a warning is logged
+const rateLimitWarnFraction = 0.1
+
+// RateLimitStatus is GitLab's view of the API quota, read from the RateLimit-* headers
+// of the latest response
+type RateLimitStatus struct {
+	Limit      int       // RateLimit-Limit: requests allowed per window
+	Remaining  int       // RateLimit-Remaining: requests left in the current window
+	Reset      time.Time // RateLimit-Reset: when the window resets (zero if not sent)
+	ObservedAt time.Time // When the response carrying these values was received
+}
+
+// rateLimitTracker records the latest RateLimit-* headers for a GitLab instance and holds
+// back new requests while the quota is exhausted
+type rateLimitTracker struct {
+	mu     sync.Mutex
+	status RateLimitStatus
+	seen   bool // A response with RateLimit-Remaining has been observed
+	warned bool // A low-quota warning was logged since the quota was last healthy
+
+	now   func() time.Time
+	sleep func(time.Duration)
+}
+
+var (
+	sharedTrackersMu sync.Mutex
+	sharedTrackers   = make(map[string]*rateLimitTracker)
+)
+
+// newRateLimitTracker creates a tracker with no observed status
+func newRateLimitTracker() *rateLimitTracker {
+	return &rateLimitTracker{
+		now:   time.Now,
+		sleep: time.Sleep,
+	}
+}
+
+// sharedRateLimitTracker returns the tracker for a GitLab instance, creating it on first use.
+// All clients talking to the same base URL see the same quota.
+func sharedRateLimitTracker(baseURL string) *rateLimitTracker {
+	sharedTrackersMu.Lock()
+	defer sharedTrackersMu.Unlock()
+
+	if tracker, ok := sharedTrackers[baseURL]; ok {
+		return tracker
+	}
+	tracker := newRateLimitTracker()
+	sharedTrackers[baseURL] = tracker
+	return tracker
+}
+
+// Status returns the latest observed quota and whether any response carried RateLimit-* headers
+func (t *rateLimitTracker) Status() (RateLimitStatus, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.status, t.seen
+}
+
+// Observe records the RateLimit-* headers of a response; responses without them are ignored
+func (t *rateLimitTracker) Observe(header http.Header) {
+	remaining, err := strconv.Atoi(header.Get("RateLimit-Remaining"))
+	if err != nil {
+		return
+	}
+	limit, _ := strconv.Atoi(header.Get("RateLimit-Limit"))
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.now()
+	t.status = RateLimitStatus{
+		Limit:      limit,
+		Remaining:  remaining,
+		Reset:      parseRateLimitReset(header),
+		ObservedAt: now,
+	}
+	t.seen = true
+
+	if !t.isLow() {
+		t.warned = false
+		return
+	}
+	if !t.warned || remaining == 0 {
+		logging.Warn("GitLab API quota running low: %d of %d requests remaining, resets at %s",
+			remaining, limit, t.status.Reset.Format(time.RFC3339))
+		t.warned = true
+	}
+}
+
+// isLow reports whether the remaining quota is at or below the warning threshold
+func (t *rateLimitTracker) isLow() bool {
+	if t.status.Remaining <= 0 {
+		return true
+	}
+	return t.status.Limit > 0 && float64(t.status.Remaining) <= float64(t.status.Limit)*rateLimitWarnFraction
+}
+
+// WaitForReset blocks while the quota is exhausted, until its reset time (at most maxRetryAfter)
+func (t *rateLimitTracker) WaitForReset() {
+	t.mu.Lock()
+	var delay time.Duration
+	if t.seen && t.status.Remaining <= 0 && !t.status.Reset.IsZero() {
+		delay = t.status.Reset.Sub(t.now())
+	}
+	t.mu.Unlock()
+
+	if delay <= 0 {
+		return
+	}
+	if delay > maxRetryAfter {
+		delay = maxRetryAfter
+	}
+	logging.Warn("GitLab API quota exhausted, pausing requests for %s", delay)
+	t.sleep(delay)
+}
+
+// parseRateLimitReset reads RateLimit-Reset (Unix seconds), falling back to RateLimit-ResetTime (HTTP date)
+func parseRateLimitReset(header http.Header) time.Time {
+	if seconds, err := strconv.ParseInt(header.Get("RateLimit-Reset"), 10, 64); err == nil {
+		return time.Unix(seconds, 0)
+	}
+	if date, err := http.ParseTime(header.Get("RateLimit-ResetTime")); err == nil {
+		return date
+	}
+	return time.Time{}
+}
+
+// rateLimitHeaderTransport records the RateLimit-* headers of every response and
+// pauses new requests while GitLab reports the quota as exhausted
+type rateLimitHeaderTransport struct {
+	base    http.RoundTripper
+	tracker *rateLimitTracker
+	calls   *callTracker // Optional: records successful responses
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *rateLimitHeaderTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.tracker.WaitForReset()
+
+	resp, err := t.base.RoundTrip(req)
+	if err == nil {
+		t.tracker.Observe(resp.Header)
+		if t.calls != nil {
+			t.calls.Observe(resp)
+		}
+	}
+	return resp, err
+}
+
+// RateLimitStatus returns the latest GitLab API quota seen by clients of this GitLab instance
+// and whether any response has reported one yet
+func (c *Client) RateLimitStatus() (RateLimitStatus, bool) {
+	return sharedRateLimitTracker(c.config.BaseURL).Status()
+}
@@ -0,0 +1,200 @@
+package gitlab
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/logging"
+)
+
+// maxRetryAfter caps how long a single Retry-After response can pause outbound calls
+const maxRetryAfter = 60 * time.Second
+
+// rateLimiter is a token-bucket limiter shared by all goroutines using a client.
+// Callers reserve a token under the lock and sleep outside it, so concurrent
+// file workers queue up fairly instead of spinning.
+type rateLimiter struct {
+	mu           sync.Mutex
+	rate         float64   // Tokens added per second
+	burst        float64   // Maximum tokens held at once
+	tokens       float64   // Currently available tokens (negative when reserved ahead)
+	last         time.Time // Last refill time
+	blockedUntil time.Time // Set from Retry-After; no tokens are handed out before this
+
+	now   func() time.Time
+	sleep func(context.Context, time.Duration) error
+}
+
+var (
+	sharedLimitersMu sync.Mutex
+	sharedLimiters   = make(map[string]*rateLimiter)
+)
+
+// newRateLimiter creates a limiter allowing rps requests per second with the given burst
+func newRateLimiter(rps float64, burst int) *rateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &rateLimiter{
+		rate:   rps,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+		now:    time.Now,
+		sleep:  sleepContext,
+	}
+}
+
+// sleepContext waits for d, returning the context's error early if ctx is done first
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// sharedRateLimiter returns the limiter for a GitLab instance, creating it on first use.
+// All clients talking to the same base URL draw from the same bucket.
+func sharedRateLimiter(baseURL string, rps float64, burst int) *rateLimiter {
+	sharedLimitersMu.Lock()
+	defer sharedLimitersMu.Unlock()
+
+	if limiter, ok := sharedLimiters[baseURL]; ok {
+		return limiter
+	}
+	limiter := newRateLimiter(rps, burst)
+	sharedLimiters[baseURL] = limiter
+	return limiter
+}
+
+// Wait blocks until a request may be issued. It returns the context's error, without
+// using a token, if ctx is done first (e.g. the request was cancelled or the server is stopping).
+func (l *rateLimiter) Wait(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	now := l.now()
+
+	// Refill tokens for elapsed time
+	elapsed := now.Sub(l.last).Seconds()
+	if elapsed > 0 {
+		l.tokens += elapsed * l.rate
+		if l.tokens > l.burst {
+			l.tokens = l.burst
+		}
+		l.last = now
+	}
+
+	// Reserve a token; if none are available the caller waits for the deficit to refill
+	l.tokens--
+	var delay time.Duration
+	if l.tokens < 0 {
+		delay = time.Duration(-l.tokens / l.rate * float64(time.Second))
+	}
+	if blocked := l.blockedUntil.Sub(now); blocked > delay {
+		delay = blocked
+	}
+	l.mu.Unlock()
+
+	if delay > 0 {
+		if err := l.sleep(ctx, delay); err != nil {
+			// Hand the reserved token back to the callers still waiting
+			l.mu.Lock()
+			l.tokens = min(l.tokens+1, l.burst)
+			l.mu.Unlock()
+			return err
+		}
+	}
+	return nil
+}
+
+// PauseFor blocks all callers for d, used when GitLab responds with Retry-After
+func (l *rateLimiter) PauseFor(d time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	until := l.now().Add(d)
+	if until.After(l.blockedUntil) {
+		l.blockedUntil = until
+	}
+}
+
+// rateLimitedTransport acquires from the limiter before every request and
+// retries once after the Retry-After delay when GitLab still returns 429
+type rateLimitedTransport struct {
+	base    http.RoundTripper
+	limiter *rateLimiter
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *rateLimitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.limiter.Wait(req.Context()); err != nil {
+		return nil, err
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusTooManyRequests {
+		return resp, err
+	}
+
+	retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After"), t.limiter.now())
+	if !ok {
+		return resp, nil
+	}
+
+	// Requests with a body can only be replayed when it can be re-read
+	retryReq := req
+	if req.Body != nil && req.Body != http.NoBody {
+		if req.GetBody == nil {
+			return resp, nil
+		}
+		body, bodyErr := req.GetBody()
+		if bodyErr != nil {
+			return resp, nil
+		}
+		retryReq = req.Clone(req.Context())
+		retryReq.Body = body
+	}
+
+	_ = resp.Body.Close()
+	logging.Warn("GitLab rate limit hit for %s %s, retrying after %s", req.Method, req.URL.Path, retryAfter)
+	t.limiter.PauseFor(retryAfter)
+	if err := t.limiter.Wait(req.Context()); err != nil {
+		return nil, err
+	}
+
+	return t.base.RoundTrip(retryReq)
+}
+
+// parseRetryAfter parses a Retry-After header given in seconds or as an HTTP date
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	var delay time.Duration
+	if seconds, err := strconv.Atoi(value); err == nil {
+		delay = time.Duration(seconds) * time.Second
+	} else if date, err := http.ParseTime(value); err == nil {
+		delay = date.Sub(now)
+	} else {
+		return 0, false
+	}
+
+	if delay < 0 {
+		delay = 0
+	}
+	if delay > maxRetryAfter {
+		delay = maxRetryAfter
+	}
+	return delay, true
+}
@@ -0,0 +1,369 @@
+package gitlab
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeClock records limiter sleeps, making rate assertions deterministic.
+// When advance is set, sleeping also moves the clock forward.
+type fakeClock struct {
+	mu      sync.Mutex
+	current time.Time
+	slept   time.Duration
+	advance bool
+}
+
+func (c *fakeClock) now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.current
+}
+
+func (c *fakeClock) sleep(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.slept += d
+	if c.advance {
+		c.current = c.current.Add(d)
+	}
+}
+
+// sleepContext records the sleep like sleep, then reports whether ctx ended meanwhile
+func (c *fakeClock) sleepContext(ctx context.Context, d time.Duration) error {
+	c.sleep(d)
+	return ctx.Err()
+}
+
+func newTestLimiter(rps float64, burst int) (*rateLimiter, *fakeClock) {
+	clock := &fakeClock{current: time.Unix(1700000000, 0)}
+	limiter := newRateLimiter(rps, burst)
+	limiter.now = clock.now
+	limiter.sleep = clock.sleepContext
+	limiter.last = clock.current
+	return limiter, clock
+}
+
+func TestRateLimiter_BurstIsBounded(t *testing.T) {
+	limiter, clock := newTestLimiter(10, 3)
+
+	// The first burst requests go through immediately
+	for i := 0; i < 3; i++ {
+		assert.NoError(t, limiter.Wait(context.Background()))
+	}
+	assert.Equal(t, time.Duration(0), clock.slept)
+
+	// The next request has to wait for a token to refill
+	assert.NoError(t, limiter.Wait(context.Background()))
+	assert.Equal(t, 100*time.Millisecond, clock.slept)
+}
+
+func TestRateLimiter_CapsRequestRate(t *testing.T) {
+	limiter, clock := newTestLimiter(5, 1)
+	clock.advance = true
+
+	for i := 0; i < 11; i++ {
+		assert.NoError(t, limiter.Wait(context.Background()))
+	}
+
+	// 1 request from the burst, the remaining 10 are spread at 5/sec
+	assert.Equal(t, 2*time.Second, clock.slept)
+}
+
+func TestRateLimiter_RefillsOverTime(t *testing.T) {
+	limiter, clock := newTestLimiter(10, 2)
+
+	assert.NoError(t, limiter.Wait(context.Background()))
+	assert.NoError(t, limiter.Wait(context.Background()))
+
+	clock.mu.Lock()
+	clock.current = clock.current.Add(time.Second)
+	clock.mu.Unlock()
+
+	// Refill never exceeds the burst size
+	assert.NoError(t, limiter.Wait(context.Background()))
+	assert.NoError(t, limiter.Wait(context.Background()))
+	assert.Equal(t, time.Duration(0), clock.slept)
+	assert.NoError(t, limiter.Wait(context.Background()))
+	assert.Equal(t, 100*time.Millisecond, clock.slept)
+}
+
+func TestRateLimiter_ConcurrentCallers(t *testing.T) {
+	limiter, clock := newTestLimiter(10, 5)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 25; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, limiter.Wait(context.Background()))
+		}()
+	}
+	wg.Wait()
+
+	// Each of the 20 requests beyond the burst reserves its own slot: 0.1s + 0.2s + ... + 2.0s
+	assert.Equal(t, 21*time.Second, clock.slept)
+	assert.InDelta(t, -20, limiter.tokens, 0.0001)
+}
+
+func TestRateLimiter_PauseFor(t *testing.T) {
+	limiter, clock := newTestLimiter(10, 5)
+
+	limiter.PauseFor(3 * time.Second)
+	assert.NoError(t, limiter.Wait(context.Background()))
+
+	assert.Equal(t, 3*time.Second, clock.slept)
+}
+
+func TestRateLimiter_WaitStopsWhenContextEnds(t *testing.T) {
+	// Real clock: the second call would wait a full second for its token
+	limiter := newRateLimiter(1, 1)
+	assert.NoError(t, limiter.Wait(context.Background()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	err := limiter.Wait(ctx)
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+
+	// The abandoned reservation is handed back rather than delaying later callers further
+	limiter.mu.Lock()
+	defer limiter.mu.Unlock()
+	assert.Greater(t, limiter.tokens, -1.0)
+}
+
+func TestRateLimiter_WaitWithEndedContextUsesNoToken(t *testing.T) {
+	limiter, clock := newTestLimiter(1, 1)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	assert.ErrorIs(t, limiter.Wait(ctx), context.Canceled)
+	assert.Equal(t, 1.0, limiter.tokens)
+	assert.Zero(t, clock.slept)
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		value    string
+		expected time.Duration
+		ok       bool
+	}{
+		{"seconds", "5", 5 * time.Second, true},
+		{"http date", now.Add(10 * time.Second).Format(http.TimeFormat), 10 * time.Second, true},
+		{"past date", now.Add(-10 * time.Second).Format(http.TimeFormat), 0, true},
+		{"capped", "3600", maxRetryAfter, true},
+		{"empty", "", 0, false},
+		{"invalid", "soon", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			delay, ok := parseRetryAfter(tt.value, now)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.expected, delay)
+		})
+	}
+}
+
+func TestRateLimitedTransport_RetriesAfter429(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.Header().Set("Retry-After", "2")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	limiter, clock := newTestLimiter(10, 5)
+	client := &http.Client{Transport: &rateLimitedTransport{base: http.DefaultTransport, limiter: limiter}}
+
+	resp, err := client.Get(server.URL)
+	assert.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+	assert.Equal(t, 2*time.Second, clock.slept)
+}
+
+func TestRateLimitedTransport_429WithoutRetryAfter(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	limiter, _ := newTestLimiter(10, 5)
+	client := &http.Client{Transport: &rateLimitedTransport{base: http.DefaultTransport, limiter: limiter}}
+
+	resp, err := client.Get(server.URL)
+	assert.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestRateLimitedTransport_CancelledRequestIsNotSent(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+	}))
+	defer server.Close()
+
+	limiter, _ := newTestLimiter(10, 5)
+	client := &http.Client{Transport: &rateLimitedTransport{base: http.DefaultTransport, limiter: limiter}}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	assert.NoError(t, err)
+
+	resp, err := client.Do(req)
+	if resp != nil {
+		_ = resp.Body.Close()
+	}
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Zero(t, atomic.LoadInt32(&calls))
+}
+
+func TestCreateHTTPClient_RateLimiting(t *testing.T) {
+	limited, err := createHTTPClient(config.GitLabConfig{BaseURL: "https://limited.example.com", RateLimitRPS: 5, RateLimitBurst: 2})
+	assert.NoError(t, err)
+	transport, ok := limited.Transport.(*rateLimitedTransport)
+	assert.True(t, ok)
+
+	// Clients for the same GitLab instance share one bucket
+	other, err := createHTTPClient(config.GitLabConfig{BaseURL: "https://limited.example.com", RateLimitRPS: 5, RateLimitBurst: 2})
+	assert.NoError(t, err)
+	assert.Same(t, transport.limiter, other.Transport.(*rateLimitedTransport).limiter)
+
+	unlimited, err := createHTTPClient(config.GitLabConfig{BaseURL: "https://limited.example.com"})
+	assert.NoError(t, err)
+	headerTransport, ok := unlimited.Transport.(*rateLimitHeaderTransport)
+	assert.True(t, ok)
+	_, ok = headerTransport.base.(*http.Transport)
+	assert.True(t, ok)
+}
+
+func newTestRateLimitTracker() (*rateLimitTracker, *fakeClock) {
+	clock := &fakeClock{current: time.Unix(1700000000, 0)}
+	tracker := newRateLimitTracker()
+	tracker.now = clock.now
+	tracker.sleep = clock.sleep
+	return tracker, clock
+}
+
+func TestRateLimitTracker_ParsesHeaders(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("RateLimit-Limit", "600")
+		w.Header().Set("RateLimit-Remaining", "42")
+		w.Header().Set("RateLimit-Reset", "1700000060")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	tracker, clock := newTestRateLimitTracker()
+	_, seen := tracker.Status()
+	assert.False(t, seen)
+
+	client := &http.Client{Transport: &rateLimitHeaderTransport{base: http.DefaultTransport, tracker: tracker}}
+	resp, err := client.Get(server.URL)
+	assert.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+
+	status, seen := tracker.Status()
+	assert.True(t, seen)
+	assert.Equal(t, 600, status.Limit)
+	assert.Equal(t, 42, status.Remaining)
+	assert.Equal(t, time.Unix(1700000060, 0), status.Reset)
+	assert.Equal(t, clock.current, status.ObservedAt)
+	assert.True(t, tracker.warned, "42 of 600 is below the warning threshold")
+}
+
+func TestRateLimitTracker_IgnoresResponsesWithoutHeaders(t *testing.T) {
+	tracker, _ := newTestRateLimitTracker()
+	tracker.Observe(http.Header{"Ratelimit-Remaining": []string{"10"}, "Ratelimit-Limit": []string{"20"}})
+
+	tracker.Observe(http.Header{})
+
+	status, seen := tracker.Status()
+	assert.True(t, seen)
+	assert.Equal(t, 10, status.Remaining, "a response without headers keeps the last status")
+	assert.True(t, status.Reset.IsZero())
+}
+
+func TestParseRateLimitReset(t *testing.T) {
+	assert.Equal(t, time.Unix(1700000060, 0), parseRateLimitReset(http.Header{"Ratelimit-Reset": []string{"1700000060"}}))
+	assert.Equal(t, time.Date(2023, 11, 14, 22, 14, 20, 0, time.UTC),
+		parseRateLimitReset(http.Header{"Ratelimit-Resettime": []string{"Tue, 14 Nov 2023 22:14:20 GMT"}}))
+	assert.True(t, parseRateLimitReset(http.Header{}).IsZero())
+}
+
+func TestRateLimitTracker_PausesWhenExhausted(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Header().Set("RateLimit-Limit", "600")
+		w.Header().Set("RateLimit-Remaining", "0")
+		w.Header().Set("RateLimit-Reset", "1700000030")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	tracker, clock := newTestRateLimitTracker()
+	client := &http.Client{Transport: &rateLimitHeaderTransport{base: http.DefaultTransport, tracker: tracker}}
+
+	// The first request goes through and reports the quota as exhausted
+	resp, err := client.Get(server.URL)
+	assert.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, time.Duration(0), clock.slept)
+
+	// The next request waits until the quota resets
+	resp, err = client.Get(server.URL)
+	assert.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, 30*time.Second, clock.slept)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
+
+func TestRateLimitTracker_PauseIsCapped(t *testing.T) {
+	tracker, clock := newTestRateLimitTracker()
+	tracker.Observe(http.Header{
+		"Ratelimit-Remaining": []string{"0"},
+		"Ratelimit-Reset":     []string{"1700003600"},
+	})
+
+	tracker.WaitForReset()
+
+	assert.Equal(t, maxRetryAfter, clock.slept)
+}
+
+func TestRateLimitTracker_NoPauseAfterReset(t *testing.T) {
+	tracker, clock := newTestRateLimitTracker()
+	tracker.Observe(http.Header{
+		"Ratelimit-Remaining": []string{"0"},
+		"Ratelimit-Reset":     []string{"1699999990"},
+	})
+
+	tracker.WaitForReset()
+
+	assert.Equal(t, time.Duration(0), clock.slept)
+}
@@ -28,15 +28,30 @@ type FileChange struct {
 
 // MRInfo represents merge request information extracted from webhook payload
 type MRInfo struct {
-	ProjectID    int
-	MRIID        int
-	Title        string
-	Author       string
-	SourceBranch string
-	TargetBranch string
-	State        string
+	ProjectID       int
+	MRIID           int
+	Title           string
+	Author          string
+	SourceBranch    string
+	TargetBranch    string
+	State           string
+	LastCommit      string // SHA of the MR's last commit (object_attributes.last_commit.id)
+	Draft           bool   // MR is marked as draft (object_attributes.draft or work_in_progress)
+	Action          string // Webhook action that triggered the event (object_attributes.action, e.g. "update", "merge")
+	SourceProjectID int    // Project holding the source branch (object_attributes.source_project_id); 0 when unknown
 }
 
+// Commit status reported for naysayer decisions
+const (
+	CommitStatusName    = "naysayer" // Status name shown in the MR pipeline widget
+	CommitStatusSuccess = "success"
+	CommitStatusPending = "pending"
+	CommitStatusFailed  = "failed"
+)
+
+// PipelineStatusSuccess is the head pipeline status required by REQUIRE_PASSING_PIPELINE
+const PipelineStatusSuccess = "success"
+
 // PipelineJob represents a GitLab CI job
 type PipelineJob struct {
 	ID            int    `json:"id"`
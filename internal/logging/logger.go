@@ -83,6 +83,22 @@ func logLevelToZap(level LogLevel) zapcore.Level {
 	}
 }
 
+// NewLoggerFromZap wraps an existing zap logger, e.g. an observer core in tests
+func NewLoggerFromZap(zapLogger *zap.Logger) *Logger {
+	return &Logger{zap: zapLogger, level: DEBUG}
+}
+
+// DebugEnabled reports whether debug entries would be written, so expensive
+// debug fields can be skipped at higher levels
+func (l *Logger) DebugEnabled() bool {
+	return l.zap.Core().Enabled(zapcore.DebugLevel)
+}
+
+// Debug logs a structured debug message
+func (l *Logger) Debug(message string, fields ...zap.Field) {
+	l.zap.Debug(message, fields...)
+}
+
 // Info logs info messages
 func (l *Logger) Info(message string, args ...interface{}) {
 	if len(args) == 0 {
@@ -144,6 +160,17 @@ func InitLogger(level string, component string) {
 }
 
 // Global logging functions (only the ones actually used)
+func Debug(message string, fields ...zap.Field) {
+	if defaultLogger != nil {
+		defaultLogger.Debug(message, fields...)
+	}
+}
+
+// DebugEnabled reports whether the global logger writes debug entries
+func DebugEnabled() bool {
+	return defaultLogger != nil && defaultLogger.DebugEnabled()
+}
+
 func Info(message string, args ...interface{}) {
 	if defaultLogger != nil {
 		defaultLogger.Info(message, args...)
@@ -186,6 +213,11 @@ func GetLogger() *Logger {
 	return defaultLogger
 }
 
+// SetLogger replaces the global logger (tests use it to capture output)
+func SetLogger(logger *Logger) {
+	defaultLogger = logger
+}
+
 func init() {
 	// Initialize with default logger if not already done
 	if defaultLogger == nil {
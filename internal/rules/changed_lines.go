@@ -0,0 +1,64 @@
+package rules
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+)
+
+// changedLines returns the new-file lines the hunk adds or modifies, rather than its whole span,
+// so unchanged context lines don't pull neighbouring sections into validation. A pure deletion
+// has no new-file line of its own and is attributed to the lines on either side of it.
+// Hunks without a body fall back to the header's span.
+func (h diffHunk) changedLines() []shared.LineRange {
+	matches := hunkNewRangePattern.FindStringSubmatch(h.header)
+	if matches == nil {
+		return nil
+	}
+	if len(h.added) == 0 && len(h.removed) == 0 {
+		if lineRange := parseHunkHeader(h.header); lineRange != nil {
+			return []shared.LineRange{*lineRange}
+		}
+		return nil
+	}
+
+	line, _ := strconv.Atoi(matches[1])
+	if matches[2] == "0" {
+		line++ // A pure deletion hunk names the line it follows
+	}
+
+	var ranges []shared.LineRange
+	mark := func(start, end int) {
+		start = max(start, 1)
+		if n := len(ranges); n > 0 && ranges[n-1].EndLine >= start-1 {
+			ranges[n-1].EndLine = max(ranges[n-1].EndLine, end)
+			return
+		}
+		ranges = append(ranges, shared.LineRange{StartLine: start, EndLine: end})
+	}
+
+	pendingDeletion := false
+	for _, bodyLine := range h.body {
+		switch {
+		case strings.HasPrefix(bodyLine, "+"):
+			mark(line, line)
+			line++
+			pendingDeletion = false // The added lines replace the removed ones
+		case strings.HasPrefix(bodyLine, "-"):
+			pendingDeletion = true
+		case strings.HasPrefix(bodyLine, `\`):
+			// "\ No newline at end of file"
+		default:
+			if pendingDeletion {
+				mark(line-1, line)
+				pendingDeletion = false
+			}
+			line++
+		}
+	}
+	if pendingDeletion {
+		mark(line-1, line)
+	}
+	return ranges
+}
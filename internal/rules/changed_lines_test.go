@@ -0,0 +1,96 @@
+package rules
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+)
+
+func TestDiffHunk_ChangedLines(t *testing.T) {
+	tests := []struct {
+		name     string
+		diff     string
+		expected []shared.LineRange
+	}{
+		{
+			name: "multi-change hunk keeps only added lines",
+			diff: "@@ -1,9 +1,10 @@\n name: test\n-kind: source\n+kind: aggregated\n warehouses:\n   - type: user\n     size: XSMALL\n tags:\n-  tier: silver\n+  tier: gold\n+  owner: data\n description: test\n",
+			expected: []shared.LineRange{
+				{StartLine: 2, EndLine: 2},
+				{StartLine: 7, EndLine: 8},
+			},
+		},
+		{
+			name:     "deletion between context lines marks its neighbours",
+			diff:     "@@ -3,3 +3,2 @@\n a: 1\n-b: 2\n c: 3",
+			expected: []shared.LineRange{{StartLine: 3, EndLine: 4}},
+		},
+		{
+			name:     "zero-context deletion",
+			diff:     "@@ -5,2 +4,0 @@\n-  - type: service_account\n-    size: SMALL",
+			expected: []shared.LineRange{{StartLine: 4, EndLine: 5}},
+		},
+		{
+			name:     "deletion at the top of the file",
+			diff:     "@@ -1 +0,0 @@\n-# header",
+			expected: []shared.LineRange{{StartLine: 1, EndLine: 1}},
+		},
+		{
+			name:     "no newline marker is not a line",
+			diff:     "@@ -4 +4 @@\n-size: SMALL\n\\ No newline at end of file\n+size: MEDIUM\n\\ No newline at end of file",
+			expected: []shared.LineRange{{StartLine: 4, EndLine: 4}},
+		},
+		{
+			name:     "header without a body uses the hunk span",
+			diff:     "@@ -1,4 +1,6 @@",
+			expected: []shared.LineRange{{StartLine: 1, EndLine: 6}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ranges []shared.LineRange
+			for _, hunk := range splitDiffHunks(tt.diff) {
+				ranges = append(ranges, hunk.changedLines()...)
+			}
+			assert.Equal(t, tt.expected, ranges)
+		})
+	}
+}
+
+func TestSectionRuleManager_ExtractChangedLines_PreciseVsCoarse(t *testing.T) {
+	content := "name: test\nkind: aggregated\nwarehouses:\n  - type: user\n    size: XSMALL\ntags:\n  tier: gold\n"
+	// A three-line-context hunk that changes kind and tags; the warehouses lines are only context
+	diff := "@@ -1,7 +1,7 @@\n name: test\n-kind: source\n+kind: aggregated\n warehouses:\n   - type: user\n     size: XSMALL\n tags:\n-  tier: silver\n+  tier: gold"
+
+	parser := NewYAMLSectionParser(map[string]config.SectionDefinition{
+		"kind":       {Name: "kind", YAMLPath: "kind"},
+		"warehouses": {Name: "warehouses", YAMLPath: "warehouses"},
+		"tags":       {Name: "tags", YAMLPath: "tags"},
+	})
+	sections, err := parser.ParseSections("product.yaml", content)
+	require.NoError(t, err)
+
+	manager := NewSectionRuleManager(&config.GlobalRuleConfig{}, nil)
+	affectedNames := func(changed []shared.LineRange) []string {
+		var names []string
+		for _, section := range manager.getAffectedSections(sections, changed) {
+			names = append(names, section.Name)
+		}
+		sort.Strings(names)
+		return names
+	}
+
+	coarse := []shared.LineRange{*parseHunkHeader("@@ -1,7 +1,7 @@")}
+	precise := manager.extractChangedLinesFromDiff(diff)
+
+	assert.Equal(t, []shared.LineRange{{StartLine: 1, EndLine: 7}}, coarse)
+	assert.Equal(t, []shared.LineRange{{StartLine: 2, EndLine: 2}, {StartLine: 7, EndLine: 7}}, precise)
+	assert.Equal(t, []string{"kind", "tags", "warehouses"}, affectedNames(coarse), "the hunk span overlaps the unchanged warehouses")
+	assert.Equal(t, []string{"kind", "tags"}, affectedNames(precise))
+}
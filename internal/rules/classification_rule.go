@@ -0,0 +1,130 @@
+package rules
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/common"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+)
+
+// classificationLinePattern matches a `classification:` tag line, optionally prefixed by a diff marker
+var classificationLinePattern = regexp.MustCompile(`^([+-]?)\s*classification:\s*(.*)$`)
+
+// ClassificationRule requires manual review when an MR lowers the `classification` tag of a config.
+// Levels are ordered from least to most restrictive; upgrades and unchanged values are approved.
+type ClassificationRule struct {
+	*common.BaseRule
+	*common.ValidationHelper
+	levels map[string]int // Classification value to rank, higher is more restrictive
+	order  string         // Configured ordering, for reasons
+}
+
+// NewClassificationRule creates a classification rule from levels ordered least to most restrictive
+func NewClassificationRule(levels []string) *ClassificationRule {
+	ranks := make(map[string]int, len(levels))
+	for i, level := range levels {
+		ranks[strings.ToLower(level)] = i
+	}
+	return &ClassificationRule{
+		BaseRule: common.NewBaseRule(
+			"classification_rule",
+			"Requires manual review when an MR downgrades the classification tag of a config",
+		),
+		ValidationHelper: common.NewValidationHelper(),
+		levels:           ranks,
+		order:            strings.Join(levels, " < "),
+	}
+}
+
+// GetCoveredLines returns which line ranges this rule validates in a file
+func (r *ClassificationRule) GetCoveredLines(filePath string, fileContent string) []shared.LineRange {
+	return r.GetFullFileCoverage(filePath, fileContent)
+}
+
+// ValidateLines compares the old and new `classification` values from the MR diff
+func (r *ClassificationRule) ValidateLines(filePath string, fileContent string, lineRanges []shared.LineRange) (shared.DecisionType, string) {
+	mrCtx := r.GetMRContext()
+	if mrCtx == nil {
+		return r.CreateApprovalResult("No MR context - classification check skipped")
+	}
+
+	for _, change := range mrCtx.Changes {
+		if change.NewPath != filePath {
+			continue
+		}
+		if change.NewFile {
+			return r.CreateApprovalResult("New file - classification check not applicable")
+		}
+
+		oldValue, newValue, changed := classificationChangeFromDiff(change.Diff)
+		if !changed {
+			return r.CreateApprovalResult("Classification unchanged")
+		}
+		return r.compareClassifications(oldValue, newValue)
+	}
+
+	return r.CreateApprovalResult("File not changed in this MR - classification check skipped")
+}
+
+// compareClassifications approves upgrades and requires manual review for downgrades,
+// removals and values outside the configured levels
+func (r *ClassificationRule) compareClassifications(oldValue, newValue string) (shared.DecisionType, string) {
+	if oldValue == "(none)" {
+		if _, known := r.levels[strings.ToLower(newValue)]; known {
+			return r.CreateApprovalResult(fmt.Sprintf("Classification '%s' added", newValue))
+		}
+		return r.CreateManualReviewResult(fmt.Sprintf("Classification '%s' added, which is not a known level (%s)", newValue, r.order))
+	}
+	if newValue == "(none)" {
+		return r.CreateManualReviewResult(fmt.Sprintf("Classification '%s' removed - removing a classification is treated as a downgrade", oldValue))
+	}
+
+	oldRank, oldKnown := r.levels[strings.ToLower(oldValue)]
+	newRank, newKnown := r.levels[strings.ToLower(newValue)]
+	switch {
+	case !oldKnown || !newKnown:
+		return r.CreateManualReviewResult(fmt.Sprintf("Classification changed from '%s' to '%s', which cannot be compared with the known levels (%s)", oldValue, newValue, r.order))
+	case newRank < oldRank:
+		return r.CreateManualReviewResult(fmt.Sprintf("Classification downgraded from '%s' to '%s'", oldValue, newValue))
+	case newRank > oldRank:
+		return r.CreateApprovalResult(fmt.Sprintf("Classification upgraded from '%s' to '%s'", oldValue, newValue))
+	}
+	return r.CreateApprovalResult("Classification unchanged")
+}
+
+// classificationChangeFromDiff extracts removed and added `classification` values from a unified diff.
+// A missing value is reported as "(none)" so adding or removing the tag counts as a change.
+func classificationChangeFromDiff(diff string) (oldValue, newValue string, changed bool) {
+	var removed, added string
+	var hasRemoved, hasAdded bool
+	for _, line := range strings.Split(diff, "\n") {
+		matches := classificationLinePattern.FindStringSubmatch(line)
+		if matches == nil {
+			continue
+		}
+		value := normalizeKind(matches[2])
+		if value == "" {
+			continue // List-valued classifications are left to tags_rule
+		}
+		switch matches[1] {
+		case "-":
+			removed, hasRemoved = value, true
+		case "+":
+			added, hasAdded = value, true
+		}
+	}
+
+	if !hasRemoved && !hasAdded {
+		return "", "", false
+	}
+	oldValue, newValue = "(none)", "(none)"
+	if hasRemoved {
+		oldValue = removed
+	}
+	if hasAdded {
+		newValue = added
+	}
+	return oldValue, newValue, !strings.EqualFold(oldValue, newValue)
+}
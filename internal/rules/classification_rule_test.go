@@ -0,0 +1,120 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClassificationRule_Name(t *testing.T) {
+	rule := NewClassificationRule([]string{"public", "internal", "confidential"})
+	assert.Equal(t, "classification_rule", rule.Name())
+	assert.Contains(t, rule.Description(), "classification")
+}
+
+func TestClassificationRule_ValidateLines(t *testing.T) {
+	filePath := "dataproducts/source/analytics/prod/product.yaml"
+
+	tests := []struct {
+		name               string
+		levels             []string
+		diff               string
+		newFile            bool
+		expectedDecision   shared.DecisionType
+		expectedReasonPart string
+	}{
+		{
+			name:               "upgrade",
+			diff:               "@@ -5,2 +5,2 @@\n tags:\n-  classification: internal\n+  classification: confidential\n",
+			expectedDecision:   shared.Approve,
+			expectedReasonPart: "Classification upgraded from 'internal' to 'confidential'",
+		},
+		{
+			name:               "downgrade",
+			diff:               "@@ -5,2 +5,2 @@\n tags:\n-  classification: confidential\n+  classification: public\n",
+			expectedDecision:   shared.ManualReview,
+			expectedReasonPart: "Classification downgraded from 'confidential' to 'public'",
+		},
+		{
+			name:               "unchanged",
+			diff:               "@@ -5,3 +5,3 @@\n tags:\n   classification: internal\n-  tier: bronze\n+  tier: gold\n",
+			expectedDecision:   shared.Approve,
+			expectedReasonPart: "Classification unchanged",
+		},
+		{
+			name:               "rewritten with the same value",
+			diff:               "@@ -6,1 +6,1 @@\n-  classification: Internal # reviewed\n+  classification: \"internal\"\n",
+			expectedDecision:   shared.Approve,
+			expectedReasonPart: "Classification unchanged",
+		},
+		{
+			name:               "unknown new value",
+			diff:               "@@ -6,1 +6,1 @@\n-  classification: internal\n+  classification: secret\n",
+			expectedDecision:   shared.ManualReview,
+			expectedReasonPart: "Classification changed from 'internal' to 'secret', which cannot be compared with the known levels (public < internal < confidential)",
+		},
+		{
+			name:               "unknown old value",
+			diff:               "@@ -6,1 +6,1 @@\n-  classification: legacy\n+  classification: public\n",
+			expectedDecision:   shared.ManualReview,
+			expectedReasonPart: "from 'legacy' to 'public'",
+		},
+		{
+			name:               "removed",
+			diff:               "@@ -5,2 +5,1 @@\n tags:\n-  classification: internal\n",
+			expectedDecision:   shared.ManualReview,
+			expectedReasonPart: "Classification 'internal' removed",
+		},
+		{
+			name:               "added",
+			diff:               "@@ -5,1 +5,2 @@\n tags:\n+  classification: public\n",
+			expectedDecision:   shared.Approve,
+			expectedReasonPart: "Classification 'public' added",
+		},
+		{
+			name:               "custom ordering",
+			levels:             []string{"confidential", "public"},
+			diff:               "@@ -6,1 +6,1 @@\n-  classification: confidential\n+  classification: public\n",
+			expectedDecision:   shared.Approve,
+			expectedReasonPart: "Classification upgraded",
+		},
+		{
+			name:               "new file",
+			diff:               "@@ -0,0 +1,2 @@\n+tags:\n+  classification: public\n",
+			newFile:            true,
+			expectedDecision:   shared.Approve,
+			expectedReasonPart: "New file",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			levels := tt.levels
+			if levels == nil {
+				levels = []string{"public", "internal", "confidential"}
+			}
+			rule := NewClassificationRule(levels)
+			rule.SetMRContext(&shared.MRContext{Changes: []gitlab.FileChange{{
+				NewPath: filePath,
+				OldPath: filePath,
+				NewFile: tt.newFile,
+				Diff:    tt.diff,
+			}}})
+
+			decision, reason := rule.ValidateLines(filePath, "tags:\n  classification: public\n", nil)
+
+			assert.Equal(t, tt.expectedDecision, decision)
+			assert.Contains(t, reason, tt.expectedReasonPart)
+		})
+	}
+}
+
+func TestClassificationRule_ValidateLines_NoContext(t *testing.T) {
+	rule := NewClassificationRule([]string{"public", "internal", "confidential"})
+
+	decision, _ := rule.ValidateLines("product.yaml", "tags:\n  classification: public\n", nil)
+
+	assert.Equal(t, shared.Approve, decision)
+}
@@ -182,7 +182,7 @@ func (r *CODEOWNERSSyncRule) fetchOwners(mrCtx *shared.MRContext, filePath strin
 		return nil
 	}
 
-	content, err := r.client.FetchFileContent(mrCtx.ProjectID, filePath, mrCtx.MRInfo.SourceBranch)
+	content, err := r.client.FetchFileContent(shared.SourceProjectID(mrCtx), filePath, mrCtx.MRInfo.SourceBranch)
 	if err != nil {
 		logging.Warn("Failed to fetch developers.yaml: %v", err)
 		return nil
@@ -202,7 +202,7 @@ func (r *CODEOWNERSSyncRule) fetchGroupInfo(mrCtx *shared.MRContext, filePath st
 		return nil
 	}
 
-	content, err := r.client.FetchFileContent(mrCtx.ProjectID, filePath, mrCtx.MRInfo.SourceBranch)
+	content, err := r.client.FetchFileContent(shared.SourceProjectID(mrCtx), filePath, mrCtx.MRInfo.SourceBranch)
 	if err != nil {
 		logging.Warn("Failed to fetch group YAML: %v", err)
 		return nil
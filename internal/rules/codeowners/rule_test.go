@@ -11,7 +11,8 @@ import (
 
 // MockGitLabClient implements gitlab.GitLabClient for testing
 type MockGitLabClient struct {
-	fileContents map[string]*gitlab.FileContent
+	fileContents      map[string]*gitlab.FileContent
+	fetchedProjectIDs []int
 }
 
 func NewMockGitLabClient() *MockGitLabClient {
@@ -19,6 +20,7 @@ func NewMockGitLabClient() *MockGitLabClient {
 }
 
 func (m *MockGitLabClient) FetchFileContent(projectID int, filePath, ref string) (*gitlab.FileContent, error) {
+	m.fetchedProjectIDs = append(m.fetchedProjectIDs, projectID)
 	key := ref + ":" + filePath
 	if content, exists := m.fileContents[key]; exists {
 		return content, nil
@@ -36,15 +38,32 @@ func (m *MockGitLabClient) SetFileContent(ref, filePath, content string) {
 func (m *MockGitLabClient) FetchMRChanges(projectID, mrIID int) ([]gitlab.FileChange, error) {
 	return nil, nil
 }
-func (m *MockGitLabClient) AddMRComment(projectID, mrIID int, comment string) error { return nil }
-func (m *MockGitLabClient) ApproveMR(projectID, mrIID int) error                    { return nil }
+func (m *MockGitLabClient) AddMRComment(projectID, mrIID int, comment string) error       { return nil }
+func (m *MockGitLabClient) AddIssueComment(projectID, issueIID int, comment string) error { return nil }
+func (m *MockGitLabClient) ApproveMR(projectID, mrIID int) error                          { return nil }
 func (m *MockGitLabClient) ApproveMRWithMessage(projectID, mrIID int, message string) error {
 	return nil
 }
+
+func (m *MockGitLabClient) SetCommitStatus(projectID int, sha, state, description string) error {
+	return nil
+}
+
+func (m *MockGitLabClient) GetMRHeadPipelineStatus(projectID, mrIID int) (string, error) {
+	return "", nil
+}
+
+func (m *MockGitLabClient) GetProjectIDByPath(pathWithNamespace string) (int, error) {
+	return 0, nil
+}
 func (m *MockGitLabClient) ResetNaysayerApproval(projectID, mrIID int) error { return nil }
+func (m *MockGitLabClient) GetMRApprovals(projectID, mrIID int) (*gitlab.MRApprovals, error) {
+	return nil, nil
+}
 func (m *MockGitLabClient) GetMRTargetBranch(projectID, mrIID int) (string, error) {
 	return "main", nil
 }
+
 func (m *MockGitLabClient) GetMRDetails(projectID, mrIID int) (*gitlab.MRDetails, error) {
 	return nil, nil
 }
@@ -57,9 +76,17 @@ func (m *MockGitLabClient) ListMRComments(projectID, mrIID int) ([]gitlab.MRComm
 func (m *MockGitLabClient) UpdateMRComment(projectID, mrIID, commentID int, newBody string) error {
 	return nil
 }
+
+func (m *MockGitLabClient) DeleteMRComment(projectID, mrIID, commentID int) error {
+	return nil
+}
 func (m *MockGitLabClient) AddOrUpdateMRComment(projectID, mrIID int, commentBody, commentType string) error {
 	return nil
 }
+
+func (m *MockGitLabClient) AddMRDiffNote(projectID, mrIID int, sha, filePath string, line int, body string) error {
+	return nil
+}
 func (m *MockGitLabClient) FindLatestNaysayerComment(projectID, mrIID int, commentType ...string) (*gitlab.MRComment, error) {
 	return nil, nil
 }
@@ -72,6 +99,13 @@ func (m *MockGitLabClient) CompareBranches(sourceProjectID int, sourceBranch str
 func (m *MockGitLabClient) GetBranchCommit(projectID int, branch string) (string, error) {
 	return "", nil
 }
+func (m *MockGitLabClient) IsBranchProtected(projectID int, branch string) (bool, error) {
+	return true, nil
+}
+
+func (m *MockGitLabClient) GetMergeRefCommit(projectID, mrIID int) (string, error) {
+	return "", nil
+}
 func (m *MockGitLabClient) CompareCommits(projectID int, fromSHA, toSHA string) (*gitlab.CompareResult, error) {
 	return nil, nil
 }
@@ -86,7 +120,9 @@ func (m *MockGitLabClient) CloseMR(projectID, mrIID int) error { return nil }
 func (m *MockGitLabClient) GetPipelineJobs(projectID, pipelineID int) ([]gitlab.PipelineJob, error) {
 	return nil, nil
 }
-func (m *MockGitLabClient) GetJobTrace(projectID, jobID int) (string, error) { return "", nil }
+func (m *MockGitLabClient) AddMRLabels(projectID, mrIID int, labels []string) error    { return nil }
+func (m *MockGitLabClient) RemoveMRLabels(projectID, mrIID int, labels []string) error { return nil }
+func (m *MockGitLabClient) GetJobTrace(projectID, jobID int) (string, error)           { return "", nil }
 func (m *MockGitLabClient) FindLatestAtlantisComment(projectID, mrIID int) (*gitlab.MRComment, error) {
 	return nil, nil
 }
@@ -263,6 +299,24 @@ func TestCODEOWNERSSyncRule_ValidateLines_ExistingDataProductUpdate(t *testing.T
 	assert.Contains(t, reason, "Auto-approved")
 }
 
+func TestCODEOWNERSSyncRule_ValidateLines_ForkMR(t *testing.T) {
+	mock := NewMockGitLabClient()
+	mock.SetFileContent("feature", "dataproducts/aggregate/new/developers.yaml", "group:\n  owners: [alice]")
+
+	rule := NewCODEOWNERSSyncRule(mock)
+	rule.SetMRContext(&shared.MRContext{
+		ProjectID: 1, MRIID: 1,
+		Changes: []gitlab.FileChange{
+			{NewPath: "CODEOWNERS", Diff: "+/dataproducts/aggregate/new/ @alice"},
+			{NewPath: "dataproducts/aggregate/new/developers.yaml", NewFile: true},
+		},
+		MRInfo: &gitlab.MRInfo{SourceBranch: "feature", TargetBranch: "main", SourceProjectID: 2},
+	})
+
+	rule.ValidateLines("CODEOWNERS", "", nil)
+	assert.Equal(t, []int{2}, mock.fetchedProjectIDs, "source-branch files are read from the fork")
+}
+
 func TestCODEOWNERSSyncRule_ValidateLines_NewGroupInExistingDP(t *testing.T) {
 	mock := NewMockGitLabClient()
 	mock.SetFileContent("feature", "dataproducts/aggregate/dp/groups/grp.yaml", "group_name: grp\napprovers:\n  - approver1")
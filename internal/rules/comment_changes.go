@@ -0,0 +1,142 @@
+package rules
+
+import (
+	"strings"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/logging"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+)
+
+// commentOnlyRuleName labels the approval recorded for files whose diff only changes YAML comments
+const commentOnlyRuleName = "comment_only_change"
+
+// diffHunk is one hunk of a unified diff: its header and the lines it removes and adds
+type diffHunk struct {
+	header  string
+	removed []string
+	added   []string
+	context []string
+	body    []string // Every line after the header, in order and with its +/-/space prefix
+}
+
+// splitDiffHunks splits a unified diff into hunks; lines before the first hunk header are dropped
+func splitDiffHunks(diff string) []diffHunk {
+	var hunks []diffHunk
+	for _, line := range strings.Split(diff, "\n") {
+		if strings.HasPrefix(line, "@@") {
+			hunks = append(hunks, diffHunk{header: line})
+			continue
+		}
+		if len(hunks) == 0 {
+			continue
+		}
+		hunk := &hunks[len(hunks)-1]
+		hunk.body = append(hunk.body, line)
+		switch {
+		case strings.HasPrefix(line, "+"):
+			hunk.added = append(hunk.added, line[1:])
+		case strings.HasPrefix(line, "-"):
+			hunk.removed = append(hunk.removed, line[1:])
+		case strings.HasPrefix(line, `\`):
+			// "\ No newline at end of file"
+		default:
+			hunk.context = append(hunk.context, strings.TrimPrefix(line, " "))
+		}
+	}
+	return hunks
+}
+
+// isCommentOnly reports whether the hunk changes lines but, once YAML comments and blank lines
+// are dropped, removes and adds the same content. A hunk next to a block scalar (`|`, `>`) is
+// never comment-only, since `#` lines inside multi-line strings are content.
+func (h diffHunk) isCommentOnly() bool {
+	if len(h.removed) == 0 && len(h.added) == 0 {
+		return false
+	}
+	for _, lines := range [][]string{h.context, h.removed, h.added} {
+		for _, line := range lines {
+			if opensBlockScalar(line) {
+				return false
+			}
+		}
+	}
+
+	removed, added := yamlContent(h.removed), yamlContent(h.added)
+	if len(removed) != len(added) {
+		return false
+	}
+	for i := range removed {
+		if removed[i] != added[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// isCommentOnlyDiff reports whether every hunk of diff only changes YAML comments
+func isCommentOnlyDiff(diff string) bool {
+	hunks := splitDiffHunks(diff)
+	if len(hunks) == 0 {
+		return false
+	}
+	for _, hunk := range hunks {
+		if !hunk.isCommentOnly() {
+			return false
+		}
+	}
+	return true
+}
+
+// yamlContent returns lines without YAML comments, dropping lines left blank
+func yamlContent(lines []string) []string {
+	var content []string
+	for _, line := range lines {
+		if stripped := stripYAMLComment(line); stripped != "" {
+			content = append(content, stripped)
+		}
+	}
+	return content
+}
+
+// stripYAMLComment removes a full-line or trailing `#` comment and trailing whitespace.
+// Lines with quotes keep their trailing text, since `#` may be part of a quoted string.
+func stripYAMLComment(line string) string {
+	if strings.HasPrefix(strings.TrimSpace(line), "#") {
+		return ""
+	}
+	if !strings.ContainsAny(line, `"'`) {
+		if idx := strings.Index(line, " #"); idx != -1 {
+			line = line[:idx]
+		}
+	}
+	return strings.TrimRight(line, " \t")
+}
+
+// opensBlockScalar reports whether a line starts a YAML block scalar, e.g. `description: |`
+func opensBlockScalar(line string) bool {
+	trimmed := strings.TrimSpace(stripYAMLComment(line))
+	for _, indicator := range []string{"|", ">", "|-", ">-", "|+", ">+"} {
+		if trimmed == indicator || strings.HasSuffix(trimmed, ": "+indicator) || strings.HasSuffix(trimmed, "- "+indicator) {
+			return true
+		}
+	}
+	return false
+}
+
+// createCommentOnlyValidation approves a file whose diff only changes YAML comments
+func (srm *SectionRuleManager) createCommentOnlyValidation(filePath string, totalLines int) *shared.FileValidationSummary {
+	logging.Info("File %s only changes YAML comments - approving", filePath)
+	return &shared.FileValidationSummary{
+		FilePath:       filePath,
+		TotalLines:     totalLines,
+		CoveredLines:   []shared.LineRange{},
+		UncoveredLines: []shared.LineRange{},
+		RuleResults: []shared.LineValidationResult{{
+			RuleName:     commentOnlyRuleName,
+			Decision:     shared.Approve,
+			Reason:       "Only YAML comments changed",
+			WasEvaluated: true,
+		}},
+		FileDecision: shared.Approve,
+	}
+}
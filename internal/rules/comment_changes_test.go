@@ -0,0 +1,165 @@
+package rules
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsCommentOnlyDiff(t *testing.T) {
+	tests := []struct {
+		name     string
+		diff     string
+		expected bool
+	}{
+		{
+			name:     "comment-only edit",
+			diff:     "@@ -2,3 +2,3 @@\n name: marketing\n-# owned by team a\n+# owned by team b\n kind: aggregated",
+			expected: true,
+		},
+		{
+			name:     "added comment and blank line",
+			diff:     "@@ -1,0 +1,2 @@\n+# Marketing data product\n+",
+			expected: true,
+		},
+		{
+			name:     "trailing comment edit",
+			diff:     "@@ -7 +7 @@\n-  size: SMALL # cheap\n+  size: SMALL   # cheapest available",
+			expected: true,
+		},
+		{
+			name:     "code edit",
+			diff:     "@@ -7 +7 @@\n-  size: SMALL\n+  size: LARGE",
+			expected: false,
+		},
+		{
+			name:     "mixed edit in one hunk",
+			diff:     "@@ -6,2 +6,2 @@\n-# small is enough\n-  size: SMALL\n+# need more\n+  size: LARGE",
+			expected: false,
+		},
+		{
+			name:     "comment and code changed on the same line",
+			diff:     "@@ -7 +7 @@\n-  size: SMALL # cheap\n+  size: LARGE # bigger",
+			expected: false,
+		},
+		{
+			name:     "comment-only hunk and code hunk",
+			diff:     "@@ -1 +1 @@\n-# old\n+# new\n@@ -7 +7 @@\n-  size: SMALL\n+  size: LARGE",
+			expected: false,
+		},
+		{
+			name:     "hash inside a quoted string",
+			diff:     "@@ -3 +3 @@\n-description: \"team #1\"\n+description: \"team #2\"",
+			expected: false,
+		},
+		{
+			name:     "hash line inside a block scalar",
+			diff:     "@@ -3,3 +3,3 @@\n description: |\n-  # Usage\n+  # How to use\n   Query the marts schema",
+			expected: false,
+		},
+		{
+			name:     "header without changed lines",
+			diff:     "@@ -1,1 +1,1 @@",
+			expected: false,
+		},
+		{
+			name:     "empty diff",
+			diff:     "",
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, isCommentOnlyDiff(tt.diff))
+		})
+	}
+}
+
+func TestExtractChangedLinesFromDiff_IgnoreCommentChanges(t *testing.T) {
+	diff := "@@ -1 +1 @@\n-# old\n+# new\n@@ -7 +7 @@\n-  size: SMALL\n+  size: LARGE"
+
+	enabled := NewSectionRuleManager(&config.GlobalRuleConfig{Enabled: true, IgnoreCommentChanges: true}, nil)
+	assert.Equal(t, []shared.LineRange{{StartLine: 7, EndLine: 7}}, enabled.extractChangedLinesFromDiff(diff), "only the code hunk counts")
+
+	disabled := NewSectionRuleManager(&config.GlobalRuleConfig{Enabled: true}, nil)
+	assert.Equal(t, []shared.LineRange{{StartLine: 1, EndLine: 1}, {StartLine: 7, EndLine: 7}}, disabled.extractChangedLinesFromDiff(diff))
+}
+
+func TestSectionRuleManager_IgnoreCommentChanges(t *testing.T) {
+	const rulesYAML = `enabled: true
+ignore_comment_changes: true
+files:
+  - name: "product_configs"
+    path: "dataproducts/**/"
+    filename: "product.yaml"
+    parser_type: yaml
+    enabled: true
+    sections:
+      - name: name
+        yaml_path: name
+        rule_configs:
+          - name: metadata_rule
+            enabled: true
+        auto_approve: true
+`
+	const content = "# Marketing data product\nname: marketing\nkind: aggregated\n"
+
+	tests := []struct {
+		name             string
+		diff             string
+		expectedDecision shared.DecisionType
+		expectedRule     string
+	}{
+		{
+			name:             "comment-only edit is approved",
+			diff:             "@@ -1 +1 @@\n-# Marketing\n+# Marketing data product",
+			expectedDecision: shared.Approve,
+			expectedRule:     commentOnlyRuleName,
+		},
+		{
+			name:             "code edit outside sections needs review",
+			diff:             "@@ -3 +3 @@\n-kind: source\n+kind: aggregated",
+			expectedDecision: shared.ManualReview,
+		},
+		{
+			name:             "mixed edit needs review",
+			diff:             "@@ -1,3 +1,3 @@\n-# Marketing\n+# Marketing data product\n name: marketing\n-kind: source\n+kind: aggregated",
+			expectedDecision: shared.ManualReview,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rulesPath := filepath.Join(t.TempDir(), "rules.yaml")
+			require.NoError(t, os.WriteFile(rulesPath, []byte(rulesYAML), 0644))
+			client := &ignoreTestGitLabClient{
+				forkMRTestGitLabClient: &forkMRTestGitLabClient{},
+				files:                  map[string]string{"dataproducts/marketing/prod/product.yaml": content},
+			}
+			manager, err := NewRuleRegistry().CreateSectionBasedRuleManager(client, rulesPath)
+			require.NoError(t, err)
+
+			result := manager.EvaluateAll(&shared.MRContext{
+				ProjectID: 123,
+				MRIID:     456,
+				Changes:   []gitlab.FileChange{{NewPath: "dataproducts/marketing/prod/product.yaml", Diff: tt.diff}},
+				MRInfo:    &gitlab.MRInfo{Author: "developer", SourceBranch: "feature"},
+			})
+
+			validation := result.FileValidations["dataproducts/marketing/prod/product.yaml"]
+			require.NotNil(t, validation)
+			assert.Equal(t, tt.expectedDecision, validation.FileDecision)
+			if tt.expectedRule != "" {
+				require.Len(t, validation.RuleResults, 1)
+				assert.Equal(t, tt.expectedRule, validation.RuleResults[0].RuleName)
+			}
+		})
+	}
+}
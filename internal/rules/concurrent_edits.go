@@ -0,0 +1,183 @@
+package rules
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/logging"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+)
+
+// concurrentEditRuleName identifies concurrent edit findings in rule results
+const concurrentEditRuleName = "concurrent_edit_check"
+
+// wholeFileSection stands in for a file's sections when they cannot be parsed on the target branch
+const wholeFileSection = "(whole file)"
+
+// hunkOldRangePattern extracts the old-file start line and line count from a diff hunk header
+var hunkOldRangePattern = regexp.MustCompile(`^@@ -(\d+)(?:,(\d+))?`)
+
+// applyConcurrentEditCheck requires manual review for approved files whose sections are also
+// modified by another open MR, so two MRs approved independently can't merge conflicting intents.
+// Both MRs' changes are mapped onto the target branch version of the file, where their line
+// numbers agree. The check fails closed: when the open MRs or their changes can't be fetched,
+// the files it could not clear require manual review too. Returns whether any file was downgraded.
+func (srm *SectionRuleManager) applyConcurrentEditCheck(fileValidations map[string]*shared.FileValidationSummary, mrCtx *shared.MRContext) bool {
+	if !srm.config.ConcurrentEditCheck || srm.gitlabClient == nil {
+		return false
+	}
+
+	candidates := make(map[string]bool)
+	for filePath, validation := range fileValidations {
+		if validation.FileDecision == shared.Approve && srm.getParserForFile(filePath) != nil {
+			candidates[filePath] = true
+		}
+	}
+	if len(candidates) == 0 {
+		return false
+	}
+
+	mrCtx.UsesLiveState = true
+	openMRs, err := srm.gitlabClient.ListOpenMRs(mrCtx.ProjectID)
+	if err != nil {
+		logging.Warn("Cannot list open MRs for the concurrent edit check (MR %d): %v", mrCtx.MRIID, err)
+		reason := fmt.Sprintf("Concurrent edit check could not run: cannot list open MRs: %v", err)
+		for filePath := range candidates {
+			requireConcurrentEditReview(fileValidations, filePath, reason)
+		}
+		return true
+	}
+
+	targetBranch := srm.resolveTargetBranch(mrCtx)
+	baseSections := make(map[string][]shared.Section)
+	touchedSections := func(filePath, diff string) map[string]bool {
+		sections, fetched := baseSections[filePath]
+		if !fetched {
+			sections = srm.targetBranchSections(mrCtx.ProjectID, filePath, targetBranch)
+			baseSections[filePath] = sections
+		}
+		if sections == nil {
+			return map[string]bool{wholeFileSection: true}
+		}
+		touched := make(map[string]bool)
+		for _, section := range srm.getAffectedSections(sections, oldLinesFromDiff(diff)) {
+			touched[section.Name] = true
+		}
+		return touched
+	}
+
+	conflicts := make(map[string][]string)
+	for _, otherMRIID := range openMRs {
+		if otherMRIID == mrCtx.MRIID {
+			continue
+		}
+		changes, err := srm.gitlabClient.FetchMRChanges(mrCtx.ProjectID, otherMRIID)
+		if err != nil {
+			logging.Warn("Cannot fetch changes of open MR %d for the concurrent edit check: %v", otherMRIID, err)
+			for filePath := range candidates {
+				conflicts[filePath] = append(conflicts[filePath], fmt.Sprintf("changes of open MR !%d could not be checked", otherMRIID))
+			}
+			continue
+		}
+		for _, change := range changes {
+			if !candidates[change.NewPath] || change.DeletedFile {
+				continue
+			}
+			ours := touchedSections(change.NewPath, srm.getDiffForFile(change.NewPath, mrCtx))
+			for name := range touchedSections(change.NewPath, change.Diff) {
+				if ours[name] {
+					conflicts[change.NewPath] = append(conflicts[change.NewPath], fmt.Sprintf("section '%s' is also modified by open MR !%d", name, otherMRIID))
+				}
+			}
+		}
+	}
+
+	for filePath, notes := range conflicts {
+		sort.Strings(notes)
+		reason := fmt.Sprintf("Concurrent edit: %s", strings.Join(notes, "; "))
+		requireConcurrentEditReview(fileValidations, filePath, reason)
+	}
+	return len(conflicts) > 0
+}
+
+// requireConcurrentEditReview downgrades a file to manual review with a concurrent edit finding
+func requireConcurrentEditReview(fileValidations map[string]*shared.FileValidationSummary, filePath, reason string) {
+	logging.Info("%s requires manual review: %s", filePath, reason)
+
+	// Copy so validations reused through reuse_unchanged_files keep their original results
+	updated := *fileValidations[filePath]
+	updated.RuleResults = append(append([]shared.LineValidationResult{}, updated.RuleResults...), shared.LineValidationResult{
+		RuleName:     concurrentEditRuleName,
+		Decision:     shared.ManualReview,
+		Reason:       reason,
+		WasEvaluated: true,
+	})
+	updated.FileDecision = shared.ManualReview
+	fileValidations[filePath] = &updated
+}
+
+// resolveTargetBranch returns the MR's target branch from the webhook payload, or from GitLab when missing
+func (srm *SectionRuleManager) resolveTargetBranch(mrCtx *shared.MRContext) string {
+	if mrCtx.MRInfo != nil && mrCtx.MRInfo.TargetBranch != "" {
+		return mrCtx.MRInfo.TargetBranch
+	}
+	targetBranch, err := srm.gitlabClient.GetMRTargetBranch(mrCtx.ProjectID, mrCtx.MRIID)
+	if err != nil {
+		logging.Warn("Cannot resolve target branch of MR %d: %v", mrCtx.MRIID, err)
+	}
+	return targetBranch
+}
+
+// targetBranchSections parses filePath on the target branch. Nil means the file is new or unparsable,
+// so any two MRs touching it overlap.
+func (srm *SectionRuleManager) targetBranchSections(projectID int, filePath, targetBranch string) []shared.Section {
+	parser := srm.getParserForFile(filePath)
+	if parser == nil || targetBranch == "" {
+		return nil
+	}
+	fileContent, err := srm.gitlabClient.FetchFileContent(projectID, filePath, targetBranch)
+	if err != nil || fileContent == nil {
+		return nil
+	}
+	sections, err := parser.ParseSections(filePath, fileContent.Content)
+	if err != nil || len(sections) == 0 {
+		return nil
+	}
+	return sections
+}
+
+// oldLinesFromDiff returns the lines of the old file a unified diff removes, plus the line
+// each insertion follows, so changes from different MRs can be compared on the same base file
+func oldLinesFromDiff(diff string) []shared.LineRange {
+	var ranges []shared.LineRange
+	oldLine := 0
+	for _, line := range strings.Split(diff, "\n") {
+		if matches := hunkOldRangePattern.FindStringSubmatch(line); matches != nil {
+			oldLine, _ = strconv.Atoi(matches[1])
+			if matches[2] == "0" {
+				oldLine++ // A pure insertion hunk names the line it follows
+			}
+			continue
+		}
+		if oldLine == 0 {
+			continue // Before the first hunk (e.g. file headers)
+		}
+
+		switch {
+		case strings.HasPrefix(line, "-"):
+			ranges = append(ranges, shared.LineRange{StartLine: oldLine, EndLine: oldLine})
+			oldLine++
+		case strings.HasPrefix(line, "+"):
+			anchor := max(oldLine-1, 1)
+			ranges = append(ranges, shared.LineRange{StartLine: anchor, EndLine: anchor})
+		case strings.HasPrefix(line, `\`):
+			// "\ No newline at end of file"
+		default:
+			oldLine++
+		}
+	}
+	return ranges
+}
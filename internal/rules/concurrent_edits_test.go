@@ -0,0 +1,163 @@
+package rules
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// concurrentEditTestGitLabClient serves a file's target and source branch versions and other open MRs
+type concurrentEditTestGitLabClient struct {
+	*forkMRTestGitLabClient
+	targetContent string
+	sourceContent string
+	openMRs       []int
+	mrChanges     map[int][]gitlab.FileChange
+	listErr       error
+	changesErr    error
+}
+
+func (m *concurrentEditTestGitLabClient) FetchFileContent(projectID int, filePath, ref string) (*gitlab.FileContent, error) {
+	if ref == "main" {
+		return &gitlab.FileContent{Content: m.targetContent, FilePath: filePath}, nil
+	}
+	return &gitlab.FileContent{Content: m.sourceContent, FilePath: filePath}, nil
+}
+
+func (m *concurrentEditTestGitLabClient) GetMRDetails(projectID, mrIID int) (*gitlab.MRDetails, error) {
+	return &gitlab.MRDetails{IID: mrIID, ProjectID: projectID, SourceProjectID: projectID}, nil
+}
+
+func (m *concurrentEditTestGitLabClient) ListOpenMRs(projectID int) ([]int, error) {
+	return m.openMRs, m.listErr
+}
+
+func (m *concurrentEditTestGitLabClient) FetchMRChanges(projectID, mrIID int) ([]gitlab.FileChange, error) {
+	return m.mrChanges[mrIID], m.changesErr
+}
+
+func TestSectionRuleManager_ConcurrentEditCheck(t *testing.T) {
+	const productPath = "dataproducts/analytics/prod/product.yaml"
+	targetContent := "name: analytics\nwarehouses:\n  - type: user\n    size: XSMALL\ntags:\n  tier: bronze\n"
+	sourceContent := "name: analytics\nwarehouses:\n  - type: user\n    size: SMALL\ntags:\n  tier: bronze\n"
+	ourDiff := "@@ -4,1 +4,1 @@\n-    size: XSMALL\n+    size: SMALL\n"
+
+	tests := []struct {
+		name             string
+		enabled          bool
+		otherDiff        string
+		listErr          error
+		changesErr       error
+		expectedDecision shared.DecisionType
+		expectedReason   string
+	}{
+		{
+			name:             "overlapping section",
+			enabled:          true,
+			otherDiff:        "@@ -3,2 +3,3 @@\n   - type: user\n     size: XSMALL\n+  - type: service_account\n",
+			expectedDecision: shared.ManualReview,
+			expectedReason:   "Concurrent edit: section 'warehouses' is also modified by open MR !124",
+		},
+		{
+			name:             "different section",
+			enabled:          true,
+			otherDiff:        "@@ -5,2 +5,2 @@\n tags:\n-  tier: bronze\n+  tier: gold\n",
+			expectedDecision: shared.Approve,
+		},
+		{
+			name:             "open MRs cannot be listed",
+			enabled:          true,
+			otherDiff:        "@@ -5,2 +5,2 @@\n tags:\n-  tier: bronze\n+  tier: gold\n",
+			listErr:          errors.New("502 Bad Gateway"),
+			expectedDecision: shared.ManualReview,
+			expectedReason:   "Concurrent edit check could not run: cannot list open MRs: 502 Bad Gateway",
+		},
+		{
+			name:             "other MR's changes cannot be fetched",
+			enabled:          true,
+			otherDiff:        "@@ -5,2 +5,2 @@\n tags:\n-  tier: bronze\n+  tier: gold\n",
+			changesErr:       errors.New("502 Bad Gateway"),
+			expectedDecision: shared.ManualReview,
+			expectedReason:   "Concurrent edit: changes of open MR !124 could not be checked",
+		},
+		{
+			name:             "disabled",
+			otherDiff:        "@@ -4,1 +4,1 @@\n-    size: XSMALL\n+    size: LARGE\n",
+			expectedDecision: shared.Approve,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ruleConfig := &config.GlobalRuleConfig{
+				Enabled:             true,
+				ConcurrentEditCheck: tt.enabled,
+				Files: []config.FileRuleConfig{{
+					Name:       "product_configs",
+					Path:       "dataproducts/**/",
+					Filename:   "product.yaml",
+					ParserType: "yaml",
+					Enabled:    true,
+					Sections: []config.SectionDefinition{
+						{Name: "name", YAMLPath: "name", AutoApprove: true},
+						{Name: "warehouses", YAMLPath: "warehouses", AutoApprove: true},
+						{Name: "tags", YAMLPath: "tags", AutoApprove: true},
+					},
+				}},
+			}
+			client := &concurrentEditTestGitLabClient{
+				forkMRTestGitLabClient: &forkMRTestGitLabClient{},
+				targetContent:          targetContent,
+				sourceContent:          sourceContent,
+				openMRs:                []int{456, 124},
+				mrChanges: map[int][]gitlab.FileChange{
+					124: {{NewPath: productPath, OldPath: productPath, Diff: tt.otherDiff}},
+				},
+				listErr:    tt.listErr,
+				changesErr: tt.changesErr,
+			}
+			manager := NewSectionRuleManager(ruleConfig, client)
+
+			result := manager.EvaluateAll(&shared.MRContext{
+				ProjectID: 123,
+				MRIID:     456,
+				Changes:   []gitlab.FileChange{{NewPath: productPath, OldPath: productPath, Diff: ourDiff}},
+				MRInfo: &gitlab.MRInfo{
+					Author:       "developer",
+					SourceBranch: "feature",
+					TargetBranch: "main",
+				},
+			})
+
+			assert.Equal(t, tt.expectedDecision, result.FinalDecision.Type)
+			validation := result.FileValidations[productPath]
+			require.NotNil(t, validation)
+			var reasons []string
+			for _, ruleResult := range validation.RuleResults {
+				if ruleResult.RuleName == concurrentEditRuleName {
+					reasons = append(reasons, ruleResult.Reason)
+				}
+			}
+			if tt.expectedReason == "" {
+				assert.Empty(t, reasons)
+			} else {
+				assert.Equal(t, []string{tt.expectedReason}, reasons)
+			}
+		})
+	}
+}
+
+func TestOldLinesFromDiff(t *testing.T) {
+	diff := "@@ -3,3 +3,3 @@\n context\n-removed\n+added\n context\n@@ -10,0 +11,1 @@\n+inserted after line 10\n"
+
+	assert.Equal(t, []shared.LineRange{
+		{StartLine: 4, EndLine: 4},
+		{StartLine: 4, EndLine: 4},
+		{StartLine: 10, EndLine: 10},
+	}, oldLinesFromDiff(diff))
+}
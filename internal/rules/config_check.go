@@ -0,0 +1,62 @@
+package rules
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+)
+
+// supportedParserTypes lists the parser_type values SectionRuleManager can parse
+var supportedParserTypes = map[string]bool{"yaml": true}
+
+// yamlPathPattern matches "." or dot-separated keys without empty parts or whitespace
+var yamlPathPattern = regexp.MustCompile(`^(\.|[^.\s]+(\.[^.\s]+)*)$`)
+
+// CheckRuleConfig reports problems in a loaded rule configuration that config.ValidateRuleConfig
+// cannot see and that would otherwise only show up as warnings at runtime: rule names missing
+// from the registry, unsupported parser types, invalid globs and malformed yaml_paths.
+func (r *RuleRegistry) CheckRuleConfig(ruleConfig *config.GlobalRuleConfig) []string {
+	var problems []string
+	checkRule := func(where string, rule config.RuleConfig) {
+		if _, exists := r.GetRule(rule.Name); !exists {
+			problems = append(problems, fmt.Sprintf("%s: unknown rule '%s'", where, rule.Name))
+		}
+	}
+	checkPattern := func(where, pattern string) {
+		if err := shared.ValidatePattern(pattern); err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %v", where, err))
+		}
+	}
+
+	for _, pattern := range ruleConfig.AlwaysManualReview {
+		checkPattern("always_manual_review", pattern)
+	}
+	for _, pattern := range ruleConfig.SafePaths {
+		checkPattern("safe_paths", pattern)
+	}
+	for _, rule := range ruleConfig.GlobalRules {
+		checkRule("global_rules", rule)
+	}
+
+	for _, fileConfig := range ruleConfig.Files {
+		where := fmt.Sprintf("file %s", fileConfig.Name)
+		if !supportedParserTypes[fileConfig.ParserType] {
+			problems = append(problems, fmt.Sprintf("%s: unsupported parser_type '%s'", where, fileConfig.ParserType))
+		}
+		checkPattern(where, fileConfig.Path+fileConfig.Filename)
+
+		for _, section := range fileConfig.Sections {
+			sectionWhere := fmt.Sprintf("%s, section %s", where, section.Name)
+			if !yamlPathPattern.MatchString(section.YAMLPath) {
+				problems = append(problems, fmt.Sprintf("%s: malformed yaml_path '%s'", sectionWhere, section.YAMLPath))
+			}
+			for _, rule := range section.RuleConfigs {
+				checkRule(sectionWhere, rule)
+			}
+		}
+	}
+
+	return problems
+}
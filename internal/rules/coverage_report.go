@@ -0,0 +1,112 @@
+package rules
+
+import (
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxUncoveredFileTypes bounds the file types a coverage recorder tracks; types first seen
+// after the limit is reached are not recorded
+const maxUncoveredFileTypes = 500
+
+// UncoveredFileType aggregates changed files of one type that required manual review
+// because no file configuration in rules.yaml covers them
+type UncoveredFileType struct {
+	Pattern     string    `json:"pattern"`      // "*.ext", or the file name for files without an extension
+	Count       int       `json:"count"`        // Files of this type seen since the rules were loaded
+	ExamplePath string    `json:"example_path"` // Most recently seen path of this type
+	LastSeen    time.Time `json:"last_seen"`
+}
+
+// CoverageReport lists the uncovered file types seen since the rules were loaded, most frequent first
+type CoverageReport struct {
+	Since     time.Time           `json:"since"`
+	FileTypes []UncoveredFileType `json:"file_types"`
+}
+
+// coverageRecorder counts files that fell through to manual review for lack of a parser
+type coverageRecorder struct {
+	mu        sync.Mutex
+	since     time.Time
+	fileTypes map[string]*UncoveredFileType // Pattern -> aggregate
+}
+
+// newCoverageRecorder creates an empty coverage recorder
+func newCoverageRecorder() *coverageRecorder {
+	return &coverageRecorder{
+		since:     time.Now(),
+		fileTypes: make(map[string]*UncoveredFileType),
+	}
+}
+
+// fileTypePattern groups a path by its extension ("docs/a.md" -> "*.md"), or by its file name
+// when it has none ("CODEOWNERS")
+func fileTypePattern(filePath string) string {
+	name := path.Base(filePath)
+	if ext := path.Ext(name); ext != "" && ext != name {
+		return "*" + strings.ToLower(ext)
+	}
+	return name
+}
+
+// record counts filePath under its file type
+func (r *coverageRecorder) record(filePath string) {
+	pattern := fileTypePattern(filePath)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	fileType, ok := r.fileTypes[pattern]
+	if !ok {
+		if len(r.fileTypes) >= maxUncoveredFileTypes {
+			return
+		}
+		fileType = &UncoveredFileType{Pattern: pattern}
+		r.fileTypes[pattern] = fileType
+	}
+	fileType.Count++
+	fileType.ExamplePath = filePath
+	fileType.LastSeen = time.Now()
+}
+
+// report returns the recorded file types, most frequent first and alphabetical within a count
+func (r *coverageRecorder) report() CoverageReport {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	fileTypes := make([]UncoveredFileType, 0, len(r.fileTypes))
+	for _, fileType := range r.fileTypes {
+		fileTypes = append(fileTypes, *fileType)
+	}
+	sort.Slice(fileTypes, func(i, j int) bool {
+		if fileTypes[i].Count != fileTypes[j].Count {
+			return fileTypes[i].Count > fileTypes[j].Count
+		}
+		return fileTypes[i].Pattern < fileTypes[j].Pattern
+	})
+	return CoverageReport{Since: r.since, FileTypes: fileTypes}
+}
+
+// reset forgets every recorded file type, e.g. once new rules may cover them
+func (r *coverageRecorder) reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.since = time.Now()
+	r.fileTypes = make(map[string]*UncoveredFileType)
+}
+
+// CoverageReport returns the file types that required manual review for lack of a file
+// configuration since the rules were last loaded
+func (srm *SectionRuleManager) CoverageReport() CoverageReport {
+	return srm.coverage.report()
+}
+
+// CoverageReport returns the uncovered file types of every project; project-specific
+// managers record into the default manager's report
+func (prm *ProjectRuleManager) CoverageReport() CoverageReport {
+	return prm.defaultManager.CoverageReport()
+}
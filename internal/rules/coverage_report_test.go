@@ -0,0 +1,107 @@
+package rules
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileTypePattern(t *testing.T) {
+	tests := map[string]string{
+		"docs/guide.md":          "*.md",
+		"scripts/deploy.SH":      "*.sh",
+		"archive.tar.gz":         "*.gz",
+		"CODEOWNERS":             "CODEOWNERS",
+		"build/Makefile":         "Makefile",
+		"config/.naysayerignore": ".naysayerignore",
+	}
+	for filePath, expected := range tests {
+		assert.Equal(t, expected, fileTypePattern(filePath), filePath)
+	}
+}
+
+func TestCoverageRecorder_Aggregates(t *testing.T) {
+	recorder := newCoverageRecorder()
+	for _, filePath := range []string{
+		"scripts/a.sh", "scripts/b.sh", "tools/c.sh",
+		"docs/diagram.png", "docs/logo.png",
+		"Makefile",
+	} {
+		recorder.record(filePath)
+	}
+
+	report := recorder.report()
+	require.Len(t, report.FileTypes, 3)
+	assert.Equal(t, "*.sh", report.FileTypes[0].Pattern)
+	assert.Equal(t, 3, report.FileTypes[0].Count)
+	assert.Equal(t, "tools/c.sh", report.FileTypes[0].ExamplePath, "the most recent path is the example")
+	assert.Equal(t, "*.png", report.FileTypes[1].Pattern)
+	assert.Equal(t, 2, report.FileTypes[1].Count)
+	assert.Equal(t, "Makefile", report.FileTypes[2].Pattern)
+	assert.False(t, report.FileTypes[2].LastSeen.IsZero())
+
+	recorder.reset()
+	assert.Empty(t, recorder.report().FileTypes)
+}
+
+func TestCoverageRecorder_BoundsFileTypes(t *testing.T) {
+	recorder := newCoverageRecorder()
+	for i := 0; i <= maxUncoveredFileTypes; i++ {
+		recorder.record(fmt.Sprintf("file.ext%d", i))
+	}
+	recorder.record("another.ext0")
+
+	report := recorder.report()
+	assert.Len(t, report.FileTypes, maxUncoveredFileTypes)
+	assert.Equal(t, "*.ext0", report.FileTypes[0].Pattern, "known types are still counted")
+	assert.Equal(t, 2, report.FileTypes[0].Count)
+}
+
+func TestSectionRuleManager_CoverageReport(t *testing.T) {
+	ruleConfig := &config.GlobalRuleConfig{
+		Enabled: true,
+		Files: []config.FileRuleConfig{{
+			Name:       "product_configs",
+			Path:       "dataproducts/**/",
+			Filename:   "product.yaml",
+			ParserType: "yaml",
+			Enabled:    true,
+			Sections:   []config.SectionDefinition{{Name: "name", YAMLPath: "name", AutoApprove: true}},
+		}},
+		AlwaysManualReview: []string{"secrets/**"},
+		UnmatchedFiles:     []config.UnmatchedFileFallback{{Pattern: "**/*.txt", Action: "auto_approve"}},
+	}
+	client := &ignoreTestGitLabClient{
+		forkMRTestGitLabClient: &forkMRTestGitLabClient{},
+		files:                  map[string]string{"dataproducts/marketing/prod/product.yaml": "name: marketing\n"},
+	}
+	manager := NewSectionRuleManager(ruleConfig, client)
+
+	evaluate := func(paths ...string) {
+		var changes []gitlab.FileChange
+		for _, path := range paths {
+			changes = append(changes, gitlab.FileChange{NewPath: path, Diff: "@@ -1 +1 @@\n-old\n+new"})
+		}
+		manager.EvaluateAll(&shared.MRContext{
+			ProjectID: 123,
+			MRIID:     456,
+			Changes:   changes,
+			MRInfo:    &gitlab.MRInfo{Author: "developer", SourceBranch: "feature"},
+		})
+	}
+	evaluate("dataproducts/marketing/prod/product.yaml", "scripts/deploy.sh", "notes.txt", "secrets/key.pem")
+	evaluate("scripts/cleanup.sh", "Makefile")
+
+	report := manager.CoverageReport()
+	require.Len(t, report.FileTypes, 2, "configured, always_manual_review and unmatched_files paths are not uncovered")
+	assert.Equal(t, UncoveredFileType{Pattern: "*.sh", Count: 2, ExamplePath: "scripts/cleanup.sh", LastSeen: report.FileTypes[0].LastSeen}, report.FileTypes[0])
+	assert.Equal(t, "Makefile", report.FileTypes[1].Pattern)
+
+	manager.Reload(ruleConfig, nil)
+	assert.Empty(t, manager.CoverageReport().FileTypes, "reloaded rules start a new report")
+}
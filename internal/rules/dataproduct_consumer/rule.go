@@ -1,6 +1,9 @@
 package dataproduct_consumer
 
 import (
+	"bufio"
+	"os"
+	"regexp"
 	"strings"
 
 	"github.com/redhat-data-and-ai/naysayer/internal/rules/common"
@@ -8,6 +11,12 @@ import (
 	"gopkg.in/yaml.v3"
 )
 
+// groupConsumerKinds are the consumer kinds that reference a rover group
+var groupConsumerKinds = map[string]bool{"rover_group": true, "consumer_group": true}
+
+// addedNamePattern captures the name set by an added diff line, e.g. `+    - name: analysts`
+var addedNamePattern = regexp.MustCompile(`^\+\s*(?:-\s*)?name:\s*["']?([^"'#\s]+)`)
+
 // DataProductConsumerRule validates consumer access changes to data products
 // Consumers can be added without TOC approval as long as data product owner approves
 // Consumer access can be granted across any environment (dev, sandbox, preprod, prod)
@@ -20,10 +29,17 @@ type DataProductConsumerRule struct {
 
 // NewDataProductConsumerRule creates a new data product consumer rule instance
 func NewDataProductConsumerRule(allowedEnvs []string) *DataProductConsumerRule {
+	return NewDataProductConsumerRuleWithAllowedGroups(allowedEnvs, nil)
+}
+
+// NewDataProductConsumerRuleWithAllowedGroups creates a consumer rule that requires manual review when an
+// added rover_group or consumer_group consumer is not in allowedGroups. A nil allowedGroups disables the check.
+func NewDataProductConsumerRuleWithAllowedGroups(allowedEnvs, allowedGroups []string) *DataProductConsumerRule {
 	config := DefaultDataProductConsumerConfig()
 	if allowedEnvs != nil {
 		config.AllowedEnvironments = allowedEnvs
 	}
+	config.AllowedGroups = allowedGroups
 
 	return &DataProductConsumerRule{
 		BaseRule:         common.NewBaseRule("dataproduct_consumer_rule", "Auto-approves consumer access changes to data products in allowed environments (preprod/prod)"),
@@ -48,6 +64,10 @@ func (r *DataProductConsumerRule) ValidateLines(filePath string, fileContent str
 		return shared.ManualReview, "Self-consumer detected: data product '" + context.SelfConsumerName + "' cannot be added as a consumer of itself - manual review required"
 	}
 
+	if len(context.UnknownGroups) > 0 {
+		return shared.ManualReview, "Consumer group(s) not in the allowed groups list: " + strings.Join(context.UnknownGroups, ", ") + " - manual review required"
+	}
+
 	// Auto-approve consumer-only changes across all environments
 	// Data product owner approval is sufficient, no TOC approval required
 	if context.HasConsumers && context.IsConsumerOnly {
@@ -102,6 +122,7 @@ func (r *DataProductConsumerRule) analyzeFile(filePath string, fileContent strin
 	isSelfConsumer, selfConsumerName := r.detectSelfConsumer(filePath, parsedContent)
 	context.IsSelfConsumer = isSelfConsumer
 	context.SelfConsumerName = selfConsumerName
+	context.UnknownGroups = r.findUnknownGroups(filePath, parsedContent)
 
 	// Check if file contains consumers section (for consumer-only change detection)
 	context.HasConsumers = r.fileContainsConsumersSection(parsedContent)
@@ -205,6 +226,81 @@ func (r *DataProductConsumerRule) detectSelfConsumer(filePath string, parsedCont
 	return false, ""
 }
 
+// findUnknownGroups returns the names of added group consumers that are not in the allowed groups.
+// Consumers count as added when the file's diff sets their name; without a diff every group consumer is checked.
+func (r *DataProductConsumerRule) findUnknownGroups(filePath string, parsedContent interface{}) []string {
+	if r.config.AllowedGroups == nil {
+		return nil
+	}
+	allowed := make(map[string]bool, len(r.config.AllowedGroups))
+	for _, group := range r.config.AllowedGroups {
+		allowed[group] = true
+	}
+	addedNames, hasDiff := r.addedNames(filePath)
+
+	var unknown []string
+	seen := make(map[string]bool)
+	for _, consumer := range r.extractConsumersFromContent(parsedContent) {
+		consumerMap, ok := consumer.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name, _ := consumerMap["name"].(string)
+		kind, _ := consumerMap["kind"].(string)
+		if name == "" || !groupConsumerKinds[kind] || allowed[name] || seen[name] {
+			continue
+		}
+		if hasDiff && !addedNames[name] {
+			continue // Existing consumer, not introduced by this MR
+		}
+		seen[name] = true
+		unknown = append(unknown, name)
+	}
+	return unknown
+}
+
+// addedNames returns the names set by added lines of the MR diff for filePath, and whether a diff was found
+func (r *DataProductConsumerRule) addedNames(filePath string) (map[string]bool, bool) {
+	mrCtx := r.GetMRContext()
+	if mrCtx == nil {
+		return nil, false
+	}
+	for _, change := range mrCtx.Changes {
+		if change.NewPath != filePath || change.Diff == "" {
+			continue
+		}
+		names := make(map[string]bool)
+		for _, line := range strings.Split(change.Diff, "\n") {
+			if matches := addedNamePattern.FindStringSubmatch(line); matches != nil {
+				names[matches[1]] = true
+			}
+		}
+		return names, true
+	}
+	return nil, false
+}
+
+// LoadAllowedGroups reads allowed consumer groups from a file with one group per line.
+// Blank lines and lines starting with # are ignored.
+func LoadAllowedGroups(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = file.Close() }()
+
+	groups := []string{}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		groups = append(groups, line)
+	}
+	return groups, scanner.Err()
+}
+
 // extractProductNameFromPath extracts the product name from the file path
 // Path format: dataproducts/<type>/<productname>/<env>/product.yaml
 func (r *DataProductConsumerRule) extractProductNameFromPath(filePath string) string {
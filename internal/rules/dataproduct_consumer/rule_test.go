@@ -1,11 +1,15 @@
 package dataproduct_consumer
 
 import (
+	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
 	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNewDataProductConsumerRule(t *testing.T) {
@@ -946,3 +950,76 @@ presentation_schemas:
 		assert.Len(t, result, 1)
 	})
 }
+
+func TestDataProductConsumerRule_ValidateLines_AllowedGroups(t *testing.T) {
+	const filePath = "dataproducts/aggregate/analytics/prod/product.yaml"
+	content := `name: analytics
+data_product_db:
+- database: analytics_db
+  presentation_schemas:
+  - name: marts
+    consumers:
+    - name: legacy-readers
+      kind: rover_group
+    - name: %s
+      kind: rover_group`
+	lineRanges := []shared.LineRange{{StartLine: 9, EndLine: 10, FilePath: filePath}}
+
+	tests := []struct {
+		name                   string
+		allowedGroups          []string
+		addedGroup             string
+		expectedDecision       shared.DecisionType
+		expectedReasonContains string
+	}{
+		{
+			name:                   "known group addition is approved",
+			allowedGroups:          []string{"dataverse-analysts"},
+			addedGroup:             "dataverse-analysts",
+			expectedDecision:       shared.Approve,
+			expectedReasonContains: "data product owner approval sufficient",
+		},
+		{
+			name:                   "unknown group addition needs review",
+			allowedGroups:          []string{"dataverse-analysts"},
+			addedGroup:             "typo-analysts",
+			expectedDecision:       shared.ManualReview,
+			expectedReasonContains: "not in the allowed groups list: typo-analysts",
+		},
+		{
+			name:                   "disabled enforcement approves any group",
+			allowedGroups:          nil,
+			addedGroup:             "typo-analysts",
+			expectedDecision:       shared.Approve,
+			expectedReasonContains: "data product owner approval sufficient",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := NewDataProductConsumerRuleWithAllowedGroups(nil, tt.allowedGroups)
+			// legacy-readers is not allowed either, but it predates the MR
+			rule.SetMRContext(&shared.MRContext{Changes: []gitlab.FileChange{{
+				NewPath: filePath,
+				Diff:    "@@ -8,0 +9,2 @@\n+    - name: " + tt.addedGroup + "\n+      kind: rover_group",
+			}}})
+
+			decision, reason := rule.ValidateLines(filePath, fmt.Sprintf(content, tt.addedGroup), lineRanges)
+
+			assert.Equal(t, tt.expectedDecision, decision)
+			assert.Contains(t, reason, tt.expectedReasonContains)
+		})
+	}
+}
+
+func TestLoadAllowedGroups(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "groups.txt")
+	require.NoError(t, os.WriteFile(path, []byte("# rover groups\ndataverse-analysts\n\n  dataverse-admins  \n"), 0644))
+
+	groups, err := LoadAllowedGroups(path)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"dataverse-analysts", "dataverse-admins"}, groups)
+
+	_, err = LoadAllowedGroups(filepath.Join(t.TempDir(), "missing.txt"))
+	assert.Error(t, err)
+}
@@ -6,6 +6,8 @@ type DataProductConsumerConfig struct {
 	AllowedEnvironments []string
 	// Whether environment matching is case-sensitive
 	CaseSensitive bool
+	// Groups that added rover_group/consumer_group consumers may reference; nil disables the check
+	AllowedGroups []string
 }
 
 // DefaultDataProductConsumerConfig returns default configuration
@@ -21,7 +23,8 @@ type ConsumerContext struct {
 	FilePath         string
 	Environment      string
 	HasConsumers     bool
-	IsConsumerOnly   bool   // Only consumer fields are being modified
-	IsSelfConsumer   bool   // Product is added as consumer of itself
-	SelfConsumerName string // Name of the self-consumer (for error message)
+	IsConsumerOnly   bool     // Only consumer fields are being modified
+	IsSelfConsumer   bool     // Product is added as consumer of itself
+	SelfConsumerName string   // Name of the self-consumer (for error message)
+	UnknownGroups    []string // Added group consumers missing from the allowed groups
 }
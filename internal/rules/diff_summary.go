@@ -0,0 +1,30 @@
+package rules
+
+import (
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+)
+
+// countDiffLines counts the lines a unified diff adds and removes
+func countDiffLines(diff string) (added, removed int) {
+	for _, hunk := range splitDiffHunks(diff) {
+		added += len(hunk.added)
+		removed += len(hunk.removed)
+	}
+	return added, removed
+}
+
+// annotateDiffSummaries records each file's added and removed line counts next to the sections
+// section-based validation found touched. Files without a diff get no summary.
+func (srm *SectionRuleManager) annotateDiffSummaries(fileValidations map[string]*shared.FileValidationSummary, mrCtx *shared.MRContext) {
+	for filePath, fileValidation := range fileValidations {
+		diff := srm.getDiffForFile(filePath, mrCtx)
+		if diff == "" {
+			fileValidation.DiffSummary = nil
+			continue
+		}
+		if fileValidation.DiffSummary == nil {
+			fileValidation.DiffSummary = &shared.DiffSummary{}
+		}
+		fileValidation.DiffSummary.AddedLines, fileValidation.DiffSummary.RemovedLines = countDiffLines(diff)
+	}
+}
@@ -0,0 +1,68 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCountDiffLines(t *testing.T) {
+	added, removed := countDiffLines("--- a/product.yaml\n+++ b/product.yaml\n@@ -1,2 +1,3 @@\n name: marketing\n-kind: source\n+kind: aggregated\n+tier: gold\n\\ No newline at end of file")
+	assert.Equal(t, 2, added)
+	assert.Equal(t, 1, removed, "file headers before the first hunk are not counted")
+}
+
+func TestSectionRuleManager_DiffSummary(t *testing.T) {
+	const productPath = "dataproducts/marketing/prod/product.yaml"
+	ruleConfig := &config.GlobalRuleConfig{Enabled: true, Files: []config.FileRuleConfig{{
+		Name:       "product_configs",
+		Path:       "dataproducts/**/",
+		Filename:   "product.yaml",
+		ParserType: "yaml",
+		Enabled:    true,
+		Sections: []config.SectionDefinition{
+			{Name: "name", YAMLPath: "name", AutoApprove: true},
+			{Name: "kind", YAMLPath: "kind", AutoApprove: true},
+			{Name: "tags", YAMLPath: "tags", AutoApprove: true},
+		},
+	}}}
+	client := &ignoreTestGitLabClient{
+		forkMRTestGitLabClient: &forkMRTestGitLabClient{},
+		files: map[string]string{
+			productPath: "name: marketing\nkind: aggregated\ntags:\n  tier: gold\n  owner: sales\n",
+		},
+	}
+	manager := NewSectionRuleManager(ruleConfig, client)
+
+	result := manager.EvaluateAll(&shared.MRContext{
+		ProjectID: 123,
+		MRIID:     456,
+		Changes: []gitlab.FileChange{
+			{NewPath: productPath, Diff: "@@ -1 +1 @@\n-name: mkt\n+name: marketing\n@@ -4 +4,2 @@\n-  tier: silver\n+  tier: gold\n+  owner: sales"},
+			{NewPath: "README.md", Diff: "@@ -1,2 +1 @@\n-# Title\n-old\n+# Title"},
+			{NewPath: "docs/empty.md"},
+		},
+		MRInfo: &gitlab.MRInfo{Author: "developer", SourceBranch: "feature"},
+	})
+
+	product := result.FileValidations[productPath]
+	require.NotNil(t, product)
+	require.NotNil(t, product.DiffSummary)
+	assert.Equal(t, 3, product.DiffSummary.AddedLines)
+	assert.Equal(t, 2, product.DiffSummary.RemovedLines)
+	assert.Equal(t, []string{"name", "tags"}, product.DiffSummary.Sections, "the untouched kind section is not listed")
+
+	readme := result.FileValidations["README.md"]
+	require.NotNil(t, readme)
+	require.NotNil(t, readme.DiffSummary)
+	assert.Equal(t, 1, readme.DiffSummary.AddedLines)
+	assert.Equal(t, 2, readme.DiffSummary.RemovedLines)
+	assert.Empty(t, readme.DiffSummary.Sections)
+
+	require.NotNil(t, result.FileValidations["docs/empty.md"])
+	assert.Nil(t, result.FileValidations["docs/empty.md"].DiffSummary)
+}
@@ -0,0 +1,59 @@
+package rules
+
+import (
+	"strings"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/logging"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+)
+
+// generatedFileRuleName labels the approval recorded for files carrying a generated_file_markers header
+const generatedFileRuleName = "generated_file"
+
+// generatedMarkerHeaderLines is how many leading lines are searched for a generated file marker,
+// leaving room for a shebang or license line above it
+const generatedMarkerHeaderLines = 5
+
+// generatedFileMarker returns the first configured marker found in the file's header, or "" if none is
+func (srm *SectionRuleManager) generatedFileMarker(fileContent string) string {
+	if len(srm.config.GeneratedFileMarkers) == 0 {
+		return ""
+	}
+
+	header := strings.SplitN(fileContent, "\n", generatedMarkerHeaderLines+1)
+	if len(header) > generatedMarkerHeaderLines {
+		header = header[:generatedMarkerHeaderLines]
+	}
+	for _, line := range header {
+		for _, marker := range srm.config.GeneratedFileMarkers {
+			if strings.Contains(line, marker) {
+				return marker
+			}
+		}
+	}
+	return ""
+}
+
+// createGeneratedFileValidation approves a generated file; its changes come from the generator, not a person
+func (srm *SectionRuleManager) createGeneratedFileValidation(filePath string, totalLines int, marker string) *shared.FileValidationSummary {
+	logging.Info("File %s has generated file marker '%s' - approving", filePath, marker)
+
+	lines := []shared.LineRange{}
+	if totalLines > 0 {
+		lines = append(lines, shared.LineRange{StartLine: 1, EndLine: totalLines, FilePath: filePath})
+	}
+	return &shared.FileValidationSummary{
+		FilePath:       filePath,
+		TotalLines:     totalLines,
+		CoveredLines:   lines,
+		UncoveredLines: []shared.LineRange{},
+		RuleResults: []shared.LineValidationResult{{
+			RuleName:     generatedFileRuleName,
+			LineRanges:   lines,
+			Decision:     shared.Approve,
+			Reason:       "generated file",
+			WasEvaluated: true,
+		}},
+		FileDecision: shared.Approve,
+	}
+}
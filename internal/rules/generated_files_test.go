@@ -0,0 +1,110 @@
+package rules
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSectionRuleManager_GeneratedFileMarkers(t *testing.T) {
+	const rulesYAML = `enabled: true
+generated_file_markers:
+  - "# DO NOT EDIT"
+always_manual_review:
+  - "dataproducts/**/locked/*"
+files:
+  - name: "product_configs"
+    path: "dataproducts/**/"
+    filename: "product.yaml"
+    parser_type: yaml
+    enabled: true
+    sections:
+      - name: name
+        yaml_path: name
+        rule_configs:
+          - name: metadata_rule
+            enabled: true
+        auto_approve: true
+`
+	const generated = "#!/usr/bin/env generator\n# DO NOT EDIT - generated by schema-sync\nschemas:\n  - orders\n"
+
+	tests := []struct {
+		name             string
+		filePath         string
+		content          string
+		diff             string
+		expectedDecision shared.DecisionType
+		expectedRule     string
+	}{
+		{
+			name:             "marked file without a file configuration is approved",
+			filePath:         "dataproducts/marketing/prod/schemas.lock",
+			content:          generated,
+			diff:             "@@ -4 +4 @@\n-  - customers\n+  - orders",
+			expectedDecision: shared.Approve,
+			expectedRule:     generatedFileRuleName,
+		},
+		{
+			name:             "marked file with a file configuration keeps its section rules",
+			filePath:         "dataproducts/marketing/prod/product.yaml",
+			content:          "# DO NOT EDIT\nname: marketing\nkind: aggregated\n",
+			diff:             "@@ -3 +3 @@\n-kind: source\n+kind: aggregated",
+			expectedDecision: shared.ManualReview,
+		},
+		{
+			name:             "unmarked file needs review",
+			filePath:         "dataproducts/marketing/prod/schemas.lock",
+			content:          "schemas:\n  - orders\n",
+			diff:             "@@ -2 +2 @@\n-  - customers\n+  - orders",
+			expectedDecision: shared.ManualReview,
+		},
+		{
+			name:             "marker below the header is ignored",
+			filePath:         "dataproducts/marketing/prod/schemas.lock",
+			content:          "a: 1\nb: 2\nc: 3\nd: 4\ne: 5\n# DO NOT EDIT\n",
+			diff:             "@@ -1 +1 @@\n-a: 0\n+a: 1",
+			expectedDecision: shared.ManualReview,
+		},
+		{
+			name:             "always_manual_review wins over the marker",
+			filePath:         "dataproducts/marketing/locked/schemas.lock",
+			content:          generated,
+			diff:             "@@ -4 +4 @@\n-  - customers\n+  - orders",
+			expectedDecision: shared.ManualReview,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rulesPath := filepath.Join(t.TempDir(), "rules.yaml")
+			require.NoError(t, os.WriteFile(rulesPath, []byte(rulesYAML), 0644))
+			client := &ignoreTestGitLabClient{
+				forkMRTestGitLabClient: &forkMRTestGitLabClient{},
+				files:                  map[string]string{tt.filePath: tt.content},
+			}
+			manager, err := NewRuleRegistry().CreateSectionBasedRuleManager(client, rulesPath)
+			require.NoError(t, err)
+
+			result := manager.EvaluateAll(&shared.MRContext{
+				ProjectID: 123,
+				MRIID:     456,
+				Changes:   []gitlab.FileChange{{NewPath: tt.filePath, Diff: tt.diff}},
+				MRInfo:    &gitlab.MRInfo{Author: "developer", SourceBranch: "feature"},
+			})
+
+			validation := result.FileValidations[tt.filePath]
+			require.NotNil(t, validation)
+			assert.Equal(t, tt.expectedDecision, validation.FileDecision)
+			if tt.expectedRule != "" {
+				require.Len(t, validation.RuleResults, 1)
+				assert.Equal(t, tt.expectedRule, validation.RuleResults[0].RuleName)
+				assert.Equal(t, "generated file", validation.RuleResults[0].Reason)
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package rules
+
+import (
+	"time"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/logging"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+)
+
+// applyGlobalRules runs the global_rules of the configuration on every changed file.
+// A global rule can only turn a file into manual review, whatever its sections,
+// .naysayerignore or always_manual_review decided; approvals are not recorded.
+// Diff-only rules get the file's diff, other rules the fetched content.
+func (srm *SectionRuleManager) applyGlobalRules(fileValidations map[string]*shared.FileValidationSummary, fileContents map[string]string, mrCtx *shared.MRContext) {
+	rules := srm.getEnabledRulesForSection(srm.config.GlobalRules)
+	if len(rules) == 0 {
+		return
+	}
+
+	for filePath, validation := range fileValidations {
+		var failures []shared.LineValidationResult
+		for _, rule := range rules {
+			start := time.Now()
+			var decision shared.DecisionType
+			var reason string
+			if diffRule, ok := rule.(shared.DiffOnlyRule); ok {
+				decision, reason = diffRule.ValidateDiff(filePath, srm.getDiffForFile(filePath, mrCtx))
+			} else {
+				decision, reason = rule.ValidateLines(filePath, fileContents[filePath], nil)
+			}
+			duration := time.Since(start)
+			if decision != shared.ManualReview {
+				continue
+			}
+			if isAdvisoryRule(srm.config.GlobalRules, rule.Name()) {
+				logging.Info("Advisory global rule %s flagged %s: %s", rule.Name(), filePath, reason)
+				continue
+			}
+			logging.Info("Global rule %s requires manual review for %s: %s", rule.Name(), filePath, reason)
+			failures = append(failures, shared.LineValidationResult{
+				RuleName:     rule.Name(),
+				Decision:     shared.ManualReview,
+				Reason:       reason,
+				WasEvaluated: true,
+				Duration:     duration,
+			})
+		}
+		if len(failures) == 0 {
+			continue
+		}
+
+		// Copy so validations reused through reuse_unchanged_files keep their original results
+		updated := *validation
+		updated.RuleResults = append(append([]shared.LineValidationResult{}, validation.RuleResults...), failures...)
+		updated.FileDecision = shared.ManualReview
+		fileValidations[filePath] = &updated
+	}
+}
+
+// globalRulesNeedContent reports whether any enabled global rule needs file content rather than just the diff
+func (srm *SectionRuleManager) globalRulesNeedContent() bool {
+	for _, rule := range srm.getEnabledRulesForSection(srm.config.GlobalRules) {
+		if _, ok := rule.(shared.DiffOnlyRule); !ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,105 @@
+package rules
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/common"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+)
+
+// topLevelKindPattern matches a top-level `kind:` line, optionally prefixed by a diff marker
+var topLevelKindPattern = regexp.MustCompile(`^([+-]?)kind:\s*(.*)$`)
+
+// KindChangeRule requires manual review when an existing config changes its top-level `kind`,
+// since a new kind reinterprets the whole file
+type KindChangeRule struct {
+	*common.BaseRule
+	*common.ValidationHelper
+}
+
+// NewKindChangeRule creates a new kind change rule
+func NewKindChangeRule() *KindChangeRule {
+	return &KindChangeRule{
+		BaseRule: common.NewBaseRule(
+			"kind_change_rule",
+			"Requires manual review when an existing config changes its top-level 'kind' field",
+		),
+		ValidationHelper: common.NewValidationHelper(),
+	}
+}
+
+// GetCoveredLines returns which line ranges this rule validates in a file
+func (r *KindChangeRule) GetCoveredLines(filePath string, fileContent string) []shared.LineRange {
+	for _, line := range strings.Split(fileContent, "\n") {
+		if matches := topLevelKindPattern.FindStringSubmatch(line); matches != nil && matches[1] == "" {
+			return r.GetFullFileCoverage(filePath, fileContent)
+		}
+	}
+	return []shared.LineRange{}
+}
+
+// ValidateLines compares the old and new `kind` values from the MR diff
+func (r *KindChangeRule) ValidateLines(filePath string, fileContent string, lineRanges []shared.LineRange) (shared.DecisionType, string) {
+	mrCtx := r.GetMRContext()
+	if mrCtx == nil {
+		return r.CreateApprovalResult("No MR context - kind change check skipped")
+	}
+
+	for _, change := range mrCtx.Changes {
+		if change.NewPath != filePath {
+			continue
+		}
+		if change.NewFile {
+			return r.CreateApprovalResult("New file - kind change check not applicable")
+		}
+
+		oldKind, newKind, changed := r.kindChangeFromDiff(change.Diff)
+		if !changed {
+			return r.CreateApprovalResult("Kind unchanged")
+		}
+		return r.CreateManualReviewResult(fmt.Sprintf("Kind changed from '%s' to '%s' - the file will be interpreted as a different config type", oldKind, newKind))
+	}
+
+	return r.CreateApprovalResult("File not changed in this MR - kind change check skipped")
+}
+
+// kindChangeFromDiff extracts removed and added top-level `kind` values from a unified diff.
+// A missing value is reported as "(none)" so adding or removing `kind` counts as a change.
+func (r *KindChangeRule) kindChangeFromDiff(diff string) (oldKind, newKind string, changed bool) {
+	var removed, added string
+	var hasRemoved, hasAdded bool
+	for _, line := range strings.Split(diff, "\n") {
+		matches := topLevelKindPattern.FindStringSubmatch(line)
+		if matches == nil {
+			continue
+		}
+		switch matches[1] {
+		case "-":
+			removed, hasRemoved = normalizeKind(matches[2]), true
+		case "+":
+			added, hasAdded = normalizeKind(matches[2]), true
+		}
+	}
+
+	if !hasRemoved && !hasAdded {
+		return "", "", false
+	}
+	oldKind, newKind = "(none)", "(none)"
+	if hasRemoved {
+		oldKind = removed
+	}
+	if hasAdded {
+		newKind = added
+	}
+	return oldKind, newKind, oldKind != newKind
+}
+
+// normalizeKind strips trailing comments and quotes from a `kind` value
+func normalizeKind(value string) string {
+	if idx := strings.Index(value, " #"); idx >= 0 {
+		value = value[:idx]
+	}
+	return strings.Trim(strings.TrimSpace(value), `"'`)
+}
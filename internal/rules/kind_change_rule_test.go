@@ -0,0 +1,119 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKindChangeRule_Name(t *testing.T) {
+	rule := NewKindChangeRule()
+	assert.Equal(t, "kind_change_rule", rule.Name())
+	assert.Contains(t, rule.Description(), "kind")
+}
+
+func TestKindChangeRule_GetCoveredLines(t *testing.T) {
+	rule := NewKindChangeRule()
+
+	tests := []struct {
+		name        string
+		content     string
+		expectCover bool
+	}{
+		{"kind section", "kind: DataProduct", true},
+		{"full file with kind", "name: analytics\nkind: aggregated\n", true},
+		{"nested kind only", "spec:\n  kind: table\n", false},
+		{"no kind", "name: analytics\n", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lines := rule.GetCoveredLines("dataproducts/source/analytics/prod/product.yaml", tt.content)
+			assert.Equal(t, tt.expectCover, len(lines) > 0)
+		})
+	}
+}
+
+func TestKindChangeRule_ValidateLines(t *testing.T) {
+	filePath := "dataproducts/source/analytics/prod/sourcebinding.yaml"
+
+	tests := []struct {
+		name               string
+		change             gitlab.FileChange
+		expectedDecision   shared.DecisionType
+		expectedReasonPart string
+	}{
+		{
+			name: "changed kind",
+			change: gitlab.FileChange{
+				NewPath: filePath,
+				OldPath: filePath,
+				Diff:    "@@ -1,3 +1,3 @@\n-kind: DataProduct\n+kind: SourceBinding\n name: analytics\n",
+			},
+			expectedDecision:   shared.ManualReview,
+			expectedReasonPart: "Kind changed from 'DataProduct' to 'SourceBinding'",
+		},
+		{
+			name: "removed kind",
+			change: gitlab.FileChange{
+				NewPath: filePath,
+				OldPath: filePath,
+				Diff:    "@@ -1,2 +1,1 @@\n-kind: \"SourceBinding\"\n name: analytics\n",
+			},
+			expectedDecision:   shared.ManualReview,
+			expectedReasonPart: "Kind changed from 'SourceBinding' to '(none)'",
+		},
+		{
+			name: "unchanged kind",
+			change: gitlab.FileChange{
+				NewPath: filePath,
+				OldPath: filePath,
+				Diff:    "@@ -1,3 +1,3 @@\n kind: SourceBinding\n-name: analytics\n+name: analytics-v2\n",
+			},
+			expectedDecision:   shared.Approve,
+			expectedReasonPart: "Kind unchanged",
+		},
+		{
+			name: "kind line rewritten with the same value",
+			change: gitlab.FileChange{
+				NewPath: filePath,
+				OldPath: filePath,
+				Diff:    "@@ -1,1 +1,1 @@\n-kind: SourceBinding # old comment\n+kind: 'SourceBinding'\n",
+			},
+			expectedDecision:   shared.Approve,
+			expectedReasonPart: "Kind unchanged",
+		},
+		{
+			name: "newly added file with kind",
+			change: gitlab.FileChange{
+				NewPath: filePath,
+				NewFile: true,
+				Diff:    "@@ -0,0 +1,2 @@\n+kind: SourceBinding\n+name: analytics\n",
+			},
+			expectedDecision:   shared.Approve,
+			expectedReasonPart: "New file",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := NewKindChangeRule()
+			rule.SetMRContext(&shared.MRContext{Changes: []gitlab.FileChange{tt.change}})
+
+			decision, reason := rule.ValidateLines(filePath, "kind: SourceBinding", nil)
+
+			assert.Equal(t, tt.expectedDecision, decision)
+			assert.Contains(t, reason, tt.expectedReasonPart)
+		})
+	}
+}
+
+func TestKindChangeRule_ValidateLines_NoContext(t *testing.T) {
+	rule := NewKindChangeRule()
+
+	decision, _ := rule.ValidateLines("product.yaml", "kind: DataProduct", nil)
+
+	assert.Equal(t, shared.Approve, decision)
+}
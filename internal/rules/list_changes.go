@@ -0,0 +1,229 @@
+package rules
+
+import (
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/logging"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+	"go.uber.org/zap"
+)
+
+// hunkNewRangePattern extracts the new-file start line and line count from a diff hunk header
+var hunkNewRangePattern = regexp.MustCompile(`^@@ -\d+(?:,\d+)? \+(\d+)(?:,(\d+))?`)
+
+// listIdentityKeys are the fields, in order of preference, that identify a list element across versions
+var listIdentityKeys = []string{"name", "type", "id"}
+
+// annotateListChanges sets ListChanges on list sections by comparing them with the same sections
+// of the file before the MR, rebuilt by reverse-applying the diff. Sections stay unannotated when
+// the diff doesn't match the content or the old file can't be parsed.
+func (srm *SectionRuleManager) annotateListChanges(filePath, fileContent, diffText string, parser shared.SectionParser, sections []shared.Section) {
+	hasList := false
+	for _, section := range sections {
+		if _, ok := sectionList(section); ok {
+			hasList = true
+			break
+		}
+	}
+	if !hasList || diffText == "" {
+		return
+	}
+	oldContent, ok := reconstructOldContent(fileContent, diffText)
+	if !ok {
+		logging.Debug("Diff does not apply to file content, list changes not classified", zap.String("file", filePath))
+		return
+	}
+
+	var oldSections []shared.Section
+	if strings.TrimSpace(oldContent) != "" {
+		var err error
+		if oldSections, err = parser.ParseSections(filePath, oldContent); err != nil {
+			logging.Debug("Cannot parse file before the MR, list changes not classified", zap.String("file", filePath), zap.Error(err))
+			return
+		}
+	}
+
+	// Multi-document files repeat section names, so sections are paired by name and occurrence
+	oldByName := make(map[string][]shared.Section)
+	for _, section := range oldSections {
+		oldByName[section.Name] = append(oldByName[section.Name], section)
+	}
+	seen := make(map[string]int)
+	for i := range sections {
+		newList, ok := sectionList(sections[i])
+		if !ok {
+			continue
+		}
+		var oldList []interface{}
+		if occurrence := seen[sections[i].Name]; occurrence < len(oldByName[sections[i].Name]) {
+			if oldList, ok = sectionList(oldByName[sections[i].Name][occurrence]); !ok {
+				seen[sections[i].Name]++
+				continue // Was not a list before the MR
+			}
+		}
+		seen[sections[i].Name]++
+		sections[i].ListChanges = classifyListChanges(oldList, newList)
+	}
+}
+
+// sectionList returns the elements of a section whose YAML value is a sequence
+func sectionList(section shared.Section) ([]interface{}, bool) {
+	if len(section.Fields) != 1 {
+		return nil, false
+	}
+	list, ok := section.Fields["value"].([]interface{})
+	return list, ok
+}
+
+// classifyListChanges compares two versions of a list. Elements are paired by their identity key
+// (name, type or id) when every element has a unique one; otherwise unchanged elements are paired
+// by value and the rest by position, so an unmatched old and new element count as a modification.
+func classifyListChanges(oldList, newList []interface{}) *shared.ListChanges {
+	changes := &shared.ListChanges{Added: []int{}, Modified: []int{}, Removed: []int{}}
+
+	if key := commonIdentityKey(oldList, newList); key != "" {
+		oldIndex := make(map[interface{}]int, len(oldList))
+		for i, element := range oldList {
+			oldIndex[element.(map[string]interface{})[key]] = i
+		}
+		matched := make(map[int]bool)
+		for i, element := range newList {
+			j, ok := oldIndex[element.(map[string]interface{})[key]]
+			switch {
+			case !ok:
+				changes.Added = append(changes.Added, i)
+			case !reflect.DeepEqual(oldList[j], element):
+				changes.Modified = append(changes.Modified, i)
+			}
+			if ok {
+				matched[j] = true
+			}
+		}
+		for j := range oldList {
+			if !matched[j] {
+				changes.Removed = append(changes.Removed, j)
+			}
+		}
+		return changes
+	}
+
+	unmatchedOld := make([]int, 0, len(oldList))
+	used := make([]bool, len(newList))
+	for j, oldElement := range oldList {
+		found := false
+		for i, newElement := range newList {
+			if !used[i] && reflect.DeepEqual(oldElement, newElement) {
+				used[i], found = true, true
+				break
+			}
+		}
+		if !found {
+			unmatchedOld = append(unmatchedOld, j)
+		}
+	}
+	for i := range newList {
+		if used[i] {
+			continue
+		}
+		if len(unmatchedOld) > 0 {
+			changes.Modified = append(changes.Modified, i)
+			unmatchedOld = unmatchedOld[1:]
+		} else {
+			changes.Added = append(changes.Added, i)
+		}
+	}
+	changes.Removed = append(changes.Removed, unmatchedOld...)
+	return changes
+}
+
+// commonIdentityKey returns the first of listIdentityKeys that every element of both lists has
+// as a unique scalar, or "" when elements can't be paired by key
+func commonIdentityKey(oldList, newList []interface{}) string {
+	for _, key := range listIdentityKeys {
+		if uniqueScalarKey(oldList, key) && uniqueScalarKey(newList, key) {
+			return key
+		}
+	}
+	return ""
+}
+
+// uniqueScalarKey reports whether every element is a mapping with a distinct scalar value for key
+func uniqueScalarKey(list []interface{}, key string) bool {
+	values := make(map[interface{}]bool, len(list))
+	for _, element := range list {
+		fields, ok := element.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		value, ok := fields[key]
+		if !ok || value == nil {
+			return false
+		}
+		switch value.(type) {
+		case map[string]interface{}, []interface{}:
+			return false
+		}
+		if values[value] {
+			return false
+		}
+		values[value] = true
+	}
+	return true
+}
+
+// reconstructOldContent rebuilds a file as it was before a unified diff by reverse-applying
+// the diff to newContent. It fails when the diff's context or added lines don't match newContent.
+func reconstructOldContent(newContent, diff string) (string, bool) {
+	newLines := strings.Split(newContent, "\n")
+	var oldLines []string
+	cursor := 0 // Next unconsumed index in newLines
+	inHunk := false
+
+	for _, line := range strings.Split(strings.TrimSuffix(diff, "\n"), "\n") {
+		if matches := hunkNewRangePattern.FindStringSubmatch(line); matches != nil {
+			start, _ := strconv.Atoi(matches[1])
+			// A hunk adding no lines names the new line it follows rather than its first line
+			if matches[2] != "0" {
+				start--
+			}
+			if start < cursor || start > len(newLines) {
+				return "", false
+			}
+			oldLines = append(oldLines, newLines[cursor:start]...)
+			cursor = start
+			inHunk = true
+			continue
+		}
+		if !inHunk {
+			continue // Before the first hunk (e.g. file headers)
+		}
+
+		switch {
+		case strings.HasPrefix(line, "-"):
+			oldLines = append(oldLines, line[1:])
+		case strings.HasPrefix(line, "+"):
+			if cursor >= len(newLines) || newLines[cursor] != line[1:] {
+				return "", false
+			}
+			cursor++
+		case strings.HasPrefix(line, `\`):
+			// "\ No newline at end of file"
+		default:
+			context := strings.TrimPrefix(line, " ")
+			if cursor >= len(newLines) || newLines[cursor] != context {
+				return "", false
+			}
+			oldLines = append(oldLines, context)
+			cursor++
+		}
+	}
+	if !inHunk {
+		return "", false
+	}
+
+	oldLines = append(oldLines, newLines[cursor:]...)
+	return strings.Join(oldLines, "\n"), true
+}
@@ -0,0 +1,231 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// appendOnlyMockRule approves list sections that only gained elements
+type appendOnlyMockRule struct {
+	AutoApproveMockRule
+	seen *shared.ListChanges
+}
+
+func (m *appendOnlyMockRule) ValidateSectionLines(section *shared.Section, lineRanges []shared.LineRange) (shared.DecisionType, string) {
+	m.seen = section.ListChanges
+	if section.ListChanges.AdditionsOnly() {
+		return shared.Approve, "only new elements"
+	}
+	return shared.ManualReview, "existing elements changed"
+}
+
+func TestReconstructOldContent(t *testing.T) {
+	tests := []struct {
+		name       string
+		newContent string
+		diff       string
+		expected   string
+		ok         bool
+	}{
+		{
+			name:       "modified line",
+			newContent: "name: a\nsize: LARGE\nkind: x\n",
+			diff:       "@@ -2 +2 @@\n-size: SMALL\n+size: LARGE\n",
+			expected:   "name: a\nsize: SMALL\nkind: x\n",
+			ok:         true,
+		},
+		{
+			name:       "appended lines with context",
+			newContent: "items:\n  - a\n  - b\n",
+			diff:       "@@ -1,2 +1,3 @@\n items:\n   - a\n+  - b",
+			expected:   "items:\n  - a\n",
+			ok:         true,
+		},
+		{
+			name:       "removed lines",
+			newContent: "items:\n  - a\n",
+			diff:       "@@ -3 +2,0 @@\n-  - b",
+			expected:   "items:\n  - a\n  - b\n",
+			ok:         true,
+		},
+		{
+			name:       "new file",
+			newContent: "items:\n  - a\n",
+			diff:       "@@ -0,0 +1,2 @@\n+items:\n+  - a",
+			expected:   "",
+			ok:         true,
+		},
+		{
+			name:       "diff does not match content",
+			newContent: "name: a\n",
+			diff:       "@@ -1 +1 @@\n-name: b\n+name: c",
+			ok:         false,
+		},
+		{
+			name:       "no hunks",
+			newContent: "name: a\n",
+			diff:       "",
+			ok:         false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			oldContent, ok := reconstructOldContent(tt.newContent, tt.diff)
+			assert.Equal(t, tt.ok, ok)
+			if tt.ok {
+				assert.Equal(t, tt.expected, oldContent)
+			}
+		})
+	}
+}
+
+func TestClassifyListChanges(t *testing.T) {
+	warehouse := func(whType, size string) interface{} {
+		return map[string]interface{}{"type": whType, "size": size}
+	}
+
+	tests := []struct {
+		name     string
+		oldList  []interface{}
+		newList  []interface{}
+		expected shared.ListChanges
+	}{
+		{
+			name:     "append only",
+			oldList:  []interface{}{warehouse("user", "XSMALL")},
+			newList:  []interface{}{warehouse("user", "XSMALL"), warehouse("service_account", "SMALL")},
+			expected: shared.ListChanges{Added: []int{1}, Modified: []int{}, Removed: []int{}},
+		},
+		{
+			name:     "modify existing",
+			oldList:  []interface{}{warehouse("user", "XSMALL"), warehouse("service_account", "SMALL")},
+			newList:  []interface{}{warehouse("user", "LARGE"), warehouse("service_account", "SMALL")},
+			expected: shared.ListChanges{Added: []int{}, Modified: []int{0}, Removed: []int{}},
+		},
+		{
+			name:     "removal",
+			oldList:  []interface{}{warehouse("user", "XSMALL"), warehouse("service_account", "SMALL")},
+			newList:  []interface{}{warehouse("service_account", "SMALL")},
+			expected: shared.ListChanges{Added: []int{}, Modified: []int{}, Removed: []int{0}},
+		},
+		{
+			name:     "insert before existing elements",
+			oldList:  []interface{}{warehouse("user", "XSMALL")},
+			newList:  []interface{}{warehouse("service_account", "SMALL"), warehouse("user", "XSMALL")},
+			expected: shared.ListChanges{Added: []int{0}, Modified: []int{}, Removed: []int{}},
+		},
+		{
+			name:     "new list",
+			oldList:  nil,
+			newList:  []interface{}{warehouse("user", "XSMALL")},
+			expected: shared.ListChanges{Added: []int{0}, Modified: []int{}, Removed: []int{}},
+		},
+		{
+			name:     "scalars appended",
+			oldList:  []interface{}{"a", "b"},
+			newList:  []interface{}{"a", "b", "c"},
+			expected: shared.ListChanges{Added: []int{2}, Modified: []int{}, Removed: []int{}},
+		},
+		{
+			name:     "scalar changed in place",
+			oldList:  []interface{}{"a", "b"},
+			newList:  []interface{}{"a", "x"},
+			expected: shared.ListChanges{Added: []int{}, Modified: []int{1}, Removed: []int{}},
+		},
+		{
+			name:     "scalar removed",
+			oldList:  []interface{}{"a", "b"},
+			newList:  []interface{}{"b"},
+			expected: shared.ListChanges{Added: []int{}, Modified: []int{}, Removed: []int{0}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, *classifyListChanges(tt.oldList, tt.newList))
+		})
+	}
+}
+
+func TestSectionRuleManager_ListChanges(t *testing.T) {
+	ruleConfig := &config.GlobalRuleConfig{
+		Enabled: true,
+		Files: []config.FileRuleConfig{{
+			Name:       "product_configs",
+			Path:       "**/",
+			Filename:   "product.yaml",
+			ParserType: "yaml",
+			Enabled:    true,
+			Sections: []config.SectionDefinition{
+				{
+					Name:        "name",
+					YAMLPath:    "name",
+					AutoApprove: true,
+				},
+				{
+					Name:        "warehouses",
+					YAMLPath:    "warehouses",
+					RuleConfigs: []config.RuleConfig{{Name: "append_only_rule", Enabled: true}},
+				},
+			},
+		}},
+	}
+
+	tests := []struct {
+		name             string
+		content          string
+		diff             string
+		expectedDecision shared.DecisionType
+		expectedChanges  *shared.ListChanges
+	}{
+		{
+			name:             "appended warehouse is approved",
+			content:          "name: marketing\nwarehouses:\n  - type: user\n    size: XSMALL\n  - type: service_account\n    size: SMALL\n",
+			diff:             "@@ -4,0 +5,2 @@\n+  - type: service_account\n+    size: SMALL",
+			expectedDecision: shared.Approve,
+			expectedChanges:  &shared.ListChanges{Added: []int{1}, Modified: []int{}, Removed: []int{}},
+		},
+		{
+			name:             "modified warehouse needs review",
+			content:          "name: marketing\nwarehouses:\n  - type: user\n    size: LARGE\n",
+			diff:             "@@ -4 +4 @@\n-    size: XSMALL\n+    size: LARGE",
+			expectedDecision: shared.ManualReview,
+			expectedChanges:  &shared.ListChanges{Added: []int{}, Modified: []int{0}, Removed: []int{}},
+		},
+		{
+			name:             "removed warehouse needs review",
+			content:          "name: marketing\nwarehouses:\n  - type: user\n    size: XSMALL\n",
+			diff:             "@@ -5,2 +4,0 @@\n-  - type: service_account\n-    size: SMALL",
+			expectedDecision: shared.ManualReview,
+			expectedChanges:  &shared.ListChanges{Added: []int{}, Modified: []int{}, Removed: []int{1}},
+		},
+		{
+			name:             "diff that does not apply leaves changes unclassified",
+			content:          "name: marketing\nwarehouses:\n  - type: user\n    size: XSMALL\n",
+			diff:             "@@ -4 +4 @@\n-    size: SMALL\n+    size: MEDIUM",
+			expectedDecision: shared.ManualReview,
+			expectedChanges:  nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manager := NewSectionRuleManager(ruleConfig, nil)
+			rule := &appendOnlyMockRule{AutoApproveMockRule: AutoApproveMockRule{name: "append_only_rule"}}
+			manager.AddRule(rule)
+			parser := manager.getParserForFile("dataproducts/marketing/prod/product.yaml")
+			require.NotNil(t, parser)
+
+			result := manager.validateFileWithSections("dataproducts/marketing/prod/product.yaml", tt.content, 6, parser,
+				manager.extractChangedLinesFromDiff(tt.diff), tt.diff)
+
+			assert.Equal(t, tt.expectedDecision, result.FileDecision)
+			assert.Equal(t, tt.expectedChanges, rule.seen)
+		})
+	}
+}
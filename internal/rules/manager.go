@@ -1,17 +1,36 @@
 package rules
 
 import (
+	"errors"
 	"fmt"
 	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/redhat-data-and-ai/naysayer/internal/config"
 	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
 	"github.com/redhat-data-and-ai/naysayer/internal/logging"
 	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+	"github.com/redhat-data-and-ai/naysayer/internal/utils"
+	"go.uber.org/zap"
 )
 
+// productDeletionRuleName labels the rule result recorded for deleted data product files
+const productDeletionRuleName = "product_deletion"
+
+// newProductRuleName labels the rule result recorded for new data product files under new_file_policy: manual_review
+const newProductRuleName = "new_product"
+
+// binaryFileRuleName labels the rule result recorded for binary files, which cannot be parsed
+const binaryFileRuleName = "binary_file"
+
+// requiredSectionRuleName labels the rule result recorded for files missing a required section
+const requiredSectionRuleName = "required_section"
+
+// emptyFileRuleName labels the rule result recorded for empty or whitespace-only files
+const emptyFileRuleName = "empty_file"
+
 // SectionRuleManager manages section-based validation
 type SectionRuleManager struct {
 	rules          []shared.Rule
@@ -19,6 +38,12 @@ type SectionRuleManager struct {
 	config         *config.GlobalRuleConfig
 	ruleRegistry   map[string]shared.Rule // Rule name -> rule instance
 	gitlabClient   gitlab.GitLabClient    // GitLab client for fetching file content
+	validationMemo *fileValidationMemo    // Validations of unchanged files reused with reuse_unchanged_files
+	parseCache     ParseCache             // Section parse results keyed by content hash; nil disables caching
+	coverage       *coverageRecorder      // Files that required manual review for lack of a parser; reset on reload
+	mu             sync.RWMutex           // Guards the fields above while rules are reloaded
+
+	parserFingerprints map[shared.SectionParser]string // Parser -> hash of the file configuration it was built from
 }
 
 // NewSectionRuleManager creates a new section-based rule manager
@@ -29,6 +54,11 @@ func NewSectionRuleManager(ruleConfig *config.GlobalRuleConfig, client gitlab.Gi
 		config:         ruleConfig,
 		ruleRegistry:   make(map[string]shared.Rule),
 		gitlabClient:   client,
+		validationMemo: newFileValidationMemo(),
+		parseCache:     NewMemoryParseCache(defaultParseCacheSize),
+		coverage:       newCoverageRecorder(),
+
+		parserFingerprints: make(map[shared.SectionParser]string),
 	}
 
 	// Initialize parsers based on configuration
@@ -55,8 +85,15 @@ func (srm *SectionRuleManager) initializeParsers() {
 			for _, section := range fileConfig.Sections {
 				definitionMap[section.Name] = section
 			}
-			srm.sectionParsers[fullPattern] = NewYAMLSectionParser(definitionMap)
-			logging.Info("Initialized YAML parser for pattern: %s (%d sections)", fullPattern, len(definitionMap))
+			var parser shared.SectionParser
+			if fileConfig.MultiDocument {
+				parser = NewMultiDocumentYAMLSectionParser(definitionMap)
+			} else {
+				parser = NewYAMLSectionParser(definitionMap)
+			}
+			srm.sectionParsers[fullPattern] = parser
+			srm.parserFingerprints[parser] = parserFingerprint(fileConfig)
+			logging.Info("Initialized YAML parser for pattern: %s (%d sections, multi-document: %t)", fullPattern, len(definitionMap), fileConfig.MultiDocument)
 		case "json":
 			// TODO: Implement JSON parser when needed
 			logging.Warn("JSON section parser not yet implemented for: %s", fileConfig.Name)
@@ -75,8 +112,45 @@ func (srm *SectionRuleManager) AddRule(rule shared.Rule) {
 	srm.ruleRegistry[rule.Name()] = rule
 }
 
+// Reload swaps in a new rule configuration and rule set. Replacement parsers and
+// the rule mapping are built before the lock is taken, so in-flight evaluations
+// finish against the previous configuration and later ones see the new one.
+func (srm *SectionRuleManager) Reload(ruleConfig *config.GlobalRuleConfig, rules []shared.Rule) {
+	replacement := NewSectionRuleManager(ruleConfig, srm.gitlabClient)
+	for _, rule := range rules {
+		replacement.AddRule(rule)
+	}
+
+	srm.mu.Lock()
+	defer srm.mu.Unlock()
+	srm.rules = replacement.rules
+	srm.sectionParsers = replacement.sectionParsers
+	srm.config = replacement.config
+	srm.ruleRegistry = replacement.ruleRegistry
+	srm.validationMemo = replacement.validationMemo // Results from the previous rules no longer apply
+	srm.parserFingerprints = replacement.parserFingerprints
+	srm.coverage.reset() // The new rules may cover previously uncovered file types
+}
+
+// RuleCount returns the number of rules registered with the manager
+func (srm *SectionRuleManager) RuleCount() int {
+	srm.mu.RLock()
+	defer srm.mu.RUnlock()
+	return len(srm.rules)
+}
+
+// RuleConfig returns the rule configuration the manager is currently using
+func (srm *SectionRuleManager) RuleConfig() *config.GlobalRuleConfig {
+	srm.mu.RLock()
+	defer srm.mu.RUnlock()
+	return srm.config
+}
+
 // EvaluateAll runs section-based validation on all files
 func (srm *SectionRuleManager) EvaluateAll(mrCtx *shared.MRContext) *shared.RuleEvaluation {
+	srm.mu.RLock()
+	defer srm.mu.RUnlock()
+
 	start := time.Now()
 
 	// Note: Draft MR filtering is now handled at the webhook level to avoid any processing
@@ -97,8 +171,20 @@ func (srm *SectionRuleManager) EvaluateAll(mrCtx *shared.MRContext) *shared.Rule
 	// Set MR context for context-aware rules
 	srm.setMRContextForRules(mrCtx)
 
-	// Perform section-based validation
-	fileValidations, overallDecision := srm.validateFilesWithSections(mrCtx)
+	// MRs that only touch safe_paths skip section-based validation
+	fileValidations, safeDecision := srm.evaluateSafePaths(mrCtx)
+	var overallDecision shared.Decision
+	if safeDecision != nil {
+		overallDecision = *safeDecision
+	} else {
+		// Perform section-based validation
+		fileValidations, overallDecision = srm.validateFilesWithSections(mrCtx)
+	}
+
+	// With concurrent_edit_check, an approval is withheld while another open MR edits the same sections
+	if overallDecision.Type == shared.Approve && srm.applyConcurrentEditCheck(fileValidations, mrCtx) {
+		overallDecision = srm.determineOverallDecision(fileValidations)
+	}
 
 	// Calculate summary statistics
 	totalFiles := len(fileValidations)
@@ -119,7 +205,7 @@ func (srm *SectionRuleManager) EvaluateAll(mrCtx *shared.MRContext) *shared.Rule
 		}
 	}
 
-	return &shared.RuleEvaluation{
+	result := &shared.RuleEvaluation{
 		FinalDecision:   overallDecision,
 		FileValidations: fileValidations,
 		ExecutionTime:   time.Since(start),
@@ -127,7 +213,42 @@ func (srm *SectionRuleManager) EvaluateAll(mrCtx *shared.MRContext) *shared.Rule
 		ApprovedFiles:   approvedFiles,
 		ReviewFiles:     reviewFiles,
 		UncoveredFiles:  uncoveredFiles,
+		UsesLiveState:   mrCtx.UsesLiveState,
+	}
+
+	if countEnabledRules(srm.config, srm.ruleRegistry) == 0 {
+		logging.Warn("RULES MISCONFIGURED: no rules are enabled in %s, every file requires manual review", srm.config.Source.Path)
+		result.RulesMisconfigured = true
+	}
+
+	return result
+}
+
+// countEnabledRules returns how many distinct rules are enabled in the sections of enabled
+// file configurations and available in ruleRegistry. Zero means nothing can auto-approve
+// except sections with auto_approve set.
+func countEnabledRules(ruleConfig *config.GlobalRuleConfig, ruleRegistry map[string]shared.Rule) int {
+	enabled := make(map[string]bool)
+	for _, fileConfig := range ruleConfig.Files {
+		if !fileConfig.Enabled {
+			continue
+		}
+		for _, section := range fileConfig.Sections {
+			for _, ruleConfig := range section.RuleConfigs {
+				if _, exists := ruleRegistry[ruleConfig.Name]; exists && ruleConfig.Enabled {
+					enabled[ruleConfig.Name] = true
+				}
+			}
+		}
 	}
+	return len(enabled)
+}
+
+// EnabledRuleCount returns how many distinct rules the current configuration enables
+func (srm *SectionRuleManager) EnabledRuleCount() int {
+	srm.mu.RLock()
+	defer srm.mu.RUnlock()
+	return countEnabledRules(srm.config, srm.ruleRegistry)
 }
 
 // validateFilesWithSections performs section-based validation for each file
@@ -138,42 +259,151 @@ func (srm *SectionRuleManager) validateFilesWithSections(mrCtx *shared.MRContext
 	filePaths := srm.getUniqueFilePaths(mrCtx.Changes)
 
 	// Source branch files for fork MRs live on the fork project, not the target (same as warehouse analyzer).
-	sourceProjectID := srm.sourceProjectIDForMR(mrCtx)
+	sourceProjectID := srm.resolveMRSource(mrCtx)
+
+	// Team-managed exemptions from .naysayerignore on the source branch
+	ignorePatterns := srm.loadIgnorePatterns(mrCtx, sourceProjectID)
+
+	// With merge_ref_validation, files are read from the merge result when GitLab can produce one
+	mergeRefCommit := srm.resolveMergeRefCommit(mrCtx)
+
+	// Contents of the fetched files, for global rules
+	fileContents := make(map[string]string)
+	needContentForGlobalRules := srm.globalRulesNeedContent()
 
 	for _, filePath := range filePaths {
-		// Get file content from source branch
-		fileContent, fetchErr := srm.getFileContent(filePath, mrCtx, sourceProjectID)
+		// Deleting a product config decommissions the data product - never auto-approve,
+		// regardless of ignore patterns (the file no longer exists on the source branch)
+		if srm.isDeletedDataProductFile(filePath, mrCtx) {
+			logging.Info("Data product file %s is deleted - requiring manual review", filePath)
+			fileValidations[filePath] = srm.createProductDeletionValidation(filePath)
+			continue
+		}
+
+		// A brand-new data product warrants a look: with new_file_policy: manual_review it is
+		// never auto-approved, regardless of ignore patterns or what its sections contain
+		if srm.config.NewFilePolicy == utils.NewFilePolicyManualReview && srm.isNewDataProductFile(filePath, mrCtx) {
+			logging.Info("Data product file %s is new - requiring manual review (new_file_policy)", filePath)
+			fileValidations[filePath] = srm.createNewProductValidation(filePath)
+			continue
+		}
+
+		alwaysManualReview := srm.isAlwaysManualReview(filePath)
+
+		// Ignore rules never override always_manual_review paths
+		if !alwaysManualReview {
+			if pattern := findIgnorePattern(filePath, ignorePatterns); pattern != "" {
+				logging.Info("Skipping validation for %s (matched %s pattern '%s')", filePath, naysayerIgnoreFile, pattern)
+				fileValidations[filePath] = srm.createIgnoredValidation(filePath, pattern, srm.getChangedLinesForFile(filePath, mrCtx))
+				continue
+			}
+		}
+
+		// Files decided without their sections only need content for content-needing global rules,
+		// or, when unconfigured, to look for a generated file marker
+		parser := srm.getParserForFile(filePath)
+		needContentForUnparsed := needContentForGlobalRules || len(srm.config.GeneratedFileMarkers) > 0
+		if (alwaysManualReview && !needContentForGlobalRules) || (parser == nil && !needContentForUnparsed) {
+			fileValidations[filePath] = srm.createUnparsedFileValidation(filePath, 0, alwaysManualReview)
+			continue
+		}
+
+		// Get file content from the merge ref or source branch
+		fileContent, blobID, fetchErr := srm.getFileContent(filePath, mrCtx, sourceProjectID, mergeRefCommit)
 		if fetchErr != nil {
 			logging.Warn("Cannot load source-branch file for validation (requiring manual review): %s: %v", filePath, fetchErr)
 			fileValidations[filePath] = srm.createManualReviewValidation(filePath, 0, fmt.Sprintf("Could not load file from source branch: %v", fetchErr))
 			continue
 		}
+		fileContents[filePath] = fileContent
 		totalLines := shared.CountLines(fileContent)
 
+		if alwaysManualReview {
+			fileValidations[filePath] = srm.createUnparsedFileValidation(filePath, totalLines, true)
+			continue
+		}
+
+		if shared.IsBinaryContent(fileContent) {
+			logging.Info("File %s is binary - requiring manual review", filePath)
+			fileValidations[filePath] = srm.createBinaryFileValidation(filePath)
+			continue
+		}
+
+		// Generated files without a file configuration are approved whatever changed; configured files
+		// keep their section rules so an added marker cannot bypass them
+		if parser == nil {
+			if marker := srm.generatedFileMarker(fileContent); marker != "" {
+				fileValidations[filePath] = srm.createGeneratedFileValidation(filePath, totalLines, marker)
+				continue
+			}
+		}
+		if parser != nil && strings.TrimSpace(fileContent) == "" {
+			fileValidations[filePath] = srm.createEmptyFileValidation(filePath, totalLines)
+			continue
+		}
+
 		// Extract changed lines from the diff for delta validation
 		changedLines := srm.getChangedLinesForFile(filePath, mrCtx)
+		if lastLine := shared.CountLines(strings.TrimSuffix(fileContent, "\n")); lastLine > 0 && srm.isRenamedFile(filePath, mrCtx) {
+			// Path-dependent checks (e.g. name/path consistency) must see a moved file as new, whatever its diff
+			changedLines = []shared.LineRange{{StartLine: 1, EndLine: lastLine, FilePath: filePath}}
+		}
 		diffText := srm.getDiffForFile(filePath, mrCtx)
 
+		if parser != nil && srm.config.IgnoreCommentChanges && !srm.isRenamedFile(filePath, mrCtx) && isCommentOnlyDiff(diffText) {
+			fileValidations[filePath] = srm.createCommentOnlyValidation(filePath, totalLines)
+			continue
+		}
+
 		// Check if this file has section-based validation
-		parser := srm.getParserForFile(filePath)
 		if parser != nil {
+			// With reuse_unchanged_files, a file whose blob and diff match the previous evaluation keeps its result
+			contentKey := ""
+			if srm.config.ReuseUnchangedFiles && blobID != "" {
+				contentKey = memoContentKey(blobID, diffText)
+				if cached := srm.validationMemo.get(mrCtx, filePath, contentKey); cached != nil {
+					logging.Info("Reusing validation for unchanged file: %s", filePath)
+					fileValidations[filePath] = cached
+					continue
+				}
+			}
+
 			logging.Info("Using section-based validation for file: %s", filePath)
 			// Use section-based validation with delta approach
+			// A validation that read live state (e.g. MR approvals) can change without the file changing, so it is not memoized
+			usedLiveState := mrCtx.UsesLiveState
+			mrCtx.UsesLiveState = false
 			fileValidation := srm.validateFileWithSections(filePath, fileContent, totalLines, parser, changedLines, diffText)
+			fileUsesLiveState := mrCtx.UsesLiveState
+			mrCtx.UsesLiveState = usedLiveState || fileUsesLiveState
 			fileValidations[filePath] = fileValidation
+			if contentKey != "" && !fileUsesLiveState {
+				srm.validationMemo.put(mrCtx, filePath, contentKey, fileValidation)
+			}
 		} else {
-			logging.Info("No parser found for file: %s - requiring manual review", filePath)
-			// No section configuration found - require manual review
-			fileValidation := srm.createManualReviewValidation(filePath, totalLines, "No section-based validation configuration found for this file type")
-			fileValidations[filePath] = fileValidation
+			fileValidations[filePath] = srm.createUnparsedFileValidation(filePath, totalLines, false)
 		}
 	}
 
+	srm.applyGlobalRules(fileValidations, fileContents, mrCtx)
+	srm.annotateDiffSummaries(fileValidations, mrCtx)
+
 	// Determine overall decision
 	overallDecision := srm.determineOverallDecision(fileValidations)
 	return fileValidations, overallDecision
 }
 
+// isRenamedFile reports whether the MR renames or moves another path to filePath.
+// The previous path is validated separately (getUniqueFilePaths lists both).
+func (srm *SectionRuleManager) isRenamedFile(filePath string, mrCtx *shared.MRContext) bool {
+	for _, change := range mrCtx.Changes {
+		if change.RenamedFile && change.NewPath == filePath && change.OldPath != "" && change.OldPath != filePath {
+			return true
+		}
+	}
+	return false
+}
+
 // getChangedLinesForFile extracts changed line ranges for a specific file from MR context
 func (srm *SectionRuleManager) getChangedLinesForFile(filePath string, mrCtx *shared.MRContext) []shared.LineRange {
 	for _, change := range mrCtx.Changes {
@@ -201,19 +431,24 @@ func (srm *SectionRuleManager) getDiffForFile(filePath string, mrCtx *shared.MRC
 // validateFileWithSections validates a file using section-based approach with delta validation
 func (srm *SectionRuleManager) validateFileWithSections(filePath, fileContent string, totalLines int, parser shared.SectionParser, changedLines []shared.LineRange, diffText string) *shared.FileValidationSummary {
 	// Parse file into sections
-	sections, err := parser.ParseSections(filePath, fileContent)
+	sections, err := srm.parseSectionsCached(filePath, fileContent, parser)
+	var missingErr *MissingSectionsError
+	if errors.As(err, &missingErr) {
+		return srm.createMissingSectionsValidation(filePath, totalLines, missingErr.Sections)
+	}
 	if err != nil {
 		logging.Error("Failed to parse sections for %s: %v", filePath, err)
 		// Section parsing failed - require manual review
 		return srm.createManualReviewValidation(filePath, totalLines, fmt.Sprintf("Failed to parse file sections: %v", err))
 	}
+	sections = srm.scopeSectionsToEnvironment(filePath, sections)
+	srm.annotateListChanges(filePath, fileContent, diffText, parser, sections)
 
 	var allCoveredLines []shared.LineRange
 	var ruleResults []shared.LineValidationResult
 	var sectionResults []shared.SectionValidationResult
 
-	// Validate ALL sections to ensure all rules appear in comments
-	// Track which sections were actually affected for potential future optimizations
+	// Track which sections were actually affected by the MR diff
 	affectedSections := make(map[string]bool)
 	if len(changedLines) > 0 {
 		affected := srm.getAffectedSections(sections, changedLines)
@@ -236,9 +471,21 @@ func (srm *SectionRuleManager) validateFileWithSections(filePath, fileContent st
 		affectedSections["warehouses"] = true
 		logging.Info("Delta validation for %s: warehouses section flagged as affected (diff heuristic)", filePath)
 	}
+	touchedSections := make([]string, 0, len(affectedSections))
+	for name := range affectedSections {
+		touchedSections = append(touchedSections, name)
+	}
+	sort.Strings(touchedSections)
 
-	// Validate all sections (not just affected ones) to show complete rule evaluation
+	// By default all sections are validated to show complete rule evaluation.
+	// With delta_only_validation, untouched sections are skipped; uncovered lines
+	// are still computed against every section below.
+	deltaOnly := srm.config.DeltaOnlyValidation && len(affectedSections) > 0
 	for _, section := range sections {
+		if deltaOnly && !affectedSections[section.Name] {
+			continue
+		}
+
 		// Get enabled rules for this section
 		sectionRules := srm.getEnabledRulesForSection(section.RuleConfigs)
 
@@ -259,6 +506,9 @@ func (srm *SectionRuleManager) validateFileWithSections(filePath, fileContent st
 	expectedRules := srm.getExpectedRulesForAffectedSections(sections, affectedSections)
 	ruleResults = srm.appendMissingExpectedRuleFallbacks(ruleResults, expectedRules, changedLines)
 
+	// A rule configured on several sections reports once per file
+	ruleResults = mergeRuleResults(ruleResults)
+
 	// Check for uncovered lines (lines not in any section)
 	// Only consider lines that were actually changed in this MR
 	uncoveredLines := srm.getUncoveredLinesInChanges(totalLines, sections, changedLines)
@@ -266,6 +516,14 @@ func (srm *SectionRuleManager) validateFileWithSections(filePath, fileContent st
 	// If there are uncovered lines and config requires manual review
 	fileDecision := srm.determineFileDecisionWithSections(ruleResults, uncoveredLines, sectionResults)
 
+	if logging.DebugEnabled() {
+		logging.Debug("Rule outcomes for file",
+			zap.String("file", filePath),
+			zap.String("file_decision", string(fileDecision)),
+			zap.Any("sections", sectionOutcomes(sectionResults)),
+			zap.Any("uncovered_lines", formatLineRanges(uncoveredLines)))
+	}
+
 	return &shared.FileValidationSummary{
 		FilePath:       filePath,
 		TotalLines:     totalLines,
@@ -273,9 +531,64 @@ func (srm *SectionRuleManager) validateFileWithSections(filePath, fileContent st
 		UncoveredLines: uncoveredLines,
 		RuleResults:    ruleResults,
 		FileDecision:   fileDecision,
+		DiffSummary:    &shared.DiffSummary{Sections: touchedSections},
 	}
 }
 
+// sectionOutcome is the debug log view of one validated section
+type sectionOutcome struct {
+	Section string        `json:"section"`
+	Lines   string        `json:"lines"`
+	Rules   []ruleOutcome `json:"rules"`
+}
+
+// ruleOutcome is the debug log view of one rule result
+type ruleOutcome struct {
+	Rule     string   `json:"rule"`
+	Decision string   `json:"decision"`
+	Reason   string   `json:"reason"`
+	Lines    []string `json:"lines"`
+	Advisory bool     `json:"advisory,omitempty"`
+	Duration string   `json:"duration,omitempty"`
+}
+
+// sectionOutcomes summarizes section results for the debug log
+func sectionOutcomes(sectionResults []shared.SectionValidationResult) []sectionOutcome {
+	outcomes := make([]sectionOutcome, 0, len(sectionResults))
+	for _, sectionResult := range sectionResults {
+		outcome := sectionOutcome{Rules: make([]ruleOutcome, 0, len(sectionResult.RuleResults))}
+		if sectionResult.Section != nil {
+			outcome.Section = sectionResult.Section.Name
+			outcome.Lines = fmt.Sprintf("%d-%d", sectionResult.Section.StartLine, sectionResult.Section.EndLine)
+		}
+		for _, ruleResult := range sectionResult.RuleResults {
+			var duration string
+			if ruleResult.Duration > 0 {
+				duration = ruleResult.Duration.String()
+			}
+			outcome.Rules = append(outcome.Rules, ruleOutcome{
+				Rule:     ruleResult.RuleName,
+				Decision: string(ruleResult.Decision),
+				Reason:   ruleResult.Reason,
+				Lines:    formatLineRanges(ruleResult.LineRanges),
+				Advisory: ruleResult.Advisory,
+				Duration: duration,
+			})
+		}
+		outcomes = append(outcomes, outcome)
+	}
+	return outcomes
+}
+
+// formatLineRanges renders line ranges as "start-end" strings
+func formatLineRanges(ranges []shared.LineRange) []string {
+	formatted := make([]string, 0, len(ranges))
+	for _, lineRange := range ranges {
+		formatted = append(formatted, fmt.Sprintf("%d-%d", lineRange.StartLine, lineRange.EndLine))
+	}
+	return formatted
+}
+
 func diffMentionsWarehouses(diffText string) bool {
 	if diffText == "" {
 		return false
@@ -290,7 +603,9 @@ func diffMentionsWarehouses(diffText string) bool {
 	return false
 }
 
-// Get all rules (enabled and disabled) defined for the affected sections
+// Get the enabled rules expected to run for the affected sections.
+// Sections with auto_approve: true accept changes that no rule matched, so their
+// rules are not expected; changes outside those sections keep the strict policy.
 func (srm *SectionRuleManager) getExpectedRulesForAffectedSections(sections []shared.Section, affectedSections map[string]bool) []string {
 	if len(affectedSections) == 0 {
 		return nil
@@ -298,7 +613,7 @@ func (srm *SectionRuleManager) getExpectedRulesForAffectedSections(sections []sh
 
 	ruleSet := make(map[string]bool)
 	for _, section := range sections {
-		if !affectedSections[section.Name] {
+		if !affectedSections[section.Name] || section.AutoApprove {
 			continue
 		}
 		for _, rc := range section.RuleConfigs {
@@ -350,14 +665,81 @@ func (srm *SectionRuleManager) appendMissingExpectedRuleFallbacks(
 	return ruleResults
 }
 
+// mergeRuleResults combines the results of a rule that ran on several sections of a file into one
+// result per rule, in order of first appearance. Line ranges are merged and durations summed. The
+// merged decision is manual review if any instance requested it, advisory only if every such
+// instance was advisory, and its reason comes from the first instance with that outcome.
+func mergeRuleResults(ruleResults []shared.LineValidationResult) []shared.LineValidationResult {
+	merged := make([]shared.LineValidationResult, 0, len(ruleResults))
+	instances := make(map[string][]shared.LineValidationResult)
+	for _, result := range ruleResults {
+		if result.RuleName == "" {
+			merged = append(merged, result)
+			continue
+		}
+		if _, seen := instances[result.RuleName]; !seen {
+			merged = append(merged, shared.LineValidationResult{RuleName: result.RuleName})
+		}
+		instances[result.RuleName] = append(instances[result.RuleName], result)
+	}
+
+	for i := range merged {
+		group, ok := instances[merged[i].RuleName]
+		if !ok {
+			continue
+		}
+		if len(group) == 1 {
+			merged[i] = group[0]
+			continue
+		}
+
+		outcome := group[0]
+		var lineRanges []shared.LineRange
+		var duration time.Duration
+		wasEvaluated := false
+		for _, result := range group {
+			lineRanges = append(lineRanges, result.LineRanges...)
+			duration += result.Duration
+			wasEvaluated = wasEvaluated || result.WasEvaluated
+			if outranks(result, outcome) {
+				outcome = result
+			}
+		}
+		outcome.LineRanges = shared.MergeLineRanges(lineRanges)
+		outcome.Duration = duration
+		outcome.WasEvaluated = wasEvaluated
+		merged[i] = outcome
+	}
+	return merged
+}
+
+// outranks reports whether a rule result's outcome takes precedence over current when merging:
+// blocking manual review beats advisory manual review, which beats approval
+func outranks(result, current shared.LineValidationResult) bool {
+	severity := func(r shared.LineValidationResult) int {
+		switch {
+		case r.Decision == shared.ManualReview && !r.Advisory:
+			return 2
+		case r.Decision == shared.ManualReview:
+			return 1
+		default:
+			return 0
+		}
+	}
+	return severity(result) > severity(current)
+}
+
 // createManualReviewValidation creates a validation summary that requires manual review
 func (srm *SectionRuleManager) createManualReviewValidation(filePath string, totalLines int, reason string) *shared.FileValidationSummary {
-	// Create uncovered lines for the entire file
-	uncoveredLines := []shared.LineRange{{
-		StartLine: 1,
-		EndLine:   totalLines,
-		FilePath:  filePath,
-	}}
+	// Create uncovered lines for the entire file (none when it has no lines or wasn't fetched)
+	uncoveredLines := []shared.LineRange{}
+	if totalLines > 0 {
+		uncoveredLines = append(uncoveredLines, shared.LineRange{
+			StartLine: 1,
+			EndLine:   totalLines,
+			FilePath:  filePath,
+		})
+	}
 
 	return &shared.FileValidationSummary{
 		FilePath:       filePath,
@@ -369,6 +751,149 @@ func (srm *SectionRuleManager) createManualReviewValidation(filePath string, tot
 	}
 }
 
+// createUnparsedFileValidation creates the manual-review validation of an always_manual_review
+// or unconfigured file; unconfigured files matching an auto_approve unmatched_files entry are
+// approved instead. totalLines is 0 when the file wasn't fetched.
+func (srm *SectionRuleManager) createUnparsedFileValidation(filePath string, totalLines int, alwaysManualReview bool) *shared.FileValidationSummary {
+	if alwaysManualReview {
+		logging.Info("File %s matches always_manual_review - requiring manual review", filePath)
+		return srm.createManualReviewValidation(filePath, totalLines, "File matches an always_manual_review path")
+	}
+	if fallback := srm.createUnmatchedFileFallbackValidation(filePath); fallback != nil {
+		fallback.TotalLines = totalLines
+		return fallback
+	}
+	logging.Info("No parser found for file: %s - requiring manual review", filePath)
+	srm.coverage.record(filePath)
+	return srm.createManualReviewValidation(filePath, totalLines, "No section-based validation configuration found for this file type")
+}
+
+// isDeletedDataProductFile reports whether the MR deletes the given data product file
+func (srm *SectionRuleManager) isDeletedDataProductFile(filePath string, mrCtx *shared.MRContext) bool {
+	if !shared.IsDataProductFile(filePath) {
+		return false
+	}
+	for _, change := range mrCtx.Changes {
+		if change.DeletedFile && (change.OldPath == filePath || change.NewPath == filePath) {
+			return true
+		}
+	}
+	return false
+}
+
+// isNewDataProductFile checks if filePath is a data product file the MR adds
+func (srm *SectionRuleManager) isNewDataProductFile(filePath string, mrCtx *shared.MRContext) bool {
+	if !shared.IsDataProductFile(filePath) {
+		return false
+	}
+	for _, change := range mrCtx.Changes {
+		if change.NewFile && change.NewPath == filePath {
+			return true
+		}
+	}
+	return false
+}
+
+// createNewProductValidation creates a manual-review validation for a new data product file
+func (srm *SectionRuleManager) createNewProductValidation(filePath string) *shared.FileValidationSummary {
+	return &shared.FileValidationSummary{
+		FilePath:       filePath,
+		CoveredLines:   []shared.LineRange{},
+		UncoveredLines: []shared.LineRange{},
+		RuleResults: []shared.LineValidationResult{{
+			RuleName:     newProductRuleName,
+			Decision:     shared.ManualReview,
+			Reason:       "new data product requires manual review",
+			WasEvaluated: true,
+		}},
+		FileDecision: shared.ManualReview,
+	}
+}
+
+// createProductDeletionValidation creates a manual-review validation for a deleted data product file
+func (srm *SectionRuleManager) createProductDeletionValidation(filePath string) *shared.FileValidationSummary {
+	return &shared.FileValidationSummary{
+		FilePath:       filePath,
+		CoveredLines:   []shared.LineRange{},
+		UncoveredLines: []shared.LineRange{},
+		RuleResults: []shared.LineValidationResult{{
+			RuleName:     productDeletionRuleName,
+			Decision:     shared.ManualReview,
+			Reason:       "product deletion requires manual review",
+			WasEvaluated: true,
+		}},
+		FileDecision: shared.ManualReview,
+	}
+}
+
+// createBinaryFileValidation creates a manual-review validation for a binary file instead of parsing it as text
+func (srm *SectionRuleManager) createBinaryFileValidation(filePath string) *shared.FileValidationSummary {
+	return &shared.FileValidationSummary{
+		FilePath:       filePath,
+		CoveredLines:   []shared.LineRange{},
+		UncoveredLines: []shared.LineRange{},
+		RuleResults: []shared.LineValidationResult{{
+			RuleName:     binaryFileRuleName,
+			Decision:     shared.ManualReview,
+			Reason:       "binary file cannot be validated",
+			WasEvaluated: true,
+		}},
+		FileDecision: shared.ManualReview,
+	}
+}
+
+// createMissingSectionsValidation creates a manual-review validation for a file that lacks required
+// sections, e.g. a product.yaml whose warehouses section was removed
+func (srm *SectionRuleManager) createMissingSectionsValidation(filePath string, totalLines int, sections []string) *shared.FileValidationSummary {
+	quoted := make([]string, len(sections))
+	for i, name := range sections {
+		quoted[i] = "'" + name + "'"
+	}
+	reason := fmt.Sprintf("required section %s is missing from the file", quoted[0])
+	if len(sections) > 1 {
+		reason = fmt.Sprintf("required sections %s are missing from the file", strings.Join(quoted, ", "))
+	}
+	logging.Info("File %s: %s - requiring manual review", filePath, reason)
+
+	validation := srm.createManualReviewValidation(filePath, totalLines, reason)
+	validation.RuleResults = []shared.LineValidationResult{{
+		RuleName:     requiredSectionRuleName,
+		Decision:     shared.ManualReview,
+		Reason:       reason,
+		WasEvaluated: true,
+	}}
+	return validation
+}
+
+// createEmptyFileValidation decides an empty or whitespace-only file, which has no sections to validate.
+// It is approved with approve_empty_files, otherwise it needs manual review.
+func (srm *SectionRuleManager) createEmptyFileValidation(filePath string, totalLines int) *shared.FileValidationSummary {
+	decision := shared.ManualReview
+	if srm.config.ApproveEmptyFiles {
+		decision = shared.Approve
+	}
+	logging.Info("File %s is empty - decision: %s", filePath, decision)
+
+	lines := []shared.LineRange{}
+	if totalLines > 0 {
+		lines = append(lines, shared.LineRange{StartLine: 1, EndLine: totalLines, FilePath: filePath})
+	}
+	return &shared.FileValidationSummary{
+		FilePath:       filePath,
+		TotalLines:     totalLines,
+		CoveredLines:   lines,
+		UncoveredLines: []shared.LineRange{},
+		RuleResults: []shared.LineValidationResult{{
+			RuleName:     emptyFileRuleName,
+			LineRanges:   lines,
+			Decision:     decision,
+			Reason:       "empty file",
+			WasEvaluated: true,
+		}},
+		FileDecision: decision,
+	}
+}
+
 // getParserForFile returns the most specific section parser for a file.
 // When multiple patterns match (e.g. dataproducts/**/product.yaml vs dataproducts/**/sandbox/product.yaml),
 // the longest pattern wins so sandbox-specific rules take precedence.
@@ -389,6 +914,25 @@ func (srm *SectionRuleManager) getParserForFile(filePath string) shared.SectionP
 	return bestParser
 }
 
+// scopeSectionsToEnvironment drops rule configs that are limited to other environments
+// than the one encoded in filePath, so every later step sees only the rules that apply
+func (srm *SectionRuleManager) scopeSectionsToEnvironment(filePath string, sections []shared.Section) []shared.Section {
+	environment := shared.ExtractEnvironment(filePath)
+
+	scoped := make([]shared.Section, len(sections))
+	for i, section := range sections {
+		var ruleConfigs []config.RuleConfig
+		for _, ruleConfig := range section.RuleConfigs {
+			if ruleConfig.AppliesToEnvironment(environment) {
+				ruleConfigs = append(ruleConfigs, ruleConfig)
+			}
+		}
+		section.RuleConfigs = ruleConfigs
+		scoped[i] = section
+	}
+	return scoped
+}
+
 // getEnabledRulesForSection returns enabled rules that apply to a specific section
 func (srm *SectionRuleManager) getEnabledRulesForSection(ruleConfigs []config.RuleConfig) []shared.Rule {
 	var sectionRules []shared.Rule
@@ -486,9 +1030,10 @@ func (srm *SectionRuleManager) getLineRangeIntersection(range1, range2 shared.Li
 
 // determineFileDecisionWithSections determines file decision considering sections
 func (srm *SectionRuleManager) determineFileDecisionWithSections(ruleResults []shared.LineValidationResult, uncoveredLines []shared.LineRange, sectionResults []shared.SectionValidationResult) shared.DecisionType {
-	// First, check if any rule explicitly failed/rejected
+	// First, check if any blocking rule explicitly failed/rejected.
+	// Advisory failures are surfaced in the comment but never change the decision.
 	for _, result := range ruleResults {
-		if result.Decision == shared.ManualReview {
+		if result.Decision == shared.ManualReview && !result.Advisory {
 			return shared.ManualReview
 		}
 	}
@@ -500,7 +1045,8 @@ func (srm *SectionRuleManager) determineFileDecisionWithSections(ruleResults []s
 		}
 	}
 
-	// Finally, check if there are uncovered lines (strict coverage policy)
+	// Finally, check if there are uncovered lines (strict coverage policy).
+	// These lie outside every section, so a section's auto_approve never applies to them.
 	if len(uncoveredLines) > 0 {
 		return shared.ManualReview
 	}
@@ -538,64 +1084,135 @@ func (srm *SectionRuleManager) getUniqueFilePaths(changes []gitlab.FileChange) [
 	return filePaths
 }
 
-// sourceProjectIDForMR returns the GitLab project ID where the MR source branch exists.
+// resolveMRSource returns the GitLab project ID where the MR source branch exists.
 // For same-repository MRs this is mrCtx.ProjectID; for fork MRs it is the fork's project ID.
-func (srm *SectionRuleManager) sourceProjectIDForMR(mrCtx *shared.MRContext) int {
+// Some webhook payloads omit the source branch; it is then recovered from the MR details
+// and cached on mrCtx for the rest of the evaluation.
+func (srm *SectionRuleManager) resolveMRSource(mrCtx *shared.MRContext) int {
 	projectID := mrCtx.ProjectID
 	if srm.gitlabClient == nil {
 		return projectID
 	}
 	mrDetails, err := srm.gitlabClient.GetMRDetails(projectID, mrCtx.MRIID)
 	if err != nil {
-		logging.Warn("Failed to get MR details for source project resolution (MR %d): %v", mrCtx.MRIID, err)
+		logging.Warn("Failed to get MR details for source resolution (MR %d): %v", mrCtx.MRIID, err)
+		return projectID
+	}
+	if mrDetails == nil {
 		return projectID
 	}
-	if mrDetails != nil && mrDetails.SourceProjectID != 0 && mrDetails.SourceProjectID != projectID {
+	missingBranch := (mrCtx.MRInfo == nil || mrCtx.MRInfo.SourceBranch == "") && mrDetails.SourceBranch != ""
+	missingProject := (mrCtx.MRInfo == nil || mrCtx.MRInfo.SourceProjectID == 0) && mrDetails.SourceProjectID != 0
+	if missingBranch || missingProject {
+		// Copy so the caller's MRInfo is left untouched; rules read the source from mrCtx
+		mrInfo := gitlab.MRInfo{ProjectID: mrCtx.ProjectID, MRIID: mrCtx.MRIID}
+		if mrCtx.MRInfo != nil {
+			mrInfo = *mrCtx.MRInfo
+		}
+		if missingBranch {
+			logging.Info("Source branch missing from webhook payload, using %s from MR details (MR %d)", mrDetails.SourceBranch, mrCtx.MRIID)
+			mrInfo.SourceBranch = mrDetails.SourceBranch
+		}
+		if missingProject {
+			mrInfo.SourceProjectID = mrDetails.SourceProjectID
+		}
+		mrCtx.MRInfo = &mrInfo
+	}
+	if mrDetails.SourceProjectID != 0 && mrDetails.SourceProjectID != projectID {
 		logging.Info("Fork MR: source-branch file fetches use project %d (target project %d)", mrDetails.SourceProjectID, projectID)
 		return mrDetails.SourceProjectID
 	}
 	return projectID
 }
 
-func (srm *SectionRuleManager) getFileContent(filePath string, mrCtx *shared.MRContext, sourceProjectID int) (string, error) {
+// resolveMergeRefCommit returns the commit of the MR's merge ref (source merged into the current target)
+// when merge_ref_validation is enabled. An empty result means files are read from the source branch.
+// Changed line ranges come from the MR diff, so the merge ref is only used while that diff is based on
+// the current target head; otherwise the ranges would not match the merged content.
+func (srm *SectionRuleManager) resolveMergeRefCommit(mrCtx *shared.MRContext) string {
+	if !srm.config.MergeRefValidation || srm.gitlabClient == nil {
+		return ""
+	}
+	mrDetails, err := srm.gitlabClient.GetMRDetails(mrCtx.ProjectID, mrCtx.MRIID)
+	if err != nil || mrDetails == nil || mrDetails.DiffRefs == nil {
+		logging.Warn("MR %d diff base unknown, validating source branch instead: %v", mrCtx.MRIID, err)
+		return ""
+	}
+	targetHead, err := srm.gitlabClient.GetBranchCommit(mrCtx.ProjectID, mrDetails.TargetBranch)
+	if err != nil {
+		logging.Warn("Target branch head unavailable for MR %d, validating source branch instead: %v", mrCtx.MRIID, err)
+		return ""
+	}
+	mrCtx.UsesLiveState = true // Whether the merge ref is used depends on the target branch head
+	if mrDetails.DiffRefs.BaseSHA != targetHead {
+		logging.Info("MR %d diff is based on %s but %s is at %s, validating source branch instead",
+			mrCtx.MRIID, mrDetails.DiffRefs.BaseSHA, mrDetails.TargetBranch, targetHead)
+		return ""
+	}
+	commit, err := srm.gitlabClient.GetMergeRefCommit(mrCtx.ProjectID, mrCtx.MRIID)
+	if err != nil {
+		logging.Warn("Merge ref unavailable for MR %d, validating source branch instead: %v", mrCtx.MRIID, err)
+		return ""
+	}
+	logging.Info("Validating merge ref %s for MR %d", commit, mrCtx.MRIID)
+	return commit
+}
+
+// getFileContent returns the file's content and blob ID (ContentSha1 when GitLab omits the blob ID).
+// Files are read at the MR's pinned commit when the event carries one, so re-evaluating later reads the same content.
+func (srm *SectionRuleManager) getFileContent(filePath string, mrCtx *shared.MRContext, sourceProjectID int, mergeRefCommit string) (string, string, error) {
 	if srm.gitlabClient == nil {
 		logging.Warn("GitLab client not available, cannot fetch file content for: %s", filePath)
-		return "", fmt.Errorf("GitLab client not available")
+		return "", "", fmt.Errorf("GitLab client not available")
 	}
-	if mrCtx.MRInfo == nil || mrCtx.MRInfo.SourceBranch == "" {
-		return "", fmt.Errorf("source branch not available in MR context")
+	// The merge ref lives in the target project, also for fork MRs
+	if mergeRefCommit != "" {
+		fileContent, err := srm.gitlabClient.FetchFileContent(mrCtx.ProjectID, filePath, mergeRefCommit)
+		if err == nil && fileContent != nil {
+			return fileContent.Content, fileContentBlobID(fileContent), nil
+		}
+		logging.Warn("Failed to fetch %s from merge ref %s, falling back to source branch: %v", filePath, mergeRefCommit, err)
 	}
-	sourceBranch := mrCtx.MRInfo.SourceBranch
-	fileContent, err := srm.gitlabClient.FetchFileContent(sourceProjectID, filePath, sourceBranch)
+	sourceRef := shared.SourceRef(mrCtx)
+	if sourceRef == "" {
+		return "", "", fmt.Errorf("source branch not available in MR context or MR details")
+	}
+	fileContent, err := srm.gitlabClient.FetchFileContent(sourceProjectID, filePath, sourceRef)
 	if err != nil {
-		logging.Warn("Failed to fetch file content for %s from project %d branch %s: %v", filePath, sourceProjectID, sourceBranch, err)
-		return "", err
+		logging.Warn("Failed to fetch file content for %s from project %d ref %s: %v", filePath, sourceProjectID, sourceRef, err)
+		return "", "", err
 	}
 	if fileContent == nil {
-		return "", fmt.Errorf("empty response when fetching %s", filePath)
+		return "", "", fmt.Errorf("empty response when fetching %s", filePath)
+	}
+	return fileContent.Content, fileContentBlobID(fileContent), nil
+}
+
+// fileContentBlobID identifies the fetched content, preferring the blob ID over ContentSha1
+func fileContentBlobID(fileContent *gitlab.FileContent) string {
+	if fileContent.BlobID != "" {
+		return fileContent.BlobID
 	}
-	return fileContent.Content, nil
+	return fileContent.ContentSha1
 }
 
 // extractChangedLinesFromDiff extracts the line ranges that were modified in a Git diff
 func (srm *SectionRuleManager) extractChangedLinesFromDiff(diff string) []shared.LineRange {
 	var changedRanges []shared.LineRange
-	lines := strings.Split(diff, "\n")
 
-	for _, line := range lines {
-		if strings.HasPrefix(line, "@@") {
-			// Parse hunk header like "@@ -1,4 +1,6 @@"
-			if lineRange := srm.parseHunkHeader(line); lineRange != nil {
-				changedRanges = append(changedRanges, *lineRange)
-			}
+	for _, hunk := range splitDiffHunks(diff) {
+		// With ignore_comment_changes, hunks that only edit comments don't count as changed
+		if srm.config.IgnoreCommentChanges && hunk.isCommentOnly() {
+			continue
 		}
+		changedRanges = append(changedRanges, hunk.changedLines()...)
 	}
 
 	return changedRanges
 }
 
 // parseHunkHeader parses a Git diff hunk header to extract the new file line range
-func (srm *SectionRuleManager) parseHunkHeader(hunkHeader string) *shared.LineRange {
+func parseHunkHeader(hunkHeader string) *shared.LineRange {
 	// Format: @@ -old_start,old_count +new_start,new_count @@
 	parts := strings.Fields(hunkHeader)
 	if len(parts) < 3 {
@@ -637,23 +1254,84 @@ func (srm *SectionRuleManager) parseHunkHeader(hunkHeader string) *shared.LineRa
 	}
 }
 
-// getAffectedSections returns only the sections that contain changed lines
+// getAffectedSections returns only the sections that contain changed lines.
+// Zero-context hunks can land just outside a section's detected bounds, e.g. on the key line
+// above its value. A changed range that touches no specific section is therefore padded by
+// diff_context_lines and attributed to the nearest section within reach; ranges that already
+// hit a specific section are never padded, so neighbouring sections aren't pulled in.
 func (srm *SectionRuleManager) getAffectedSections(sections []shared.Section, changedLines []shared.LineRange) []shared.Section {
-	var affectedSections []shared.Section
-
-	for _, section := range sections {
-		for _, changedRange := range changedLines {
-			// Check if this section overlaps with any changed line range
+	padding := srm.config.DiffContext()
+	enclosing := enclosingSections(sections)
+	affected := make(map[int]bool)
+
+	for _, changedRange := range changedLines {
+		hitSpecific := false
+		for i, section := range sections {
+			// Check if this section overlaps with the changed line range
 			if srm.sectionsOverlap(section, changedRange) {
-				affectedSections = append(affectedSections, section)
-				break // Don't add the same section multiple times
+				affected[i] = true
+				hitSpecific = hitSpecific || !enclosing[i]
 			}
 		}
+		if hitSpecific || padding == 0 {
+			continue
+		}
+
+		if nearest := nearestSection(sections, enclosing, changedRange, padding); nearest >= 0 {
+			affected[nearest] = true
+		}
 	}
 
+	var affectedSections []shared.Section
+	for i, section := range sections {
+		if affected[i] {
+			affectedSections = append(affectedSections, section)
+		}
+	}
 	return affectedSections
 }
 
+// enclosingSections marks sections that contain another section, such as `yaml_path: .`
+// sections. They overlap nearly every change, so they don't count as a specific hit.
+func enclosingSections(sections []shared.Section) map[int]bool {
+	enclosing := make(map[int]bool)
+	for i, outer := range sections {
+		for j, inner := range sections {
+			if i != j && outer.StartLine <= inner.StartLine && outer.EndLine >= inner.EndLine &&
+				(outer.StartLine != inner.StartLine || outer.EndLine != inner.EndLine) {
+				enclosing[i] = true
+				break
+			}
+		}
+	}
+	return enclosing
+}
+
+// nearestSection returns the index of the non-enclosing section closest to changedRange within
+// padding lines, or -1. On a tie the section below wins, since a key line precedes its value.
+func nearestSection(sections []shared.Section, enclosing map[int]bool, changedRange shared.LineRange, padding int) int {
+	nearest, nearestDistance, nearestBelow := -1, 0, false
+	for i, section := range sections {
+		if enclosing[i] {
+			continue
+		}
+
+		below := section.StartLine > changedRange.EndLine
+		distance := changedRange.StartLine - section.EndLine
+		if below {
+			distance = section.StartLine - changedRange.EndLine
+		}
+		if distance > padding {
+			continue
+		}
+
+		if nearest < 0 || distance < nearestDistance || (distance == nearestDistance && below && !nearestBelow) {
+			nearest, nearestDistance, nearestBelow = i, distance, below
+		}
+	}
+	return nearest
+}
+
 // sectionsOverlap checks if a section overlaps with a changed line range
 func (srm *SectionRuleManager) sectionsOverlap(section shared.Section, changedRange shared.LineRange) bool {
 	// Sections overlap if there's any line in common
@@ -692,7 +1370,7 @@ func (srm *SectionRuleManager) determineOverallDecision(fileValidations map[stri
 
 		if fileValidation != nil {
 			for _, rr := range fileValidation.RuleResults {
-				if rr.RuleName == "warehouse_rule" && rr.Decision == shared.ManualReview {
+				if rr.RuleName == "warehouse_rule" && rr.Decision == shared.ManualReview && !rr.Advisory {
 					warehouseManualReasons = append(warehouseManualReasons, rr.Reason)
 				}
 			}
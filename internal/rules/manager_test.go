@@ -1,11 +1,24 @@
 package rules
 
 import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/logging"
 	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+	"github.com/redhat-data-and-ai/naysayer/internal/utils"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
 )
 
 type stubSectionParser struct {
@@ -193,11 +206,19 @@ func TestSectionRuleManager_GetExpectedRulesForAffectedSections(t *testing.T) {
 				{Name: "metadata_rule", Enabled: true},
 			},
 		},
+		{
+			Name:        "docs",
+			AutoApprove: true,
+			RuleConfigs: []config.RuleConfig{
+				{Name: "docs_rule", Enabled: true},
+			},
+		},
 	}
 
 	affectedSections := map[string]bool{
 		"warehouses": true,
 		"workload":   true,
+		"docs":       true,
 	}
 
 	expected := manager.getExpectedRulesForAffectedSections(sections, affectedSections)
@@ -314,3 +335,963 @@ func TestSectionRuleManager_ValidateFileWithSections_AddsFallbackForMissingExpec
 	assert.False(t, fallback.WasEvaluated)
 	assert.Contains(t, fallback.Reason, "not evaluated")
 }
+
+func TestSectionRuleManager_ValidateFileWithSections_SectionAutoApprove(t *testing.T) {
+	tests := []struct {
+		name             string
+		autoApprove      bool
+		changedLines     []shared.LineRange
+		expectedDecision shared.DecisionType
+		expectedResults  int
+	}{
+		{
+			name:             "auto-approve section accepts changes no rule matched",
+			autoApprove:      true,
+			changedLines:     []shared.LineRange{{StartLine: 12, EndLine: 14, FilePath: "product.yaml"}},
+			expectedDecision: shared.Approve,
+			expectedResults:  0,
+		},
+		{
+			name:             "strict section requires manual review",
+			autoApprove:      false,
+			changedLines:     []shared.LineRange{{StartLine: 12, EndLine: 14, FilePath: "product.yaml"}},
+			expectedDecision: shared.ManualReview,
+			expectedResults:  1,
+		},
+		{
+			name:        "auto-approve does not extend past the section range",
+			autoApprove: true,
+			changedLines: []shared.LineRange{
+				{StartLine: 12, EndLine: 12, FilePath: "product.yaml"},
+				{StartLine: 25, EndLine: 25, FilePath: "product.yaml"},
+			},
+			expectedDecision: shared.ManualReview,
+			expectedResults:  0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manager := NewSectionRuleManager(&config.GlobalRuleConfig{Files: []config.FileRuleConfig{}}, nil)
+			parser := &stubSectionParser{
+				sections: []shared.Section{
+					{
+						Name:        "metadata",
+						StartLine:   10,
+						EndLine:     20,
+						FilePath:    "product.yaml",
+						AutoApprove: tt.autoApprove,
+						RuleConfigs: []config.RuleConfig{
+							{Name: "metadata_rule", Enabled: true},
+						},
+					},
+				},
+				validateFn: func(section *shared.Section, rules []shared.Rule) *shared.SectionValidationResult {
+					// metadata_rule is not registered, so no rule matches the changed lines
+					return &shared.SectionValidationResult{
+						Section:     section,
+						Decision:    shared.Approve,
+						RuleResults: []shared.LineValidationResult{},
+					}
+				},
+			}
+
+			result := manager.validateFileWithSections("product.yaml", "name: test", 30, parser, tt.changedLines, "")
+
+			assert.Equal(t, tt.expectedDecision, result.FileDecision)
+			assert.Len(t, result.RuleResults, tt.expectedResults)
+		})
+	}
+}
+
+func TestSectionRuleManager_ValidateFileWithSections_AdvisorySeverity(t *testing.T) {
+	tests := []struct {
+		name             string
+		rules            []shared.Rule
+		expectedDecision shared.DecisionType
+		expectedAdvisory []string
+	}{
+		{
+			name: "advisory failure alone still approves",
+			rules: []shared.Rule{
+				&AutoApproveMockRule{name: "style_rule", decision: shared.ManualReview, reason: "description should be a full sentence"},
+				&AutoApproveMockRule{name: "warehouse_rule", decision: shared.Approve, reason: "warehouse unchanged"},
+			},
+			expectedDecision: shared.Approve,
+			expectedAdvisory: []string{"style_rule"},
+		},
+		{
+			name: "blocking failure after an advisory failure requires manual review",
+			rules: []shared.Rule{
+				&AutoApproveMockRule{name: "style_rule", decision: shared.ManualReview, reason: "description should be a full sentence"},
+				&AutoApproveMockRule{name: "warehouse_rule", decision: shared.ManualReview, reason: "warehouse size increased"},
+			},
+			expectedDecision: shared.ManualReview,
+			expectedAdvisory: []string{"style_rule"},
+		},
+		{
+			name: "passing advisory rule is not flagged",
+			rules: []shared.Rule{
+				&AutoApproveMockRule{name: "style_rule", decision: shared.Approve, reason: "style ok"},
+				&AutoApproveMockRule{name: "warehouse_rule", decision: shared.Approve, reason: "warehouse unchanged"},
+			},
+			expectedDecision: shared.Approve,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manager := NewSectionRuleManager(&config.GlobalRuleConfig{Files: []config.FileRuleConfig{}}, nil)
+			for _, rule := range tt.rules {
+				manager.AddRule(rule)
+			}
+			yamlParser := NewYAMLSectionParser(nil)
+			parser := &stubSectionParser{
+				sections: []shared.Section{
+					{
+						Name:      "spec",
+						StartLine: 1,
+						EndLine:   10,
+						FilePath:  "product.yaml",
+						RuleConfigs: []config.RuleConfig{
+							{Name: "style_rule", Enabled: true, Severity: "advisory"},
+							{Name: "warehouse_rule", Enabled: true},
+						},
+					},
+				},
+				validateFn: yamlParser.ValidateSection,
+			}
+			changedLines := []shared.LineRange{{StartLine: 2, EndLine: 4, FilePath: "product.yaml"}}
+
+			result := manager.validateFileWithSections("product.yaml", "name: test", 10, parser, changedLines, "")
+
+			assert.Equal(t, tt.expectedDecision, result.FileDecision)
+			require.Len(t, result.RuleResults, len(tt.rules))
+			var advisory []string
+			for _, rr := range result.RuleResults {
+				if rr.Advisory {
+					assert.Equal(t, shared.ManualReview, rr.Decision)
+					advisory = append(advisory, rr.RuleName)
+				}
+			}
+			assert.Equal(t, tt.expectedAdvisory, advisory)
+		})
+	}
+}
+
+func TestSectionRuleManager_ValidateFileWithSections_DeltaOnlyValidation(t *testing.T) {
+	sections := []shared.Section{
+		{Name: "name", StartLine: 1, EndLine: 1, FilePath: "product.yaml"},
+		{Name: "tags", StartLine: 2, EndLine: 4, FilePath: "product.yaml"},
+		{Name: "consumers", StartLine: 5, EndLine: 10, FilePath: "product.yaml"},
+	}
+
+	// Line 3 is in the tags section, line 12 is outside every section
+	// (padding is disabled below so line 12 isn't attributed to consumers)
+	noPadding := 0
+	changedLines := []shared.LineRange{
+		{StartLine: 3, EndLine: 3, FilePath: "product.yaml"},
+		{StartLine: 12, EndLine: 12, FilePath: "product.yaml"},
+	}
+
+	tests := []struct {
+		name              string
+		deltaOnly         bool
+		changedLines      []shared.LineRange
+		expectedValidated []string
+	}{
+		{
+			name:              "full validation evaluates every section",
+			deltaOnly:         false,
+			changedLines:      changedLines,
+			expectedValidated: []string{"name", "tags", "consumers"},
+		},
+		{
+			name:              "delta-only validation evaluates only changed sections",
+			deltaOnly:         true,
+			changedLines:      changedLines,
+			expectedValidated: []string{"tags"},
+		},
+		{
+			name:              "delta-only validation without changed lines evaluates every section",
+			deltaOnly:         true,
+			changedLines:      nil,
+			expectedValidated: []string{"name", "tags", "consumers"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manager := NewSectionRuleManager(&config.GlobalRuleConfig{
+				Files:               []config.FileRuleConfig{},
+				DeltaOnlyValidation: tt.deltaOnly,
+				DiffContextLines:    &noPadding,
+			}, nil)
+
+			var validated []string
+			parser := &stubSectionParser{
+				sections: sections,
+				validateFn: func(section *shared.Section, rules []shared.Rule) *shared.SectionValidationResult {
+					validated = append(validated, section.Name)
+					lineRanges := []shared.LineRange{{StartLine: section.StartLine, EndLine: section.EndLine, FilePath: section.FilePath}}
+					return &shared.SectionValidationResult{
+						Section:  section,
+						Decision: shared.Approve,
+						RuleResults: []shared.LineValidationResult{
+							{RuleName: section.Name + "_rule", LineRanges: lineRanges, Decision: shared.Approve, WasEvaluated: true},
+						},
+					}
+				},
+			}
+
+			result := manager.validateFileWithSections("product.yaml", "name: test", 12, parser, tt.changedLines, "")
+
+			assert.Equal(t, tt.expectedValidated, validated)
+			assert.Len(t, result.RuleResults, len(tt.expectedValidated))
+			if tt.changedLines != nil {
+				// Uncovered lines are computed against all sections in both modes
+				assert.Equal(t, []shared.LineRange{{StartLine: 12, EndLine: 12}}, result.UncoveredLines)
+				assert.Equal(t, shared.ManualReview, result.FileDecision)
+			}
+		})
+	}
+}
+
+func TestSectionRuleManager_GetAffectedSections_ZeroContextHunks(t *testing.T) {
+	content := "name: test\nwarehouses:\n  - type: user\n    size: XSMALL\ntags:\n  tier: gold\n"
+	parser := NewYAMLSectionParser(map[string]config.SectionDefinition{
+		"name":       {Name: "name", YAMLPath: "name"},
+		"warehouses": {Name: "warehouses", YAMLPath: "warehouses"},
+		"tags":       {Name: "tags", YAMLPath: "tags"},
+		"full_file":  {Name: "full_file", YAMLPath: "."},
+	})
+	sections, err := parser.ParseSections("product.yaml", content)
+	require.NoError(t, err)
+	require.Equal(t, []string{"3-4"}, sectionLines(sections, "warehouses"), "sections start at their value, below the key line")
+
+	defaultPadding, noPadding, wide := config.DefaultDiffContextLines, 0, 10
+	tests := []struct {
+		name     string
+		diff     string
+		padding  *int
+		expected []string
+	}{
+		{
+			name:     "key line above a section is attributed to it",
+			diff:     "@@ -2,0 +2 @@\n+warehouses:",
+			expected: []string{"full_file", "warehouses"},
+		},
+		{
+			name:     "change inside a section doesn't pull in its neighbours",
+			diff:     "@@ -6 +6 @@\n-  tier: silver\n+  tier: gold",
+			padding:  &wide,
+			expected: []string{"full_file", "tags"},
+		},
+		{
+			name:     "explicit default padding",
+			diff:     "@@ -5 +5 @@\n-labels:\n+tags:",
+			padding:  &defaultPadding,
+			expected: []string{"full_file", "tags"},
+		},
+		{
+			name:     "padding disabled",
+			diff:     "@@ -2,0 +2 @@\n+warehouses:",
+			padding:  &noPadding,
+			expected: []string{"full_file"},
+		},
+		{
+			name:     "change beyond the padding",
+			diff:     "@@ -20,0 +20 @@\n+# trailing comment",
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manager := NewSectionRuleManager(&config.GlobalRuleConfig{DiffContextLines: tt.padding}, nil)
+
+			affected := manager.getAffectedSections(sections, manager.extractChangedLinesFromDiff(tt.diff))
+
+			var names []string
+			for _, section := range affected {
+				names = append(names, section.Name)
+			}
+			sort.Strings(names)
+			assert.Equal(t, tt.expected, names)
+		})
+	}
+}
+
+func TestSectionRuleManager_ProductDeletion(t *testing.T) {
+	deleted := gitlab.FileChange{
+		OldPath:     "dataproducts/source/marketing/prod/product.yaml",
+		NewPath:     "dataproducts/source/marketing/prod/product.yaml",
+		DeletedFile: true,
+		Diff:        "@@ -1,2 +0,0 @@\n-name: marketing\n-kind: source",
+	}
+	added := gitlab.FileChange{
+		NewPath: "dataproducts/source/sales/prod/product.yaml",
+		NewFile: true,
+		Diff:    "@@ -0,0 +1,1 @@\n+name: sales",
+	}
+
+	tests := []struct {
+		name            string
+		changes         []gitlab.FileChange
+		expectedFiles   int
+		expectedAddedOK bool
+	}{
+		{
+			name:          "delete-only MR",
+			changes:       []gitlab.FileChange{deleted},
+			expectedFiles: 1,
+		},
+		{
+			name:            "mixed add and delete MR",
+			changes:         []gitlab.FileChange{added, deleted},
+			expectedFiles:   2,
+			expectedAddedOK: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The ignore file exempts every data product so only the deletion can block approval
+			client := &ignoreTestGitLabClient{
+				forkMRTestGitLabClient: &forkMRTestGitLabClient{},
+				files: map[string]string{
+					".naysayerignore": "dataproducts/\n",
+					added.NewPath:     "name: sales",
+				},
+			}
+			manager := NewSectionRuleManager(&config.GlobalRuleConfig{Enabled: true}, client)
+
+			result := manager.EvaluateAll(&shared.MRContext{
+				ProjectID: 123,
+				MRIID:     456,
+				Changes:   tt.changes,
+				MRInfo:    &gitlab.MRInfo{Title: "Decommission marketing", Author: "developer", SourceBranch: "feature"},
+			})
+
+			assert.Equal(t, shared.ManualReview, result.FinalDecision.Type)
+			assert.Len(t, result.FileValidations, tt.expectedFiles)
+
+			validation := result.FileValidations[deleted.NewPath]
+			require.NotNil(t, validation)
+			assert.Equal(t, shared.ManualReview, validation.FileDecision)
+			require.Len(t, validation.RuleResults, 1)
+			assert.Equal(t, "product deletion requires manual review", validation.RuleResults[0].Reason)
+
+			if tt.expectedAddedOK {
+				assert.Equal(t, shared.Approve, result.FileValidations[added.NewPath].FileDecision)
+			}
+		})
+	}
+}
+
+func TestSectionRuleManager_NewFilePolicy(t *testing.T) {
+	const productPath = "dataproducts/source/sales/prod/product.yaml"
+	added := gitlab.FileChange{NewPath: productPath, NewFile: true, Diff: "@@ -0,0 +1 @@\n+name: sales"}
+	modified := gitlab.FileChange{NewPath: productPath, Diff: "@@ -1 +1 @@\n-name: marketing\n+name: sales"}
+
+	tests := []struct {
+		name             string
+		policy           string
+		change           gitlab.FileChange
+		expectedDecision shared.DecisionType
+		expectedRule     string
+	}{
+		{
+			name:             "manual_review requires review for new files",
+			policy:           utils.NewFilePolicyManualReview,
+			change:           added,
+			expectedDecision: shared.ManualReview,
+			expectedRule:     newProductRuleName,
+		},
+		{
+			name:             "manual_review validates modified files normally",
+			policy:           utils.NewFilePolicyManualReview,
+			change:           modified,
+			expectedDecision: shared.Approve,
+		},
+		{
+			name:             "validate runs section validation on new files",
+			policy:           utils.NewFilePolicyValidate,
+			change:           added,
+			expectedDecision: shared.Approve,
+		},
+		{
+			name:             "unset validates new files",
+			change:           added,
+			expectedDecision: shared.Approve,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &ignoreTestGitLabClient{
+				forkMRTestGitLabClient: &forkMRTestGitLabClient{},
+				files:                  map[string]string{productPath: "name: sales\n"},
+			}
+			manager := NewSectionRuleManager(&config.GlobalRuleConfig{
+				Enabled:       true,
+				NewFilePolicy: tt.policy,
+				Files: []config.FileRuleConfig{{
+					Name:       "product_configs",
+					Path:       "dataproducts/**/",
+					Filename:   "product.yaml",
+					ParserType: "yaml",
+					Enabled:    true,
+					Sections:   []config.SectionDefinition{{Name: "name", YAMLPath: "name", AutoApprove: true}},
+				}},
+			}, client)
+
+			result := manager.EvaluateAll(&shared.MRContext{
+				ProjectID: 123,
+				MRIID:     456,
+				Changes:   []gitlab.FileChange{tt.change},
+				MRInfo:    &gitlab.MRInfo{Author: "developer", SourceBranch: "feature"},
+			})
+
+			validation := result.FileValidations[productPath]
+			require.NotNil(t, validation)
+			assert.Equal(t, tt.expectedDecision, validation.FileDecision)
+			if tt.expectedRule != "" {
+				require.Len(t, validation.RuleResults, 1)
+				assert.Equal(t, tt.expectedRule, validation.RuleResults[0].RuleName)
+				assert.Equal(t, "new data product requires manual review", validation.RuleResults[0].Reason)
+			}
+		})
+	}
+}
+
+func TestSectionRuleManager_BinaryFile(t *testing.T) {
+	rulesPath := filepath.Join(t.TempDir(), "rules.yaml")
+	require.NoError(t, os.WriteFile(rulesPath, []byte(projectOverrideRulesYAML), 0644))
+
+	client := &ignoreTestGitLabClient{
+		forkMRTestGitLabClient: &forkMRTestGitLabClient{},
+		files: map[string]string{
+			"docs/diagram.md": "\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR\x00\x00\x01\x00",
+			"docs/README.md":  "# Team docs\n",
+		},
+	}
+	manager, err := NewRuleRegistry().CreateSectionBasedRuleManager(client, rulesPath)
+	require.NoError(t, err)
+
+	result := manager.EvaluateAll(&shared.MRContext{
+		ProjectID: 123,
+		MRIID:     456,
+		Changes: []gitlab.FileChange{
+			{NewPath: "docs/diagram.md", NewFile: true},
+			{NewPath: "docs/README.md", Diff: "@@ -1,1 +1,1 @@\n+# Team docs"},
+		},
+		MRInfo: &gitlab.MRInfo{Author: "developer", SourceBranch: "feature"},
+	})
+
+	assert.Equal(t, shared.ManualReview, result.FinalDecision.Type)
+
+	binary := result.FileValidations["docs/diagram.md"]
+	require.NotNil(t, binary)
+	assert.Equal(t, shared.ManualReview, binary.FileDecision)
+	require.Len(t, binary.RuleResults, 1)
+	assert.Equal(t, binaryFileRuleName, binary.RuleResults[0].RuleName)
+	assert.Equal(t, "binary file cannot be validated", binary.RuleResults[0].Reason)
+
+	text := result.FileValidations["docs/README.md"]
+	require.NotNil(t, text)
+	assert.Equal(t, shared.Approve, text.FileDecision, "text files are still parsed and validated")
+}
+
+func TestSectionRuleManager_EmptyFiles(t *testing.T) {
+	tests := []struct {
+		name             string
+		content          string
+		approveEmpty     bool
+		expectedDecision shared.DecisionType
+		expectedRule     string
+		expectedLines    []shared.LineRange
+	}{
+		{
+			name:             "truly empty file",
+			content:          "",
+			expectedDecision: shared.ManualReview,
+			expectedRule:     emptyFileRuleName,
+			expectedLines:    []shared.LineRange{},
+		},
+		{
+			name:             "whitespace-only file",
+			content:          "  \n\t\n",
+			expectedDecision: shared.ManualReview,
+			expectedRule:     emptyFileRuleName,
+			expectedLines:    []shared.LineRange{{StartLine: 1, EndLine: 3, FilePath: "docs/NOTES.md"}},
+		},
+		{
+			name:             "empty file approved with approve_empty_files",
+			content:          "",
+			approveEmpty:     true,
+			expectedDecision: shared.Approve,
+			expectedRule:     emptyFileRuleName,
+			expectedLines:    []shared.LineRange{},
+		},
+		{
+			name:             "single-line file is validated normally",
+			content:          "# Notes",
+			expectedDecision: shared.Approve,
+			expectedRule:     "metadata_rule",
+			expectedLines:    []shared.LineRange{{StartLine: 1, EndLine: 1, FilePath: "docs/NOTES.md"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rulesYAML := projectOverrideRulesYAML
+			if tt.approveEmpty {
+				rulesYAML = "approve_empty_files: true\n" + rulesYAML
+			}
+			rulesPath := filepath.Join(t.TempDir(), "rules.yaml")
+			require.NoError(t, os.WriteFile(rulesPath, []byte(rulesYAML), 0644))
+			client := &ignoreTestGitLabClient{
+				forkMRTestGitLabClient: &forkMRTestGitLabClient{},
+				files:                  map[string]string{"docs/NOTES.md": tt.content},
+			}
+			manager, err := NewRuleRegistry().CreateSectionBasedRuleManager(client, rulesPath)
+			require.NoError(t, err)
+
+			result := manager.EvaluateAll(&shared.MRContext{
+				ProjectID: 123,
+				MRIID:     456,
+				Changes:   []gitlab.FileChange{{NewPath: "docs/NOTES.md", NewFile: true}},
+				MRInfo:    &gitlab.MRInfo{Author: "developer", SourceBranch: "feature"},
+			})
+
+			validation := result.FileValidations["docs/NOTES.md"]
+			require.NotNil(t, validation)
+			assert.Equal(t, tt.expectedDecision, validation.FileDecision)
+			require.NotEmpty(t, validation.RuleResults)
+			assert.Equal(t, tt.expectedRule, validation.RuleResults[0].RuleName)
+			assert.Equal(t, tt.expectedLines, validation.CoveredLines)
+			assert.Empty(t, validation.UncoveredLines)
+			if tt.expectedRule == emptyFileRuleName {
+				assert.Equal(t, "empty file", validation.RuleResults[0].Reason)
+			}
+		})
+	}
+}
+
+func TestCreateManualReviewValidation_NoLines(t *testing.T) {
+	manager := NewSectionRuleManager(&config.GlobalRuleConfig{Enabled: true}, nil)
+
+	validation := manager.createManualReviewValidation("docs/NOTES.md", 0, "Could not load file from source branch")
+
+	assert.Equal(t, shared.ManualReview, validation.FileDecision)
+	assert.Empty(t, validation.UncoveredLines, "a file without lines has no uncovered range")
+}
+
+// mergeRefTestGitLabClient serves different file contents for the merge ref commit and the source branch
+type mergeRefTestGitLabClient struct {
+	*ignoreTestGitLabClient
+	diffBaseSHA    string
+	mergeRefCommit string
+	mergeRefErr    error
+	mergeRefFiles  map[string]string
+	fetchedRefs    []string
+}
+
+func (m *mergeRefTestGitLabClient) GetMRDetails(projectID, mrIID int) (*gitlab.MRDetails, error) {
+	details, err := m.ignoreTestGitLabClient.GetMRDetails(projectID, mrIID)
+	if err == nil && m.diffBaseSHA != "" {
+		details.DiffRefs = &gitlab.DiffRefs{BaseSHA: m.diffBaseSHA}
+	}
+	return details, err
+}
+
+func (m *mergeRefTestGitLabClient) GetMergeRefCommit(projectID, mrIID int) (string, error) {
+	return m.mergeRefCommit, m.mergeRefErr
+}
+
+func (m *mergeRefTestGitLabClient) FetchFileContent(projectID int, filePath, ref string) (*gitlab.FileContent, error) {
+	m.fetchedRefs = append(m.fetchedRefs, ref)
+	if ref == m.mergeRefCommit {
+		if content, ok := m.mergeRefFiles[filePath]; ok {
+			return &gitlab.FileContent{Content: content, FilePath: filePath}, nil
+		}
+		return nil, fmt.Errorf("file not found at merge ref: %s", filePath)
+	}
+	return m.ignoreTestGitLabClient.FetchFileContent(projectID, filePath, ref)
+}
+
+func TestSectionRuleManager_MergeRefValidation(t *testing.T) {
+	rulesPath := filepath.Join(t.TempDir(), "rules.yaml")
+	require.NoError(t, os.WriteFile(rulesPath, []byte("merge_ref_validation: true\n"+projectOverrideRulesYAML), 0644))
+
+	// The merged result differs from the source branch: after merging, the file is binary
+	mergedContent := "\x00\x01merged"
+	// forkMRTestGitLabClient reports abc123 as the target branch head
+	tests := []struct {
+		name              string
+		diffBaseSHA       string
+		mergeRefCommit    string
+		mergeRefErr       error
+		mergeRefFiles     map[string]string
+		expectedRefs      []string
+		expectedDecision  shared.DecisionType
+		expectedLiveState bool
+	}{
+		{
+			name:              "merge ref present",
+			diffBaseSHA:       "abc123",
+			mergeRefCommit:    "merge-sha",
+			mergeRefFiles:     map[string]string{"docs/README.md": mergedContent},
+			expectedRefs:      []string{"merge-sha"},
+			expectedDecision:  shared.ManualReview,
+			expectedLiveState: true,
+		},
+		{
+			name:              "merge ref unavailable falls back to source branch",
+			diffBaseSHA:       "abc123",
+			mergeRefErr:       fmt.Errorf("get merge ref failed with status 400: not mergeable"),
+			expectedRefs:      []string{"feature"},
+			expectedDecision:  shared.Approve,
+			expectedLiveState: true,
+		},
+		{
+			name:              "file missing at merge ref falls back to source branch",
+			diffBaseSHA:       "abc123",
+			mergeRefCommit:    "merge-sha",
+			mergeRefFiles:     map[string]string{},
+			expectedRefs:      []string{"merge-sha", "feature"},
+			expectedDecision:  shared.Approve,
+			expectedLiveState: true,
+		},
+		{
+			name:              "target moved since the diff falls back to source branch",
+			diffBaseSHA:       "old-target-sha",
+			mergeRefCommit:    "merge-sha",
+			mergeRefFiles:     map[string]string{"docs/README.md": mergedContent},
+			expectedRefs:      []string{"feature"},
+			expectedDecision:  shared.Approve,
+			expectedLiveState: true,
+		},
+		{
+			name:             "diff base unknown falls back to source branch",
+			mergeRefCommit:   "merge-sha",
+			mergeRefFiles:    map[string]string{"docs/README.md": mergedContent},
+			expectedRefs:     []string{"feature"},
+			expectedDecision: shared.Approve,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &mergeRefTestGitLabClient{
+				ignoreTestGitLabClient: &ignoreTestGitLabClient{
+					forkMRTestGitLabClient: &forkMRTestGitLabClient{},
+					files:                  map[string]string{"docs/README.md": "# Team docs\n"},
+				},
+				diffBaseSHA:    tt.diffBaseSHA,
+				mergeRefCommit: tt.mergeRefCommit,
+				mergeRefErr:    tt.mergeRefErr,
+				mergeRefFiles:  tt.mergeRefFiles,
+			}
+			manager, err := NewRuleRegistry().CreateSectionBasedRuleManager(client, rulesPath)
+			require.NoError(t, err)
+
+			result := manager.EvaluateAll(&shared.MRContext{
+				ProjectID: 123,
+				MRIID:     456,
+				Changes:   []gitlab.FileChange{{NewPath: "docs/README.md", Diff: "@@ -1,1 +1,1 @@\n+# Team docs"}},
+				MRInfo:    &gitlab.MRInfo{Author: "developer", SourceBranch: "feature"},
+			})
+
+			assert.Equal(t, tt.expectedDecision, result.FinalDecision.Type)
+			assert.Equal(t, tt.expectedLiveState, result.UsesLiveState, "using the merge ref depends on the target branch head")
+			// .naysayerignore is always read from the source branch
+			assert.Equal(t, append([]string{"feature"}, tt.expectedRefs...), client.fetchedRefs)
+		})
+	}
+}
+
+// observeLogs routes the global logger to an in-memory observer at the given level
+func observeLogs(t *testing.T, level zapcore.Level) *observer.ObservedLogs {
+	t.Helper()
+	core, logs := observer.New(level)
+	previous := logging.GetLogger()
+	logging.SetLogger(logging.NewLoggerFromZap(zap.New(core)))
+	t.Cleanup(func() { logging.SetLogger(previous) })
+	return logs
+}
+
+func TestSectionRuleManager_DebugLogsRuleOutcomes(t *testing.T) {
+	evaluate := func() {
+		parser := &stubSectionParser{
+			sections: []shared.Section{{Name: "warehouses", StartLine: 1, EndLine: 3, FilePath: "dataproducts/agg/product.yaml"}},
+			validateFn: func(section *shared.Section, rules []shared.Rule) *shared.SectionValidationResult {
+				return &shared.SectionValidationResult{
+					Section:  section,
+					Decision: shared.ManualReview,
+					RuleResults: []shared.LineValidationResult{{
+						RuleName:     "warehouse_rule",
+						Decision:     shared.ManualReview,
+						Reason:       "Warehouse size increased",
+						LineRanges:   []shared.LineRange{{StartLine: 1, EndLine: 3}},
+						WasEvaluated: true,
+					}},
+				}
+			},
+		}
+		manager := NewSectionRuleManager(&config.GlobalRuleConfig{Enabled: true}, nil)
+		manager.validateFileWithSections("dataproducts/agg/product.yaml", "warehouses:\n- type: user\n  size: LARGE", 3, parser, nil, "")
+	}
+
+	t.Run("debug level", func(t *testing.T) {
+		logs := observeLogs(t, zapcore.DebugLevel)
+
+		evaluate()
+
+		entries := logs.FilterMessage("Rule outcomes for file").All()
+		require.Len(t, entries, 1)
+		fields := entries[0].ContextMap()
+		assert.Equal(t, "dataproducts/agg/product.yaml", fields["file"])
+		assert.Equal(t, string(shared.ManualReview), fields["file_decision"])
+		assert.Equal(t, []sectionOutcome{{
+			Section: "warehouses",
+			Lines:   "1-3",
+			Rules: []ruleOutcome{{
+				Rule:     "warehouse_rule",
+				Decision: string(shared.ManualReview),
+				Reason:   "Warehouse size increased",
+				Lines:    []string{"1-3"},
+			}},
+		}}, fields["sections"])
+	})
+
+	t.Run("info level", func(t *testing.T) {
+		logs := observeLogs(t, zapcore.InfoLevel)
+
+		evaluate()
+
+		assert.Zero(t, logs.FilterMessage("Rule outcomes for file").Len())
+	})
+}
+
+func TestSectionRuleManager_EnvironmentScopedRules(t *testing.T) {
+	ruleConfig := &config.GlobalRuleConfig{
+		Enabled: true,
+		Files: []config.FileRuleConfig{{
+			Name:       "product_configs",
+			Path:       "**/",
+			Filename:   "product.yaml",
+			ParserType: "yaml",
+			Enabled:    true,
+			Sections: []config.SectionDefinition{{
+				Name:     "full_file",
+				YAMLPath: ".",
+				RuleConfigs: []config.RuleConfig{
+					{Name: "baseline_rule", Enabled: true},
+					{Name: "dev_rule", Enabled: true, Environments: []string{"dev", "sandbox"}},
+					{Name: "prod_rule", Enabled: true, Environments: []string{"prod"}},
+				},
+			}},
+		}},
+	}
+	manager := NewSectionRuleManager(ruleConfig, nil)
+	manager.AddRule(&AutoApproveMockRule{name: "baseline_rule", decision: shared.Approve, reason: "baseline checks passed"})
+	manager.AddRule(&AutoApproveMockRule{name: "dev_rule", decision: shared.Approve, reason: "dev changes allowed"})
+	manager.AddRule(&AutoApproveMockRule{name: "prod_rule", decision: shared.ManualReview, reason: "prod changes need review"})
+
+	content := "name: marketing\nkind: aggregated\n"
+	tests := []struct {
+		name             string
+		filePath         string
+		expectedDecision shared.DecisionType
+		expectedRules    []string
+	}{
+		{"dev path runs dev rules", "dataproducts/agg/marketing/dev/product.yaml", shared.Approve, []string{"baseline_rule", "dev_rule"}},
+		{"prod path runs prod rules", "dataproducts/agg/marketing/prod/product.yaml", shared.ManualReview, []string{"baseline_rule", "prod_rule"}},
+		{"unknown environment runs default rules", "dataproducts/agg/marketing/product.yaml", shared.Approve, []string{"baseline_rule"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parser := manager.getParserForFile(tt.filePath)
+			require.NotNil(t, parser)
+
+			result := manager.validateFileWithSections(tt.filePath, content, 2, parser, nil, "")
+
+			assert.Equal(t, tt.expectedDecision, result.FileDecision)
+			var ranRules []string
+			for _, ruleResult := range result.RuleResults {
+				ranRules = append(ranRules, ruleResult.RuleName)
+			}
+			assert.ElementsMatch(t, tt.expectedRules, ranRules)
+		})
+	}
+}
+
+// contentMockRule requests manual review for content containing reviewMarker
+type contentMockRule struct {
+	AutoApproveMockRule
+	reviewMarker string
+}
+
+func (m *contentMockRule) ValidateLines(filePath string, fileContent string, lineRanges []shared.LineRange) (shared.DecisionType, string) {
+	if strings.Contains(fileContent, m.reviewMarker) {
+		return shared.ManualReview, "large warehouse"
+	}
+	return shared.Approve, "small warehouse"
+}
+
+func TestSectionRuleManager_MergesRuleResultsAcrossSections(t *testing.T) {
+	ruleConfig := &config.GlobalRuleConfig{
+		Enabled: true,
+		Files: []config.FileRuleConfig{{
+			Name:       "product_configs",
+			Path:       "**/",
+			Filename:   "product.yaml",
+			ParserType: "yaml",
+			Enabled:    true,
+			Sections: []config.SectionDefinition{
+				{Name: "dev", YAMLPath: "dev", RuleConfigs: []config.RuleConfig{{Name: "size_rule", Enabled: true}}},
+				{Name: "prod", YAMLPath: "prod", RuleConfigs: []config.RuleConfig{{Name: "size_rule", Enabled: true}}},
+			},
+		}},
+	}
+
+	tests := []struct {
+		name             string
+		content          string
+		expectedDecision shared.DecisionType
+		expectedReason   string
+	}{
+		{
+			name:             "one section rejects",
+			content:          "dev:\n  size: SMALL\nprod:\n  size: LARGE\n",
+			expectedDecision: shared.ManualReview,
+			expectedReason:   "large warehouse",
+		},
+		{
+			name:             "every section approves",
+			content:          "dev:\n  size: SMALL\nprod:\n  size: SMALL\n",
+			expectedDecision: shared.Approve,
+			expectedReason:   "small warehouse",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manager := NewSectionRuleManager(ruleConfig, nil)
+			manager.AddRule(&contentMockRule{AutoApproveMockRule: AutoApproveMockRule{name: "size_rule"}, reviewMarker: "LARGE"})
+			parser := manager.getParserForFile("product.yaml")
+			require.NotNil(t, parser)
+
+			changedLines := []shared.LineRange{{StartLine: 2, EndLine: 2}, {StartLine: 4, EndLine: 4}}
+			result := manager.validateFileWithSections("product.yaml", tt.content, 4, parser, changedLines, "")
+
+			require.Len(t, result.RuleResults, 1, "one result per rule")
+			assert.Equal(t, "size_rule", result.RuleResults[0].RuleName)
+			assert.Equal(t, tt.expectedDecision, result.RuleResults[0].Decision)
+			assert.Equal(t, tt.expectedReason, result.RuleResults[0].Reason)
+			assert.Equal(t, []shared.LineRange{
+				{StartLine: 2, EndLine: 2, FilePath: "product.yaml"},
+				{StartLine: 4, EndLine: 4, FilePath: "product.yaml"},
+			}, result.RuleResults[0].LineRanges)
+			assert.Equal(t, tt.expectedDecision, result.FileDecision)
+		})
+	}
+}
+
+func TestMergeRuleResults(t *testing.T) {
+	results := []shared.LineValidationResult{
+		{RuleName: "size_rule", LineRanges: []shared.LineRange{{StartLine: 5, EndLine: 6}}, Decision: shared.Approve, Reason: "ok", WasEvaluated: true, Duration: time.Millisecond},
+		{RuleName: "naming_rule", LineRanges: []shared.LineRange{{StartLine: 1, EndLine: 1}}, Decision: shared.Approve, Reason: "named", WasEvaluated: true},
+		{RuleName: "size_rule", LineRanges: []shared.LineRange{{StartLine: 1, EndLine: 2}}, Decision: shared.ManualReview, Reason: "too big", Advisory: true, WasEvaluated: true, Duration: time.Millisecond},
+		{RuleName: "size_rule", LineRanges: []shared.LineRange{{StartLine: 9, EndLine: 9}}, Decision: shared.ManualReview, Reason: "not evaluated"},
+	}
+
+	merged := mergeRuleResults(results)
+
+	require.Len(t, merged, 2)
+	assert.Equal(t, shared.LineValidationResult{
+		RuleName:     "size_rule",
+		LineRanges:   []shared.LineRange{{StartLine: 1, EndLine: 2}, {StartLine: 5, EndLine: 6}, {StartLine: 9, EndLine: 9}},
+		Decision:     shared.ManualReview,
+		Reason:       "not evaluated",
+		WasEvaluated: true,
+		Duration:     2 * time.Millisecond,
+	}, merged[0], "blocking manual review outranks advisory")
+	assert.Equal(t, results[1], merged[1])
+
+	advisoryOnly := mergeRuleResults(results[:3])
+	assert.Equal(t, shared.ManualReview, advisoryOnly[0].Decision)
+	assert.True(t, advisoryOnly[0].Advisory, "advisory when every manual review instance was advisory")
+	assert.Equal(t, "too big", advisoryOnly[0].Reason)
+}
+
+func TestSectionRuleManager_RequiredSections(t *testing.T) {
+	ruleConfig := &config.GlobalRuleConfig{
+		Enabled: true,
+		Files: []config.FileRuleConfig{{
+			Name:       "product_configs",
+			Path:       "**/",
+			Filename:   "product.yaml",
+			ParserType: "yaml",
+			Enabled:    true,
+			Sections: []config.SectionDefinition{
+				{Name: "name", YAMLPath: "name", Required: true, AutoApprove: true},
+				{Name: "kind", YAMLPath: "kind", Required: true, AutoApprove: true},
+				{Name: "tags", YAMLPath: "tags", AutoApprove: true},
+			},
+		}},
+	}
+	const filePath = "dataproducts/marketing/prod/product.yaml"
+
+	tests := []struct {
+		name             string
+		content          string
+		expectedDecision shared.DecisionType
+		expectedReason   string
+	}{
+		{
+			name:             "missing required section needs review",
+			content:          "name: marketing\ntags:\n  - sales\n",
+			expectedDecision: shared.ManualReview,
+			expectedReason:   "required section 'kind' is missing from the file",
+		},
+		{
+			name:             "all missing required sections are named",
+			content:          "tags:\n  - sales\n",
+			expectedDecision: shared.ManualReview,
+			expectedReason:   "required sections 'kind', 'name' are missing from the file",
+		},
+		{
+			name:             "present required sections are validated",
+			content:          "name: marketing\nkind: aggregated\ntags:\n  - sales\n",
+			expectedDecision: shared.Approve,
+		},
+		{
+			name:             "missing optional section is fine",
+			content:          "name: marketing\nkind: aggregated\n",
+			expectedDecision: shared.Approve,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manager := NewSectionRuleManager(ruleConfig, nil)
+			parser := manager.getParserForFile(filePath)
+			require.NotNil(t, parser)
+			totalLines := len(strings.Split(strings.TrimSuffix(tt.content, "\n"), "\n"))
+
+			result := manager.validateFileWithSections(filePath, tt.content, totalLines, parser,
+				[]shared.LineRange{{StartLine: 1, EndLine: 1}}, "@@ -1 +1 @@\n-name: sales\n+name: marketing")
+
+			assert.Equal(t, tt.expectedDecision, result.FileDecision)
+			if tt.expectedReason != "" {
+				require.Len(t, result.RuleResults, 1)
+				assert.Equal(t, requiredSectionRuleName, result.RuleResults[0].RuleName)
+				assert.Equal(t, tt.expectedReason, result.RuleResults[0].Reason)
+			}
+		})
+	}
+}
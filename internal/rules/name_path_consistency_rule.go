@@ -0,0 +1,100 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/common"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+	"gopkg.in/yaml.v3"
+)
+
+// NamePathConsistencyRule checks that the `name` field of a product config matches
+// the product directory in dataproducts/<domain>/<name>/<env>/product.yaml.
+// For a renamed product file the check is against the new path.
+type NamePathConsistencyRule struct {
+	*common.BaseRule
+	*common.FileTypeMatcher
+	*common.ValidationHelper
+}
+
+// NewNamePathConsistencyRule creates a new name/path consistency rule
+func NewNamePathConsistencyRule() *NamePathConsistencyRule {
+	return &NamePathConsistencyRule{
+		BaseRule: common.NewBaseRule(
+			"name_path_consistency_rule",
+			"Requires manual review when the product.yaml 'name' field does not match its dataproducts/<domain>/<name>/<env>/ directory",
+		),
+		FileTypeMatcher:  common.NewFileTypeMatcher(),
+		ValidationHelper: common.NewValidationHelper(),
+	}
+}
+
+// GetCoveredLines returns which line ranges this rule validates in a file
+func (r *NamePathConsistencyRule) GetCoveredLines(filePath string, fileContent string) []shared.LineRange {
+	if !r.IsProductFile(filePath) || strings.TrimSpace(fileContent) == "" {
+		return []shared.LineRange{}
+	}
+	return r.GetFullFileCoverage(filePath, fileContent)
+}
+
+// ValidateLines compares the product name with the name derived from the file path
+func (r *NamePathConsistencyRule) ValidateLines(filePath string, fileContent string, lineRanges []shared.LineRange) (shared.DecisionType, string) {
+	if !r.IsProductFile(filePath) {
+		return r.CreateApprovalResult("Not a product.yaml file - name consistency check not applicable")
+	}
+
+	expectedName := r.expectedNameFromPath(filePath)
+	if expectedName == "" {
+		return r.CreateApprovalResult("Path does not follow dataproducts/<domain>/<name>/<env>/ layout - name consistency check skipped")
+	}
+
+	var product struct {
+		Name string `yaml:"name"`
+	}
+	if err := yaml.Unmarshal([]byte(fileContent), &product); err != nil {
+		return r.CreateManualReviewResult(fmt.Sprintf("Failed to parse product name: %v", err))
+	}
+
+	// Moving a product to another directory must keep the name in step with the new path
+	prefix := ""
+	if oldPath := r.renamedFrom(filePath); oldPath != "" {
+		prefix = fmt.Sprintf("Renamed from %s: ", oldPath)
+	}
+
+	if product.Name == "" {
+		return r.CreateManualReviewResult(fmt.Sprintf("%sProduct 'name' field is missing (expected '%s' from path)", prefix, expectedName))
+	}
+
+	if product.Name != expectedName {
+		return r.CreateManualReviewResult(fmt.Sprintf("%sProduct name '%s' does not match directory name '%s'", prefix, product.Name, expectedName))
+	}
+
+	return r.CreateApprovalResult(fmt.Sprintf("%sProduct name '%s' matches its directory", prefix, product.Name))
+}
+
+// renamedFrom returns the previous path when the MR renames or moves filePath, or an empty string
+func (r *NamePathConsistencyRule) renamedFrom(filePath string) string {
+	mrCtx := r.GetMRContext()
+	if mrCtx == nil {
+		return ""
+	}
+	for _, change := range mrCtx.Changes {
+		if change.RenamedFile && change.NewPath == filePath && change.OldPath != filePath {
+			return change.OldPath
+		}
+	}
+	return ""
+}
+
+// expectedNameFromPath extracts <name> from dataproducts/<domain>/<name>/<env>/product.yaml.
+// Returns an empty string for paths that don't follow this layout.
+func (r *NamePathConsistencyRule) expectedNameFromPath(filePath string) string {
+	parts := strings.Split(filePath, "/")
+	for i, part := range parts {
+		if part == "dataproducts" && len(parts) == i+5 {
+			return parts[i+2]
+		}
+	}
+	return ""
+}
@@ -0,0 +1,204 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNamePathConsistencyRule_Name(t *testing.T) {
+	rule := NewNamePathConsistencyRule()
+	assert.Equal(t, "name_path_consistency_rule", rule.Name())
+	assert.Contains(t, rule.Description(), "name")
+}
+
+func TestNamePathConsistencyRule_GetCoveredLines(t *testing.T) {
+	rule := NewNamePathConsistencyRule()
+
+	tests := []struct {
+		name        string
+		filePath    string
+		content     string
+		expectCover bool
+	}{
+		{"product.yaml", "dataproducts/source/analytics/prod/product.yaml", "name: analytics\n", true},
+		{"product.yml", "dataproducts/source/analytics/prod/product.yml", "name: analytics\n", true},
+		{"non-product file", "dataproducts/source/analytics/prod/developers.yaml", "name: analytics\n", false},
+		{"empty content", "dataproducts/source/analytics/prod/product.yaml", "  \n", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lines := rule.GetCoveredLines(tt.filePath, tt.content)
+			assert.Equal(t, tt.expectCover, len(lines) > 0)
+		})
+	}
+}
+
+func TestNamePathConsistencyRule_ValidateLines(t *testing.T) {
+	rule := NewNamePathConsistencyRule()
+
+	tests := []struct {
+		name               string
+		filePath           string
+		content            string
+		expectedDecision   shared.DecisionType
+		expectedReasonPart string
+	}{
+		{
+			name:               "matching name",
+			filePath:           "dataproducts/source/analytics/prod/product.yaml",
+			content:            "name: analytics\nkind: aggregated\n",
+			expectedDecision:   shared.Approve,
+			expectedReasonPart: "matches its directory",
+		},
+		{
+			name:               "matching name in name section only",
+			filePath:           "dataproducts/aggregate/sales/dev/product.yml",
+			content:            "name: sales",
+			expectedDecision:   shared.Approve,
+			expectedReasonPart: "matches its directory",
+		},
+		{
+			name:               "mismatching name",
+			filePath:           "dataproducts/source/analytics/prod/product.yaml",
+			content:            "name: analytics-v2\n",
+			expectedDecision:   shared.ManualReview,
+			expectedReasonPart: "Product name 'analytics-v2' does not match directory name 'analytics'",
+		},
+		{
+			name:               "missing name",
+			filePath:           "dataproducts/source/analytics/prod/product.yaml",
+			content:            "kind: aggregated\n",
+			expectedDecision:   shared.ManualReview,
+			expectedReasonPart: "'name' field is missing (expected 'analytics'",
+		},
+		{
+			name:               "invalid yaml",
+			filePath:           "dataproducts/source/analytics/prod/product.yaml",
+			content:            "name: [analytics\n",
+			expectedDecision:   shared.ManualReview,
+			expectedReasonPart: "Failed to parse product name",
+		},
+		{
+			name:               "path outside expected layout",
+			filePath:           "dataproducts/analytics/prod/product.yaml",
+			content:            "name: something-else\n",
+			expectedDecision:   shared.Approve,
+			expectedReasonPart: "name consistency check skipped",
+		},
+		{
+			name:               "non-product file",
+			filePath:           "dataproducts/source/analytics/prod/developers.yaml",
+			content:            "name: other\n",
+			expectedDecision:   shared.Approve,
+			expectedReasonPart: "Not a product.yaml file",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decision, reason := rule.ValidateLines(tt.filePath, tt.content, nil)
+			assert.Equal(t, tt.expectedDecision, decision)
+			assert.Contains(t, reason, tt.expectedReasonPart)
+		})
+	}
+}
+
+func TestNamePathConsistencyRule_Rename(t *testing.T) {
+	newPath := "dataproducts/source/analytics-v2/prod/product.yaml"
+
+	tests := []struct {
+		name               string
+		content            string
+		expectedDecision   shared.DecisionType
+		expectedReasonPart string
+	}{
+		{
+			name:               "name updated with the directory",
+			content:            "name: analytics-v2\n",
+			expectedDecision:   shared.Approve,
+			expectedReasonPart: "Renamed from dataproducts/source/analytics/prod/product.yaml: Product name 'analytics-v2' matches its directory",
+		},
+		{
+			name:               "name left behind",
+			content:            "name: analytics\n",
+			expectedDecision:   shared.ManualReview,
+			expectedReasonPart: "Renamed from dataproducts/source/analytics/prod/product.yaml: Product name 'analytics' does not match directory name 'analytics-v2'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := NewNamePathConsistencyRule()
+			rule.SetMRContext(&shared.MRContext{Changes: []gitlab.FileChange{{
+				OldPath:     "dataproducts/source/analytics/prod/product.yaml",
+				NewPath:     newPath,
+				RenamedFile: true,
+			}}})
+
+			decision, reason := rule.ValidateLines(newPath, tt.content, nil)
+
+			assert.Equal(t, tt.expectedDecision, decision)
+			assert.Contains(t, reason, tt.expectedReasonPart)
+		})
+	}
+}
+
+func TestSectionRuleManager_RenamedProductChecksName(t *testing.T) {
+	oldPath := "dataproducts/source/analytics/prod/product.yaml"
+	newPath := "dataproducts/source/analytics-v2/prod/product.yaml"
+
+	tests := []struct {
+		name             string
+		content          string
+		expectedDecision shared.DecisionType
+	}{
+		{name: "consistent rename", content: "name: analytics-v2\nkind: source\n", expectedDecision: shared.Approve},
+		{name: "inconsistent rename", content: "name: analytics\nkind: source\n", expectedDecision: shared.ManualReview},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ruleConfig := &config.GlobalRuleConfig{
+				Enabled: true,
+				Files: []config.FileRuleConfig{{
+					Name:       "product_configs",
+					Path:       "dataproducts/**/",
+					Filename:   "product.yaml",
+					ParserType: "yaml",
+					Enabled:    true,
+					Sections: []config.SectionDefinition{
+						{Name: "name", YAMLPath: "name", AutoApprove: true, RuleConfigs: []config.RuleConfig{{Name: "name_path_consistency_rule", Enabled: true}}},
+						{Name: "kind", YAMLPath: "kind", AutoApprove: true},
+					},
+				}},
+			}
+			client := &ignoreTestGitLabClient{
+				forkMRTestGitLabClient: &forkMRTestGitLabClient{},
+				files:                  map[string]string{newPath: tt.content},
+			}
+			manager := NewSectionRuleManager(ruleConfig, client)
+			manager.AddRule(NewNamePathConsistencyRule())
+
+			// A pure move: GitLab sends no diff, but the name must still be checked against the new path
+			result := manager.EvaluateAll(&shared.MRContext{
+				ProjectID: 123,
+				MRIID:     456,
+				Changes:   []gitlab.FileChange{{OldPath: oldPath, NewPath: newPath, RenamedFile: true}},
+				MRInfo:    &gitlab.MRInfo{Author: "developer", SourceBranch: "feature"},
+			})
+
+			validation := result.FileValidations[newPath]
+			require.NotNil(t, validation)
+			assert.Equal(t, tt.expectedDecision, validation.FileDecision)
+			require.Len(t, validation.RuleResults, 1)
+			assert.Equal(t, "name_path_consistency_rule", validation.RuleResults[0].RuleName)
+			assert.Contains(t, validation.RuleResults[0].Reason, "Renamed from "+oldPath)
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package rules
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/logging"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+)
+
+// naysayerIgnoreFile is the repo-root file listing gitignore-style path globs exempt from validation
+const naysayerIgnoreFile = ".naysayerignore"
+
+// loadIgnorePatterns fetches .naysayerignore from the MR source (pinned commit or branch).
+// A missing or unreadable file means no exemptions.
+func (srm *SectionRuleManager) loadIgnorePatterns(mrCtx *shared.MRContext, sourceProjectID int) []string {
+	sourceRef := shared.SourceRef(mrCtx)
+	if srm.gitlabClient == nil || sourceRef == "" {
+		return nil
+	}
+
+	fileContent, err := srm.gitlabClient.FetchFileContent(sourceProjectID, naysayerIgnoreFile, sourceRef)
+	if err != nil || fileContent == nil {
+		logging.Info("No %s found on ref %s - no paths exempted", naysayerIgnoreFile, sourceRef)
+		return nil
+	}
+
+	patterns := parseIgnorePatterns(fileContent.Content)
+	logging.Info("Loaded %d pattern(s) from %s", len(patterns), naysayerIgnoreFile)
+	return patterns
+}
+
+// parseIgnorePatterns returns the patterns in an ignore file, skipping blank lines and comments.
+// Negated (!) patterns are not supported and are skipped.
+func parseIgnorePatterns(content string) []string {
+	var patterns []string
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if strings.HasPrefix(line, "!") {
+			logging.Warn("Negated pattern '%s' in %s is not supported - skipping", line, naysayerIgnoreFile)
+			continue
+		}
+		patterns = append(patterns, line)
+	}
+	return patterns
+}
+
+// findIgnorePattern returns the first pattern exempting filePath, or "" if none does.
+// The ignore file itself can never be exempted.
+func findIgnorePattern(filePath string, patterns []string) string {
+	if filePath == naysayerIgnoreFile {
+		return ""
+	}
+	for _, pattern := range patterns {
+		if matchesIgnorePattern(filePath, pattern) {
+			return pattern
+		}
+	}
+	return ""
+}
+
+// matchesIgnorePattern applies gitignore semantics: a pattern without a slash matches
+// any path component, a pattern with a slash is anchored at the repo root, and a
+// trailing slash only matches directories (and therefore everything beneath them).
+func matchesIgnorePattern(filePath, pattern string) bool {
+	dirOnly := strings.HasSuffix(pattern, "/")
+	pattern = strings.TrimSuffix(pattern, "/")
+	anchored := strings.Contains(pattern, "/")
+	pattern = strings.TrimPrefix(pattern, "/")
+	if pattern == "" {
+		return false
+	}
+
+	segments := strings.Split(filePath, "/")
+	limit := len(segments)
+	if dirOnly {
+		limit-- // The last segment is the file name
+	}
+
+	if !anchored {
+		for _, segment := range segments[:limit] {
+			if matched, _ := filepath.Match(pattern, segment); matched {
+				return true
+			}
+		}
+		return false
+	}
+
+	// Anchored patterns match the full path or any leading directory of it
+	for i := 1; i <= limit; i++ {
+		if shared.MatchesPattern(strings.Join(segments[:i], "/"), pattern) {
+			return true
+		}
+	}
+	return false
+}
+
+// isAlwaysManualReview checks if a path matches the always_manual_review globs.
+// The ignore file itself always qualifies, so an MR can't exempt its own files whatever rules.yaml lists.
+func (srm *SectionRuleManager) isAlwaysManualReview(filePath string) bool {
+	if filePath == naysayerIgnoreFile {
+		return true
+	}
+	for _, pattern := range srm.config.AlwaysManualReview {
+		if shared.MatchesPattern(filePath, pattern) {
+			return true
+		}
+	}
+	return false
+}
+
+// createIgnoredValidation creates an approved validation summary for a file exempted by .naysayerignore
+func (srm *SectionRuleManager) createIgnoredValidation(filePath, pattern string, changedLines []shared.LineRange) *shared.FileValidationSummary {
+	return &shared.FileValidationSummary{
+		FilePath:       filePath,
+		CoveredLines:   changedLines,
+		UncoveredLines: []shared.LineRange{},
+		RuleResults: []shared.LineValidationResult{{
+			RuleName:     naysayerIgnoreFile,
+			LineRanges:   changedLines,
+			Decision:     shared.Approve,
+			Reason:       fmt.Sprintf("Exempted by %s pattern '%s'", naysayerIgnoreFile, pattern),
+			WasEvaluated: true,
+		}},
+		FileDecision: shared.Approve,
+	}
+}
@@ -0,0 +1,177 @@
+package rules
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ignoreTestGitLabClient serves source-branch files from an in-memory map
+type ignoreTestGitLabClient struct {
+	*forkMRTestGitLabClient
+	files map[string]string
+}
+
+func (m *ignoreTestGitLabClient) FetchFileContent(projectID int, filePath, ref string) (*gitlab.FileContent, error) {
+	content, ok := m.files[filePath]
+	if !ok {
+		return nil, fmt.Errorf("file not found: %s", filePath)
+	}
+	return &gitlab.FileContent{Content: content, FilePath: filePath}, nil
+}
+
+func (m *ignoreTestGitLabClient) GetMRDetails(projectID, mrIID int) (*gitlab.MRDetails, error) {
+	return &gitlab.MRDetails{IID: mrIID, ProjectID: projectID, SourceProjectID: projectID}, nil
+}
+
+func evaluateWithIgnoreFile(t *testing.T, ruleConfig *config.GlobalRuleConfig, files map[string]string, changedPaths ...string) *shared.RuleEvaluation {
+	t.Helper()
+
+	client := &ignoreTestGitLabClient{forkMRTestGitLabClient: &forkMRTestGitLabClient{}, files: files}
+	manager := NewSectionRuleManager(ruleConfig, client)
+
+	var changes []gitlab.FileChange
+	for _, path := range changedPaths {
+		changes = append(changes, gitlab.FileChange{NewPath: path, Diff: "@@ -0,0 +1,1 @@\n+content"})
+	}
+
+	return manager.EvaluateAll(&shared.MRContext{
+		ProjectID: 123,
+		MRIID:     456,
+		Changes:   changes,
+		MRInfo: &gitlab.MRInfo{
+			Title:        "Update team files",
+			Author:       "developer",
+			SourceBranch: "feature",
+		},
+	})
+}
+
+func TestNaysayerIgnore_MatchingFilesApproved(t *testing.T) {
+	files := map[string]string{
+		".naysayerignore":          "# team-owned scratch space\ndocs/drafts/\n*.tmp\n",
+		"docs/drafts/notes.txt":    "notes",
+		"build/cache/output.tmp":   "tmp",
+		"docs/drafts/sub/more.txt": "more",
+	}
+
+	result := evaluateWithIgnoreFile(t, &config.GlobalRuleConfig{Enabled: true}, files,
+		"docs/drafts/notes.txt", "build/cache/output.tmp", "docs/drafts/sub/more.txt")
+
+	assert.Equal(t, shared.Approve, result.FinalDecision.Type)
+	assert.Equal(t, 3, result.ApprovedFiles)
+
+	validation := result.FileValidations["build/cache/output.tmp"]
+	require.NotNil(t, validation)
+	require.Len(t, validation.RuleResults, 1)
+	assert.Equal(t, "Exempted by .naysayerignore pattern '*.tmp'", validation.RuleResults[0].Reason)
+	assert.Empty(t, validation.UncoveredLines)
+}
+
+func TestNaysayerIgnore_NonMatchingFilesStillValidated(t *testing.T) {
+	files := map[string]string{
+		".naysayerignore":   "docs/drafts/\n",
+		"docs/drafts/a.txt": "a",
+		"scripts/deploy.sh": "echo deploy",
+	}
+
+	result := evaluateWithIgnoreFile(t, &config.GlobalRuleConfig{Enabled: true}, files,
+		"docs/drafts/a.txt", "scripts/deploy.sh")
+
+	assert.Equal(t, shared.ManualReview, result.FinalDecision.Type)
+	assert.Equal(t, shared.Approve, result.FileValidations["docs/drafts/a.txt"].FileDecision)
+	assert.Equal(t, shared.ManualReview, result.FileValidations["scripts/deploy.sh"].FileDecision)
+}
+
+func TestNaysayerIgnore_AbsentIgnoreFile(t *testing.T) {
+	files := map[string]string{
+		"docs/drafts/a.txt": "a",
+	}
+
+	result := evaluateWithIgnoreFile(t, &config.GlobalRuleConfig{Enabled: true}, files, "docs/drafts/a.txt")
+
+	assert.Equal(t, shared.ManualReview, result.FinalDecision.Type)
+	assert.Equal(t, shared.ManualReview, result.FileValidations["docs/drafts/a.txt"].FileDecision)
+}
+
+func TestNaysayerIgnore_DoesNotOverrideAlwaysManualReview(t *testing.T) {
+	files := map[string]string{
+		".naysayerignore": "CODEOWNERS\n/ops/\n",
+		"CODEOWNERS":      "* @team",
+		"ops/deploy.yaml": "replicas: 3",
+	}
+	ruleConfig := &config.GlobalRuleConfig{
+		Enabled:            true,
+		AlwaysManualReview: []string{"CODEOWNERS", "ops/**"},
+	}
+
+	result := evaluateWithIgnoreFile(t, ruleConfig, files, "CODEOWNERS", "ops/deploy.yaml")
+
+	assert.Equal(t, shared.ManualReview, result.FinalDecision.Type)
+	assert.Equal(t, shared.ManualReview, result.FileValidations["CODEOWNERS"].FileDecision)
+	assert.Equal(t, shared.ManualReview, result.FileValidations["ops/deploy.yaml"].FileDecision)
+}
+
+func TestNaysayerIgnore_CannotExemptItself(t *testing.T) {
+	files := map[string]string{
+		".naysayerignore": ".naysayerignore\n*\n",
+	}
+
+	result := evaluateWithIgnoreFile(t, &config.GlobalRuleConfig{Enabled: true}, files, ".naysayerignore")
+
+	assert.Equal(t, shared.ManualReview, result.FinalDecision.Type)
+}
+
+func TestNaysayerIgnore_ChangesRequireManualReviewWithoutRulesEntry(t *testing.T) {
+	files := map[string]string{
+		".naysayerignore":   "docs/\n",
+		"docs/drafts/a.txt": "a",
+	}
+	// Neither always_manual_review nor a catch-all auto_approve fallback can let the ignore file through
+	ruleConfig := &config.GlobalRuleConfig{
+		Enabled:        true,
+		UnmatchedFiles: []config.UnmatchedFileFallback{{Pattern: "**", Action: "auto_approve"}},
+	}
+
+	result := evaluateWithIgnoreFile(t, ruleConfig, files, ".naysayerignore", "docs/drafts/a.txt")
+
+	assert.Equal(t, shared.ManualReview, result.FinalDecision.Type)
+	validation := result.FileValidations[".naysayerignore"]
+	require.NotNil(t, validation)
+	assert.Equal(t, shared.ManualReview, validation.FileDecision)
+}
+
+func TestMatchesIgnorePattern(t *testing.T) {
+	tests := []struct {
+		name     string
+		filePath string
+		pattern  string
+		expected bool
+	}{
+		{"unanchored file name", "a/b/notes.tmp", "*.tmp", true},
+		{"unanchored directory name", "a/scratch/notes.md", "scratch", true},
+		{"directory-only pattern matches contents", "docs/drafts/x.md", "docs/drafts/", true},
+		{"directory-only pattern does not match file", "a/scratch", "scratch/", false},
+		{"anchored pattern at root", "docs/x.md", "/docs", true},
+		{"anchored pattern not nested", "team/docs/x.md", "/docs", false},
+		{"anchored globstar", "dataproducts/a/sandbox/b.yaml", "dataproducts/**/sandbox", true},
+		{"no match", "src/main.go", "docs/", false},
+		{"empty pattern", "src/main.go", "/", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, matchesIgnorePattern(tt.filePath, tt.pattern))
+		})
+	}
+}
+
+func TestParseIgnorePatterns(t *testing.T) {
+	patterns := parseIgnorePatterns("# comment\n\n  docs/  \n!docs/keep.md\n*.tmp\n")
+	assert.Equal(t, []string{"docs/", "*.tmp"}, patterns)
+}
@@ -0,0 +1,128 @@
+package rules
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sync"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+)
+
+// defaultParseCacheSize bounds how many parsed files the default in-memory cache keeps
+const defaultParseCacheSize = 500
+
+// ParseCache stores section parse results keyed by a hash of the file path, content and parser
+// configuration. Implementations must be safe for concurrent use; a shared backend such as Redis
+// lets several naysayer replicas reuse each other's parse results.
+type ParseCache interface {
+	// Get returns the sections cached for key
+	Get(key string) ([]shared.Section, bool)
+	// Put caches sections for key
+	Put(key string, sections []shared.Section)
+}
+
+// MemoryParseCache is a bounded in-memory ParseCache that evicts the least recently used entry
+type MemoryParseCache struct {
+	mu       sync.Mutex
+	capacity int
+	entries  map[string]*list.Element
+	order    *list.List // Most recently used at the front
+}
+
+type parseCacheEntry struct {
+	key      string
+	sections []shared.Section
+}
+
+// NewMemoryParseCache creates an in-memory cache holding at most capacity parsed files
+func NewMemoryParseCache(capacity int) *MemoryParseCache {
+	return &MemoryParseCache{
+		capacity: capacity,
+		entries:  make(map[string]*list.Element),
+		order:    list.New(),
+	}
+}
+
+// Get returns the sections cached for key and marks them recently used
+func (c *MemoryParseCache) Get(key string) ([]shared.Section, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(element)
+	return element.Value.(*parseCacheEntry).sections, true
+}
+
+// Put caches sections for key, evicting the least recently used entry when full
+func (c *MemoryParseCache) Put(key string, sections []shared.Section) {
+	if c.capacity <= 0 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, ok := c.entries[key]; ok {
+		element.Value.(*parseCacheEntry).sections = sections
+		c.order.MoveToFront(element)
+		return
+	}
+	if c.order.Len() >= c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*parseCacheEntry).key)
+	}
+	c.entries[key] = c.order.PushFront(&parseCacheEntry{key: key, sections: sections})
+}
+
+// Len returns the number of cached files
+func (c *MemoryParseCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+// parserFingerprint identifies the section definitions a parser was built from, so cached
+// results are not reused after rules.yaml changes them
+func parserFingerprint(fileConfig config.FileRuleConfig) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%+v", fileConfig)))
+	return hex.EncodeToString(sum[:])
+}
+
+// parseCacheKey combines the parser fingerprint, file path and content into a cache key
+func parseCacheKey(fingerprint, filePath, content string) string {
+	sum := sha256.Sum256([]byte(fingerprint + "\x00" + filePath + "\x00" + content))
+	return hex.EncodeToString(sum[:])
+}
+
+// parseSectionsCached parses a file through the manager's parse cache. Sections are copied in and
+// out of the cache because later validation steps annotate them.
+func (srm *SectionRuleManager) parseSectionsCached(filePath, fileContent string, parser shared.SectionParser) ([]shared.Section, error) {
+	fingerprint, known := srm.parserFingerprints[parser]
+	if srm.parseCache == nil || !known {
+		return parser.ParseSections(filePath, fileContent)
+	}
+
+	key := parseCacheKey(fingerprint, filePath, fileContent)
+	if sections, ok := srm.parseCache.Get(key); ok {
+		return append([]shared.Section(nil), sections...), nil
+	}
+	sections, err := parser.ParseSections(filePath, fileContent)
+	if err != nil {
+		return nil, err
+	}
+	srm.parseCache.Put(key, append([]shared.Section(nil), sections...))
+	return sections, nil
+}
+
+// SetParseCache replaces the cache used for section parse results; nil disables caching
+func (srm *SectionRuleManager) SetParseCache(cache ParseCache) {
+	srm.mu.Lock()
+	defer srm.mu.Unlock()
+	srm.parseCache = cache
+}
@@ -0,0 +1,116 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingParseCache records cache lookups and hits around a MemoryParseCache
+type countingParseCache struct {
+	*MemoryParseCache
+	gets, hits int
+}
+
+func (c *countingParseCache) Get(key string) ([]shared.Section, bool) {
+	c.gets++
+	sections, ok := c.MemoryParseCache.Get(key)
+	if ok {
+		c.hits++
+	}
+	return sections, ok
+}
+
+func TestMemoryParseCache_Eviction(t *testing.T) {
+	cache := NewMemoryParseCache(2)
+	cache.Put("a", []shared.Section{{Name: "a"}})
+	cache.Put("b", []shared.Section{{Name: "b"}})
+
+	_, ok := cache.Get("a") // "a" becomes most recently used
+	require.True(t, ok)
+	cache.Put("c", []shared.Section{{Name: "c"}})
+
+	assert.Equal(t, 2, cache.Len())
+	_, ok = cache.Get("b")
+	assert.False(t, ok, "least recently used entry is evicted")
+	sections, ok := cache.Get("a")
+	require.True(t, ok)
+	assert.Equal(t, "a", sections[0].Name)
+	_, ok = cache.Get("c")
+	assert.True(t, ok)
+}
+
+func TestMemoryParseCache_ZeroCapacityDisablesCaching(t *testing.T) {
+	cache := NewMemoryParseCache(0)
+	cache.Put("a", []shared.Section{{Name: "a"}})
+
+	_, ok := cache.Get("a")
+	assert.False(t, ok)
+	assert.Equal(t, 0, cache.Len())
+}
+
+func TestSectionRuleManager_ParseCache(t *testing.T) {
+	fileConfig := config.FileRuleConfig{
+		Name:       "product_configs",
+		Path:       "**/",
+		Filename:   "product.yaml",
+		ParserType: "yaml",
+		Enabled:    true,
+		Sections: []config.SectionDefinition{{
+			Name:        "name",
+			YAMLPath:    "name",
+			AutoApprove: true,
+		}},
+	}
+	const filePath = "dataproducts/marketing/prod/product.yaml"
+	changedLines := []shared.LineRange{{StartLine: 1, EndLine: 1}}
+
+	manager := NewSectionRuleManager(&config.GlobalRuleConfig{Enabled: true, Files: []config.FileRuleConfig{fileConfig}}, nil)
+	cache := &countingParseCache{MemoryParseCache: NewMemoryParseCache(10)}
+	manager.SetParseCache(cache)
+	parser := manager.getParserForFile(filePath)
+	require.NotNil(t, parser)
+
+	first := manager.validateFileWithSections(filePath, "name: marketing\n", 1, parser, changedLines, "")
+	assert.Equal(t, 0, cache.hits, "first parse is a miss")
+	assert.Equal(t, 1, cache.Len())
+
+	second := manager.validateFileWithSections(filePath, "name: marketing\n", 1, parser, changedLines, "")
+	assert.Equal(t, 1, cache.hits, "unchanged content is served from the cache")
+	assert.Equal(t, first.FileDecision, second.FileDecision)
+
+	manager.validateFileWithSections(filePath, "name: sales\n", 1, parser, changedLines, "")
+	assert.Equal(t, 1, cache.hits, "changed content misses")
+	assert.Equal(t, 2, cache.Len())
+
+	manager.validateFileWithSections("dataproducts/sales/prod/product.yaml", "name: marketing\n", 1, parser, changedLines, "")
+	assert.Equal(t, 1, cache.hits, "same content at another path misses")
+
+	// Changing the section definitions must not reuse results parsed under the old ones
+	fileConfig.Sections[0].AutoApprove = false
+	manager.Reload(&config.GlobalRuleConfig{Enabled: true, Files: []config.FileRuleConfig{fileConfig}}, nil)
+	reloadedParser := manager.getParserForFile(filePath)
+	manager.validateFileWithSections(filePath, "name: marketing\n", 1, reloadedParser, changedLines, "")
+	assert.Equal(t, 1, cache.hits, "reloaded section definitions miss")
+	assert.Equal(t, 5, cache.gets)
+}
+
+func TestSectionRuleManager_ParseCacheDisabled(t *testing.T) {
+	manager := NewSectionRuleManager(&config.GlobalRuleConfig{Enabled: true, Files: []config.FileRuleConfig{{
+		Name:       "product_configs",
+		Path:       "**/",
+		Filename:   "product.yaml",
+		ParserType: "yaml",
+		Enabled:    true,
+		Sections:   []config.SectionDefinition{{Name: "name", YAMLPath: "name", AutoApprove: true}},
+	}}}, nil)
+	manager.SetParseCache(nil)
+	parser := manager.getParserForFile("product.yaml")
+	require.NotNil(t, parser)
+
+	result := manager.validateFileWithSections("product.yaml", "name: marketing\n", 1, parser, []shared.LineRange{{StartLine: 1, EndLine: 1}}, "")
+	assert.Equal(t, shared.Approve, result.FileDecision)
+}
@@ -0,0 +1,131 @@
+package rules
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/logging"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+)
+
+// ProjectRuleManager selects a rule configuration per GitLab project.
+// A project with <configDir>/<projectID>.yaml gets its own SectionRuleManager built from
+// that file; every other project uses the default manager.
+type ProjectRuleManager struct {
+	registry       *RuleRegistry
+	client         gitlab.GitLabClient
+	defaultManager *SectionRuleManager
+	configDir      string
+	managers       map[int]*SectionRuleManager // Project ID -> manager (defaultManager when no override exists)
+	extraRules     []shared.Rule               // Rules added through AddRule, applied to every manager
+	mu             sync.Mutex
+}
+
+// NewProjectRuleManager creates a manager that falls back to defaultManager for projects
+// without a rule file in configDir
+func NewProjectRuleManager(registry *RuleRegistry, client gitlab.GitLabClient, defaultManager *SectionRuleManager, configDir string) *ProjectRuleManager {
+	return &ProjectRuleManager{
+		registry:       registry,
+		client:         client,
+		defaultManager: defaultManager,
+		configDir:      configDir,
+		managers:       make(map[int]*SectionRuleManager),
+	}
+}
+
+// AddRule registers a rule with the default manager and every project manager
+func (prm *ProjectRuleManager) AddRule(rule shared.Rule) {
+	prm.mu.Lock()
+	defer prm.mu.Unlock()
+
+	prm.extraRules = append(prm.extraRules, rule)
+	prm.defaultManager.AddRule(rule)
+	for _, manager := range prm.managers {
+		if manager != prm.defaultManager {
+			manager.AddRule(rule)
+		}
+	}
+}
+
+// EvaluateAll evaluates the MR with the rule configuration of its project.
+// A project rule file that fails to load requires manual review rather than
+// silently falling back to the default rules.
+func (prm *ProjectRuleManager) EvaluateAll(mrCtx *shared.MRContext) *shared.RuleEvaluation {
+	manager, err := prm.managerFor(mrCtx.ProjectID)
+	if err != nil {
+		logging.Error("Failed to load rules for project %d: %v", mrCtx.ProjectID, err)
+		return &shared.RuleEvaluation{
+			FinalDecision: shared.Decision{
+				Type:    shared.ManualReview,
+				Reason:  "Project rule configuration could not be loaded",
+				Summary: "⚠️ Invalid project rules",
+				Details: err.Error(),
+			},
+			FileValidations: make(map[string]*shared.FileValidationSummary),
+		}
+	}
+	return manager.EvaluateAll(mrCtx)
+}
+
+// managerFor returns the cached manager for projectID, building it on first use.
+// Load failures are not cached so a corrected file is picked up on the next MR.
+func (prm *ProjectRuleManager) managerFor(projectID int) (*SectionRuleManager, error) {
+	prm.mu.Lock()
+	defer prm.mu.Unlock()
+
+	if manager, ok := prm.managers[projectID]; ok {
+		return manager, nil
+	}
+
+	ruleConfigPath := prm.projectRuleConfigPath(projectID)
+	if _, err := os.Stat(ruleConfigPath); err != nil {
+		if !os.IsNotExist(err) {
+			return nil, fmt.Errorf("failed to check %s: %w", ruleConfigPath, err)
+		}
+		prm.managers[projectID] = prm.defaultManager
+		return prm.defaultManager, nil
+	}
+
+	created, err := prm.registry.CreateSectionBasedRuleManager(prm.client, ruleConfigPath)
+	if err != nil {
+		return nil, err
+	}
+	manager := created.(*SectionRuleManager)
+	manager.coverage = prm.defaultManager.coverage // One coverage report across projects
+	for _, rule := range prm.extraRules {
+		manager.AddRule(rule)
+	}
+
+	logging.Info("Using project-specific rules for project %d from %s", projectID, ruleConfigPath)
+	prm.managers[projectID] = manager
+	return manager, nil
+}
+
+// projectRuleConfigPath returns the rule file that overrides the defaults for projectID
+func (prm *ProjectRuleManager) projectRuleConfigPath(projectID int) string {
+	return filepath.Join(prm.configDir, fmt.Sprintf("%d.yaml", projectID))
+}
+
+// RuleConfig returns the default rule configuration used by projects without an override
+func (prm *ProjectRuleManager) RuleConfig() *config.GlobalRuleConfig {
+	return prm.defaultManager.RuleConfig()
+}
+
+// Reload re-reads the default rule configuration and drops cached project managers,
+// so project rule files are re-read on their next MR.
+// Returns the number of rules active after the reload.
+func (prm *ProjectRuleManager) Reload(ruleConfigPath string) (int, error) {
+	ruleCount, err := prm.registry.ReloadSectionBasedRuleManager(prm.defaultManager, prm.client, ruleConfigPath)
+	if err != nil {
+		return 0, err
+	}
+
+	prm.mu.Lock()
+	defer prm.mu.Unlock()
+	prm.managers = make(map[int]*SectionRuleManager)
+	return ruleCount, nil
+}
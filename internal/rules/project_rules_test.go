@@ -0,0 +1,110 @@
+package rules
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const projectOverrideRulesYAML = `enabled: true
+files:
+  - name: "documentation_files"
+    path: "**/"
+    filename: "*.md"
+    parser_type: yaml
+    enabled: true
+    sections:
+      - name: full_file
+        yaml_path: .
+        rule_configs:
+          - name: metadata_rule
+            enabled: true
+        auto_approve: true
+`
+
+// newTestProjectRuleManager builds a manager whose defaults only cover product.yaml,
+// with an override for project 100 that auto-approves markdown files
+func newTestProjectRuleManager(t *testing.T) (*ProjectRuleManager, string) {
+	t.Helper()
+	dir := t.TempDir()
+	defaultPath := filepath.Join(dir, "rules.yaml")
+	projectDir := filepath.Join(dir, "rules")
+	require.NoError(t, os.WriteFile(defaultPath, []byte(reloadTestRulesYAML), 0644))
+	require.NoError(t, os.MkdirAll(projectDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(projectDir, "100.yaml"), []byte(projectOverrideRulesYAML), 0644))
+
+	registry := NewRuleRegistry()
+	client := &ignoreTestGitLabClient{
+		forkMRTestGitLabClient: &forkMRTestGitLabClient{},
+		files:                  map[string]string{"docs/README.md": "# Team docs\n"},
+	}
+	defaultManager, err := registry.CreateSectionBasedRuleManager(client, defaultPath)
+	require.NoError(t, err)
+
+	return NewProjectRuleManager(registry, client, defaultManager.(*SectionRuleManager), projectDir), projectDir
+}
+
+func evaluateReadmeChange(manager *ProjectRuleManager, projectID int) *shared.RuleEvaluation {
+	return manager.EvaluateAll(&shared.MRContext{
+		ProjectID: projectID,
+		MRIID:     1,
+		Changes:   []gitlab.FileChange{{NewPath: "docs/README.md", Diff: "@@ -1,1 +1,1 @@\n+# Team docs"}},
+		MRInfo:    &gitlab.MRInfo{Author: "developer", SourceBranch: "feature"},
+	})
+}
+
+func TestProjectRuleManager_ProjectWithOverride(t *testing.T) {
+	manager, _ := newTestProjectRuleManager(t)
+
+	result := evaluateReadmeChange(manager, 100)
+
+	assert.Equal(t, shared.Approve, result.FinalDecision.Type)
+	projectManager, err := manager.managerFor(100)
+	require.NoError(t, err)
+	assert.NotSame(t, manager.defaultManager, projectManager)
+	assert.Nil(t, projectManager.getParserForFile("dataproducts/agg/product.yaml"), "override replaces the default config")
+
+	cached, err := manager.managerFor(100)
+	require.NoError(t, err)
+	assert.Same(t, projectManager, cached)
+}
+
+func TestProjectRuleManager_ProjectWithoutOverride(t *testing.T) {
+	manager, _ := newTestProjectRuleManager(t)
+
+	result := evaluateReadmeChange(manager, 200)
+
+	assert.Equal(t, shared.ManualReview, result.FinalDecision.Type, "default config has no rules for markdown")
+	projectManager, err := manager.managerFor(200)
+	require.NoError(t, err)
+	assert.Same(t, manager.defaultManager, projectManager)
+}
+
+func TestProjectRuleManager_InvalidOverrideRequiresManualReview(t *testing.T) {
+	manager, projectDir := newTestProjectRuleManager(t)
+	require.NoError(t, os.WriteFile(filepath.Join(projectDir, "300.yaml"), []byte("enabled: true\nfiles: [unclosed"), 0644))
+
+	result := evaluateReadmeChange(manager, 300)
+
+	assert.Equal(t, shared.ManualReview, result.FinalDecision.Type)
+	assert.Equal(t, "Project rule configuration could not be loaded", result.FinalDecision.Reason)
+	assert.NotContains(t, manager.managers, 300, "failed loads are retried on the next MR")
+}
+
+func TestProjectRuleManager_ReloadDropsProjectManagers(t *testing.T) {
+	manager, _ := newTestProjectRuleManager(t)
+	_, err := manager.managerFor(100)
+	require.NoError(t, err)
+
+	rulesPath := filepath.Join(filepath.Dir(manager.configDir), "rules.yaml")
+	ruleCount, err := manager.Reload(rulesPath)
+
+	require.NoError(t, err)
+	assert.Equal(t, manager.defaultManager.RuleCount(), ruleCount)
+	assert.Empty(t, manager.managers)
+}
@@ -60,7 +60,12 @@ func (r *RuleRegistry) registerBuiltInRules() {
 		Description: "Auto-approves MRs with only dataverse-safe files (warehouse/sourcebinding), requires manual review for warehouse increases",
 		Version:     "1.0.0",
 		Factory: func(client gitlab.GitLabClient) shared.Rule {
-			return warehouse.NewRule(client)
+			cfg := config.Load()
+			rule := warehouse.NewRuleWithLimits(client, cfg.Rules.WarehouseRule.MaxAutoSuspend, cfg.Rules.WarehouseRule.MaxNewSize)
+			// A TOC member's approval of a size increase satisfies this rule as well as toc_approval_rule
+			tocCfg := cfg.Rules.TOCApprovalRule
+			rule.SetIncreaseApprover(toc_approval.NewTOCApprovalRuleWithWarehouseCheck(tocCfg.CriticalEnvironments, client, tocCfg.WarehouseEnvironments, tocCfg.Approvers))
+			return rule
 		},
 		Enabled:  true,
 		Category: "warehouse",
@@ -83,20 +88,91 @@ func (r *RuleRegistry) registerBuiltInRules() {
 		Description: "Auto-approves Astro service account files (**_astro_<env>_appuser.yaml/yml) when name field matches filename. Other service account files require manual review.",
 		Version:     "1.0.0",
 		Factory: func(client gitlab.GitLabClient) shared.Rule {
-			return NewServiceAccountRule(client)
+			cfg := config.Load()
+			return NewServiceAccountRuleWithNamePatterns(client, cfg.Rules.ServiceAccountRule.NamePatterns)
 		},
 		Enabled:  true,
 		Category: "service_account",
 	})
 
+	_ = r.RegisterRule(&RuleInfo{
+		Name:        "service_account_section_rule",
+		Description: "Requires manual review when a product.yaml service_account section is empty, has malformed account names or grants privileged scopes",
+		Version:     "1.0.0",
+		Factory: func(client gitlab.GitLabClient) shared.Rule {
+			cfg := config.Load()
+			return NewServiceAccountSectionRule(cfg.Rules.ServiceAccountRule.PrivilegedScopes)
+		},
+		Enabled:  true,
+		Category: "service_account",
+	})
+
+	_ = r.RegisterRule(&RuleInfo{
+		Name:        "name_path_consistency_rule",
+		Description: "Requires manual review when the product.yaml name field does not match its dataproducts/<domain>/<name>/<env>/ directory",
+		Version:     "1.0.0",
+		Factory: func(client gitlab.GitLabClient) shared.Rule {
+			return NewNamePathConsistencyRule()
+		},
+		Enabled:  true,
+		Category: "naming",
+	})
+
+	_ = r.RegisterRule(&RuleInfo{
+		Name:        "kind_change_rule",
+		Description: "Requires manual review when an existing config changes its top-level 'kind' field",
+		Version:     "1.0.0",
+		Factory: func(client gitlab.GitLabClient) shared.Rule {
+			return NewKindChangeRule()
+		},
+		Enabled:  true,
+		Category: "naming",
+	})
+
+	_ = r.RegisterRule(&RuleInfo{
+		Name:        "tags_rule",
+		Description: "Requires manual review when product.yaml tags use values outside the allowed vocabulary",
+		Version:     "1.0.0",
+		Factory: func(client gitlab.GitLabClient) shared.Rule {
+			cfg := config.Load()
+			return NewTagsRule(cfg.Rules.TagsRule.AllowedValues)
+		},
+		Enabled:  true,
+		Category: "naming",
+	})
+
+	_ = r.RegisterRule(&RuleInfo{
+		Name:        "classification_rule",
+		Description: "Requires manual review when an MR downgrades the classification tag of a config",
+		Version:     "1.0.0",
+		Factory: func(client gitlab.GitLabClient) shared.Rule {
+			cfg := config.Load()
+			return NewClassificationRule(cfg.Rules.ClassificationRule.Levels)
+		},
+		Enabled:  true,
+		Category: "security",
+	})
+
+	_ = r.RegisterRule(&RuleInfo{
+		Name:        "secret_scan_rule",
+		Description: "Requires manual review when added lines contain AWS keys, private keys or API tokens",
+		Version:     "1.0.0",
+		Factory: func(client gitlab.GitLabClient) shared.Rule {
+			return NewSecretScanRule()
+		},
+		Enabled:  true,
+		Category: "security",
+	})
+
 	_ = r.RegisterRule(&RuleInfo{
 		Name:        "toc_approval_rule",
-		Description: "Requires TOC approval for new product.yaml files in preprod/prod environments",
+		Description: "Requires TOC approval for new product.yaml files in preprod/prod environments and production warehouse size increases",
 		Version:     "1.0.0",
 		Factory: func(client gitlab.GitLabClient) shared.Rule {
 			// Get critical environments from dedicated TOC approval rule config
 			cfg := config.Load()
-			return toc_approval.NewTOCApprovalRule(cfg.Rules.TOCApprovalRule.CriticalEnvironments)
+			tocCfg := cfg.Rules.TOCApprovalRule
+			return toc_approval.NewTOCApprovalRuleWithWarehouseCheck(tocCfg.CriticalEnvironments, client, tocCfg.WarehouseEnvironments, tocCfg.Approvers)
 		},
 		Enabled:  true,
 		Category: "toc_approval",
@@ -110,12 +186,37 @@ func (r *RuleRegistry) registerBuiltInRules() {
 		Factory: func(client gitlab.GitLabClient) shared.Rule {
 			// Get allowed environments from dedicated consumer rule config
 			cfg := config.Load()
-			return dataproduct_consumer.NewDataProductConsumerRule(cfg.Rules.DataProductConsumerRule.AllowedEnvironments)
+			consumerCfg := cfg.Rules.DataProductConsumerRule
+			if !consumerCfg.EnforcesAllowedGroups() {
+				return dataproduct_consumer.NewDataProductConsumerRule(consumerCfg.AllowedEnvironments)
+			}
+			allowedGroups := append([]string{}, consumerCfg.AllowedGroups...)
+			if consumerCfg.AllowedGroupsFile != "" {
+				// An unreadable file leaves only the env groups allowed, so unknown groups still get reviewed
+				fileGroups, err := dataproduct_consumer.LoadAllowedGroups(consumerCfg.AllowedGroupsFile)
+				if err != nil {
+					logging.Error("Failed to load allowed consumer groups from %s: %v", consumerCfg.AllowedGroupsFile, err)
+				}
+				allowedGroups = append(allowedGroups, fileGroups...)
+			}
+			return dataproduct_consumer.NewDataProductConsumerRuleWithAllowedGroups(consumerCfg.AllowedEnvironments, allowedGroups)
 		},
 		Enabled:  true,
 		Category: "consumer_access",
 	})
 
+	// Source binding rule
+	_ = r.RegisterRule(&RuleInfo{
+		Name:        "source_binding_rule",
+		Description: "Auto-approves consumer additions to sourcebinding.yaml files, requires manual review for source/target changes",
+		Version:     "1.0.0",
+		Factory: func(client gitlab.GitLabClient) shared.Rule {
+			return NewSourceBindingRule()
+		},
+		Enabled:  true,
+		Category: "source",
+	})
+
 	// CODEOWNERS sync rule
 	_ = r.RegisterRule(&RuleInfo{
 		Name:        "codeowners_sync_rule",
@@ -312,31 +413,100 @@ func (r *RuleRegistry) CreateDataverseRuleManager(client gitlab.GitLabClient) sh
 
 // CreateSectionBasedRuleManager creates a section-aware rule manager
 func (r *RuleRegistry) CreateSectionBasedRuleManager(client gitlab.GitLabClient, ruleConfigPath string) (shared.RuleManager, error) {
-	// Load rule configuration
-	ruleConfig, err := config.LoadRuleConfig(ruleConfigPath)
+	ruleConfig, err := loadSectionRuleConfig(ruleConfigPath)
 	if err != nil {
-		return nil, fmt.Errorf("failed to load rule config from %s: %w", ruleConfigPath, err)
+		return nil, err
 	}
 
-	// Section-based validation must be enabled
-	if !ruleConfig.Enabled {
-		return nil, fmt.Errorf("section-based validation is disabled in configuration - this is required for operation")
+	rules := r.createEnabledRules(client)
+	if err := checkEnabledRules(ruleConfig, rules); err != nil {
+		return nil, err
 	}
 
 	// Create section-based manager
 	sectionManager := NewSectionRuleManager(ruleConfig, client)
 
 	// Add all enabled rules to the section manager
-	for _, info := range r.ListEnabledRules() {
-		rule := info.Factory(client)
+	for _, rule := range rules {
 		sectionManager.AddRule(rule)
-		logging.Info("Added rule to section manager: %s", info.Name)
 	}
 
 	logging.Info("Created section-based rule manager with %d file configurations", len(ruleConfig.Files))
 	return sectionManager, nil
 }
 
+// ReloadSectionBasedRuleManager re-reads the rule configuration and swaps it into an
+// existing manager. On error the manager keeps its current configuration.
+// Returns the number of rules active after the reload.
+func (r *RuleRegistry) ReloadSectionBasedRuleManager(manager *SectionRuleManager, client gitlab.GitLabClient, ruleConfigPath string) (int, error) {
+	ruleConfig, err := loadSectionRuleConfig(ruleConfigPath)
+	if err != nil {
+		return 0, err
+	}
+
+	rules := r.createEnabledRules(client)
+	if err := checkEnabledRules(ruleConfig, rules); err != nil {
+		return 0, err
+	}
+	manager.Reload(ruleConfig, rules)
+
+	logging.Info("Reloaded section-based rule manager with %d rules and %d file configurations", len(rules), len(ruleConfig.Files))
+	return len(rules), nil
+}
+
+// CheckSectionRuleConfig reports whether the rule configuration at ruleConfigPath would reload
+// successfully, without applying it
+func (r *RuleRegistry) CheckSectionRuleConfig(client gitlab.GitLabClient, ruleConfigPath string) error {
+	ruleConfig, err := loadSectionRuleConfig(ruleConfigPath)
+	if err != nil {
+		return err
+	}
+	return checkEnabledRules(ruleConfig, r.createEnabledRules(client))
+}
+
+// loadSectionRuleConfig loads and validates the rule configuration for section-based validation
+func loadSectionRuleConfig(ruleConfigPath string) (*config.GlobalRuleConfig, error) {
+	ruleConfig, err := config.LoadRuleConfig(ruleConfigPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load rule config from %s: %w", ruleConfigPath, err)
+	}
+
+	// Section-based validation must be enabled
+	if !ruleConfig.Enabled {
+		return nil, fmt.Errorf("section-based validation is disabled in configuration - this is required for operation")
+	}
+
+	return ruleConfig, nil
+}
+
+// checkEnabledRules warns when the configuration enables none of the available rules,
+// and rejects it when fail_on_no_rules is set
+func checkEnabledRules(ruleConfig *config.GlobalRuleConfig, rules []shared.Rule) error {
+	ruleRegistry := make(map[string]shared.Rule, len(rules))
+	for _, rule := range rules {
+		ruleRegistry[rule.Name()] = rule
+	}
+	if countEnabledRules(ruleConfig, ruleRegistry) > 0 {
+		return nil
+	}
+
+	if ruleConfig.FailOnNoRules {
+		return fmt.Errorf("no rules are enabled in %s and fail_on_no_rules is set", ruleConfig.Source.Path)
+	}
+	logging.Warn("RULES MISCONFIGURED: no rules are enabled in %s, every file will require manual review", ruleConfig.Source.Path)
+	return nil
+}
+
+// createEnabledRules instantiates every enabled rule with the given client
+func (r *RuleRegistry) createEnabledRules(client gitlab.GitLabClient) []shared.Rule {
+	var rules []shared.Rule
+	for _, info := range r.ListEnabledRules() {
+		rules = append(rules, info.Factory(client))
+		logging.Info("Added rule to section manager: %s", info.Name)
+	}
+	return rules
+}
+
 // Global registry instance
 var globalRegistry *RuleRegistry
 
@@ -1,11 +1,15 @@
 package rules
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
 	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
 )
 
 // MockRule is a simple mock rule for testing
@@ -241,7 +245,8 @@ func TestRuleRegistry_ListRulesByCategory(t *testing.T) {
 
 	// Test source category
 	sourceRules := registry.ListRulesByCategory("source")
-	assert.Contains(t, sourceRules, "source_test_rule") // Our test rule
+	assert.Contains(t, sourceRules, "source_binding_rule") // Built-in
+	assert.Contains(t, sourceRules, "source_test_rule")    // Our test rule
 	assert.NotContains(t, sourceRules, "warehouse_rule")
 	assert.NotContains(t, sourceRules, "security_rule")
 
@@ -419,3 +424,151 @@ func TestRuleInfo_Completeness(t *testing.T) {
 		assert.NotEmpty(t, rule.Category, "Rule %s should have a category", name)
 	}
 }
+
+const reloadTestRulesYAML = `enabled: true
+files:
+  - name: "product_configs"
+    path: "**/"
+    filename: "product.yaml"
+    parser_type: yaml
+    enabled: true
+    sections:
+      - name: warehouses
+        yaml_path: warehouses
+        required: true
+        rule_configs:
+          - name: warehouse_rule
+            enabled: true
+`
+
+func TestRuleRegistry_ReloadSectionBasedRuleManager(t *testing.T) {
+	registry := NewRuleRegistry()
+	client := &gitlab.Client{}
+	rulesPath := filepath.Join(t.TempDir(), "rules.yaml")
+	require.NoError(t, os.WriteFile(rulesPath, []byte(reloadTestRulesYAML), 0644))
+
+	manager, err := registry.CreateSectionBasedRuleManager(client, rulesPath)
+	require.NoError(t, err)
+	sectionManager := manager.(*SectionRuleManager)
+	assert.Nil(t, sectionManager.getParserForFile("docs/README.md"))
+
+	updated := reloadTestRulesYAML + `  - name: "documentation_files"
+    path: "**/"
+    filename: "*.md"
+    parser_type: yaml
+    enabled: true
+    sections:
+      - name: full_file
+        yaml_path: .
+        rule_configs:
+          - name: metadata_rule
+            enabled: true
+`
+	require.NoError(t, os.WriteFile(rulesPath, []byte(updated), 0644))
+
+	ruleCount, err := registry.ReloadSectionBasedRuleManager(sectionManager, client, rulesPath)
+
+	require.NoError(t, err)
+	assert.Equal(t, len(registry.ListEnabledRules()), ruleCount)
+	assert.Equal(t, ruleCount, sectionManager.RuleCount())
+	assert.NotNil(t, sectionManager.getParserForFile("docs/README.md"))
+	assert.Len(t, sectionManager.config.Files, 2)
+}
+
+func TestRuleRegistry_ReloadSectionBasedRuleManager_MalformedYAML(t *testing.T) {
+	registry := NewRuleRegistry()
+	client := &gitlab.Client{}
+	rulesPath := filepath.Join(t.TempDir(), "rules.yaml")
+	require.NoError(t, os.WriteFile(rulesPath, []byte(reloadTestRulesYAML), 0644))
+
+	manager, err := registry.CreateSectionBasedRuleManager(client, rulesPath)
+	require.NoError(t, err)
+	sectionManager := manager.(*SectionRuleManager)
+	previousConfig := sectionManager.config
+	previousRuleCount := sectionManager.RuleCount()
+
+	require.NoError(t, os.WriteFile(rulesPath, []byte("enabled: true\nfiles: [unclosed"), 0644))
+
+	ruleCount, err := registry.ReloadSectionBasedRuleManager(sectionManager, client, rulesPath)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to load rule config")
+	assert.Zero(t, ruleCount)
+	assert.Same(t, previousConfig, sectionManager.config)
+	assert.Equal(t, previousRuleCount, sectionManager.RuleCount())
+	assert.NotNil(t, sectionManager.getParserForFile("dataproducts/agg/product.yaml"))
+}
+
+const noRulesYAML = `enabled: true
+files:
+  - name: "product_configs"
+    path: "**/"
+    filename: "product.yaml"
+    parser_type: yaml
+    enabled: true
+    sections:
+      - name: warehouses
+        yaml_path: warehouses
+        rule_configs:
+          - name: warehouse_rule
+            enabled: false
+`
+
+func TestRuleRegistry_CreateSectionBasedRuleManager_NoEnabledRules(t *testing.T) {
+	logs := observeLogs(t, zapcore.WarnLevel)
+	registry := NewRuleRegistry()
+	client := &ignoreTestGitLabClient{
+		forkMRTestGitLabClient: &forkMRTestGitLabClient{},
+		files:                  map[string]string{"dataproducts/agg/product.yaml": "warehouses:\n- type: user\n  size: XSMALL\n"},
+	}
+	rulesPath := filepath.Join(t.TempDir(), "rules.yaml")
+	require.NoError(t, os.WriteFile(rulesPath, []byte(noRulesYAML), 0644))
+
+	manager, err := registry.CreateSectionBasedRuleManager(client, rulesPath)
+
+	require.NoError(t, err)
+	assert.Zero(t, manager.(*SectionRuleManager).EnabledRuleCount())
+	assert.Equal(t, 1, logs.FilterMessageSnippet("RULES MISCONFIGURED").Len(), "startup warns about the empty rule set")
+
+	result := manager.EvaluateAll(&shared.MRContext{
+		ProjectID: 1,
+		MRIID:     1,
+		Changes:   []gitlab.FileChange{{NewPath: "dataproducts/agg/product.yaml", Diff: "@@ -3,1 +3,1 @@\n-  size: SMALL\n+  size: XSMALL"}},
+		MRInfo:    &gitlab.MRInfo{Author: "developer", SourceBranch: "feature"},
+	})
+
+	assert.True(t, result.RulesMisconfigured)
+	assert.Equal(t, shared.ManualReview, result.FinalDecision.Type)
+	assert.Equal(t, 2, logs.FilterMessageSnippet("RULES MISCONFIGURED").Len(), "evaluation warns about the empty rule set")
+}
+
+func TestRuleRegistry_CreateSectionBasedRuleManager_FailOnNoRules(t *testing.T) {
+	registry := NewRuleRegistry()
+	client := &gitlab.Client{}
+	rulesPath := filepath.Join(t.TempDir(), "rules.yaml")
+	require.NoError(t, os.WriteFile(rulesPath, []byte("fail_on_no_rules: true\n"+noRulesYAML), 0644))
+
+	manager, err := registry.CreateSectionBasedRuleManager(client, rulesPath)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no rules are enabled")
+	assert.Nil(t, manager)
+}
+
+func TestRuleRegistry_ReloadSectionBasedRuleManager_FailOnNoRules(t *testing.T) {
+	registry := NewRuleRegistry()
+	client := &gitlab.Client{}
+	rulesPath := filepath.Join(t.TempDir(), "rules.yaml")
+	require.NoError(t, os.WriteFile(rulesPath, []byte(reloadTestRulesYAML), 0644))
+	manager, err := registry.CreateSectionBasedRuleManager(client, rulesPath)
+	require.NoError(t, err)
+	sectionManager := manager.(*SectionRuleManager)
+	previousConfig := sectionManager.config
+
+	require.NoError(t, os.WriteFile(rulesPath, []byte("fail_on_no_rules: true\n"+noRulesYAML), 0644))
+	_, err = registry.ReloadSectionBasedRuleManager(sectionManager, client, rulesPath)
+
+	require.Error(t, err)
+	assert.Same(t, previousConfig, sectionManager.config, "a reload enabling no rules keeps the current configuration")
+	assert.Equal(t, 1, sectionManager.EnabledRuleCount())
+}
@@ -31,19 +31,109 @@ func NewSectionRuleManagerFromConfig(ruleConfig *config.GlobalRuleConfig, client
 	return NewSectionRuleManager(ruleConfig, client)
 }
 
-// CreateSectionBasedDataverseManager creates a section-aware manager for dataverse workflows
+// DataverseRuleConfigPath is the rule configuration file used by dataverse workflows
+const DataverseRuleConfigPath = "rules.yaml"
+
+// dataverseRuleConfigPath returns RULES_CONFIG_DIR when set, so rule fragments from a
+// mounted ConfigMap are merged, and DataverseRuleConfigPath otherwise
+func dataverseRuleConfigPath() string {
+	if dir := config.Load().Rules.ConfigDir; dir != "" {
+		return dir
+	}
+	return DataverseRuleConfigPath
+}
+
+// DataverseProjectRuleConfigDir holds per-project overrides named <projectID>.yaml
+const DataverseProjectRuleConfigDir = "rules"
+
+// CreateSectionBasedDataverseManager creates a section-aware manager for dataverse workflows.
+// Projects with a file in DataverseProjectRuleConfigDir use it instead of DataverseRuleConfigPath.
 func CreateSectionBasedDataverseManager(client gitlab.GitLabClient) (shared.RuleManager, error) {
 	registry := GetGlobalRegistry()
 
 	// Create section-based manager - no fallback allowed
-	sectionManager, err := registry.CreateSectionBasedRuleManager(client, "rules.yaml")
+	sectionManager, err := registry.CreateSectionBasedRuleManager(client, dataverseRuleConfigPath())
 	if err != nil {
 		return nil, fmt.Errorf("failed to create section-based rule manager: %w", err)
 	}
 
+	return NewProjectRuleManager(registry, client, sectionManager.(*SectionRuleManager), DataverseProjectRuleConfigDir), nil
+}
+
+// CreateSectionBasedManager creates a section-aware manager for the rule configuration at
+// ruleConfigPath (a file or fragment directory), without per-project overrides
+func CreateSectionBasedManager(client gitlab.GitLabClient, ruleConfigPath string) (shared.RuleManager, error) {
+	sectionManager, err := GetGlobalRegistry().CreateSectionBasedRuleManager(client, ruleConfigPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create section-based rule manager: %w", err)
+	}
 	return sectionManager, nil
 }
 
+// ReloadSectionBasedDataverseManager re-reads the dataverse rule configuration into an
+// existing manager created by CreateSectionBasedDataverseManager
+func ReloadSectionBasedDataverseManager(manager shared.RuleManager, client gitlab.GitLabClient) (int, error) {
+	return ReloadSectionBasedManager(manager, client, dataverseRuleConfigPath())
+}
+
+// ReloadSectionBasedManager re-reads the rule configuration at ruleConfigPath into an existing
+// manager created by CreateSectionBasedManager or CreateSectionBasedDataverseManager
+func ReloadSectionBasedManager(manager shared.RuleManager, client gitlab.GitLabClient, ruleConfigPath string) (int, error) {
+	switch m := manager.(type) {
+	case *ProjectRuleManager:
+		return m.Reload(ruleConfigPath)
+	case *SectionRuleManager:
+		return GetGlobalRegistry().ReloadSectionBasedRuleManager(m, client, ruleConfigPath)
+	default:
+		return 0, fmt.Errorf("rule manager %T does not support reloading", manager)
+	}
+}
+
+// CheckSectionBasedDataverseReload reports whether ReloadSectionBasedDataverseManager would
+// succeed, without changing the manager
+func CheckSectionBasedDataverseReload(manager shared.RuleManager, client gitlab.GitLabClient) error {
+	return CheckSectionBasedReload(manager, client, dataverseRuleConfigPath())
+}
+
+// CheckSectionBasedReload reports whether ReloadSectionBasedManager would succeed,
+// without changing the manager
+func CheckSectionBasedReload(manager shared.RuleManager, client gitlab.GitLabClient, ruleConfigPath string) error {
+	switch m := manager.(type) {
+	case *ProjectRuleManager:
+		return m.registry.CheckSectionRuleConfig(client, ruleConfigPath)
+	case *SectionRuleManager:
+		return GetGlobalRegistry().CheckSectionRuleConfig(client, ruleConfigPath)
+	default:
+		return fmt.Errorf("rule manager %T does not support reloading", manager)
+	}
+}
+
+// DataverseRuleConfig returns the rule configuration loaded into a manager created by
+// CreateSectionBasedDataverseManager
+func DataverseRuleConfig(manager shared.RuleManager) (*config.GlobalRuleConfig, error) {
+	switch m := manager.(type) {
+	case *ProjectRuleManager:
+		return m.RuleConfig(), nil
+	case *SectionRuleManager:
+		return m.RuleConfig(), nil
+	default:
+		return nil, fmt.Errorf("rule manager %T does not expose its rule configuration", manager)
+	}
+}
+
+// DataverseCoverageReport returns the uncovered file types recorded by a manager created by
+// CreateSectionBasedDataverseManager
+func DataverseCoverageReport(manager shared.RuleManager) (CoverageReport, error) {
+	switch m := manager.(type) {
+	case *ProjectRuleManager:
+		return m.CoverageReport(), nil
+	case *SectionRuleManager:
+		return m.CoverageReport(), nil
+	default:
+		return CoverageReport{}, fmt.Errorf("rule manager %T does not record rule coverage", manager)
+	}
+}
+
 // ListAvailableRules returns information about all available rules
 func ListAvailableRules() map[string]*RuleInfo {
 	registry := GetGlobalRegistry()
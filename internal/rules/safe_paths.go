@@ -0,0 +1,92 @@
+package rules
+
+import (
+	"fmt"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/logging"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+)
+
+// safePathsRuleName identifies safe_paths approvals in rule results
+const safePathsRuleName = "safe_paths"
+
+// evaluateSafePaths approves an MR whose changed files all match safe_paths without section
+// validation. It returns nil when any file falls outside safe_paths, matches
+// always_manual_review or deletes a data product, so the MR is validated normally.
+// Global rules still run, so a safe-path file can still require manual review.
+func (srm *SectionRuleManager) evaluateSafePaths(mrCtx *shared.MRContext) (map[string]*shared.FileValidationSummary, *shared.Decision) {
+	if len(srm.config.SafePaths) == 0 {
+		return nil, nil
+	}
+	filePaths := srm.getUniqueFilePaths(mrCtx.Changes)
+	if len(filePaths) == 0 {
+		return nil, nil
+	}
+
+	fileValidations := make(map[string]*shared.FileValidationSummary, len(filePaths))
+	for _, filePath := range filePaths {
+		if srm.isAlwaysManualReview(filePath) || srm.isDeletedDataProductFile(filePath, mrCtx) {
+			return nil, nil
+		}
+		pattern := srm.matchingSafePath(filePath)
+		if pattern == "" {
+			return nil, nil
+		}
+		fileValidations[filePath] = srm.createSafePathValidation(filePath, pattern, srm.getChangedLinesForFile(filePath, mrCtx))
+	}
+
+	// Content is only fetched for global rules that can't work from the diff
+	fileContents := make(map[string]string)
+	if srm.globalRulesNeedContent() {
+		sourceProjectID := srm.resolveMRSource(mrCtx)
+		mergeRefCommit := srm.resolveMergeRefCommit(mrCtx)
+		for _, filePath := range filePaths {
+			content, _, err := srm.getFileContent(filePath, mrCtx, sourceProjectID, mergeRefCommit)
+			if err != nil {
+				logging.Warn("Cannot load safe-path file %s for global rules, validating the MR normally: %v", filePath, err)
+				return nil, nil
+			}
+			fileContents[filePath] = content
+		}
+	}
+	srm.applyGlobalRules(fileValidations, fileContents, mrCtx)
+
+	decision := srm.determineOverallDecision(fileValidations)
+	if decision.Type == shared.Approve {
+		logging.Info("All %d changed files match safe_paths - approving", len(filePaths))
+		decision = shared.Decision{
+			Type:    shared.Approve,
+			Reason:  "All changes in safe paths",
+			Summary: "✅ Auto-approved",
+			Details: fmt.Sprintf("All %d files match the safe_paths allowlist", len(filePaths)),
+		}
+	}
+	return fileValidations, &decision
+}
+
+// matchingSafePath returns the first safe_paths glob matching filePath, or an empty string
+func (srm *SectionRuleManager) matchingSafePath(filePath string) string {
+	for _, pattern := range srm.config.SafePaths {
+		if shared.MatchesPattern(filePath, pattern) {
+			return pattern
+		}
+	}
+	return ""
+}
+
+// createSafePathValidation creates an approved validation summary for a file matching safe_paths
+func (srm *SectionRuleManager) createSafePathValidation(filePath, pattern string, changedLines []shared.LineRange) *shared.FileValidationSummary {
+	return &shared.FileValidationSummary{
+		FilePath:       filePath,
+		CoveredLines:   changedLines,
+		UncoveredLines: []shared.LineRange{},
+		RuleResults: []shared.LineValidationResult{{
+			RuleName:     safePathsRuleName,
+			LineRanges:   changedLines,
+			Decision:     shared.Approve,
+			Reason:       fmt.Sprintf("Matches safe path '%s'", pattern),
+			WasEvaluated: true,
+		}},
+		FileDecision: shared.Approve,
+	}
+}
@@ -0,0 +1,97 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSectionRuleManager_SafePaths(t *testing.T) {
+	tests := []struct {
+		name             string
+		changedPaths     []string
+		expectedDecision shared.DecisionType
+		expectedReason   string
+		safePathResults  int
+	}{
+		{
+			name:             "all changes in safe paths",
+			changedPaths:     []string{"docs/guide.md", "examples/pipeline/config.json"},
+			expectedDecision: shared.Approve,
+			expectedReason:   "All changes in safe paths",
+			safePathResults:  2,
+		},
+		{
+			name:             "mixed MR validates normally",
+			changedPaths:     []string{"docs/guide.md", "terraform/main.tf"},
+			expectedDecision: shared.ManualReview,
+			expectedReason:   "One or more files require manual review",
+		},
+		{
+			name:             "no safe paths",
+			changedPaths:     []string{"terraform/main.tf"},
+			expectedDecision: shared.ManualReview,
+			expectedReason:   "One or more files require manual review",
+		},
+		{
+			name:             "always_manual_review wins over safe paths",
+			changedPaths:     []string{"docs/CODEOWNERS"},
+			expectedDecision: shared.ManualReview,
+			expectedReason:   "One or more files require manual review",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ruleConfig := &config.GlobalRuleConfig{
+				Enabled:            true,
+				SafePaths:          []string{"docs/**", "examples/**"},
+				AlwaysManualReview: []string{"**/CODEOWNERS"},
+			}
+			files := map[string]string{"docs/guide.md": "# Guide\n", "terraform/main.tf": "resource {}\n"}
+
+			result := evaluateWithIgnoreFile(t, ruleConfig, files, tt.changedPaths...)
+
+			assert.Equal(t, tt.expectedDecision, result.FinalDecision.Type)
+			assert.Equal(t, tt.expectedReason, result.FinalDecision.Reason)
+			safePathResults := 0
+			for _, validation := range result.FileValidations {
+				for _, ruleResult := range validation.RuleResults {
+					if ruleResult.RuleName == safePathsRuleName {
+						safePathResults++
+					}
+				}
+			}
+			assert.Equal(t, tt.safePathResults, safePathResults)
+		})
+	}
+}
+
+func TestSectionRuleManager_SafePathsStillRunGlobalRules(t *testing.T) {
+	ruleConfig := &config.GlobalRuleConfig{
+		Enabled:     true,
+		SafePaths:   []string{"docs/**"},
+		GlobalRules: []config.RuleConfig{{Name: "secret_scan_rule", Enabled: true}},
+	}
+	client := &ignoreTestGitLabClient{forkMRTestGitLabClient: &forkMRTestGitLabClient{}}
+	manager := NewSectionRuleManager(ruleConfig, client)
+	manager.AddRule(NewSecretScanRule())
+
+	result := manager.EvaluateAll(&shared.MRContext{
+		ProjectID: 123,
+		MRIID:     456,
+		Changes:   []gitlab.FileChange{{NewPath: "docs/setup.md", Diff: "@@ -0,0 +1,1 @@\n+key: " + exampleAWSAccessKeyID}},
+		MRInfo:    &gitlab.MRInfo{Author: "developer", SourceBranch: "feature"},
+	})
+
+	assert.Equal(t, shared.ManualReview, result.FinalDecision.Type)
+	validation := result.FileValidations["docs/setup.md"]
+	require.NotNil(t, validation)
+	require.Len(t, validation.RuleResults, 2)
+	assert.Equal(t, safePathsRuleName, validation.RuleResults[0].RuleName)
+	assert.Equal(t, "secret_scan_rule", validation.RuleResults[1].RuleName)
+}
@@ -329,7 +329,7 @@ func (r *DevelopersRule) fetchCodeowners() (string, error) {
 		return "", fmt.Errorf("source branch not available")
 	}
 
-	fileContent, err := r.client.FetchFileContent(r.mrContext.ProjectID, "CODEOWNERS", sourceBranch)
+	fileContent, err := r.client.FetchFileContent(shared.SourceProjectID(r.mrContext), "CODEOWNERS", sourceBranch)
 	if err != nil {
 		return "", fmt.Errorf("failed to fetch CODEOWNERS: %w", err)
 	}
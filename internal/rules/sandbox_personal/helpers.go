@@ -75,7 +75,7 @@ func IsSandboxPersonalProductForFile(mrContext *shared.MRContext, client gitlab.
 		return false, nil // No source branch, not an error
 	}
 
-	fileContent, err := client.FetchFileContent(mrContext.ProjectID, sandboxProductPath, sourceBranch)
+	fileContent, err := client.FetchFileContent(shared.SourceProjectID(mrContext), sandboxProductPath, sourceBranch)
 	if err != nil {
 		// Network error, API error, or rate limit - fail-closed (return error)
 		logging.Warn("Failed to fetch %s: %v - requiring manual review", sandboxProductPath, err)
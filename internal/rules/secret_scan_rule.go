@@ -0,0 +1,120 @@
+package rules
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/common"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+)
+
+// secretPattern is a high-confidence pattern for a kind of credential
+type secretPattern struct {
+	kind    string
+	pattern *regexp.Regexp
+}
+
+// secretPatterns only match formats that are very unlikely to appear by accident,
+// so a match is worth a manual review on every file type
+var secretPatterns = []secretPattern{
+	{"AWS access key ID", regexp.MustCompile(`\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`)},
+	{"AWS secret access key", regexp.MustCompile(`(?i)aws_secret_access_key["']?\s*[:=]\s*["']?[A-Za-z0-9/+]{40}\b`)},
+	{"private key", regexp.MustCompile(`-----BEGIN (?:[A-Z]+ )?PRIVATE KEY(?: BLOCK)?-----`)},
+	{"GitLab token", regexp.MustCompile(`\bglpat-[0-9A-Za-z_-]{20,}`)},
+	{"GitHub token", regexp.MustCompile(`\bgh[pousr]_[0-9A-Za-z]{36,}`)},
+	{"Slack token", regexp.MustCompile(`\bxox[abposr]-[0-9A-Za-z-]{10,}`)},
+}
+
+// hunkNewStartPattern extracts the new-file start line from a diff hunk header
+var hunkNewStartPattern = regexp.MustCompile(`^@@ -\d+(?:,\d+)? \+(\d+)`)
+
+// SecretScanRule requires manual review when an MR adds lines that look like credentials.
+// It runs on every changed file through global_rules and reports the file and line, never the value.
+type SecretScanRule struct {
+	*common.BaseRule
+	*common.ValidationHelper
+}
+
+// NewSecretScanRule creates a new secret scan rule
+func NewSecretScanRule() *SecretScanRule {
+	return &SecretScanRule{
+		BaseRule: common.NewBaseRule(
+			"secret_scan_rule",
+			"Requires manual review when added lines contain AWS keys, private keys or API tokens",
+		),
+		ValidationHelper: common.NewValidationHelper(),
+	}
+}
+
+// GetCoveredLines returns no lines: finding no secrets says nothing about whether a change is safe
+func (r *SecretScanRule) GetCoveredLines(filePath string, fileContent string) []shared.LineRange {
+	return []shared.LineRange{}
+}
+
+// ValidateLines scans the lines the MR adds to filePath for secret patterns
+func (r *SecretScanRule) ValidateLines(filePath string, fileContent string, lineRanges []shared.LineRange) (shared.DecisionType, string) {
+	mrCtx := r.GetMRContext()
+	if mrCtx == nil {
+		return r.CreateApprovalResult("No MR context - secret scan skipped")
+	}
+
+	for _, change := range mrCtx.Changes {
+		if change.NewPath == filePath && !change.DeletedFile {
+			return r.ValidateDiff(filePath, change.Diff)
+		}
+	}
+	return r.CreateApprovalResult("No secrets found in added lines")
+}
+
+// ValidateDiff scans the added lines of a file's diff for secret patterns
+func (r *SecretScanRule) ValidateDiff(filePath string, diff string) (shared.DecisionType, string) {
+	var findings []string
+	for _, finding := range scanDiffForSecrets(diff) {
+		findings = append(findings, fmt.Sprintf("%s at %s:%d", finding.kind, filePath, finding.line))
+	}
+
+	if len(findings) > 0 {
+		return r.CreateManualReviewResult(fmt.Sprintf("Possible secrets added: %s", strings.Join(findings, ", ")))
+	}
+	return r.CreateApprovalResult("No secrets found in added lines")
+}
+
+// secretFinding is a secret pattern match on a line of the new file
+type secretFinding struct {
+	kind string
+	line int
+}
+
+// scanDiffForSecrets returns the secret patterns matched by added lines of a unified diff,
+// with their line numbers in the new file
+func scanDiffForSecrets(diff string) []secretFinding {
+	var findings []secretFinding
+	newLine := 0
+	for _, line := range strings.Split(diff, "\n") {
+		if matches := hunkNewStartPattern.FindStringSubmatch(line); matches != nil {
+			newLine, _ = strconv.Atoi(matches[1])
+			continue
+		}
+		if newLine == 0 {
+			continue // Before the first hunk (e.g. file headers)
+		}
+
+		switch {
+		case strings.HasPrefix(line, "+"):
+			for _, secret := range secretPatterns {
+				if secret.pattern.MatchString(line[1:]) {
+					findings = append(findings, secretFinding{kind: secret.kind, line: newLine})
+					break
+				}
+			}
+			newLine++
+		case strings.HasPrefix(line, "-"), strings.HasPrefix(line, `\`):
+			// Removed lines and "\ No newline at end of file" don't exist in the new file
+		default:
+			newLine++
+		}
+	}
+	return findings
+}
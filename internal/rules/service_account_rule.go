@@ -2,6 +2,7 @@ package rules
 
 import (
 	"path/filepath"
+	"regexp"
 	"strings"
 
 	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
@@ -11,24 +12,61 @@ import (
 	"gopkg.in/yaml.v3"
 )
 
+// DefaultServiceAccountNamePattern derives the expected 'name' field from the filename without its .yaml/.yml extension
+const DefaultServiceAccountNamePattern = `(?i)(?:^|/)(?P<name>[^/]+)\.ya?ml$`
+
+// serviceAccountNameGroup is the named capture holding the expected name in a name pattern
+const serviceAccountNameGroup = "name"
+
 // ServiceAccountRule validates service account files based on configurable patterns and rules.
 // This rule supports various service account types and can be extended with additional validation logic.
 type ServiceAccountRule struct {
 	*common.BaseRule
-	client gitlab.GitLabClient
+	client       gitlab.GitLabClient
+	namePatterns []*regexp.Regexp // Tried in order against the file path; the first match yields the expected name
 }
 
 // NewServiceAccountRule creates a new service account rule
 func NewServiceAccountRule(client gitlab.GitLabClient) *ServiceAccountRule {
+	return NewServiceAccountRuleWithNamePatterns(client, nil)
+}
+
+// NewServiceAccountRuleWithNamePatterns creates a service account rule that derives the expected
+// 'name' field from the file path with the given regexes. Each pattern needs a (?P<name>...)
+// capture; invalid patterns are skipped, and DefaultServiceAccountNamePattern is used when none remain.
+func NewServiceAccountRuleWithNamePatterns(client gitlab.GitLabClient, patterns []string) *ServiceAccountRule {
 	return &ServiceAccountRule{
 		BaseRule: common.NewBaseRule(
 			"service_account_rule",
 			"Auto-approves Astro service account files (**_astro_<env>_appuser.yaml/yml) when the 'name' field matches the filename. Other service account files require manual review.",
 		),
-		client: client,
+		client:       client,
+		namePatterns: compileServiceAccountNamePatterns(patterns),
 	}
 }
 
+// compileServiceAccountNamePatterns compiles the name patterns, falling back to the default pattern
+func compileServiceAccountNamePatterns(patterns []string) []*regexp.Regexp {
+	var compiled []*regexp.Regexp
+	for _, pattern := range patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			logging.Warn("Ignoring invalid service account name pattern %q: %v", pattern, err)
+			continue
+		}
+		if re.SubexpIndex(serviceAccountNameGroup) < 0 {
+			logging.Warn("Ignoring service account name pattern %q: missing (?P<%s>...) capture", pattern, serviceAccountNameGroup)
+			continue
+		}
+		compiled = append(compiled, re)
+	}
+
+	if len(compiled) == 0 {
+		compiled = append(compiled, regexp.MustCompile(DefaultServiceAccountNamePattern))
+	}
+	return compiled
+}
+
 // GetCoveredLines returns which line ranges this rule validates in a file
 func (r *ServiceAccountRule) GetCoveredLines(filePath string, fileContent string) []shared.LineRange {
 	if !r.isServiceAccountFile(filePath) {
@@ -130,7 +168,7 @@ func (r *ServiceAccountRule) validateAstroServiceAccount(filePath string, fileCo
 		return shared.ManualReview, "'name' field is not a string"
 	}
 
-	// Get expected name from filename (without extension)
+	// Get expected name from the file path using the configured name patterns
 	expectedName := r.getExpectedNameFromFilename(filePath)
 	if expectedName == "" {
 		return shared.ManualReview, "Could not extract expected name from filename"
@@ -146,16 +184,15 @@ func (r *ServiceAccountRule) validateAstroServiceAccount(filePath string, fileCo
 	return shared.Approve, "Astro service account file follows naming convention and name field matches filename"
 }
 
-// getExpectedNameFromFilename extracts the expected name from the filename by removing the extension
+// getExpectedNameFromFilename extracts the expected name from the file path with the first
+// matching name pattern. Returns "" when no pattern matches.
 func (r *ServiceAccountRule) getExpectedNameFromFilename(filePath string) string {
-	filename := filepath.Base(filePath)
-	lowerFilename := strings.ToLower(filename)
-
-	// Remove .yaml or .yml extension (case insensitive)
-	if strings.HasSuffix(lowerFilename, ".yaml") {
-		return filename[:len(filename)-5] // Remove ".yaml"
-	} else if strings.HasSuffix(lowerFilename, ".yml") {
-		return filename[:len(filename)-4] // Remove ".yml"
+	for _, pattern := range r.namePatterns {
+		matches := pattern.FindStringSubmatch(filePath)
+		if matches == nil {
+			continue
+		}
+		return matches[pattern.SubexpIndex(serviceAccountNameGroup)]
 	}
 
 	return ""
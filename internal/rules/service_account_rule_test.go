@@ -289,6 +289,46 @@ func TestServiceAccountRule_getExpectedNameFromFilename(t *testing.T) {
 	}
 }
 
+func TestServiceAccountRule_getExpectedNameFromFilename_NamePatterns(t *testing.T) {
+	rule := NewServiceAccountRuleWithNamePatterns(nil, []string{
+		`sa-(?P<name>[^/]+)\.appuser\.ya?ml$`,
+		`serviceaccounts/(?P<name>[^/]+)/[^/]+\.ya?ml$`,
+	})
+
+	tests := []struct {
+		name         string
+		filePath     string
+		expectedName string
+	}{
+		{"prefixed appuser file", "dataproducts/analytics/sa-analytics_astro_prod.appuser.yaml", "analytics_astro_prod"},
+		{"account in subdirectory", "serviceaccounts/marketing_bot/marketing_astro_prod_appuser.yml", "marketing_bot"},
+		{"first matching pattern wins", "serviceaccounts/team/sa-team_astro_dev.appuser.yaml", "team_astro_dev"},
+		{"unmatched file", "dataproducts/analytics/analytics_astro_prod_appuser.yaml", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expectedName, rule.getExpectedNameFromFilename(tt.filePath))
+		})
+	}
+}
+
+func TestServiceAccountRule_NamePatternsFallBackToDefault(t *testing.T) {
+	rule := NewServiceAccountRuleWithNamePatterns(nil, []string{`[unclosed`, `no_named_capture\.yaml$`})
+
+	assert.Equal(t, "sa_astro_analytics", rule.getExpectedNameFromFilename("dataproducts/analytics/sa_astro_analytics.yaml"))
+}
+
+func TestServiceAccountRule_NamePatternUnmatchedRequiresReview(t *testing.T) {
+	rule := NewServiceAccountRuleWithNamePatterns(nil, []string{`sa-(?P<name>[^/]+)\.appuser\.ya?ml$`})
+	filePath := "dataproducts/analytics/analytics_astro_prod_appuser.yaml"
+
+	decision, reason := rule.ValidateLines(filePath, "name: analytics_astro_prod_appuser\n", []shared.LineRange{{StartLine: 1, EndLine: 1, FilePath: filePath}})
+
+	assert.Equal(t, shared.ManualReview, decision)
+	assert.Equal(t, "Could not extract expected name from filename", reason)
+}
+
 // Integration tests showing the complete workflow
 func TestServiceAccountRule_IntegrationScenarios(t *testing.T) {
 	tests := []struct {
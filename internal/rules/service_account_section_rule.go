@@ -0,0 +1,148 @@
+package rules
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/common"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+	"gopkg.in/yaml.v3"
+)
+
+// serviceAccountEntryNamePattern is the allowed format for service account names in a product.yaml
+// `service_account` section: lowercase letters, digits, '_' and '-', starting with a letter
+var serviceAccountEntryNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_-]*$`)
+
+// serviceAccountScopeKeys are the per-account keys whose values grant access
+var serviceAccountScopeKeys = []string{"scopes", "roles"}
+
+// ServiceAccountSectionRule validates the `service_account` section of product configs.
+// Each entry maps an account name to `true`/`false` or to a mapping whose `scopes`/`roles`
+// lists must not contain privileged values.
+type ServiceAccountSectionRule struct {
+	*common.BaseRule
+	*common.ValidationHelper
+	privilegedScopes map[string]bool // Upper-cased scopes that always require manual review
+}
+
+// NewServiceAccountSectionRule creates a service account section rule that flags the given scopes
+func NewServiceAccountSectionRule(privilegedScopes []string) *ServiceAccountSectionRule {
+	privileged := make(map[string]bool, len(privilegedScopes))
+	for _, scope := range privilegedScopes {
+		privileged[strings.ToUpper(scope)] = true
+	}
+	return &ServiceAccountSectionRule{
+		BaseRule: common.NewBaseRule(
+			"service_account_section_rule",
+			"Requires manual review when a product.yaml service_account section is empty, has malformed account names or grants privileged scopes",
+		),
+		ValidationHelper: common.NewValidationHelper(),
+		privilegedScopes: privileged,
+	}
+}
+
+// GetCoveredLines returns which line ranges this rule validates in a file
+func (r *ServiceAccountSectionRule) GetCoveredLines(filePath string, fileContent string) []shared.LineRange {
+	return r.GetFullFileCoverage(filePath, fileContent)
+}
+
+// ValidateLines checks the account names and scopes of the section
+func (r *ServiceAccountSectionRule) ValidateLines(filePath string, fileContent string, lineRanges []shared.LineRange) (shared.DecisionType, string) {
+	node, err := parseServiceAccountSection(fileContent)
+	if err != nil {
+		return r.CreateManualReviewResult(fmt.Sprintf("Could not parse service_account section: %v", err))
+	}
+	if node == nil || node.Kind != yaml.MappingNode || len(node.Content) == 0 {
+		return r.CreateManualReviewResult("service_account section is empty or not a mapping of account names")
+	}
+
+	var problems []string
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		name, value := node.Content[i].Value, node.Content[i+1]
+		if !serviceAccountEntryNamePattern.MatchString(name) {
+			problems = append(problems, fmt.Sprintf("invalid service account name '%s'", name))
+			continue
+		}
+		problems = append(problems, r.checkServiceAccountEntry(name, value)...)
+	}
+	if len(problems) > 0 {
+		return r.CreateManualReviewResult(fmt.Sprintf("Service account changes need review: %s", strings.Join(problems, "; ")))
+	}
+
+	return r.CreateApprovalResult("Service account names are valid and grant no privileged scopes")
+}
+
+// checkServiceAccountEntry validates one account's value and returns its problems
+func (r *ServiceAccountSectionRule) checkServiceAccountEntry(name string, value *yaml.Node) []string {
+	switch value.Kind {
+	case yaml.ScalarNode:
+		if value.Tag != "!!bool" {
+			return []string{fmt.Sprintf("service account '%s' must be true, false or a mapping", name)}
+		}
+		return nil
+	case yaml.MappingNode:
+		var problems []string
+		for i := 0; i+1 < len(value.Content); i += 2 {
+			key := value.Content[i].Value
+			if !isServiceAccountScopeKey(key) {
+				continue
+			}
+			if privileged := r.privilegedValues(value.Content[i+1]); len(privileged) > 0 {
+				problems = append(problems, fmt.Sprintf("service account '%s' requests privileged %s: %s", name, key, strings.Join(privileged, ", ")))
+			}
+		}
+		return problems
+	default:
+		return []string{fmt.Sprintf("service account '%s' must be true, false or a mapping", name)}
+	}
+}
+
+// privilegedValues returns the sorted privileged scopes in a scalar or list value
+func (r *ServiceAccountSectionRule) privilegedValues(node *yaml.Node) []string {
+	values := []*yaml.Node{node}
+	if node.Kind == yaml.SequenceNode {
+		values = node.Content
+	}
+
+	var privileged []string
+	for _, value := range values {
+		if value.Kind == yaml.ScalarNode && r.privilegedScopes[strings.ToUpper(value.Value)] {
+			privileged = append(privileged, value.Value)
+		}
+	}
+	sort.Strings(privileged)
+	return privileged
+}
+
+// isServiceAccountScopeKey reports whether key lists the access granted to an account
+func isServiceAccountScopeKey(key string) bool {
+	for _, scopeKey := range serviceAccountScopeKeys {
+		if key == scopeKey {
+			return true
+		}
+	}
+	return false
+}
+
+// parseServiceAccountSection returns the value node of a `service_account` section.
+// The section text includes the `service_account:` key itself.
+func parseServiceAccountSection(content string) (*yaml.Node, error) {
+	var root yaml.Node
+	if err := yaml.Unmarshal([]byte(content), &root); err != nil {
+		return nil, err
+	}
+
+	node := &root
+	if node.Kind == yaml.DocumentNode {
+		if len(node.Content) == 0 {
+			return nil, nil
+		}
+		node = node.Content[0]
+	}
+	if node.Kind == yaml.MappingNode && len(node.Content) == 2 && node.Content[0].Value == "service_account" {
+		node = node.Content[1]
+	}
+	return node, nil
+}
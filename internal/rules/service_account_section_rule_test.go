@@ -0,0 +1,81 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServiceAccountSectionRule_Name(t *testing.T) {
+	rule := NewServiceAccountSectionRule(nil)
+	assert.Equal(t, "service_account_section_rule", rule.Name())
+	assert.Contains(t, rule.Description(), "service_account")
+}
+
+func TestServiceAccountSectionRule_ValidateLines(t *testing.T) {
+	filePath := "dataproducts/source/analytics/prod/product.yaml"
+	privileged := []string{"ACCOUNTADMIN", "SYSADMIN"}
+
+	tests := []struct {
+		name           string
+		content        string
+		expected       shared.DecisionType
+		reasonContains string
+	}{
+		{
+			name:           "valid section",
+			content:        "service_account:\n  dbt: true\n  reporting:\n    roles:\n      - ANALYST\n",
+			expected:       shared.Approve,
+			reasonContains: "no privileged scopes",
+		},
+		{
+			name:           "disallowed scope",
+			content:        "service_account:\n  dbt:\n    scopes:\n      - reader\n      - sysadmin\n",
+			expected:       shared.ManualReview,
+			reasonContains: "service account 'dbt' requests privileged scopes: sysadmin",
+		},
+		{
+			name:           "disallowed role as a scalar",
+			content:        "service_account:\n  dbt:\n    roles: ACCOUNTADMIN\n",
+			expected:       shared.ManualReview,
+			reasonContains: "privileged roles: ACCOUNTADMIN",
+		},
+		{
+			name:           "invalid name",
+			content:        "service_account:\n  DBT Runner: true\n",
+			expected:       shared.ManualReview,
+			reasonContains: "invalid service account name 'DBT Runner'",
+		},
+		{
+			name:           "empty section",
+			content:        "service_account:\n",
+			expected:       shared.ManualReview,
+			reasonContains: "empty",
+		},
+		{
+			name:           "unsupported value",
+			content:        "service_account:\n  dbt: yes please\n",
+			expected:       shared.ManualReview,
+			reasonContains: "must be true, false or a mapping",
+		},
+		{
+			name:           "unparseable section",
+			content:        "service_account: [unclosed",
+			expected:       shared.ManualReview,
+			reasonContains: "Could not parse service_account section",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := NewServiceAccountSectionRule(privileged)
+			lineRanges := rule.GetCoveredLines(filePath, tt.content)
+
+			decision, reason := rule.ValidateLines(filePath, tt.content, lineRanges)
+
+			assert.Equal(t, tt.expected, decision)
+			assert.Contains(t, reason, tt.reasonContains)
+		})
+	}
+}
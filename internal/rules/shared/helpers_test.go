@@ -18,6 +18,13 @@ func TestIsDraftMR(t *testing.T) {
 			mrCtx:    &MRContext{MRInfo: nil},
 			expected: false,
 		},
+		{
+			name: "draft flag without title marker",
+			mrCtx: &MRContext{
+				MRInfo: &gitlab.MRInfo{Title: "Add new feature", Draft: true},
+			},
+			expected: true,
+		},
 		{
 			name: "draft in title",
 			mrCtx: &MRContext{
@@ -91,6 +98,36 @@ func TestIsDraftMR(t *testing.T) {
 	}
 }
 
+func TestSourceProjectID(t *testing.T) {
+	tests := []struct {
+		name     string
+		mrCtx    *MRContext
+		expected int
+	}{
+		{
+			name:     "nil MR info",
+			mrCtx:    &MRContext{ProjectID: 1},
+			expected: 1,
+		},
+		{
+			name:     "source project unknown",
+			mrCtx:    &MRContext{ProjectID: 1, MRInfo: &gitlab.MRInfo{}},
+			expected: 1,
+		},
+		{
+			name:     "fork MR",
+			mrCtx:    &MRContext{ProjectID: 1, MRInfo: &gitlab.MRInfo{SourceProjectID: 2}},
+			expected: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, SourceProjectID(tt.mrCtx))
+		})
+	}
+}
+
 func TestIsAutomatedUser(t *testing.T) {
 	tests := []struct {
 		name     string
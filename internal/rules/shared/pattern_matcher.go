@@ -1,6 +1,7 @@
 package shared
 
 import (
+	"fmt"
 	"path/filepath"
 	"strings"
 )
@@ -127,6 +128,30 @@ func (pm *PatternMatcher) MatchesAnyPattern(filePath string, patterns []string)
 	return false
 }
 
+// ValidatePattern reports why a glob pattern can never match as intended: unbalanced braces,
+// more than one ** or syntax that filepath.Match rejects
+func (pm *PatternMatcher) ValidatePattern(pattern string) error {
+	if pattern == "" {
+		return fmt.Errorf("empty pattern")
+	}
+	if strings.Count(pattern, "{") != strings.Count(pattern, "}") {
+		return fmt.Errorf("unbalanced braces in %q", pattern)
+	}
+
+	for _, expandedPattern := range pm.expandBracePattern(pattern) {
+		parts := strings.Split(expandedPattern, "**")
+		if len(parts) > 2 {
+			return fmt.Errorf("%q uses ** more than once, which is not supported", pattern)
+		}
+		for _, part := range parts {
+			if _, err := filepath.Match(strings.Trim(part, "/"), ""); err != nil {
+				return fmt.Errorf("invalid glob %q: %w", pattern, err)
+			}
+		}
+	}
+	return nil
+}
+
 // Global pattern matcher instance for convenience
 var GlobalPatternMatcher = NewPatternMatcher()
 
@@ -138,3 +163,7 @@ func MatchesPattern(filePath, pattern string) bool {
 func MatchesAnyPattern(filePath string, patterns []string) bool {
 	return GlobalPatternMatcher.MatchesAnyPattern(filePath, patterns)
 }
+
+func ValidatePattern(pattern string) error {
+	return GlobalPatternMatcher.ValidatePattern(pattern)
+}
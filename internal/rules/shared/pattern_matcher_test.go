@@ -191,3 +191,25 @@ func TestPatternMatcher_GlobstarPatterns(t *testing.T) {
 		})
 	}
 }
+
+func TestValidatePattern(t *testing.T) {
+	tests := []struct {
+		pattern     string
+		expectError bool
+	}{
+		{"dataproducts/**/product.{yaml,yml}", false},
+		{"**/*.md", false},
+		{"dataproducts/*/[a-z]*.yaml", false},
+		{"", true},
+		{"dataproducts/**/product.{yaml,yml", true},
+		{"dataproducts/**/groups/**/*.yaml", true},
+		{"dataproducts/[a-/product.yaml", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern, func(t *testing.T) {
+			err := ValidatePattern(tt.pattern)
+			assert.Equal(t, tt.expectError, err != nil, "pattern %q: %v", tt.pattern, err)
+		})
+	}
+}
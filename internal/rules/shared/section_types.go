@@ -16,17 +16,35 @@ const (
 
 // Section represents a logical section within a file
 type Section struct {
-	Name        string                 `json:"name"`         // e.g., "warehouse", "consumers", "serviceaccount"
-	StartLine   int                    `json:"start_line"`   // Section start line (1-based)
-	EndLine     int                    `json:"end_line"`     // Section end line (1-based)
-	Content     string                 `json:"content"`      // Raw section content
-	Type        SectionType            `json:"type"`         // Section content type
-	Fields      map[string]interface{} `json:"fields"`       // Parsed fields for this section
-	FilePath    string                 `json:"file_path"`    // Parent file path
-	YAMLPath    string                 `json:"yaml_path"`    // YAML path (e.g., "spec.warehouse")
-	Required    bool                   `json:"required"`     // Is this section required?
-	RuleConfigs []config.RuleConfig    `json:"rule_configs"` // Rules with enable/disable control
-	AutoApprove bool                   `json:"auto_approve"` // Auto-approve this section if rules pass
+	Name        string                 `json:"name"`                   // e.g., "warehouse", "consumers", "serviceaccount"
+	StartLine   int                    `json:"start_line"`             // Section start line (1-based)
+	EndLine     int                    `json:"end_line"`               // Section end line (1-based)
+	Content     string                 `json:"content"`                // Raw section content
+	Type        SectionType            `json:"type"`                   // Section content type
+	Fields      map[string]interface{} `json:"fields"`                 // Parsed fields for this section
+	FilePath    string                 `json:"file_path"`              // Parent file path
+	YAMLPath    string                 `json:"yaml_path"`              // YAML path (e.g., "spec.warehouse")
+	Required    bool                   `json:"required"`               // Is this section required?
+	RuleConfigs []config.RuleConfig    `json:"rule_configs"`           // Rules with enable/disable control
+	AutoApprove bool                   `json:"auto_approve"`           // Auto-approve this section if rules pass
+	ListChanges *ListChanges           `json:"list_changes,omitempty"` // How a list section's elements changed (nil when unknown or not a list)
+}
+
+// ListChanges classifies the changed elements of a list section against the file before the MR
+type ListChanges struct {
+	Added    []int `json:"added"`    // Indexes in the new list of elements that were appended or inserted
+	Modified []int `json:"modified"` // Indexes in the new list of existing elements changed in place
+	Removed  []int `json:"removed"`  // Indexes in the old list of elements that were removed
+}
+
+// AdditionsOnly reports whether the list only gained new elements
+func (c *ListChanges) AdditionsOnly() bool {
+	return c != nil && len(c.Added) > 0 && len(c.Modified) == 0 && len(c.Removed) == 0
+}
+
+// HasChanges reports whether any element was added, modified or removed
+func (c *ListChanges) HasChanges() bool {
+	return c != nil && len(c.Added)+len(c.Modified)+len(c.Removed) > 0
 }
 
 // SectionValidationResult represents validation result for a specific section
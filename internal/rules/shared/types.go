@@ -42,6 +42,10 @@ type MRContext struct {
 	Environment string              `json:"environment,omitempty"`
 	Labels      []string            `json:"labels,omitempty"`
 	Metadata    map[string]any      `json:"metadata,omitempty"`
+
+	// UsesLiveState is set while evaluating when the outcome depends on more than the MR's commits,
+	// e.g. its approvals, the target branch head or other open MRs
+	UsesLiveState bool `json:"-"`
 }
 
 // Rule defines a simplified interface for all rules
@@ -67,6 +71,25 @@ type ContextAwareRule interface {
 	SetMRContext(mrCtx *MRContext)
 }
 
+// DiffOnlyRule is an optional interface for rules that only need a file's diff, not its content.
+// The manager passes such rules the diff and doesn't fetch the file on their behalf.
+type DiffOnlyRule interface {
+	Rule
+
+	// ValidateDiff validates the unified diff of a file
+	ValidateDiff(filePath string, diff string) (DecisionType, string)
+}
+
+// SectionAwareRule is an optional interface for rules that need the parsed section, e.g. its
+// ListChanges to approve appended list elements but review modified ones. The parser calls
+// ValidateSectionLines instead of ValidateLines for such rules.
+type SectionAwareRule interface {
+	Rule
+
+	// ValidateSectionLines validates the specified line ranges of section
+	ValidateSectionLines(section *Section, lineRanges []LineRange) (DecisionType, string)
+}
+
 // RuleManager manages and executes rules with simple logic
 type RuleManager interface {
 	// AddRule registers a rule
@@ -85,11 +108,13 @@ type LineRange struct {
 
 // LineValidationResult represents validation result for specific lines
 type LineValidationResult struct {
-	RuleName     string       `json:"rule_name"`
-	LineRanges   []LineRange  `json:"line_ranges"`
-	Decision     DecisionType `json:"decision"`
-	Reason       string       `json:"reason"`
-	WasEvaluated bool         `json:"was_evaluated"` // true if rule actually executed (vs skipped)
+	RuleName     string        `json:"rule_name"`
+	LineRanges   []LineRange   `json:"line_ranges"`
+	Decision     DecisionType  `json:"decision"`
+	Reason       string        `json:"reason"`
+	WasEvaluated bool          `json:"was_evaluated"`      // true if rule actually executed (vs skipped)
+	Advisory     bool          `json:"advisory,omitempty"` // true if a ManualReview decision is a warning only
+	Duration     time.Duration `json:"duration,omitempty"` // Time the rule took to validate (zero when not evaluated)
 }
 
 // FileValidationSummary shows validation results for a single file
@@ -100,6 +125,14 @@ type FileValidationSummary struct {
 	UncoveredLines []LineRange            `json:"uncovered_lines"`
 	RuleResults    []LineValidationResult `json:"rule_results"`
 	FileDecision   DecisionType           `json:"file_decision"`
+	DiffSummary    *DiffSummary           `json:"diff_summary,omitempty"` // What the MR changed in the file; nil when it has no diff
+}
+
+// DiffSummary describes what an MR changed in a file, for reviewers skimming the comment
+type DiffSummary struct {
+	AddedLines   int      `json:"added_lines"`
+	RemovedLines int      `json:"removed_lines"`
+	Sections     []string `json:"sections,omitempty"` // Sorted names of the sections the changed lines touch
 }
 
 // RuleEvaluation contains the results of evaluating all rules
@@ -113,16 +146,35 @@ type RuleEvaluation struct {
 	ApprovedFiles  int `json:"approved_files"`
 	ReviewFiles    int `json:"review_files"`
 	UncoveredFiles int `json:"uncovered_files"`
+
+	CorrelationID string `json:"correlation_id,omitempty"` // Ties the decision to the webhook delivery that produced it
+
+	RulesMisconfigured bool `json:"rules_misconfigured,omitempty"` // No rules are enabled, so every change requires manual review
+
+	UsesLiveState bool `json:"-"` // The decision depends on MR state beyond its commits, so it must not be reused
 }
 
 // Common helper functions for rule evaluation
 
-// IsDraftMR returns true if the MR is a draft/WIP
+// SourceProjectID returns the project to read source-branch files from: the fork for fork MRs,
+// otherwise the MR's own project
+func SourceProjectID(mrCtx *MRContext) int {
+	if mrCtx.MRInfo != nil && mrCtx.MRInfo.SourceProjectID != 0 {
+		return mrCtx.MRInfo.SourceProjectID
+	}
+	return mrCtx.ProjectID
+}
+
+// IsDraftMR returns true if the MR is flagged as a draft or its title marks it as draft/WIP
 func IsDraftMR(mrCtx *MRContext) bool {
 	if mrCtx.MRInfo == nil {
 		return false
 	}
 
+	if mrCtx.MRInfo.Draft {
+		return true
+	}
+
 	title := strings.ToLower(mrCtx.MRInfo.Title)
 	return strings.Contains(title, "draft") ||
 		strings.Contains(title, "wip") ||
@@ -130,6 +182,18 @@ func IsDraftMR(mrCtx *MRContext) bool {
 		strings.HasPrefix(title, "wip:")
 }
 
+// SourceRef returns the ref to read the MR's files at: the MR's last commit when the event carries it,
+// so re-evaluation reads the same content after further pushes, otherwise the source branch head
+func SourceRef(mrCtx *MRContext) string {
+	if mrCtx.MRInfo == nil {
+		return ""
+	}
+	if mrCtx.MRInfo.LastCommit != "" {
+		return mrCtx.MRInfo.LastCommit
+	}
+	return mrCtx.MRInfo.SourceBranch
+}
+
 // IsAutomatedUser returns true if the MR author is a bot or automated user
 func IsAutomatedUser(mrCtx *MRContext) bool {
 	if mrCtx.MRInfo == nil {
@@ -2,8 +2,30 @@ package shared
 
 import (
 	"strings"
+	"unicode/utf8"
 )
 
+// binarySniffLength is how much of a file is inspected for binary content (same window as git)
+const binarySniffLength = 8000
+
+// KnownEnvironments are the environment directory names recognized in file paths
+var KnownEnvironments = []string{"dev", "sandbox", "platformtest", "preprod", "prod"}
+
+// ExtractEnvironment returns the environment encoded as a directory in the path
+// (e.g. "dataproducts/agg/prod/product.yaml" -> "prod"), preferring the innermost match.
+// Returns "" when no directory is a known environment.
+func ExtractEnvironment(path string) string {
+	dirs := strings.Split(strings.ToLower(path), "/")
+	for i := len(dirs) - 2; i >= 0; i-- {
+		for _, env := range KnownEnvironments {
+			if dirs[i] == env {
+				return env
+			}
+		}
+	}
+	return ""
+}
+
 // IsDataProductFile checks if a file is a dataproduct configuration file
 func IsDataProductFile(path string) bool {
 	if path == "" {
@@ -24,3 +46,17 @@ func IsMigrationFile(path string) bool {
 	return strings.Contains(lowerPath, "/migrations/") &&
 		(strings.HasSuffix(lowerPath, ".sql") || strings.HasSuffix(lowerPath, ".yaml") || strings.HasSuffix(lowerPath, ".yml"))
 }
+
+// IsBinaryContent reports whether file content looks binary rather than text:
+// it contains a NUL byte or is not valid UTF-8 within the first binarySniffLength bytes
+func IsBinaryContent(content string) bool {
+	sample := content
+	if len(sample) > binarySniffLength {
+		sample = sample[:binarySniffLength]
+		// Don't let a multi-byte rune split by the cut count as invalid UTF-8
+		for i := 0; i < utf8.UTFMax-1 && len(sample) > 0 && !utf8.ValidString(sample); i++ {
+			sample = sample[:len(sample)-1]
+		}
+	}
+	return strings.IndexByte(sample, 0) >= 0 || !utf8.ValidString(sample)
+}
@@ -1,6 +1,7 @@
 package shared
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -144,3 +145,47 @@ func TestUtilsFunctions_RealWorldPaths(t *testing.T) {
 		})
 	}
 }
+
+func TestIsBinaryContent(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected bool
+	}{
+		{"empty file", "", false},
+		{"yaml", "name: marketing\nkind: source\n", false},
+		{"utf-8 text", "description: Données clients ✅\n", false},
+		{"png header", "\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR", true},
+		{"nul byte in text", "name: test\x00\n", true},
+		{"invalid utf-8", "caf\xe9\n", true},
+		{"multi-byte rune at sniff boundary", strings.Repeat("a", binarySniffLength-1) + "é", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, IsBinaryContent(tt.content))
+		})
+	}
+}
+
+func TestExtractEnvironment(t *testing.T) {
+	tests := []struct {
+		path     string
+		expected string
+	}{
+		{"dataproducts/agg/marketing/prod/product.yaml", "prod"},
+		{"dataproducts/source/billing/dev/product.yaml", "dev"},
+		{"dataproducts/agg/marketing/PreProd/product.yaml", "preprod"},
+		{"dataproducts/agg/dev/marketing/prod/product.yaml", "prod"},
+		{"dataproducts/agg/marketing/product.yaml", ""},
+		{"dataproducts/agg/production/product.yaml", ""},
+		{"prod", ""},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ExtractEnvironment(tt.path))
+		})
+	}
+}
@@ -0,0 +1,197 @@
+package rules
+
+import (
+	"fmt"
+	"path"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/common"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+	"gopkg.in/yaml.v3"
+)
+
+// sourceBindingKind is the `kind` every source binding must declare
+const sourceBindingKind = "SourceBinding"
+
+// sourceBindingConsumersKey holds the consumers of a source binding; every other top-level
+// key describes where data is read from or written to
+const sourceBindingConsumersKey = "consumers"
+
+// SourceBindingRule validates sourcebinding.{yaml,yml} files. Adding consumers is approved;
+// changing the binding's source or target, or removing consumers, requires manual review.
+type SourceBindingRule struct {
+	*common.BaseRule
+	*common.ValidationHelper
+}
+
+// NewSourceBindingRule creates a new source binding rule
+func NewSourceBindingRule() *SourceBindingRule {
+	return &SourceBindingRule{
+		BaseRule: common.NewBaseRule(
+			"source_binding_rule",
+			"Auto-approves consumer additions to sourcebinding.yaml files, requires manual review for source/target changes",
+		),
+		ValidationHelper: common.NewValidationHelper(),
+	}
+}
+
+// isSourceBindingFile reports whether filePath is a sourcebinding.yaml or sourcebinding.yml file
+func isSourceBindingFile(filePath string) bool {
+	name := strings.ToLower(path.Base(filePath))
+	return name == "sourcebinding.yaml" || name == "sourcebinding.yml"
+}
+
+// GetCoveredLines returns which line ranges this rule validates in a file
+func (r *SourceBindingRule) GetCoveredLines(filePath string, fileContent string) []shared.LineRange {
+	if !isSourceBindingFile(filePath) {
+		return []shared.LineRange{}
+	}
+	return r.GetFullFileCoverage(filePath, fileContent)
+}
+
+// ValidateLines checks the binding's structure and compares it with the version before the MR
+func (r *SourceBindingRule) ValidateLines(filePath string, fileContent string, lineRanges []shared.LineRange) (shared.DecisionType, string) {
+	if !isSourceBindingFile(filePath) {
+		return r.CreateApprovalResult("Not a sourcebinding file - source binding rule does not apply")
+	}
+
+	binding, err := parseSourceBinding(fileContent)
+	if err != nil {
+		return r.CreateManualReviewResult(fmt.Sprintf("Invalid source binding: %v", err))
+	}
+
+	mrCtx := r.GetMRContext()
+	if mrCtx == nil {
+		return r.CreateManualReviewResult("No MR context - cannot tell whether only consumers changed")
+	}
+
+	for _, change := range mrCtx.Changes {
+		if change.NewPath != filePath {
+			continue
+		}
+		if change.NewFile {
+			return r.CreateManualReviewResult("New source binding - its source and target require manual review")
+		}
+
+		oldContent, ok := reconstructOldContent(fileContent, change.Diff)
+		if !ok {
+			return r.CreateManualReviewResult("Could not reconstruct the previous source binding from the diff")
+		}
+		oldBinding, err := parseSourceBinding(oldContent)
+		if err != nil {
+			// A broken binding being fixed still needs its source and target reviewed
+			return r.CreateManualReviewResult(fmt.Sprintf("Previous source binding is invalid (%v) - manual review required", err))
+		}
+		return r.compareBindings(oldBinding, binding)
+	}
+
+	return r.CreateApprovalResult("File not changed in this MR - source binding check skipped")
+}
+
+// compareBindings approves a binding whose only change is added consumers
+func (r *SourceBindingRule) compareBindings(oldBinding, newBinding map[string]interface{}) (shared.DecisionType, string) {
+	if changed := changedBindingFields(oldBinding, newBinding); len(changed) > 0 {
+		return r.CreateManualReviewResult(fmt.Sprintf("Source binding source/target changed (%s) - manual review required", strings.Join(changed, ", ")))
+	}
+
+	oldConsumers := bindingConsumers(oldBinding)
+	newConsumers := bindingConsumers(newBinding)
+	var removed []string
+	for _, consumer := range oldConsumers {
+		if !containsConsumer(newConsumers, consumer) {
+			removed = append(removed, consumerName(consumer))
+		}
+	}
+	if len(removed) > 0 {
+		return r.CreateManualReviewResult(fmt.Sprintf("Consumers removed or modified (%s) - manual review required", strings.Join(removed, ", ")))
+	}
+
+	var added []string
+	for _, consumer := range newConsumers {
+		if !containsConsumer(oldConsumers, consumer) {
+			added = append(added, consumerName(consumer))
+		}
+	}
+	if len(added) == 0 {
+		return r.CreateApprovalResult("Source binding unchanged")
+	}
+	return r.CreateApprovalResult(fmt.Sprintf("Consumer additions only (%s)", strings.Join(added, ", ")))
+}
+
+// parseSourceBinding parses a source binding and checks its kind and consumers list
+func parseSourceBinding(content string) (map[string]interface{}, error) {
+	var binding map[string]interface{}
+	if err := yaml.Unmarshal([]byte(content), &binding); err != nil {
+		return nil, fmt.Errorf("could not parse YAML: %w", err)
+	}
+	if binding == nil {
+		return nil, fmt.Errorf("file is empty")
+	}
+
+	if kind, _ := binding["kind"].(string); kind != sourceBindingKind {
+		return nil, fmt.Errorf("kind must be '%s', got '%v'", sourceBindingKind, binding["kind"])
+	}
+
+	consumers, ok := binding[sourceBindingConsumersKey]
+	if !ok || consumers == nil {
+		return binding, nil
+	}
+	list, ok := consumers.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("consumers must be a list")
+	}
+	for i, consumer := range list {
+		entry, ok := consumer.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("consumer %d must be a mapping", i+1)
+		}
+		if name, _ := entry["name"].(string); strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf("consumer %d has no name", i+1)
+		}
+	}
+	return binding, nil
+}
+
+// changedBindingFields returns the sorted top-level keys other than consumers that differ
+func changedBindingFields(oldBinding, newBinding map[string]interface{}) []string {
+	var changed []string
+	for key, value := range newBinding {
+		if key == sourceBindingConsumersKey {
+			continue
+		}
+		if oldValue, ok := oldBinding[key]; !ok || !reflect.DeepEqual(oldValue, value) {
+			changed = append(changed, key)
+		}
+	}
+	for key := range oldBinding {
+		if _, ok := newBinding[key]; !ok && key != sourceBindingConsumersKey {
+			changed = append(changed, key)
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
+
+// bindingConsumers returns the consumers of a validated binding
+func bindingConsumers(binding map[string]interface{}) []interface{} {
+	consumers, _ := binding[sourceBindingConsumersKey].([]interface{})
+	return consumers
+}
+
+// containsConsumer reports whether consumers has an entry identical to consumer
+func containsConsumer(consumers []interface{}, consumer interface{}) bool {
+	for _, candidate := range consumers {
+		if reflect.DeepEqual(candidate, consumer) {
+			return true
+		}
+	}
+	return false
+}
+
+// consumerName returns the name of a validated consumer entry
+func consumerName(consumer interface{}) string {
+	name, _ := consumer.(map[string]interface{})["name"].(string)
+	return name
+}
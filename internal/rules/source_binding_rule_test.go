@@ -0,0 +1,157 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSourceBindingRule_Name(t *testing.T) {
+	rule := NewSourceBindingRule()
+	assert.Equal(t, "source_binding_rule", rule.Name())
+	assert.Contains(t, rule.Description(), "consumer")
+}
+
+func TestSourceBindingRule_GetCoveredLines(t *testing.T) {
+	rule := NewSourceBindingRule()
+	content := "kind: SourceBinding\nconsumers: []\n"
+
+	assert.NotEmpty(t, rule.GetCoveredLines("dataproducts/analytics/prod/sourcebinding.yaml", content))
+	assert.NotEmpty(t, rule.GetCoveredLines("dataproducts/analytics/prod/sourcebinding.yml", content))
+	assert.Empty(t, rule.GetCoveredLines("dataproducts/analytics/prod/product.yaml", content))
+}
+
+func TestSourceBindingRule_ValidateLines(t *testing.T) {
+	filePath := "dataproducts/analytics/prod/sourcebinding.yaml"
+	twoConsumers := "consumers:\n  - name: analytics_consumer\n  - name: finance_consumer\ndata_product: analytics\ndatabase: fivetran_db\nkind: SourceBinding\nschema: analytics\n"
+
+	tests := []struct {
+		name               string
+		content            string
+		change             gitlab.FileChange
+		expectedDecision   shared.DecisionType
+		expectedReasonPart string
+	}{
+		{
+			name:    "consumer added",
+			content: twoConsumers,
+			change: gitlab.FileChange{
+				NewPath: filePath,
+				OldPath: filePath,
+				Diff:    "@@ -1,3 +1,4 @@\n consumers:\n   - name: analytics_consumer\n+  - name: finance_consumer\n data_product: analytics\n",
+			},
+			expectedDecision:   shared.Approve,
+			expectedReasonPart: "Consumer additions only (finance_consumer)",
+		},
+		{
+			name:    "first consumer added to an empty list",
+			content: "consumers:\n  - name: analytics_consumer\nkind: SourceBinding\n",
+			change: gitlab.FileChange{
+				NewPath: filePath,
+				OldPath: filePath,
+				Diff:    "@@ -1,2 +1,3 @@\n-consumers: []\n+consumers:\n+  - name: analytics_consumer\n kind: SourceBinding\n",
+			},
+			expectedDecision:   shared.Approve,
+			expectedReasonPart: "Consumer additions only (analytics_consumer)",
+		},
+		{
+			name:    "source changed",
+			content: "consumers:\n  - name: analytics_consumer\n  - name: finance_consumer\ndata_product: analytics\ndatabase: snowpipe_db\nkind: SourceBinding\nschema: analytics\n",
+			change: gitlab.FileChange{
+				NewPath: filePath,
+				OldPath: filePath,
+				Diff:    "@@ -4,3 +4,3 @@\n data_product: analytics\n-database: fivetran_db\n+database: snowpipe_db\n kind: SourceBinding\n",
+			},
+			expectedDecision:   shared.ManualReview,
+			expectedReasonPart: "source/target changed (database)",
+		},
+		{
+			name:    "consumer added alongside a target change",
+			content: "consumers:\n  - name: analytics_consumer\n  - name: finance_consumer\ndata_product: analytics\ndatabase: fivetran_db\nkind: SourceBinding\nschema: finance\n",
+			change: gitlab.FileChange{
+				NewPath: filePath,
+				OldPath: filePath,
+				Diff:    "@@ -1,6 +1,7 @@\n consumers:\n   - name: analytics_consumer\n+  - name: finance_consumer\n data_product: analytics\n database: fivetran_db\n kind: SourceBinding\n-schema: analytics\n+schema: finance\n",
+			},
+			expectedDecision:   shared.ManualReview,
+			expectedReasonPart: "source/target changed (schema)",
+		},
+		{
+			name:    "consumer removed",
+			content: "consumers:\n  - name: analytics_consumer\ndata_product: analytics\nkind: SourceBinding\n",
+			change: gitlab.FileChange{
+				NewPath: filePath,
+				OldPath: filePath,
+				Diff:    "@@ -1,4 +1,3 @@\n consumers:\n   - name: analytics_consumer\n-  - name: finance_consumer\n data_product: analytics\n",
+			},
+			expectedDecision:   shared.ManualReview,
+			expectedReasonPart: "Consumers removed or modified (finance_consumer)",
+		},
+		{
+			name:    "new binding",
+			content: twoConsumers,
+			change: gitlab.FileChange{
+				NewPath: filePath,
+				NewFile: true,
+				Diff:    "@@ -0,0 +1,7 @@\n+consumers:\n",
+			},
+			expectedDecision:   shared.ManualReview,
+			expectedReasonPart: "New source binding",
+		},
+		{
+			name:    "wrong kind",
+			content: "kind: DataProduct\nconsumers: []\n",
+			change: gitlab.FileChange{
+				NewPath: filePath,
+				OldPath: filePath,
+				Diff:    "@@ -1,1 +1,1 @@\n-kind: SourceBinding\n+kind: DataProduct\n",
+			},
+			expectedDecision:   shared.ManualReview,
+			expectedReasonPart: "kind must be 'SourceBinding'",
+		},
+		{
+			name:    "consumers not a list",
+			content: "kind: SourceBinding\nconsumers: analytics_consumer\n",
+			change: gitlab.FileChange{
+				NewPath: filePath,
+				OldPath: filePath,
+				Diff:    "@@ -1,2 +1,2 @@\n kind: SourceBinding\n-consumers: []\n+consumers: analytics_consumer\n",
+			},
+			expectedDecision:   shared.ManualReview,
+			expectedReasonPart: "consumers must be a list",
+		},
+		{
+			name:    "consumer without a name",
+			content: "kind: SourceBinding\nconsumers:\n  - role: reader\n",
+			change: gitlab.FileChange{
+				NewPath: filePath,
+				OldPath: filePath,
+				Diff:    "@@ -1,2 +1,3 @@\n kind: SourceBinding\n-consumers: []\n+consumers:\n+  - role: reader\n",
+			},
+			expectedDecision:   shared.ManualReview,
+			expectedReasonPart: "consumer 1 has no name",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := NewSourceBindingRule()
+			rule.SetMRContext(&shared.MRContext{Changes: []gitlab.FileChange{tt.change}})
+
+			decision, reason := rule.ValidateLines(filePath, tt.content, nil)
+
+			assert.Equal(t, tt.expectedDecision, decision)
+			assert.Contains(t, reason, tt.expectedReasonPart)
+		})
+	}
+}
+
+func TestSourceBindingRule_ValidateLines_NoContext(t *testing.T) {
+	rule := NewSourceBindingRule()
+
+	decision, _ := rule.ValidateLines("sourcebinding.yaml", "kind: SourceBinding\nconsumers: []\n", nil)
+
+	assert.Equal(t, shared.ManualReview, decision)
+}
@@ -38,16 +38,25 @@ func (m *MockGitLabClient) GetMRDetails(projectID, mrIID int) (*gitlab.MRDetails
 func (m *MockGitLabClient) FetchMRChanges(projectID, mrIID int) ([]gitlab.FileChange, error) {
 	return nil, nil
 }
-func (m *MockGitLabClient) AddMRComment(projectID, mrIID int, comment string) error { return nil }
+func (m *MockGitLabClient) AddMRComment(projectID, mrIID int, comment string) error       { return nil }
+func (m *MockGitLabClient) AddIssueComment(projectID, issueIID int, comment string) error { return nil }
 func (m *MockGitLabClient) AddOrUpdateMRComment(projectID, mrIID int, commentBody, commentType string) error {
 	return nil
 }
+
+func (m *MockGitLabClient) AddMRDiffNote(projectID, mrIID int, sha, filePath string, line int, body string) error {
+	return nil
+}
 func (m *MockGitLabClient) ListMRComments(projectID, mrIID int) ([]gitlab.MRComment, error) {
 	return nil, nil
 }
 func (m *MockGitLabClient) UpdateMRComment(projectID, mrIID, commentID int, newBody string) error {
 	return nil
 }
+
+func (m *MockGitLabClient) DeleteMRComment(projectID, mrIID, commentID int) error {
+	return nil
+}
 func (m *MockGitLabClient) FindLatestNaysayerComment(projectID, mrIID int, commentType ...string) (*gitlab.MRComment, error) {
 	return nil, nil
 }
@@ -55,11 +64,27 @@ func (m *MockGitLabClient) ApproveMR(projectID, mrIID int) error { return nil }
 func (m *MockGitLabClient) ApproveMRWithMessage(projectID, mrIID int, message string) error {
 	return nil
 }
+
+func (m *MockGitLabClient) SetCommitStatus(projectID int, sha, state, description string) error {
+	return nil
+}
+
+func (m *MockGitLabClient) GetMRHeadPipelineStatus(projectID, mrIID int) (string, error) {
+	return "", nil
+}
+
+func (m *MockGitLabClient) GetProjectIDByPath(pathWithNamespace string) (int, error) {
+	return 0, nil
+}
 func (m *MockGitLabClient) ResetNaysayerApproval(projectID, mrIID int) error { return nil }
-func (m *MockGitLabClient) GetCurrentBotUsername() (string, error)           { return "test-bot", nil }
+func (m *MockGitLabClient) GetMRApprovals(projectID, mrIID int) (*gitlab.MRApprovals, error) {
+	return nil, nil
+}
+func (m *MockGitLabClient) GetCurrentBotUsername() (string, error) { return "test-bot", nil }
 func (m *MockGitLabClient) IsNaysayerBotAuthor(author map[string]interface{}) bool {
 	return false
 }
+
 func (m *MockGitLabClient) RebaseMR(projectID, mrIID int) (bool, error) { return true, nil }
 func (m *MockGitLabClient) CompareBranches(sourceProjectID int, sourceBranch string, targetProjectID int, targetBranch string) (*gitlab.CompareResult, error) {
 	return nil, nil
@@ -67,6 +92,13 @@ func (m *MockGitLabClient) CompareBranches(sourceProjectID int, sourceBranch str
 func (m *MockGitLabClient) GetBranchCommit(projectID int, branch string) (string, error) {
 	return "", nil
 }
+func (m *MockGitLabClient) IsBranchProtected(projectID int, branch string) (bool, error) {
+	return true, nil
+}
+
+func (m *MockGitLabClient) GetMergeRefCommit(projectID, mrIID int) (string, error) {
+	return "", nil
+}
 func (m *MockGitLabClient) CompareCommits(projectID int, fromSHA, toSHA string) (*gitlab.CompareResult, error) {
 	return nil, nil
 }
@@ -94,7 +126,8 @@ func (m *MockGitLabClient) CloseMR(projectID, mrIID int) error { return nil }
 func (m *MockGitLabClient) FindCommentByPattern(projectID, mrIID int, pattern string) (bool, error) {
 	return false, nil
 }
-
+func (m *MockGitLabClient) AddMRLabels(projectID, mrIID int, labels []string) error    { return nil }
+func (m *MockGitLabClient) RemoveMRLabels(projectID, mrIID int, labels []string) error { return nil }
 func TestRule_Name(t *testing.T) {
 	r := NewRule(nil)
 	assert.Equal(t, "tag_rule", r.Name())
@@ -0,0 +1,106 @@
+package rules
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/common"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+	"gopkg.in/yaml.v3"
+)
+
+// TagsRule validates the values in a product.yaml `tags` block against an allowed vocabulary
+type TagsRule struct {
+	*common.BaseRule
+	*common.ValidationHelper
+	allowedValues map[string]bool // Empty disables enforcement
+}
+
+// NewTagsRule creates a tags rule; an empty allowedValues list approves every tag value
+func NewTagsRule(allowedValues []string) *TagsRule {
+	allowed := make(map[string]bool, len(allowedValues))
+	for _, value := range allowedValues {
+		allowed[value] = true
+	}
+	return &TagsRule{
+		BaseRule: common.NewBaseRule(
+			"tags_rule",
+			"Requires manual review when product.yaml tags use values outside the allowed vocabulary",
+		),
+		ValidationHelper: common.NewValidationHelper(),
+		allowedValues:    allowed,
+	}
+}
+
+// GetCoveredLines returns which line ranges this rule validates in a file
+func (r *TagsRule) GetCoveredLines(filePath string, fileContent string) []shared.LineRange {
+	return r.GetFullFileCoverage(filePath, fileContent)
+}
+
+// ValidateLines checks every tag value against the allowed vocabulary
+func (r *TagsRule) ValidateLines(filePath string, fileContent string, lineRanges []shared.LineRange) (shared.DecisionType, string) {
+	if len(r.allowedValues) == 0 {
+		return r.CreateApprovalResult("Tag vocabulary not configured - tag values not enforced")
+	}
+
+	values, err := parseTagValues(fileContent)
+	if err != nil {
+		return r.CreateManualReviewResult(fmt.Sprintf("Could not parse tags: %v", err))
+	}
+
+	var disallowed []string
+	for _, value := range values {
+		if !r.allowedValues[value] {
+			disallowed = append(disallowed, value)
+		}
+	}
+	if len(disallowed) > 0 {
+		sort.Strings(disallowed)
+		return r.CreateManualReviewResult(fmt.Sprintf("Tag values not in the allowed vocabulary: %s", strings.Join(disallowed, ", ")))
+	}
+
+	return r.CreateApprovalResult("All tag values are in the allowed vocabulary")
+}
+
+// parseTagValues returns the distinct scalar values of a `tags` section.
+// Tags may be a key/value map or a list; nested values are flattened.
+func parseTagValues(content string) ([]string, error) {
+	var root yaml.Node
+	if err := yaml.Unmarshal([]byte(content), &root); err != nil {
+		return nil, err
+	}
+
+	node := &root
+	if node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		node = node.Content[0]
+	}
+	// The section text includes the `tags:` key itself
+	if node.Kind == yaml.MappingNode && len(node.Content) == 2 && node.Content[0].Value == "tags" {
+		node = node.Content[1]
+	}
+
+	seen := make(map[string]bool)
+	var values []string
+	var collect func(n *yaml.Node)
+	collect = func(n *yaml.Node) {
+		switch n.Kind {
+		case yaml.ScalarNode:
+			if n.Value != "" && !seen[n.Value] {
+				seen[n.Value] = true
+				values = append(values, n.Value)
+			}
+		case yaml.MappingNode:
+			// Values only: keys name the tag, they are not part of the vocabulary
+			for i := 1; i < len(n.Content); i += 2 {
+				collect(n.Content[i])
+			}
+		case yaml.SequenceNode:
+			for _, child := range n.Content {
+				collect(child)
+			}
+		}
+	}
+	collect(node)
+	return values, nil
+}
@@ -0,0 +1,81 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTagsRule_Name(t *testing.T) {
+	rule := NewTagsRule(nil)
+	assert.Equal(t, "tags_rule", rule.Name())
+	assert.Contains(t, rule.Description(), "tags")
+}
+
+func TestTagsRule_ValidateLines(t *testing.T) {
+	filePath := "dataproducts/source/analytics/prod/product.yaml"
+	allowed := []string{"analytics", "production", "pii", "internal"}
+
+	tests := []struct {
+		name           string
+		allowedValues  []string
+		content        string
+		expected       shared.DecisionType
+		reasonContains string
+	}{
+		{
+			name:           "allowed values only",
+			allowedValues:  allowed,
+			content:        "tags:\n  data_product: analytics\n  tier: production\n",
+			expected:       shared.Approve,
+			reasonContains: "allowed vocabulary",
+		},
+		{
+			name:           "allowed values in a list",
+			allowedValues:  allowed,
+			content:        "tags:\n  classification:\n    - pii\n    - internal\n",
+			expected:       shared.Approve,
+			reasonContains: "allowed vocabulary",
+		},
+		{
+			name:           "disallowed value",
+			allowedValues:  allowed,
+			content:        "tags:\n  data_product: analytics\n  tier: experimental\n  owner: team-x\n",
+			expected:       shared.ManualReview,
+			reasonContains: "not in the allowed vocabulary: experimental, team-x",
+		},
+		{
+			name:           "empty tags",
+			allowedValues:  allowed,
+			content:        "tags:\n",
+			expected:       shared.Approve,
+			reasonContains: "allowed vocabulary",
+		},
+		{
+			name:           "empty allowlist disables enforcement",
+			content:        "tags:\n  tier: anything-goes\n",
+			expected:       shared.Approve,
+			reasonContains: "not configured",
+		},
+		{
+			name:           "unparseable tags",
+			allowedValues:  allowed,
+			content:        "tags: [unclosed",
+			expected:       shared.ManualReview,
+			reasonContains: "Could not parse tags",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := NewTagsRule(tt.allowedValues)
+			lineRanges := rule.GetCoveredLines(filePath, tt.content)
+
+			decision, reason := rule.ValidateLines(filePath, tt.content, lineRanges)
+
+			assert.Equal(t, tt.expected, decision)
+			assert.Contains(t, reason, tt.reasonContains)
+		})
+	}
+}
@@ -1,18 +1,29 @@
 package toc_approval
 
 import (
+	"fmt"
 	"strings"
 
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
 	"github.com/redhat-data-and-ai/naysayer/internal/rules/common"
 	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/warehouse"
 )
 
-// TOCApprovalRule requires TOC approval for new product.yaml files in preprod/prod environments
+// MRApprovalsClient reads who approved a merge request
+type MRApprovalsClient interface {
+	GetMRApprovals(projectID, mrIID int) (*gitlab.MRApprovals, error)
+}
+
+// TOCApprovalRule requires TOC approval for new product.yaml files in preprod/prod environments,
+// and for warehouse size increases in production until a TOC member has approved the MR
 type TOCApprovalRule struct {
 	*common.BaseRule
 	*common.FileTypeMatcher
 	*common.ValidationHelper
-	config *TOCEnvironmentConfig
+	config    *TOCEnvironmentConfig
+	approvals MRApprovalsClient           // Nil disables the warehouse increase check
+	analyzer  warehouse.AnalyzerInterface // Detects warehouse size changes
 }
 
 // NewTOCApprovalRule creates a new TOC approval rule instance
@@ -23,13 +34,26 @@ func NewTOCApprovalRule(preprodProdEnvs []string) *TOCApprovalRule {
 	}
 
 	return &TOCApprovalRule{
-		BaseRule:         common.NewBaseRule("toc_approval_rule", "Requires TOC approval for new product.yaml files in preprod/prod environments"),
+		BaseRule:         common.NewBaseRule("toc_approval_rule", "Requires TOC approval for new product.yaml files in preprod/prod environments and production warehouse size increases"),
 		FileTypeMatcher:  common.NewFileTypeMatcher(),
 		ValidationHelper: common.NewValidationHelper(),
 		config:           config,
 	}
 }
 
+// NewTOCApprovalRuleWithWarehouseCheck creates a TOC approval rule that also requires a TOC member's approval
+// for warehouse size increases in warehouseEnvs. approvers are the TOC members' GitLab usernames.
+func NewTOCApprovalRuleWithWarehouseCheck(preprodProdEnvs []string, client gitlab.GitLabClient, warehouseEnvs, approvers []string) *TOCApprovalRule {
+	rule := NewTOCApprovalRule(preprodProdEnvs)
+	rule.config.WarehouseEnvironments = warehouseEnvs
+	rule.config.Approvers = approvers
+	if client != nil {
+		rule.approvals = client
+		rule.analyzer = warehouse.NewAnalyzer(client)
+	}
+	return rule
+}
+
 // ValidateLines validates lines for TOC approval requirements
 func (r *TOCApprovalRule) ValidateLines(filePath string, fileContent string, lineRanges []shared.LineRange) (shared.DecisionType, string) {
 	// Only apply to product.yaml files
@@ -44,6 +68,10 @@ func (r *TOCApprovalRule) ValidateLines(filePath string, fileContent string, lin
 		return r.CreateManualReviewResult(context.ApprovalReason)
 	}
 
+	if decision, reason, checked := r.validateWarehouseIncreases(filePath); checked {
+		return decision, reason
+	}
+
 	// For existing files or non-critical environments, no TOC approval needed
 	return r.CreateApprovalResult("Existing product.yaml file or not in critical environment - no TOC approval required")
 }
@@ -129,9 +157,14 @@ func (r *TOCApprovalRule) isEnvironmentCritical(environment string) bool {
 
 // extractEnvironmentFromPath attempts to extract the environment name from the file path
 func (r *TOCApprovalRule) extractEnvironmentFromPath(filePath string) string {
+	return matchEnvironment(filePath, r.config.RequiredEnvironments)
+}
+
+// matchEnvironment returns the first of environments named by a path segment of filePath
+func matchEnvironment(filePath string, environments []string) string {
 	lowerPath := strings.ToLower(filePath)
 
-	for _, env := range r.config.RequiredEnvironments {
+	for _, env := range environments {
 		lowerEnv := strings.ToLower(env)
 		if strings.Contains(lowerPath, "/"+lowerEnv+"/") ||
 			strings.Contains(lowerPath, "/"+lowerEnv+"_") ||
@@ -152,3 +185,82 @@ func (r *TOCApprovalRule) getTOCApprovalReason(filePath, environment string) str
 
 	return "Manual review required: New data product in critical environment requires TOC (Technical Oversight Committee) approval before deployment"
 }
+
+// validateWarehouseIncreases requires a TOC member's approval for warehouse size increases in
+// production environments. checked is false when the check doesn't apply to the file.
+func (r *TOCApprovalRule) validateWarehouseIncreases(filePath string) (decision shared.DecisionType, reason string, checked bool) {
+	mrCtx := r.GetMRContext()
+	if r.approvals == nil || r.analyzer == nil || mrCtx == nil {
+		return "", "", false
+	}
+	environment := matchEnvironment(filePath, r.config.WarehouseEnvironments)
+	if environment == "" {
+		return "", "", false
+	}
+
+	changes, err := r.analyzer.AnalyzeChanges(mrCtx.ProjectID, mrCtx.MRIID, shared.SourceRef(mrCtx), mrCtx.Changes)
+	if err != nil {
+		decision, reason = r.CreateManualReviewResult(fmt.Sprintf("Manual review required: cannot check %s warehouse sizes for TOC approval: %v", environment, err))
+		return decision, reason, true
+	}
+	increases := warehouseIncreases(changes, filePath)
+	if len(increases) == 0 {
+		return "", "", false
+	}
+
+	approver, err := r.tocApprover(mrCtx)
+	if err != nil {
+		decision, reason = r.CreateManualReviewResult(fmt.Sprintf("Manual review required: %s warehouse size increase (%s) requires TOC approval, but MR approvals could not be checked: %v", environment, strings.Join(increases, ", "), err))
+		return decision, reason, true
+	}
+	if approver == "" {
+		decision, reason = r.CreateManualReviewResult(fmt.Sprintf("Manual review required: %s warehouse size increase (%s) requires approval from a TOC (Technical Oversight Committee) member", environment, strings.Join(increases, ", ")))
+		return decision, reason, true
+	}
+	decision, reason = r.CreateApprovalResult(fmt.Sprintf("%s warehouse size increase (%s) approved by TOC member @%s", environment, strings.Join(increases, ", "), approver))
+	return decision, reason, true
+}
+
+// WarehouseIncreaseApprover returns the TOC member who approved the MR when filePath is in a TOC warehouse
+// environment, so warehouse_rule accepts the same sign-off. applies is false outside those environments.
+func (r *TOCApprovalRule) WarehouseIncreaseApprover(filePath string, mrCtx *shared.MRContext) (string, bool, error) {
+	if r.approvals == nil || mrCtx == nil || matchEnvironment(filePath, r.config.WarehouseEnvironments) == "" {
+		return "", false, nil
+	}
+	approver, err := r.tocApprover(mrCtx)
+	return approver, true, err
+}
+
+// tocApprover returns the username of a TOC member who approved the MR, or an empty string
+func (r *TOCApprovalRule) tocApprover(mrCtx *shared.MRContext) (string, error) {
+	mrCtx.UsesLiveState = true // Approvals change without a new commit
+	approvals, err := r.approvals.GetMRApprovals(mrCtx.ProjectID, mrCtx.MRIID)
+	if err != nil || approvals == nil {
+		return "", err
+	}
+	for _, approval := range approvals.ApprovedBy {
+		username, _ := approval.User["username"].(string)
+		for _, member := range r.config.Approvers {
+			if username != "" && strings.EqualFold(username, strings.TrimPrefix(member, "@")) {
+				return username, nil
+			}
+		}
+	}
+	return "", nil
+}
+
+// warehouseIncreases describes the size increases of existing warehouses in filePath, e.g. "user: SMALL → LARGE"
+func warehouseIncreases(changes []warehouse.WarehouseChange, filePath string) []string {
+	var increases []string
+	for _, change := range changes {
+		if !strings.HasPrefix(change.FilePath, filePath) || change.Setting != "" || change.Ambiguous || change.IsDecrease {
+			continue
+		}
+		if change.FromSize == "" || change.FromSize == "N/A" || change.ToSize == "" || change.ToSize == "N/A" {
+			continue // Added or removed warehouses aren't size increases
+		}
+		label := strings.TrimSuffix(strings.TrimPrefix(strings.TrimPrefix(change.FilePath, filePath), " (type: "), ")")
+		increases = append(increases, fmt.Sprintf("%s: %s → %s", label, change.FromSize, change.ToSize))
+	}
+	return increases
+}
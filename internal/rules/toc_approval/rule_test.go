@@ -1,10 +1,12 @@
 package toc_approval
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
 	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/warehouse"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -361,3 +363,102 @@ func TestTOCApprovalRule_extractEnvironmentFromPath(t *testing.T) {
 		})
 	}
 }
+
+// stubApprovals returns fixed MR approvals
+type stubApprovals struct {
+	approvers []string
+	err       error
+}
+
+func (s *stubApprovals) GetMRApprovals(projectID, mrIID int) (*gitlab.MRApprovals, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	approvals := &gitlab.MRApprovals{Approved: len(s.approvers) > 0}
+	for _, username := range s.approvers {
+		approvals.ApprovedBy = append(approvals.ApprovedBy, gitlab.MRApprover{User: map[string]interface{}{"username": username}})
+	}
+	return approvals, nil
+}
+
+// stubAnalyzer returns fixed warehouse changes
+type stubAnalyzer struct {
+	changes []warehouse.WarehouseChange
+}
+
+func (s *stubAnalyzer) AnalyzeChanges(projectID, mrIID int, sourceRef string, changes []gitlab.FileChange) ([]warehouse.WarehouseChange, error) {
+	return s.changes, nil
+}
+
+func TestTOCApprovalRule_WarehouseIncrease(t *testing.T) {
+	prodPath := "dataproducts/analytics/prod/product.yaml"
+	devPath := "dataproducts/analytics/dev/product.yaml"
+
+	tests := []struct {
+		name                   string
+		filePath               string
+		changes                []warehouse.WarehouseChange
+		approvals              *stubApprovals
+		expectedDecision       shared.DecisionType
+		expectedReasonContains string
+	}{
+		{
+			name:                   "prod increase without TOC approval",
+			filePath:               prodPath,
+			changes:                []warehouse.WarehouseChange{{FilePath: prodPath + " (type: user)", FromSize: "SMALL", ToSize: "LARGE"}},
+			approvals:              &stubApprovals{approvers: []string{"developer"}},
+			expectedDecision:       shared.ManualReview,
+			expectedReasonContains: "prod warehouse size increase (user: SMALL → LARGE) requires approval from a TOC",
+		},
+		{
+			name:                   "prod increase with TOC approval",
+			filePath:               prodPath,
+			changes:                []warehouse.WarehouseChange{{FilePath: prodPath + " (type: user)", FromSize: "SMALL", ToSize: "LARGE"}},
+			approvals:              &stubApprovals{approvers: []string{"developer", "Toc-Lead"}},
+			expectedDecision:       shared.Approve,
+			expectedReasonContains: "approved by TOC member @Toc-Lead",
+		},
+		{
+			name:                   "approvals lookup failure",
+			filePath:               prodPath,
+			changes:                []warehouse.WarehouseChange{{FilePath: prodPath + " (type: user)", FromSize: "SMALL", ToSize: "LARGE"}},
+			approvals:              &stubApprovals{err: fmt.Errorf("gitlab API error 500")},
+			expectedDecision:       shared.ManualReview,
+			expectedReasonContains: "MR approvals could not be checked",
+		},
+		{
+			name:                   "prod decrease needs no TOC",
+			filePath:               prodPath,
+			changes:                []warehouse.WarehouseChange{{FilePath: prodPath + " (type: user)", FromSize: "LARGE", ToSize: "SMALL", IsDecrease: true}},
+			approvals:              &stubApprovals{},
+			expectedDecision:       shared.Approve,
+			expectedReasonContains: "no TOC approval required",
+		},
+		{
+			name:                   "non-prod increase needs no TOC",
+			filePath:               devPath,
+			changes:                []warehouse.WarehouseChange{{FilePath: devPath + " (type: user)", FromSize: "SMALL", ToSize: "LARGE"}},
+			approvals:              &stubApprovals{},
+			expectedDecision:       shared.Approve,
+			expectedReasonContains: "no TOC approval required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := NewTOCApprovalRuleWithWarehouseCheck(nil, nil, []string{"prod"}, []string{"@toc-lead"})
+			rule.approvals = tt.approvals
+			rule.analyzer = &stubAnalyzer{changes: tt.changes}
+			rule.SetMRContext(&shared.MRContext{
+				ProjectID: 123,
+				MRIID:     456,
+				Changes:   []gitlab.FileChange{{OldPath: tt.filePath, NewPath: tt.filePath}},
+			})
+
+			decision, reason := rule.ValidateLines(tt.filePath, "name: analytics", nil)
+
+			assert.Equal(t, tt.expectedDecision, decision)
+			assert.Contains(t, reason, tt.expectedReasonContains)
+		})
+	}
+}
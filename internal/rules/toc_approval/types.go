@@ -7,6 +7,12 @@ type TOCEnvironmentConfig struct {
 
 	// CaseSensitive determines if environment matching is case sensitive
 	CaseSensitive bool `json:"case_sensitive"`
+
+	// WarehouseEnvironments are environments where warehouse size increases require TOC approval
+	WarehouseEnvironments []string `json:"warehouse_environments"`
+
+	// Approvers are the usernames of TOC members whose MR approval satisfies the requirement
+	Approvers []string `json:"approvers"`
 }
 
 // DefaultTOCEnvironmentConfig returns the default configuration
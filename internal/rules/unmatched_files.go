@@ -0,0 +1,46 @@
+package rules
+
+import (
+	"fmt"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/logging"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+	"github.com/redhat-data-and-ai/naysayer/internal/utils"
+)
+
+// unmatchedFileRuleName labels the decision recorded for files approved by an unmatched_files fallback
+const unmatchedFileRuleName = "unmatched_file_fallback"
+
+// matchingUnmatchedFileFallback returns the first unmatched_files entry whose glob matches filePath
+func (srm *SectionRuleManager) matchingUnmatchedFileFallback(filePath string) *config.UnmatchedFileFallback {
+	for i, fallback := range srm.config.UnmatchedFiles {
+		if shared.MatchesPattern(filePath, fallback.Pattern) {
+			return &srm.config.UnmatchedFiles[i]
+		}
+	}
+	return nil
+}
+
+// createUnmatchedFileFallbackValidation approves a file without a parser whose unmatched_files
+// entry is auto_approve. Nil means the file keeps the default manual review.
+func (srm *SectionRuleManager) createUnmatchedFileFallbackValidation(filePath string) *shared.FileValidationSummary {
+	fallback := srm.matchingUnmatchedFileFallback(filePath)
+	if fallback == nil || fallback.Action != utils.DefaultActionAutoApprove {
+		return nil
+	}
+
+	logging.Info("No parser found for file: %s - approving (matched unmatched_files pattern '%s')", filePath, fallback.Pattern)
+	return &shared.FileValidationSummary{
+		FilePath:       filePath,
+		CoveredLines:   []shared.LineRange{},
+		UncoveredLines: []shared.LineRange{},
+		RuleResults: []shared.LineValidationResult{{
+			RuleName:     unmatchedFileRuleName,
+			Decision:     shared.Approve,
+			Reason:       fmt.Sprintf("Unconfigured file matches unmatched_files pattern '%s'", fallback.Pattern),
+			WasEvaluated: true,
+		}},
+		FileDecision: shared.Approve,
+	}
+}
@@ -0,0 +1,73 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+	"github.com/redhat-data-and-ai/naysayer/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSectionRuleManager_UnmatchedFiles(t *testing.T) {
+	tests := []struct {
+		name             string
+		filePath         string
+		expectedDecision shared.DecisionType
+		expectedRule     string
+	}{
+		{
+			name:             "extension mapped to approve",
+			filePath:         "docs/guide.md",
+			expectedDecision: shared.Approve,
+			expectedRule:     unmatchedFileRuleName,
+		},
+		{
+			name:             "extension mapped to review",
+			filePath:         "scripts/deploy.sh",
+			expectedDecision: shared.ManualReview,
+		},
+		{
+			name:             "first matching entry wins",
+			filePath:         "docs/internal/notes.txt",
+			expectedDecision: shared.ManualReview,
+		},
+		{
+			name:             "unmapped file keeps manual review",
+			filePath:         "terraform/main.tf",
+			expectedDecision: shared.ManualReview,
+		},
+		{
+			name:             "always_manual_review wins over fallback",
+			filePath:         "docs/CODEOWNERS.md",
+			expectedDecision: shared.ManualReview,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ruleConfig := &config.GlobalRuleConfig{
+				Enabled:            true,
+				AlwaysManualReview: []string{"**/CODEOWNERS.md"},
+				UnmatchedFiles: []config.UnmatchedFileFallback{
+					{Pattern: "docs/internal/**", Action: utils.DefaultActionManualReview},
+					{Pattern: "**/*.{md,txt}", Action: utils.DefaultActionAutoApprove},
+					{Pattern: "**/*.sh", Action: utils.DefaultActionManualReview},
+				},
+			}
+			files := map[string]string{tt.filePath: "content\n"}
+
+			result := evaluateWithIgnoreFile(t, ruleConfig, files, tt.filePath)
+
+			validation := result.FileValidations[tt.filePath]
+			require.NotNil(t, validation)
+			assert.Equal(t, tt.expectedDecision, validation.FileDecision)
+			assert.Equal(t, tt.expectedDecision, result.FinalDecision.Type)
+			if tt.expectedRule != "" {
+				require.Len(t, validation.RuleResults, 1)
+				assert.Equal(t, tt.expectedRule, validation.RuleResults[0].RuleName)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package rules
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+)
+
+// maxMemoizedMRs bounds how many MRs keep memoized file validations; the oldest MR is evicted first
+const maxMemoizedMRs = 1000
+
+// memoMRKey identifies the MR a memoized validation belongs to
+type memoMRKey struct {
+	projectID int
+	mrIID     int
+}
+
+// memoEntry is a file validation together with the content it was computed for
+type memoEntry struct {
+	contentKey string
+	validation *shared.FileValidationSummary
+}
+
+// fileValidationMemo remembers per-MR file validations so repeated events for an MR
+// don't re-parse files whose blob and diff are unchanged
+type fileValidationMemo struct {
+	mu      sync.Mutex
+	entries map[memoMRKey]map[string]memoEntry // MR -> file path -> validation
+	order   []memoMRKey                        // MRs in insertion order, for eviction
+}
+
+// newFileValidationMemo creates an empty memo
+func newFileValidationMemo() *fileValidationMemo {
+	return &fileValidationMemo{entries: make(map[memoMRKey]map[string]memoEntry)}
+}
+
+// memoContentKey combines a file's blob ID with its MR diff; either changing invalidates the entry
+func memoContentKey(blobID, diff string) string {
+	diffSum := sha256.Sum256([]byte(diff))
+	return blobID + ":" + hex.EncodeToString(diffSum[:])
+}
+
+// get returns a copy of the memoized validation for filePath if it was computed for contentKey
+func (m *fileValidationMemo) get(mrCtx *shared.MRContext, filePath, contentKey string) *shared.FileValidationSummary {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entry, ok := m.entries[memoMRKey{mrCtx.ProjectID, mrCtx.MRIID}][filePath]
+	if !ok || entry.contentKey != contentKey {
+		return nil
+	}
+	validation := *entry.validation
+	return &validation
+}
+
+// put memoizes the validation of filePath for contentKey, replacing any previous entry
+func (m *fileValidationMemo) put(mrCtx *shared.MRContext, filePath, contentKey string, validation *shared.FileValidationSummary) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := memoMRKey{mrCtx.ProjectID, mrCtx.MRIID}
+	files, ok := m.entries[key]
+	if !ok {
+		if len(m.order) >= maxMemoizedMRs {
+			delete(m.entries, m.order[0])
+			m.order = m.order[1:]
+		}
+		files = make(map[string]memoEntry)
+		m.entries[key] = files
+		m.order = append(m.order, key)
+	}
+	stored := *validation
+	files[filePath] = memoEntry{contentKey: contentKey, validation: &stored}
+}
@@ -0,0 +1,104 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingSectionParser counts how often files are parsed
+type countingSectionParser struct {
+	stubSectionParser
+	parses map[string]int
+}
+
+func (sp *countingSectionParser) ParseSections(filePath string, content string) ([]shared.Section, error) {
+	sp.parses[filePath]++
+	return sp.stubSectionParser.ParseSections(filePath, content)
+}
+
+// blobTestGitLabClient serves file contents together with their blob IDs
+type blobTestGitLabClient struct {
+	*forkMRTestGitLabClient
+	files map[string]*gitlab.FileContent
+}
+
+func (m *blobTestGitLabClient) FetchFileContent(projectID int, filePath, ref string) (*gitlab.FileContent, error) {
+	return m.files[filePath], nil
+}
+
+func (m *blobTestGitLabClient) GetMRDetails(projectID, mrIID int) (*gitlab.MRDetails, error) {
+	return &gitlab.MRDetails{IID: mrIID, ProjectID: projectID, SourceProjectID: projectID}, nil
+}
+
+func newMemoTestManager(reuse bool) (*SectionRuleManager, *countingSectionParser, *blobTestGitLabClient) {
+	client := &blobTestGitLabClient{
+		forkMRTestGitLabClient: &forkMRTestGitLabClient{},
+		files: map[string]*gitlab.FileContent{
+			"a/product.yaml": {Content: "name: a\n", BlobID: "blob-a1"},
+			"b/product.yaml": {Content: "name: b\n", BlobID: "blob-b1"},
+		},
+	}
+	parser := &countingSectionParser{
+		stubSectionParser: stubSectionParser{sections: []shared.Section{{Name: "all", StartLine: 1, EndLine: 1}}},
+		parses:            make(map[string]int),
+	}
+	manager := NewSectionRuleManager(&config.GlobalRuleConfig{Enabled: true, ReuseUnchangedFiles: reuse}, client)
+	manager.sectionParsers["**/product.yaml"] = parser
+	return manager, parser, client
+}
+
+func evaluateMemoTestMR(manager *SectionRuleManager) *shared.RuleEvaluation {
+	return manager.EvaluateAll(&shared.MRContext{
+		ProjectID: 123,
+		MRIID:     456,
+		Changes: []gitlab.FileChange{
+			{NewPath: "a/product.yaml", Diff: "@@ -1,1 +1,1 @@\n+name: a"},
+			{NewPath: "b/product.yaml", Diff: "@@ -1,1 +1,1 @@\n+name: b"},
+		},
+		MRInfo: &gitlab.MRInfo{Author: "developer", SourceBranch: "feature"},
+	})
+}
+
+func TestSectionRuleManager_ReuseUnchangedFiles(t *testing.T) {
+	manager, parser, client := newMemoTestManager(true)
+
+	first := evaluateMemoTestMR(manager)
+	require.Contains(t, first.FileValidations, "a/product.yaml")
+
+	// Only b/product.yaml changes before the next event
+	client.files["b/product.yaml"] = &gitlab.FileContent{Content: "name: b2\n", BlobID: "blob-b2"}
+	second := evaluateMemoTestMR(manager)
+
+	assert.Equal(t, 1, parser.parses["a/product.yaml"], "unchanged file is not re-parsed")
+	assert.Equal(t, 2, parser.parses["b/product.yaml"], "changed blob is re-parsed")
+	assert.Equal(t, first.FileValidations["a/product.yaml"].FileDecision, second.FileValidations["a/product.yaml"].FileDecision)
+	assert.Equal(t, first.FinalDecision.Type, second.FinalDecision.Type)
+}
+
+func TestSectionRuleManager_ReuseUnchangedFilesDisabled(t *testing.T) {
+	manager, parser, _ := newMemoTestManager(false)
+
+	evaluateMemoTestMR(manager)
+	evaluateMemoTestMR(manager)
+
+	assert.Equal(t, 2, parser.parses["a/product.yaml"])
+	assert.Equal(t, 2, parser.parses["b/product.yaml"])
+}
+
+func TestFileValidationMemo_InvalidatesOnDiffChange(t *testing.T) {
+	memo := newFileValidationMemo()
+	mrCtx := &shared.MRContext{ProjectID: 1, MRIID: 2}
+	validation := &shared.FileValidationSummary{FilePath: "product.yaml", FileDecision: shared.Approve}
+
+	memo.put(mrCtx, "product.yaml", memoContentKey("blob", "+a"), validation)
+
+	assert.NotNil(t, memo.get(mrCtx, "product.yaml", memoContentKey("blob", "+a")))
+	assert.Nil(t, memo.get(mrCtx, "product.yaml", memoContentKey("blob", "+b")), "diff changed")
+	assert.Nil(t, memo.get(mrCtx, "product.yaml", memoContentKey("other", "+a")), "blob changed")
+	assert.Nil(t, memo.get(&shared.MRContext{ProjectID: 1, MRIID: 3}, "product.yaml", memoContentKey("blob", "+a")), "other MR")
+}
@@ -2,6 +2,7 @@ package warehouse
 
 import (
 	"fmt"
+	"reflect"
 	"strings"
 
 	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
@@ -20,8 +21,11 @@ type DataProduct struct {
 
 // Warehouse represents a warehouse configuration
 type Warehouse struct {
-	Type string `yaml:"type"`
-	Size string `yaml:"size"`
+	Type        string `yaml:"type"`
+	Name        string `yaml:"name,omitempty"` // Optional; distinguishes warehouses of the same type
+	Size        string `yaml:"size"`
+	AutoSuspend *int   `yaml:"auto_suspend,omitempty"` // Seconds of inactivity before suspending; 0 never suspends
+	AutoResume  *bool  `yaml:"auto_resume,omitempty"`  // Resume automatically when queried
 }
 
 // Tags represents the tags section
@@ -38,23 +42,34 @@ type GitLabClientInterface interface {
 
 // AnalyzerInterface defines the interface for warehouse analyzers
 type AnalyzerInterface interface {
-	AnalyzeChanges(projectID, mrIID int, changes []gitlab.FileChange) ([]WarehouseChange, error)
+	AnalyzeChanges(projectID, mrIID int, sourceRef string, changes []gitlab.FileChange) ([]WarehouseChange, error)
 }
 
 // Analyzer analyzes YAML files for warehouse changes
 type Analyzer struct {
-	gitlabClient GitLabClientInterface
+	gitlabClient          GitLabClientInterface
+	maxAutoSuspendSeconds int // auto_suspend values above this require manual review
 }
 
 // NewAnalyzer creates a new warehouse analyzer
 func NewAnalyzer(gitlabClient GitLabClientInterface) *Analyzer {
+	return NewAnalyzerWithAutoSuspendLimit(gitlabClient, DefaultMaxAutoSuspendSeconds)
+}
+
+// NewAnalyzerWithAutoSuspendLimit creates a warehouse analyzer with a custom auto_suspend threshold
+func NewAnalyzerWithAutoSuspendLimit(gitlabClient GitLabClientInterface, maxAutoSuspendSeconds int) *Analyzer {
+	if maxAutoSuspendSeconds <= 0 {
+		maxAutoSuspendSeconds = DefaultMaxAutoSuspendSeconds
+	}
 	return &Analyzer{
-		gitlabClient: gitlabClient,
+		gitlabClient:          gitlabClient,
+		maxAutoSuspendSeconds: maxAutoSuspendSeconds,
 	}
 }
 
-// AnalyzeChanges analyzes GitLab MR changes for warehouse modifications using proper YAML parsing
-func (a *Analyzer) AnalyzeChanges(projectID, mrIID int, changes []gitlab.FileChange) ([]WarehouseChange, error) {
+// AnalyzeChanges analyzes GitLab MR changes for warehouse modifications using proper YAML parsing.
+// New file content is read at sourceRef (e.g. the MR's last commit SHA), or the source branch head when empty.
+func (a *Analyzer) AnalyzeChanges(projectID, mrIID int, sourceRef string, changes []gitlab.FileChange) ([]WarehouseChange, error) {
 	warehouseChanges := make([]WarehouseChange, 0)
 
 	for _, change := range changes {
@@ -69,7 +84,7 @@ func (a *Analyzer) AnalyzeChanges(projectID, mrIID int, changes []gitlab.FileCha
 		}
 
 		// Analyze this specific file for warehouse changes
-		fileChanges, err := a.analyzeFileChange(projectID, mrIID, change.NewPath)
+		fileChanges, err := a.analyzeFileChange(projectID, mrIID, sourceRef, change.NewPath)
 		if err != nil {
 			return nil, fmt.Errorf("failed to analyze file %s: %v", change.NewPath, err)
 		}
@@ -83,7 +98,7 @@ func (a *Analyzer) AnalyzeChanges(projectID, mrIID int, changes []gitlab.FileCha
 }
 
 // analyzeFileChange fetches complete file content and compares YAML structures
-func (a *Analyzer) analyzeFileChange(projectID, mrIID int, filePath string) (*[]WarehouseChange, error) {
+func (a *Analyzer) analyzeFileChange(projectID, mrIID int, sourceRef, filePath string) (*[]WarehouseChange, error) {
 	// Get target branch
 	targetBranch, err := a.gitlabClient.GetMRTargetBranch(projectID, mrIID)
 	if err != nil {
@@ -104,19 +119,22 @@ func (a *Analyzer) analyzeFileChange(projectID, mrIID int, filePath string) (*[]
 	if mrDetails.SourceProjectID != 0 && mrDetails.SourceProjectID != targetProjectID {
 		sourceProjectID = mrDetails.SourceProjectID
 	}
+	if sourceRef == "" {
+		sourceRef = mrDetails.SourceBranch
+	}
 
 	// Fetch file content from target branch (before changes)
 	oldContent, err := a.gitlabClient.FetchFileContent(targetProjectID, filePath, targetBranch)
 	if err != nil && strings.Contains(err.Error(), "file not found") {
 		// File is new - doesn't exist in target branch
 		// Try to fetch from source branch to analyze the new file
-		newContent, err := a.gitlabClient.FetchFileContent(sourceProjectID, filePath, mrDetails.SourceBranch)
+		newContent, err := a.gitlabClient.FetchFileContent(sourceProjectID, filePath, sourceRef)
 		if err != nil {
 			if strings.Contains(err.Error(), "file not found") {
 				// File doesn't exist in either branch - this shouldn't happen for non-deleted files
 				return &[]WarehouseChange{}, nil
 			}
-			return nil, fmt.Errorf("failed to fetch new file content from source project %d, branch %s: %v", sourceProjectID, mrDetails.SourceBranch, err)
+			return nil, fmt.Errorf("failed to fetch new file content from source project %d, ref %s: %v", sourceProjectID, sourceRef, err)
 		}
 
 		// New file - compare empty state with new content
@@ -132,7 +150,7 @@ func (a *Analyzer) analyzeFileChange(projectID, mrIID int, filePath string) (*[]
 	}
 
 	// Fetch file content from source branch (after changes)
-	newContent, err := a.gitlabClient.FetchFileContent(sourceProjectID, filePath, mrDetails.SourceBranch)
+	newContent, err := a.gitlabClient.FetchFileContent(sourceProjectID, filePath, sourceRef)
 	if err != nil {
 		// File might be deleted in source branch
 		if strings.Contains(err.Error(), "file not found") {
@@ -145,7 +163,7 @@ func (a *Analyzer) analyzeFileChange(projectID, mrIID int, filePath string) (*[]
 			changes := a.compareWarehouses(filePath, oldDP, newDP)
 			return &changes, nil
 		}
-		return nil, fmt.Errorf("failed to fetch new file content from source project %d, branch %s: %v", sourceProjectID, mrDetails.SourceBranch, err)
+		return nil, fmt.Errorf("failed to fetch new file content from source project %d, ref %s: %v", sourceProjectID, sourceRef, err)
 	}
 
 	// Parse both YAML contents
@@ -191,66 +209,161 @@ func (a *Analyzer) parseDataProduct(content string) (*DataProduct, error) {
 func (a *Analyzer) compareWarehouses(filePath string, oldDP, newDP *DataProduct) []WarehouseChange {
 	changes := make([]WarehouseChange, 0)
 
-	// Create maps for easier comparison
-	oldWarehouses := make(map[string]string) // type -> size
-	newWarehouses := make(map[string]string) // type -> size
+	// Match warehouses by type (+ name when present) rather than list position,
+	// so reordering or inserting entries cannot shift comparisons
+	oldGroups, oldKeys := groupWarehouses(oldDP.Warehouses)
+	newGroups, newKeys := groupWarehouses(newDP.Warehouses)
 
-	for _, wh := range oldDP.Warehouses {
-		oldWarehouses[wh.Type] = wh.Size
-	}
-
-	for _, wh := range newDP.Warehouses {
-		newWarehouses[wh.Type] = wh.Size
-	}
+	// Check for warehouse size changes, setting changes and new warehouse creation
+	for _, key := range newKeys {
+		changePath := fmt.Sprintf("%s (type: %s)", filePath, key)
+		oldGroup, newGroup := oldGroups[key], newGroups[key]
 
-	// Check for warehouse size changes and new warehouse creation
-	for whType, newSize := range newWarehouses {
-		if oldSize, exists := oldWarehouses[whType]; exists {
-			if oldSize != newSize {
-				// Warehouse size changed
-				oldValue, oldExists := WarehouseSizes[oldSize]
-				newValue, newExists := WarehouseSizes[newSize]
-
-				if oldExists && newExists {
-					changes = append(changes, WarehouseChange{
-						FilePath:   fmt.Sprintf("%s (type: %s)", filePath, whType),
-						FromSize:   oldSize,
-						ToSize:     newSize,
-						IsDecrease: oldValue > newValue,
-					})
-				}
+		if len(oldGroup) > 1 || len(newGroup) > 1 {
+			// Several warehouses share this key, so pairs cannot be matched reliably
+			if !reflect.DeepEqual(oldGroup, newGroup) {
+				changes = append(changes, WarehouseChange{FilePath: changePath, Ambiguous: true})
 			}
-		} else {
+			continue
+		}
+
+		newWH := newGroup[0]
+		if len(oldGroup) == 0 {
 			// New warehouse created - treat as an increase
-			if _, newExists := WarehouseSizes[newSize]; newExists {
+			if _, newExists := WarehouseSizes[newWH.Size]; newExists {
 				changes = append(changes, WarehouseChange{
-					FilePath:   fmt.Sprintf("%s (type: %s)", filePath, whType),
+					FilePath:   changePath,
 					FromSize:   "", // Empty for new warehouses
-					ToSize:     newSize,
+					ToSize:     newWH.Size,
 					IsDecrease: false, // New warehouse creation is always an increase
 				})
 			}
+			continue
 		}
-	}
 
-	// Check for removed warehouses
-	for whType, oldSize := range oldWarehouses {
-		if _, exists := newWarehouses[whType]; !exists {
-			// Warehouse was removed - treat as a decrease (requires manual review)
-			if _, oldExists := WarehouseSizes[oldSize]; oldExists {
+		oldWH := oldGroup[0]
+		changes = append(changes, a.compareWarehouseSettings(filePath, oldWH, newWH)...)
+
+		if oldWH.Size != newWH.Size {
+			// Warehouse size changed
+			oldValue, oldExists := WarehouseSizes[oldWH.Size]
+			newValue, newExists := WarehouseSizes[newWH.Size]
+
+			if oldExists && newExists {
 				changes = append(changes, WarehouseChange{
-					FilePath:   fmt.Sprintf("%s (type: %s)", filePath, whType),
-					FromSize:   oldSize,
-					ToSize:     "",   // Empty for removed warehouses
-					IsDecrease: true, // Removal is considered a decrease
+					FilePath:   changePath,
+					FromSize:   oldWH.Size,
+					ToSize:     newWH.Size,
+					IsDecrease: oldValue > newValue,
 				})
 			}
 		}
 	}
 
+	// Check for removed warehouses
+	for _, key := range oldKeys {
+		if _, exists := newGroups[key]; exists {
+			continue
+		}
+		changePath := fmt.Sprintf("%s (type: %s)", filePath, key)
+
+		if len(oldGroups[key]) > 1 {
+			changes = append(changes, WarehouseChange{FilePath: changePath, Ambiguous: true})
+			continue
+		}
+
+		// Warehouse was removed - treat as a decrease (requires manual review)
+		oldSize := oldGroups[key][0].Size
+		if _, oldExists := WarehouseSizes[oldSize]; oldExists {
+			changes = append(changes, WarehouseChange{
+				FilePath:   changePath,
+				FromSize:   oldSize,
+				ToSize:     "",   // Empty for removed warehouses
+				IsDecrease: true, // Removal is considered a decrease
+			})
+		}
+	}
+
 	return changes
 }
 
+// warehouseKey returns the stable key used to match a warehouse between
+// versions: its type, qualified by name when one is set ("user/etl")
+func warehouseKey(wh Warehouse) string {
+	if wh.Name == "" {
+		return wh.Type
+	}
+	return wh.Type + "/" + wh.Name
+}
+
+// groupWarehouses groups warehouses by key, returning keys in first-seen order
+func groupWarehouses(warehouses []Warehouse) (map[string][]Warehouse, []string) {
+	groups := make(map[string][]Warehouse)
+	var keys []string
+
+	for _, wh := range warehouses {
+		key := warehouseKey(wh)
+		if _, seen := groups[key]; !seen {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], wh)
+	}
+
+	return groups, keys
+}
+
+// compareWarehouseSettings detects auto_suspend increases beyond the configured
+// threshold and auto_resume being disabled, both of which can drive up cost
+func (a *Analyzer) compareWarehouseSettings(filePath string, oldWH, newWH Warehouse) []WarehouseChange {
+	var changes []WarehouseChange
+	changePath := fmt.Sprintf("%s (type: %s)", filePath, warehouseKey(newWH))
+
+	if newWH.AutoSuspend != nil && (oldWH.AutoSuspend == nil || *oldWH.AutoSuspend != *newWH.AutoSuspend) {
+		newValue := *newWH.AutoSuspend
+		// 0 means the warehouse never suspends
+		exceedsLimit := newValue == 0 || newValue > a.maxAutoSuspendSeconds
+		isIncrease := oldWH.AutoSuspend == nil || (*oldWH.AutoSuspend != 0 && (newValue == 0 || newValue > *oldWH.AutoSuspend))
+		if exceedsLimit && isIncrease {
+			changes = append(changes, WarehouseChange{
+				FilePath:  changePath,
+				Setting:   "auto_suspend",
+				FromValue: formatAutoSuspend(oldWH.AutoSuspend),
+				ToValue:   formatAutoSuspend(newWH.AutoSuspend),
+			})
+		}
+	}
+
+	if newWH.AutoResume != nil && !*newWH.AutoResume && (oldWH.AutoResume == nil || *oldWH.AutoResume) {
+		changes = append(changes, WarehouseChange{
+			FilePath:  changePath,
+			Setting:   "auto_resume",
+			FromValue: formatAutoResume(oldWH.AutoResume),
+			ToValue:   "false",
+		})
+	}
+
+	return changes
+}
+
+// formatAutoSuspend renders an auto_suspend value for review messages
+func formatAutoSuspend(seconds *int) string {
+	if seconds == nil {
+		return "unset"
+	}
+	if *seconds == 0 {
+		return "never"
+	}
+	return fmt.Sprintf("%ds", *seconds)
+}
+
+// formatAutoResume renders an auto_resume value for review messages
+func formatAutoResume(enabled *bool) string {
+	if enabled == nil {
+		return "unset"
+	}
+	return fmt.Sprintf("%t", *enabled)
+}
+
 // hasNonWarehouseChanges checks if there are changes beyond warehouse sizes
 func (a *Analyzer) hasNonWarehouseChanges(oldContent, newContent string, oldDP, newDP *DataProduct) bool {
 	// Compare non-warehouse fields from the parsed struct
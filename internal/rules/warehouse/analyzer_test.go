@@ -316,6 +316,154 @@ func TestAnalyzer_compareWarehouses(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "reordered list without changes",
+			oldDP: &DataProduct{
+				Warehouses: []Warehouse{
+					{Type: "user", Size: "SMALL"},
+					{Type: "service_account", Size: "LARGE"},
+				},
+			},
+			newDP: &DataProduct{
+				Warehouses: []Warehouse{
+					{Type: "service_account", Size: "LARGE"},
+					{Type: "user", Size: "SMALL"},
+				},
+			},
+			expected: []WarehouseChange{},
+		},
+		{
+			name: "reordered list with size change",
+			oldDP: &DataProduct{
+				Warehouses: []Warehouse{
+					{Type: "user", Size: "SMALL"},
+					{Type: "service_account", Size: "LARGE"},
+				},
+			},
+			newDP: &DataProduct{
+				Warehouses: []Warehouse{
+					{Type: "service_account", Size: "XLARGE"},
+					{Type: "user", Size: "SMALL"},
+				},
+			},
+			expected: []WarehouseChange{
+				{
+					FilePath:   "dataproducts/agg/test/product.yaml (type: service_account)",
+					FromSize:   "LARGE",
+					ToSize:     "XLARGE",
+					IsDecrease: false,
+				},
+			},
+		},
+		{
+			name: "inserted warehouse at the front",
+			oldDP: &DataProduct{
+				Warehouses: []Warehouse{
+					{Type: "user", Size: "SMALL"},
+				},
+			},
+			newDP: &DataProduct{
+				Warehouses: []Warehouse{
+					{Type: "service_account", Size: "MEDIUM"},
+					{Type: "user", Size: "SMALL"},
+				},
+			},
+			expected: []WarehouseChange{
+				{
+					FilePath:   "dataproducts/agg/test/product.yaml (type: service_account)",
+					FromSize:   "",
+					ToSize:     "MEDIUM",
+					IsDecrease: false,
+				},
+			},
+		},
+		{
+			name: "named warehouses of the same type matched by name",
+			oldDP: &DataProduct{
+				Warehouses: []Warehouse{
+					{Type: "user", Name: "adhoc", Size: "SMALL"},
+					{Type: "user", Name: "etl", Size: "MEDIUM"},
+				},
+			},
+			newDP: &DataProduct{
+				Warehouses: []Warehouse{
+					{Type: "user", Name: "etl", Size: "LARGE"},
+					{Type: "user", Name: "adhoc", Size: "SMALL"},
+				},
+			},
+			expected: []WarehouseChange{
+				{
+					FilePath:   "dataproducts/agg/test/product.yaml (type: user/etl)",
+					FromSize:   "MEDIUM",
+					ToSize:     "LARGE",
+					IsDecrease: false,
+				},
+			},
+		},
+		{
+			name: "duplicate type with changes is ambiguous",
+			oldDP: &DataProduct{
+				Warehouses: []Warehouse{
+					{Type: "user", Size: "SMALL"},
+					{Type: "user", Size: "LARGE"},
+				},
+			},
+			newDP: &DataProduct{
+				Warehouses: []Warehouse{
+					{Type: "user", Size: "LARGE"},
+					{Type: "user", Size: "XLARGE"},
+				},
+			},
+			expected: []WarehouseChange{
+				{
+					FilePath:  "dataproducts/agg/test/product.yaml (type: user)",
+					Ambiguous: true,
+				},
+			},
+		},
+		{
+			name: "duplicate type removed entirely is ambiguous",
+			oldDP: &DataProduct{
+				Warehouses: []Warehouse{
+					{Type: "user", Size: "SMALL"},
+					{Type: "user", Size: "LARGE"},
+				},
+			},
+			newDP: &DataProduct{
+				Warehouses: []Warehouse{},
+			},
+			expected: []WarehouseChange{
+				{
+					FilePath:  "dataproducts/agg/test/product.yaml (type: user)",
+					Ambiguous: true,
+				},
+			},
+		},
+		{
+			name: "unchanged duplicate type is not reported",
+			oldDP: &DataProduct{
+				Warehouses: []Warehouse{
+					{Type: "user", Size: "SMALL"},
+					{Type: "user", Size: "LARGE"},
+					{Type: "service_account", Size: "SMALL"},
+				},
+			},
+			newDP: &DataProduct{
+				Warehouses: []Warehouse{
+					{Type: "user", Size: "SMALL"},
+					{Type: "user", Size: "LARGE"},
+					{Type: "service_account", Size: "MEDIUM"},
+				},
+			},
+			expected: []WarehouseChange{
+				{
+					FilePath:   "dataproducts/agg/test/product.yaml (type: service_account)",
+					FromSize:   "SMALL",
+					ToSize:     "MEDIUM",
+					IsDecrease: false,
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -378,7 +526,7 @@ func TestAnalyzer_AnalyzeChanges_FilteringLogic(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result, err := analyzer.AnalyzeChanges(123, 456, tt.changes)
+			result, err := analyzer.AnalyzeChanges(123, 456, "", tt.changes)
 			assert.NoError(t, err, "AnalyzeChanges should not return error for filtering tests")
 			assert.Equal(t, tt.expected, result, "AnalyzeChanges filtering result mismatch")
 		})
@@ -439,7 +587,7 @@ func TestAnalyzer_analyzeFileChange_ErrorHandling(t *testing.T) {
 				mrDetails:      &gitlab.MRDetails{SourceBranch: "feature", ProjectID: 123, SourceProjectID: 123, TargetProjectID: 123},
 				newFileError:   fmt.Errorf("file corrupted"),
 			},
-			expectedError:  "failed to fetch new file content from source project 123, branch feature: file corrupted",
+			expectedError:  "failed to fetch new file content from source project 123, ref feature: file corrupted",
 			expectedResult: nil,
 		},
 		{
@@ -486,7 +634,7 @@ func TestAnalyzer_analyzeFileChange_ErrorHandling(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			var mockClient GitLabClientInterface = tt.mockClient
 			analyzer := NewAnalyzer(mockClient)
-			result, err := analyzer.analyzeFileChange(123, 456, "dataproducts/agg/test/product.yaml")
+			result, err := analyzer.analyzeFileChange(123, 456, "", "dataproducts/agg/test/product.yaml")
 
 			if tt.expectedError != "" {
 				assert.Error(t, err, "analyzeFileChange should return error")
@@ -500,6 +648,34 @@ func TestAnalyzer_analyzeFileChange_ErrorHandling(t *testing.T) {
 	}
 }
 
+func TestAnalyzer_analyzeFileChange_SourceRef(t *testing.T) {
+	tests := []struct {
+		name        string
+		sourceRef   string
+		expectedRef string
+	}{
+		{name: "pinned commit SHA", sourceRef: "da1560886d4f094c3e6c9ef40349f7d38b5d27d7", expectedRef: "da1560886d4f094c3e6c9ef40349f7d38b5d27d7"},
+		{name: "falls back to source branch head", sourceRef: "", expectedRef: "feature"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockGitLabClient{
+				targetBranch:   "main",
+				oldFileContent: &gitlab.FileContent{Content: "name: test\nrover_group: test"},
+				mrDetails:      &gitlab.MRDetails{SourceBranch: "feature", ProjectID: 123, SourceProjectID: 123, TargetProjectID: 123},
+				newFileContent: &gitlab.FileContent{Content: "name: test\nrover_group: test"},
+			}
+			analyzer := NewAnalyzer(mockClient)
+
+			_, err := analyzer.analyzeFileChange(123, 456, tt.sourceRef, "dataproducts/agg/test/product.yaml")
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedRef, mockClient.lastFetchBranch, "New content should be read at the expected ref")
+		})
+	}
+}
+
 // MockGitLabClient is a test implementation of the GitLab client interface
 type MockGitLabClient struct {
 	targetBranch       string
@@ -557,10 +733,18 @@ func (m *MockGitLabClient) AddMRComment(projectID, mrIID int, comment string) er
 	return nil
 }
 
+func (m *MockGitLabClient) AddIssueComment(projectID, issueIID int, comment string) error {
+	return nil
+}
+
 func (m *MockGitLabClient) AddOrUpdateMRComment(projectID, mrIID int, commentBody, commentType string) error {
 	return nil
 }
 
+func (m *MockGitLabClient) AddMRDiffNote(projectID, mrIID int, sha, filePath string, line int, body string) error {
+	return nil
+}
+
 func (m *MockGitLabClient) ListMRComments(projectID, mrIID int) ([]gitlab.MRComment, error) {
 	return []gitlab.MRComment{}, nil
 }
@@ -569,6 +753,10 @@ func (m *MockGitLabClient) UpdateMRComment(projectID, mrIID, commentID int, newB
 	return nil
 }
 
+func (m *MockGitLabClient) DeleteMRComment(projectID, mrIID, commentID int) error {
+	return nil
+}
+
 func (m *MockGitLabClient) FindLatestNaysayerComment(projectID, mrIID int, commentType ...string) (*gitlab.MRComment, error) {
 	return nil, nil
 }
@@ -581,10 +769,26 @@ func (m *MockGitLabClient) ApproveMRWithMessage(projectID, mrIID int, message st
 	return nil
 }
 
+func (m *MockGitLabClient) SetCommitStatus(projectID int, sha, state, description string) error {
+	return nil
+}
+
+func (m *MockGitLabClient) GetMRHeadPipelineStatus(projectID, mrIID int) (string, error) {
+	return "", nil
+}
+
+func (m *MockGitLabClient) GetProjectIDByPath(pathWithNamespace string) (int, error) {
+	return 0, nil
+}
+
 func (m *MockGitLabClient) ResetNaysayerApproval(projectID, mrIID int) error {
 	return nil
 }
 
+func (m *MockGitLabClient) GetMRApprovals(projectID, mrIID int) (*gitlab.MRApprovals, error) {
+	return nil, nil
+}
+
 func (m *MockGitLabClient) GetCurrentBotUsername() (string, error) {
 	return "naysayer-bot", nil
 }
@@ -696,3 +900,76 @@ data_product_db:
 		})
 	}
 }
+
+func TestAnalyzer_compareWarehouseSettings(t *testing.T) {
+	analyzer := NewAnalyzerWithAutoSuspendLimit(nil, 600)
+	filePath := "dataproducts/agg/test/product.yaml"
+
+	tests := []struct {
+		name     string
+		oldYAML  string
+		newYAML  string
+		expected []WarehouseChange
+	}{
+		{
+			name:     "unchanged settings",
+			oldYAML:  "warehouses:\n  - type: user\n    size: SMALL\n    auto_suspend: 300\n    auto_resume: true\n",
+			newYAML:  "warehouses:\n  - type: user\n    size: SMALL\n    auto_suspend: 300\n    auto_resume: true\n",
+			expected: []WarehouseChange{},
+		},
+		{
+			name:     "auto_suspend increase within threshold",
+			oldYAML:  "warehouses:\n  - type: user\n    size: SMALL\n    auto_suspend: 60\n",
+			newYAML:  "warehouses:\n  - type: user\n    size: SMALL\n    auto_suspend: 600\n",
+			expected: []WarehouseChange{},
+		},
+		{
+			name:    "auto_suspend increase beyond threshold",
+			oldYAML: "warehouses:\n  - type: user\n    size: SMALL\n    auto_suspend: 300\n",
+			newYAML: "warehouses:\n  - type: user\n    size: SMALL\n    auto_suspend: 3600\n",
+			expected: []WarehouseChange{
+				{FilePath: filePath + " (type: user)", Setting: "auto_suspend", FromValue: "300s", ToValue: "3600s"},
+			},
+		},
+		{
+			name:    "auto_suspend disabled",
+			oldYAML: "warehouses:\n  - type: user\n    size: SMALL\n    auto_suspend: 300\n",
+			newYAML: "warehouses:\n  - type: user\n    size: SMALL\n    auto_suspend: 0\n",
+			expected: []WarehouseChange{
+				{FilePath: filePath + " (type: user)", Setting: "auto_suspend", FromValue: "300s", ToValue: "never"},
+			},
+		},
+		{
+			name:     "auto_suspend decrease below threshold",
+			oldYAML:  "warehouses:\n  - type: user\n    size: SMALL\n    auto_suspend: 3600\n",
+			newYAML:  "warehouses:\n  - type: user\n    size: SMALL\n    auto_suspend: 300\n",
+			expected: []WarehouseChange{},
+		},
+		{
+			name:    "auto_resume disabled",
+			oldYAML: "warehouses:\n  - type: user\n    size: SMALL\n    auto_resume: true\n",
+			newYAML: "warehouses:\n  - type: user\n    size: SMALL\n    auto_resume: false\n",
+			expected: []WarehouseChange{
+				{FilePath: filePath + " (type: user)", Setting: "auto_resume", FromValue: "true", ToValue: "false"},
+			},
+		},
+		{
+			name:     "auto_resume enabled",
+			oldYAML:  "warehouses:\n  - type: user\n    size: SMALL\n    auto_resume: false\n",
+			newYAML:  "warehouses:\n  - type: user\n    size: SMALL\n    auto_resume: true\n",
+			expected: []WarehouseChange{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			oldDP, err := analyzer.parseDataProduct(tt.oldYAML)
+			assert.NoError(t, err)
+			newDP, err := analyzer.parseDataProduct(tt.newYAML)
+			assert.NoError(t, err)
+
+			result := analyzer.compareWarehouses(filePath, oldDP, newDP)
+			assert.ElementsMatch(t, tt.expected, result)
+		})
+	}
+}
@@ -2,6 +2,7 @@ package warehouse
 
 import (
 	"fmt"
+	"regexp"
 	"sort"
 	"strings"
 
@@ -9,26 +10,54 @@ import (
 	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
 )
 
+// revertTitlePattern matches titles generated by `git revert` and GitLab's revert button
+var revertTitlePattern = regexp.MustCompile(`(?i)^\s*revert\b`)
+
+// IncreaseApprover finds who may sign off warehouse size increases in a file (e.g. a TOC member).
+// applies is false when the file is outside the approver's scope.
+type IncreaseApprover interface {
+	WarehouseIncreaseApprover(filePath string, mrCtx *shared.MRContext) (approver string, applies bool, err error)
+}
+
 // Rule implements warehouse file validation for product.yaml files
 type Rule struct {
-	client   gitlab.GitLabClient
-	analyzer AnalyzerInterface
-	mrCtx    *shared.MRContext // Store MR context for warehouse analysis
+	client              gitlab.GitLabClient
+	analyzer            AnalyzerInterface
+	mrCtx               *shared.MRContext // Store MR context for warehouse analysis
+	maxNewWarehouseSize string            // New warehouses up to this size don't need manual review
+	increaseApprover    IncreaseApprover  // Nil requires manual review for every size increase
 }
 
 // NewRule creates a new warehouse validation rule
 func NewRule(client gitlab.GitLabClient) *Rule {
+	return NewRuleWithAutoSuspendLimit(client, DefaultMaxAutoSuspendSeconds)
+}
+
+// NewRuleWithAutoSuspendLimit creates a warehouse validation rule with a custom auto_suspend threshold
+func NewRuleWithAutoSuspendLimit(client gitlab.GitLabClient, maxAutoSuspendSeconds int) *Rule {
+	return NewRuleWithLimits(client, maxAutoSuspendSeconds, DefaultMaxNewWarehouseSize)
+}
+
+// NewRuleWithLimits creates a warehouse validation rule with custom auto_suspend and new warehouse size thresholds.
+// An unknown maxNewWarehouseSize requires manual review for every new warehouse.
+func NewRuleWithLimits(client gitlab.GitLabClient, maxAutoSuspendSeconds int, maxNewWarehouseSize string) *Rule {
 	var analyzer AnalyzerInterface
 	if client != nil {
-		analyzer = NewAnalyzer(client)
+		analyzer = NewAnalyzerWithAutoSuspendLimit(client, maxAutoSuspendSeconds)
 	}
 
 	return &Rule{
-		client:   client,
-		analyzer: analyzer,
+		client:              client,
+		analyzer:            analyzer,
+		maxNewWarehouseSize: strings.ToUpper(strings.TrimSpace(maxNewWarehouseSize)),
 	}
 }
 
+// SetIncreaseApprover lets an approval from approver's members stand in for manual review of size increases
+func (r *Rule) SetIncreaseApprover(approver IncreaseApprover) {
+	r.increaseApprover = approver
+}
+
 // Name returns the rule identifier
 func (r *Rule) Name() string {
 	return "warehouse_rule"
@@ -36,7 +65,7 @@ func (r *Rule) Name() string {
 
 // Description returns human-readable description
 func (r *Rule) Description() string {
-	return "Validates warehouse size changes in product.yaml files - all warehouse changes require manual review for cost control and governance."
+	return "Validates warehouse size changes in product.yaml files - warehouse changes require manual review for cost control and governance, except new warehouses within the size cap."
 }
 
 // SetMRContext implements ContextAwareRule interface
@@ -82,28 +111,43 @@ func (r *Rule) ValidateLines(filePath string, fileContent string, lineRanges []s
 	}
 
 	// Use the analyzer to detect warehouse changes
-	changes, err := r.analyzer.AnalyzeChanges(r.mrCtx.ProjectID, r.mrCtx.MRIID, r.mrCtx.Changes)
+	changes, err := r.analyzer.AnalyzeChanges(r.mrCtx.ProjectID, r.mrCtx.MRIID, shared.SourceRef(r.mrCtx), r.mrCtx.Changes)
 	if err != nil {
 		// If analysis fails, require manual review for safety
 		return shared.ManualReview, fmt.Sprintf("Warehouse analysis failed: %v", err)
 	}
 
 	// Check if this specific file has ANY warehouse changes
-	// Categories: additions, removals, increases, decreases
+	// Categories: additions, removals, increases, decreases, risky setting changes, ambiguous matches
 	var warehouseAdditions []WarehouseChange
 	var warehouseRemovals []WarehouseChange
 	var warehouseIncreases []WarehouseChange
 	var warehouseDecreases []WarehouseChange
+	var warehouseSettings []WarehouseChange
+	var warehouseAmbiguous []WarehouseChange
+	var smallAdditions []WarehouseChange
 
 	for _, change := range changes {
 		// Check if this change affects the current file
 		if strings.Contains(change.FilePath, filePath) {
+			if change.Ambiguous {
+				warehouseAmbiguous = append(warehouseAmbiguous, change)
+				continue
+			}
+			if change.Setting != "" {
+				warehouseSettings = append(warehouseSettings, change)
+				continue
+			}
+
 			// Categorize ALL warehouse changes (not just size changes to existing)
 			// Note: FromSize can be "N/A" or empty string "" for new warehouses
 			isNewWarehouse := (change.FromSize == "N/A" || change.FromSize == "") && change.ToSize != "N/A" && change.ToSize != ""
 			isRemovedWarehouse := change.FromSize != "N/A" && change.FromSize != "" && (change.ToSize == "N/A" || change.ToSize == "")
 
-			if isNewWarehouse {
+			if isNewWarehouse && r.isWithinNewWarehouseCap(change.ToSize) {
+				// New warehouse small enough to add without review
+				smallAdditions = append(smallAdditions, change)
+			} else if isNewWarehouse {
 				// New warehouse added
 				warehouseAdditions = append(warehouseAdditions, change)
 			} else if isRemovedWarehouse {
@@ -121,7 +165,7 @@ func (r *Rule) ValidateLines(filePath string, fileContent string, lineRanges []s
 	}
 
 	// ALL warehouse changes require manual review - no auto-approval
-	allChanges := len(warehouseAdditions) + len(warehouseRemovals) + len(warehouseIncreases) + len(warehouseDecreases)
+	allChanges := len(warehouseAdditions) + len(warehouseRemovals) + len(warehouseIncreases) + len(warehouseDecreases) + len(warehouseSettings) + len(warehouseAmbiguous)
 	if allChanges > 0 {
 		var details []string
 
@@ -139,7 +183,7 @@ func (r *Rule) ValidateLines(filePath string, fileContent string, lineRanges []s
 
 		// Count the number of different change types present
 		changeTypesPresent := 0
-		for _, changes := range [][]WarehouseChange{warehouseAdditions, warehouseRemovals, warehouseIncreases, warehouseDecreases} {
+		for _, changes := range [][]WarehouseChange{warehouseAdditions, warehouseRemovals, warehouseIncreases, warehouseDecreases, warehouseSettings, warehouseAmbiguous} {
 			if len(changes) > 0 {
 				changeTypesPresent++
 			}
@@ -160,9 +204,43 @@ func (r *Rule) ValidateLines(filePath string, fileContent string, lineRanges []s
 			details = append(details, formatSizeChangeDetail(warehouseType, change.FromSize, change.ToSize, hasMixedChanges, "decreased"))
 		}
 
+		// Report risky setting changes
+		for _, change := range warehouseSettings {
+			warehouseType := r.extractWarehouseType(change.FilePath)
+			details = append(details, fmt.Sprintf("%s warehouse %s: %s → %s", warehouseType, change.Setting, change.FromValue, change.ToValue))
+		}
+
+		// Report warehouses that could not be matched between versions
+		for _, change := range warehouseAmbiguous {
+			warehouseType := r.extractWarehouseType(change.FilePath)
+			details = append(details, fmt.Sprintf("multiple %s warehouses changed (add a unique name to each)", warehouseType))
+		}
+
 		// Sort details for consistent ordering in comments
 		sort.Strings(details)
 
+		// Increases alone are approved once someone with authority over them (a TOC member) has approved the MR
+		if len(warehouseIncreases) > 0 && !hasMixedChanges && r.increaseApprover != nil {
+			approver, applies, err := r.increaseApprover.WarehouseIncreaseApprover(filePath, r.mrCtx)
+			if applies && err != nil {
+				return shared.ManualReview, fmt.Sprintf("Warehouse size increase detected - MR approvals could not be checked: %s", strings.Join(details, ", "))
+			}
+			if applies && approver != "" {
+				return shared.Approve, fmt.Sprintf("Warehouse size increase approved by TOC member @%s: %s", approver, strings.Join(details, ", "))
+			}
+		}
+
+		// Reverts that only restore smaller sizes undo a previously approved increase
+		// and are safe; restoring a larger size is still an increase and needs review
+		if r.isRevertMR() {
+			if len(warehouseDecreases) > 0 && !hasMixedChanges {
+				return shared.Approve, fmt.Sprintf("Revert restores lower warehouse size: %s", strings.Join(details, ", "))
+			}
+			if len(warehouseIncreases) > 0 && !hasMixedChanges {
+				return shared.ManualReview, fmt.Sprintf("Revert restores higher warehouse size - manual review required: %s", strings.Join(details, ", "))
+			}
+		}
+
 		// Use appropriate message format based on change type
 		if hasMixedChanges {
 			// Multiple types of changes - use generic message
@@ -173,15 +251,47 @@ func (r *Rule) ValidateLines(filePath string, fileContent string, lineRanges []s
 		} else if len(warehouseDecreases) > 0 {
 			// Only decreases
 			return shared.ManualReview, fmt.Sprintf("Warehouse size decrease detected: %s", strings.Join(details, ", "))
+		} else if len(warehouseAmbiguous) > 0 {
+			// Only changes to warehouses sharing the same type/name
+			return shared.ManualReview, fmt.Sprintf("Ambiguous warehouse change detected: %s", strings.Join(details, ", "))
+		} else if len(warehouseSettings) > 0 {
+			// Only auto_suspend/auto_resume changes
+			return shared.ManualReview, fmt.Sprintf("Warehouse cost setting change detected: %s", strings.Join(details, ", "))
 		}
 		// Only additions OR only increases - use "increase" message
 		return shared.ManualReview, fmt.Sprintf("Warehouse size increase detected: %s", strings.Join(details, ", "))
 	}
 
+	if len(smallAdditions) > 0 {
+		var details []string
+		for _, change := range smallAdditions {
+			details = append(details, fmt.Sprintf("New %s warehouse: %s", r.extractWarehouseType(change.FilePath), change.ToSize))
+		}
+		sort.Strings(details)
+		return shared.Approve, fmt.Sprintf("New warehouses within the %s size cap: %s", r.maxNewWarehouseSize, strings.Join(details, ", "))
+	}
+
 	// No warehouse changes detected in this file - approve (using old format)
 	return shared.Approve, "No warehouse size changes detected - approved"
 }
 
+// isWithinNewWarehouseCap reports whether a newly added warehouse of the given size can skip manual review
+func (r *Rule) isWithinNewWarehouseCap(size string) bool {
+	capValue, capExists := WarehouseSizes[r.maxNewWarehouseSize]
+	sizeValue, sizeExists := WarehouseSizes[size]
+	return capExists && sizeExists && sizeValue <= capValue
+}
+
+// isRevertMR reports whether the MR reverts an earlier change, based on the
+// title (`Revert "..."`) or the revert-<sha> source branch GitLab creates
+func (r *Rule) isRevertMR() bool {
+	if r.mrCtx == nil || r.mrCtx.MRInfo == nil {
+		return false
+	}
+	return revertTitlePattern.MatchString(r.mrCtx.MRInfo.Title) ||
+		strings.HasPrefix(r.mrCtx.MRInfo.SourceBranch, "revert-")
+}
+
 // isWarehouseFile checks if a file is a warehouse configuration file
 func (r *Rule) isWarehouseFile(path string) bool {
 	if path == "" {
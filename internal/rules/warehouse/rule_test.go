@@ -15,7 +15,7 @@ type MockAnalyzer struct {
 	err     error
 }
 
-func (m *MockAnalyzer) AnalyzeChanges(projectID int, mrIID int, changes []gitlab.FileChange) ([]WarehouseChange, error) {
+func (m *MockAnalyzer) AnalyzeChanges(projectID int, mrIID int, sourceRef string, changes []gitlab.FileChange) ([]WarehouseChange, error) {
 	return m.changes, m.err
 }
 
@@ -151,6 +151,16 @@ func TestWarehouseRule_ValidateLines_WithContext(t *testing.T) {
 			expectedResult:     shared.ManualReview,
 			expectedReasonPart: "Warehouse size decrease detected",
 		},
+		{
+			name:     "ambiguous warehouse match - requires manual review",
+			filePath: "dataproducts/analytics/product.yaml",
+			mockChanges: []WarehouseChange{
+				{FilePath: "dataproducts/analytics/product.yaml (type: user)", Ambiguous: true},
+			},
+			mockError:          nil,
+			expectedResult:     shared.ManualReview,
+			expectedReasonPart: "Ambiguous warehouse change detected: multiple user warehouses changed",
+		},
 		{
 			name:     "non-warehouse changes ignored",
 			filePath: "dataproducts/analytics/product.yaml",
@@ -162,14 +172,14 @@ func TestWarehouseRule_ValidateLines_WithContext(t *testing.T) {
 			expectedReasonPart: "No warehouse size changes detected",
 		},
 		{
-			name:     "new warehouse added",
+			name:     "new warehouse added above size cap",
 			filePath: "dataproducts/analytics/product.yaml",
 			mockChanges: []WarehouseChange{
-				{FilePath: "dataproducts/analytics/product.yaml (type: user)", FromSize: "", ToSize: "SMALL", IsDecrease: false},
+				{FilePath: "dataproducts/analytics/product.yaml (type: user)", FromSize: "", ToSize: "LARGE", IsDecrease: false},
 			},
 			mockError:          nil,
 			expectedResult:     shared.ManualReview,
-			expectedReasonPart: "New user warehouse: SMALL",
+			expectedReasonPart: "New user warehouse: LARGE",
 		},
 		{
 			name:     "mixed warehouse changes - increase and decrease",
@@ -182,6 +192,37 @@ func TestWarehouseRule_ValidateLines_WithContext(t *testing.T) {
 			expectedResult:     shared.ManualReview,
 			expectedReasonPart: "Warehouse changes detected - manual review required",
 		},
+		{
+			name:     "auto_suspend increase - requires manual review",
+			filePath: "dataproducts/analytics/product.yaml",
+			mockChanges: []WarehouseChange{
+				{FilePath: "dataproducts/analytics/product.yaml (type: user)", Setting: "auto_suspend", FromValue: "300s", ToValue: "3600s"},
+			},
+			mockError:          nil,
+			expectedResult:     shared.ManualReview,
+			expectedReasonPart: "Warehouse cost setting change detected: user warehouse auto_suspend: 300s → 3600s",
+		},
+		{
+			name:     "auto_resume disabled - requires manual review",
+			filePath: "dataproducts/analytics/product.yaml",
+			mockChanges: []WarehouseChange{
+				{FilePath: "dataproducts/analytics/product.yaml (type: user)", Setting: "auto_resume", FromValue: "true", ToValue: "false"},
+			},
+			mockError:          nil,
+			expectedResult:     shared.ManualReview,
+			expectedReasonPart: "user warehouse auto_resume: true → false",
+		},
+		{
+			name:     "size decrease with auto_suspend increase",
+			filePath: "dataproducts/analytics/product.yaml",
+			mockChanges: []WarehouseChange{
+				{FilePath: "dataproducts/analytics/product.yaml (type: user)", FromSize: "SMALL", ToSize: "XSMALL", IsDecrease: true},
+				{FilePath: "dataproducts/analytics/product.yaml (type: user)", Setting: "auto_suspend", FromValue: "300s", ToValue: "never"},
+			},
+			mockError:          nil,
+			expectedResult:     shared.ManualReview,
+			expectedReasonPart: "Warehouse changes detected - manual review required",
+		},
 		{
 			name:               "analyzer error",
 			filePath:           "dataproducts/analytics/product.yaml",
@@ -223,6 +264,292 @@ func TestWarehouseRule_ValidateLines_WithContext(t *testing.T) {
 	}
 }
 
+func TestWarehouseRule_isRevertMR(t *testing.T) {
+	tests := []struct {
+		name         string
+		title        string
+		sourceBranch string
+		expected     bool
+	}{
+		{"git revert title", `Revert "Increase analytics warehouse"`, "feature/x", true},
+		{"lowercase revert title", "revert warehouse resize", "feature/x", true},
+		{"gitlab revert branch", "Restore previous sizing", "revert-1a2b3c4d", true},
+		{"regular title", "Increase analytics warehouse", "feature/x", false},
+		{"revert not at start", "Fix: do not revert sizing", "feature/x", false},
+		{"word starting with revert", "Reverted sizing docs", "feature/x", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := NewRule(nil)
+			rule.SetMRContext(&shared.MRContext{
+				MRInfo: &gitlab.MRInfo{Title: tt.title, SourceBranch: tt.sourceBranch},
+			})
+			assert.Equal(t, tt.expected, rule.isRevertMR())
+		})
+	}
+
+	t.Run("no MR info", func(t *testing.T) {
+		rule := NewRule(nil)
+		rule.SetMRContext(&shared.MRContext{})
+		assert.False(t, rule.isRevertMR())
+	})
+}
+
+func TestWarehouseRule_ValidateLines_RevertMR(t *testing.T) {
+	filePath := "dataproducts/analytics/product.yaml"
+
+	tests := []struct {
+		name               string
+		title              string
+		mockChanges        []WarehouseChange
+		expectedResult     shared.DecisionType
+		expectedReasonPart string
+	}{
+		{
+			name:  "revert restoring lower size is approved",
+			title: `Revert "Increase analytics warehouse"`,
+			mockChanges: []WarehouseChange{
+				{FilePath: filePath + " (type: user)", FromSize: "LARGE", ToSize: "SMALL", IsDecrease: true},
+			},
+			expectedResult:     shared.Approve,
+			expectedReasonPart: "Revert restores lower warehouse size: user warehouse: LARGE → SMALL",
+		},
+		{
+			name:  "revert restoring higher size requires review",
+			title: `Revert "Decrease analytics warehouse"`,
+			mockChanges: []WarehouseChange{
+				{FilePath: filePath + " (type: user)", FromSize: "SMALL", ToSize: "LARGE", IsDecrease: false},
+			},
+			expectedResult:     shared.ManualReview,
+			expectedReasonPart: "Revert restores higher warehouse size",
+		},
+		{
+			name:  "revert with mixed changes requires review",
+			title: `Revert "Resize warehouses"`,
+			mockChanges: []WarehouseChange{
+				{FilePath: filePath + " (type: user)", FromSize: "LARGE", ToSize: "SMALL", IsDecrease: true},
+				{FilePath: filePath + " (type: loader)", FromSize: "SMALL", ToSize: "MEDIUM", IsDecrease: false},
+			},
+			expectedResult:     shared.ManualReview,
+			expectedReasonPart: "Warehouse changes detected - manual review required",
+		},
+		{
+			name:  "revert adding a warehouse requires review",
+			title: `Revert "Remove loader warehouse"`,
+			mockChanges: []WarehouseChange{
+				{FilePath: filePath + " (type: loader)", FromSize: "", ToSize: "XLARGE", IsDecrease: false},
+			},
+			expectedResult:     shared.ManualReview,
+			expectedReasonPart: "New loader warehouse: XLARGE",
+		},
+		{
+			name:  "non-revert decrease still requires review",
+			title: "Shrink analytics warehouse",
+			mockChanges: []WarehouseChange{
+				{FilePath: filePath + " (type: user)", FromSize: "LARGE", ToSize: "SMALL", IsDecrease: true},
+			},
+			expectedResult:     shared.ManualReview,
+			expectedReasonPart: "Warehouse size decrease detected",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := NewRule(nil)
+			rule.analyzer = &MockAnalyzer{changes: tt.mockChanges}
+			rule.SetMRContext(&shared.MRContext{
+				ProjectID: 123,
+				MRIID:     456,
+				Changes:   []gitlab.FileChange{{NewPath: filePath}},
+				MRInfo:    &gitlab.MRInfo{Title: tt.title, SourceBranch: "feature/x"},
+			})
+
+			lineRanges := []shared.LineRange{{StartLine: 1, EndLine: 4, FilePath: filePath}}
+			decision, reason := rule.ValidateLines(filePath, "test content", lineRanges)
+
+			assert.Equal(t, tt.expectedResult, decision)
+			assert.Contains(t, reason, tt.expectedReasonPart)
+		})
+	}
+}
+
+func TestWarehouseRule_ValidateLines_NewWarehouseSizeCap(t *testing.T) {
+	filePath := "dataproducts/analytics/product.yaml"
+
+	tests := []struct {
+		name               string
+		maxNewSize         string
+		mockChanges        []WarehouseChange
+		expectedResult     shared.DecisionType
+		expectedReasonPart string
+	}{
+		{
+			name:       "small new warehouse is approved",
+			maxNewSize: DefaultMaxNewWarehouseSize,
+			mockChanges: []WarehouseChange{
+				{FilePath: filePath + " (type: user)", FromSize: "", ToSize: "XSMALL"},
+			},
+			expectedResult:     shared.Approve,
+			expectedReasonPart: "New warehouses within the MEDIUM size cap: New user warehouse: XSMALL",
+		},
+		{
+			name:       "new warehouse at the cap is approved",
+			maxNewSize: DefaultMaxNewWarehouseSize,
+			mockChanges: []WarehouseChange{
+				{FilePath: filePath + " (type: user)", FromSize: "", ToSize: "MEDIUM"},
+			},
+			expectedResult:     shared.Approve,
+			expectedReasonPart: "New user warehouse: MEDIUM",
+		},
+		{
+			name:       "new warehouse one size above the cap requires review",
+			maxNewSize: DefaultMaxNewWarehouseSize,
+			mockChanges: []WarehouseChange{
+				{FilePath: filePath + " (type: user)", FromSize: "", ToSize: "LARGE"},
+			},
+			expectedResult:     shared.ManualReview,
+			expectedReasonPart: "Warehouse size increase detected: New user warehouse: LARGE",
+		},
+		{
+			name:       "large new warehouse requires review",
+			maxNewSize: DefaultMaxNewWarehouseSize,
+			mockChanges: []WarehouseChange{
+				{FilePath: filePath + " (type: user)", FromSize: "", ToSize: "X6LARGE"},
+			},
+			expectedResult:     shared.ManualReview,
+			expectedReasonPart: "New user warehouse: X6LARGE",
+		},
+		{
+			name:       "small new warehouse alongside an increase requires review",
+			maxNewSize: DefaultMaxNewWarehouseSize,
+			mockChanges: []WarehouseChange{
+				{FilePath: filePath + " (type: user)", FromSize: "", ToSize: "SMALL"},
+				{FilePath: filePath + " (type: loader)", FromSize: "SMALL", ToSize: "LARGE"},
+			},
+			expectedResult:     shared.ManualReview,
+			expectedReasonPart: "loader warehouse: SMALL → LARGE",
+		},
+		{
+			name:       "lower cap from config",
+			maxNewSize: "xsmall",
+			mockChanges: []WarehouseChange{
+				{FilePath: filePath + " (type: user)", FromSize: "", ToSize: "SMALL"},
+			},
+			expectedResult:     shared.ManualReview,
+			expectedReasonPart: "New user warehouse: SMALL",
+		},
+		{
+			name:       "unknown cap requires review for every new warehouse",
+			maxNewSize: "HUGE",
+			mockChanges: []WarehouseChange{
+				{FilePath: filePath + " (type: user)", FromSize: "", ToSize: "XSMALL"},
+			},
+			expectedResult:     shared.ManualReview,
+			expectedReasonPart: "New user warehouse: XSMALL",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := NewRuleWithLimits(nil, DefaultMaxAutoSuspendSeconds, tt.maxNewSize)
+			rule.analyzer = &MockAnalyzer{changes: tt.mockChanges}
+			rule.SetMRContext(&shared.MRContext{
+				ProjectID: 123,
+				MRIID:     456,
+				Changes:   []gitlab.FileChange{{NewPath: filePath}},
+			})
+
+			lineRanges := []shared.LineRange{{StartLine: 1, EndLine: 4, FilePath: filePath}}
+			decision, reason := rule.ValidateLines(filePath, "test content", lineRanges)
+
+			assert.Equal(t, tt.expectedResult, decision)
+			assert.Contains(t, reason, tt.expectedReasonPart)
+		})
+	}
+}
+
+// stubIncreaseApprover returns a fixed increase approver
+type stubIncreaseApprover struct {
+	approver string
+	applies  bool
+	err      error
+}
+
+func (s *stubIncreaseApprover) WarehouseIncreaseApprover(filePath string, mrCtx *shared.MRContext) (string, bool, error) {
+	return s.approver, s.applies, s.err
+}
+
+func TestWarehouseRule_ValidateLines_IncreaseApprover(t *testing.T) {
+	filePath := "dataproducts/analytics/prod/product.yaml"
+	increase := WarehouseChange{FilePath: filePath + " (type: user)", FromSize: "SMALL", ToSize: "LARGE"}
+
+	tests := []struct {
+		name               string
+		approver           *stubIncreaseApprover
+		mockChanges        []WarehouseChange
+		expectedResult     shared.DecisionType
+		expectedReasonPart string
+	}{
+		{
+			name:               "increase approved by TOC member",
+			approver:           &stubIncreaseApprover{approver: "toc-lead", applies: true},
+			mockChanges:        []WarehouseChange{increase},
+			expectedResult:     shared.Approve,
+			expectedReasonPart: "Warehouse size increase approved by TOC member @toc-lead: user warehouse: SMALL → LARGE",
+		},
+		{
+			name:               "increase without TOC approval",
+			approver:           &stubIncreaseApprover{applies: true},
+			mockChanges:        []WarehouseChange{increase},
+			expectedResult:     shared.ManualReview,
+			expectedReasonPart: "Warehouse size increase detected",
+		},
+		{
+			name:               "approvals lookup failure",
+			approver:           &stubIncreaseApprover{applies: true, err: errors.New("gitlab API error 500")},
+			mockChanges:        []WarehouseChange{increase},
+			expectedResult:     shared.ManualReview,
+			expectedReasonPart: "MR approvals could not be checked",
+		},
+		{
+			name:               "environment outside the TOC check",
+			approver:           &stubIncreaseApprover{approver: "toc-lead"},
+			mockChanges:        []WarehouseChange{increase},
+			expectedResult:     shared.ManualReview,
+			expectedReasonPart: "Warehouse size increase detected",
+		},
+		{
+			name:     "increase mixed with a removal",
+			approver: &stubIncreaseApprover{approver: "toc-lead", applies: true},
+			mockChanges: []WarehouseChange{
+				increase,
+				{FilePath: filePath + " (type: loader)", FromSize: "SMALL", ToSize: "N/A"},
+			},
+			expectedResult:     shared.ManualReview,
+			expectedReasonPart: "Warehouse changes detected - manual review required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := NewRule(nil)
+			rule.analyzer = &MockAnalyzer{changes: tt.mockChanges}
+			rule.SetIncreaseApprover(tt.approver)
+			rule.SetMRContext(&shared.MRContext{
+				ProjectID: 123,
+				MRIID:     456,
+				Changes:   []gitlab.FileChange{{NewPath: filePath}},
+			})
+
+			decision, reason := rule.ValidateLines(filePath, "test content", nil)
+
+			assert.Equal(t, tt.expectedResult, decision)
+			assert.Contains(t, reason, tt.expectedReasonPart)
+		})
+	}
+}
+
 func TestWarehouseRule_SetMRContext(t *testing.T) {
 	rule := NewRule(nil)
 
@@ -1,13 +1,23 @@
 package warehouse
 
-// WarehouseChange represents a detected warehouse size change
+// WarehouseChange represents a detected warehouse size or setting change
 type WarehouseChange struct {
 	FilePath   string
 	FromSize   string
 	ToSize     string
 	IsDecrease bool
+	Setting    string // Risky setting change (auto_suspend, auto_resume); empty for size changes
+	FromValue  string // Previous setting value
+	ToValue    string // New setting value
+	Ambiguous  bool   // Several warehouses share the same type/name key and cannot be matched
 }
 
+// DefaultMaxAutoSuspendSeconds is the highest auto_suspend value allowed without manual review
+const DefaultMaxAutoSuspendSeconds = 600
+
+// DefaultMaxNewWarehouseSize is the largest size a newly added warehouse may have without manual review
+const DefaultMaxNewWarehouseSize = "MEDIUM"
+
 // ValidationResult represents warehouse validation outcome
 type ValidationResult struct {
 	IsValid          bool
@@ -1,8 +1,13 @@
 package rules
 
 import (
+	"errors"
 	"fmt"
+	"io"
+	"regexp"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/redhat-data-and-ai/naysayer/internal/config"
 	"github.com/redhat-data-and-ai/naysayer/internal/logging"
@@ -10,10 +15,26 @@ import (
 	"gopkg.in/yaml.v3"
 )
 
+// documentMarkerPattern matches a `---` line that starts a YAML document
+var documentMarkerPattern = regexp.MustCompile(`^---(\s|$)`)
+
+// MissingSectionsError reports required sections that were not found in a file
+type MissingSectionsError struct {
+	Sections []string // Names of the missing required sections, sorted
+}
+
+func (e *MissingSectionsError) Error() string {
+	if len(e.Sections) == 1 {
+		return fmt.Sprintf("required section %s not found", e.Sections[0])
+	}
+	return fmt.Sprintf("required sections %s not found", strings.Join(e.Sections, ", "))
+}
+
 // YAMLSectionParser parses YAML files into logical sections
 type YAMLSectionParser struct {
 	sectionDefinitions map[string]config.SectionDefinition
 	filePath           string
+	multiDocument      bool // Extract sections from every `---` separated document, not just the first
 }
 
 // NewYAMLSectionParser creates a new YAML section parser
@@ -23,41 +44,118 @@ func NewYAMLSectionParser(definitions map[string]config.SectionDefinition) *YAML
 	}
 }
 
-// ParseSections extracts sections from YAML content based on definitions
+// NewMultiDocumentYAMLSectionParser creates a YAML section parser that extracts sections from
+// every document of a multi-document file, so later documents are covered too
+func NewMultiDocumentYAMLSectionParser(definitions map[string]config.SectionDefinition) *YAMLSectionParser {
+	parser := NewYAMLSectionParser(definitions)
+	parser.multiDocument = true
+	return parser
+}
+
+// ParseSections extracts sections from YAML content based on definitions.
+// A multi-document parser yields a section per document that contains it, keeping the
+// definition's name; a required section must then appear in at least one document.
 func (p *YAMLSectionParser) ParseSections(filePath string, content string) ([]shared.Section, error) {
 	p.filePath = filePath
+	contentLines := strings.Split(content, "\n")
 
-	// Parse YAML with line number tracking
-	var yamlNode yaml.Node
-	if err := yaml.Unmarshal([]byte(content), &yamlNode); err != nil {
-		return nil, fmt.Errorf("failed to parse YAML: %w", err)
+	documents, err := p.parseDocuments(content)
+	if err != nil {
+		return nil, err
 	}
+	documentLines := p.documentLineRanges(documents, contentLines)
 
 	var sections []shared.Section
-	contentLines := strings.Split(content, "\n")
+	var missing []string
 
 	// Extract sections based on definitions
 	for _, definition := range p.sectionDefinitions {
-
-		section, err := p.extractSection(definition, &yamlNode, contentLines)
-		if err != nil {
-			if definition.Required {
-				return nil, fmt.Errorf("required section %s not found: %w", definition.Name, err)
+		found := false
+		for i, document := range documents {
+			section, err := p.extractSection(definition, document, contentLines, documentLines[i])
+			if err != nil {
+				continue
+			}
+			if section != nil {
+				sections = append(sections, *section)
+				found = true
 			}
-			// Optional section not found - continue
-			continue
 		}
 
-		if section != nil {
-			sections = append(sections, *section)
+		if !found && definition.Required {
+			missing = append(missing, definition.Name)
 		}
+		// Optional section not found - continue
 	}
 
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return nil, &MissingSectionsError{Sections: missing}
+	}
 	return sections, nil
 }
 
-// extractSection extracts a specific section from the YAML node
-func (p *YAMLSectionParser) extractSection(definition config.SectionDefinition, rootNode *yaml.Node, contentLines []string) (*shared.Section, error) {
+// parseDocuments parses the YAML documents to extract sections from: only the first one
+// unless the parser is multi-document
+func (p *YAMLSectionParser) parseDocuments(content string) ([]*yaml.Node, error) {
+	if !p.multiDocument {
+		// Parse YAML with line number tracking
+		var yamlNode yaml.Node
+		if err := yaml.Unmarshal([]byte(content), &yamlNode); err != nil {
+			return nil, fmt.Errorf("failed to parse YAML: %w", err)
+		}
+		return []*yaml.Node{&yamlNode}, nil
+	}
+
+	// Node line numbers from the decoder are relative to the whole file
+	var documents []*yaml.Node
+	decoder := yaml.NewDecoder(strings.NewReader(content))
+	for {
+		var document yaml.Node
+		err := decoder.Decode(&document)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse YAML document %d: %w", len(documents)+1, err)
+		}
+		documents = append(documents, &document)
+	}
+
+	if len(documents) == 0 {
+		documents = append(documents, &yaml.Node{})
+	}
+	return documents, nil
+}
+
+// documentLineRanges returns the lines each document spans: from its `---` marker (or the
+// start of the file) up to the line before the next document's marker (or the end of the file)
+func (p *YAMLSectionParser) documentLineRanges(documents []*yaml.Node, contentLines []string) []shared.LineRange {
+	starts := make([]int, len(documents))
+	starts[0] = 1
+	for i := 1; i < len(documents); i++ {
+		starts[i] = documents[i].Line
+		for line := documents[i].Line; line > starts[i-1]; line-- {
+			if documentMarkerPattern.MatchString(contentLines[line-1]) {
+				starts[i] = line
+				break
+			}
+		}
+	}
+
+	ranges := make([]shared.LineRange, len(documents))
+	for i := range documents {
+		endLine := len(contentLines)
+		if i+1 < len(documents) {
+			endLine = starts[i+1] - 1
+		}
+		ranges[i] = shared.LineRange{StartLine: starts[i], EndLine: endLine, FilePath: p.filePath}
+	}
+	return ranges
+}
+
+// extractSection extracts a specific section from the YAML node of a document spanning documentLines
+func (p *YAMLSectionParser) extractSection(definition config.SectionDefinition, rootNode *yaml.Node, contentLines []string, documentLines shared.LineRange) (*shared.Section, error) {
 	// Navigate to the YAML path
 	node, err := p.navigateYAMLPath(rootNode, definition.YAMLPath)
 	if err != nil {
@@ -69,24 +167,17 @@ func (p *YAMLSectionParser) extractSection(definition config.SectionDefinition,
 	}
 
 	// Calculate line range for this section
-	startLine, endLine := p.calculateSectionLines(node, contentLines, definition.YAMLPath)
+	startLine, endLine := p.calculateSectionLines(node, contentLines, definition.YAMLPath, documentLines)
 
 	// Extract section content
 	sectionContent := p.extractSectionContent(contentLines, startLine, endLine)
 
-	// Parse fields from the node
-	fields, err := p.parseNodeToMap(node)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse section fields: %w", err)
-	}
-
 	section := &shared.Section{
 		Name:        definition.Name,
 		StartLine:   startLine,
 		EndLine:     endLine,
 		Content:     sectionContent,
 		Type:        shared.YAMLSection,
-		Fields:      fields,
 		FilePath:    p.filePath,
 		YAMLPath:    definition.YAMLPath,
 		Required:    definition.Required,
@@ -94,6 +185,19 @@ func (p *YAMLSectionParser) extractSection(definition config.SectionDefinition,
 		AutoApprove: definition.AutoApprove,
 	}
 
+	// Raw sections are free-form text for their rules; only structured sections are decoded
+	if definition.IsRaw() {
+		section.Type = shared.TextSection
+		return section, nil
+	}
+
+	// Parse fields from the node
+	fields, err := p.parseNodeToMap(node)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse section fields: %w", err)
+	}
+	section.Fields = fields
+
 	return section, nil
 }
 
@@ -152,19 +256,19 @@ func (p *YAMLSectionParser) findChildNode(parent *yaml.Node, key string) (*yaml.
 }
 
 // calculateSectionLines determines the start and end lines for a section
-func (p *YAMLSectionParser) calculateSectionLines(node *yaml.Node, contentLines []string, yamlPath string) (int, int) {
+func (p *YAMLSectionParser) calculateSectionLines(node *yaml.Node, contentLines []string, yamlPath string, documentLines shared.LineRange) (int, int) {
 	startLine := node.Line
 	endLine := node.Line
 
-	// For complex nodes, calculate the end line by traversing all content
-	if node.Kind == yaml.MappingNode || node.Kind == yaml.SequenceNode {
-		endLine = p.calculateEndLine(node)
+	// For complex nodes and block scalars, calculate the end line by traversing all content
+	if node.Kind == yaml.MappingNode || node.Kind == yaml.SequenceNode || node.Kind == yaml.ScalarNode {
+		endLine = p.calculateEndLine(node, contentLines)
 	}
 
-	// Special handling for root path ("."): should cover entire file from line 1
+	// Special handling for root path ("."): should cover the entire document (the whole file for single-document parsing)
 	if yamlPath == "." {
-		startLine = 1
-		endLine = len(contentLines)
+		startLine = documentLines.StartLine
+		endLine = documentLines.EndLine
 	}
 
 	// Ensure we don't go beyond the file bounds
@@ -176,17 +280,16 @@ func (p *YAMLSectionParser) calculateSectionLines(node *yaml.Node, contentLines
 }
 
 // calculateEndLine recursively calculates the last line of a YAML node
-func (p *YAMLSectionParser) calculateEndLine(node *yaml.Node) int {
+func (p *YAMLSectionParser) calculateEndLine(node *yaml.Node, contentLines []string) int {
 	maxLine := node.Line
 
-	// Also check the Column to get a more accurate end position
-	// For scalar nodes, use the line they're on
-	if node.Kind == yaml.ScalarNode {
-		maxLine = node.Line
+	// Scalars end on their own line, except block scalars (| and >), whose text follows the indicator
+	if node.Kind == yaml.ScalarNode && node.Style&(yaml.LiteralStyle|yaml.FoldedStyle) != 0 {
+		maxLine = blockScalarEndLine(node.Line, contentLines)
 	}
 
 	for _, child := range node.Content {
-		childEndLine := p.calculateEndLine(child)
+		childEndLine := p.calculateEndLine(child, contentLines)
 		if childEndLine > maxLine {
 			maxLine = childEndLine
 		}
@@ -195,6 +298,34 @@ func (p *YAMLSectionParser) calculateEndLine(node *yaml.Node) int {
 	return maxLine
 }
 
+// blockScalarEndLine returns the last line of a block scalar whose indicator is on indicatorLine:
+// the text runs while lines are blank or indented deeper than the indicator line, and trailing
+// blank lines are not part of it
+func blockScalarEndLine(indicatorLine int, contentLines []string) int {
+	if indicatorLine < 1 || indicatorLine > len(contentLines) {
+		return indicatorLine
+	}
+	indent := lineIndent(contentLines[indicatorLine-1])
+
+	endLine := indicatorLine
+	for i := indicatorLine; i < len(contentLines); i++ {
+		line := contentLines[i]
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		if lineIndent(line) <= indent {
+			break
+		}
+		endLine = i + 1
+	}
+	return endLine
+}
+
+// lineIndent returns the number of leading spaces of a line
+func lineIndent(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " "))
+}
+
 // extractSectionContent extracts the text content for a section
 func (p *YAMLSectionParser) extractSectionContent(contentLines []string, startLine, endLine int) string {
 	if startLine < 1 || endLine < startLine || startLine > len(contentLines) {
@@ -268,6 +399,8 @@ func (p *YAMLSectionParser) ValidateSection(section *shared.Section, rules []sha
 	if hasRules {
 		// Apply each rule to the section
 		for _, rule := range rules {
+			start := time.Now()
+
 			// Check if this rule applies to this section
 			coveredLines := rule.GetCoveredLines(section.FilePath, section.Content)
 			if len(coveredLines) == 0 {
@@ -275,7 +408,15 @@ func (p *YAMLSectionParser) ValidateSection(section *shared.Section, rules []sha
 			}
 
 			// Validate using the rule
-			decision, reason := rule.ValidateLines(section.FilePath, section.Content, lineRanges)
+			var decision shared.DecisionType
+			var reason string
+			if sectionRule, ok := rule.(shared.SectionAwareRule); ok {
+				decision, reason = sectionRule.ValidateSectionLines(section, lineRanges)
+			} else {
+				decision, reason = rule.ValidateLines(section.FilePath, section.Content, lineRanges)
+			}
+			duration := time.Since(start)
+			advisory := decision == shared.ManualReview && isAdvisoryRule(section.RuleConfigs, rule.Name())
 
 			result.AppliedRules = append(result.AppliedRules, rule.Name())
 			result.RuleResults = append(result.RuleResults, shared.LineValidationResult{
@@ -284,8 +425,16 @@ func (p *YAMLSectionParser) ValidateSection(section *shared.Section, rules []sha
 				Decision:     decision,
 				Reason:       reason,
 				WasEvaluated: true, // Mark that this rule actually executed
+				Advisory:     advisory,
+				Duration:     duration,
 			})
 
+			// Advisory failures are reported but don't fail the section
+			if advisory {
+				logging.Info("Advisory rule %s flagged section '%s' at %s: %s", rule.Name(), section.Name, section.FilePath, reason)
+				continue
+			}
+
 			lastRuleReason = reason
 
 			// If any rule requires manual review, rules failed
@@ -323,6 +472,10 @@ func (p *YAMLSectionParser) ValidateSection(section *shared.Section, rules []sha
 			result.Reason = fmt.Sprintf("Auto-approved: %s (no applicable rules)", section.Name)
 			logging.Info("AUTO_APPROVE_AUDIT: Section '%s' at %s:%d-%d auto-approved (no applicable rules)",
 				section.Name, section.FilePath, section.StartLine, section.EndLine)
+		} else if lastRuleReason == "" {
+			result.Reason = fmt.Sprintf("Auto-approved: %s (advisory warnings only)", section.Name)
+			logging.Info("AUTO_APPROVE_AUDIT: Section '%s' at %s:%d-%d auto-approved (advisory rules: %v)",
+				section.Name, section.FilePath, section.StartLine, section.EndLine, result.AppliedRules)
 		} else {
 			result.Reason = fmt.Sprintf("Auto-approved: %s (validation passed)", lastRuleReason)
 			logging.Info("AUTO_APPROVE_AUDIT: Section '%s' at %s:%d-%d auto-approved (rules: %v passed)",
@@ -337,12 +490,24 @@ func (p *YAMLSectionParser) ValidateSection(section *shared.Section, rules []sha
 		result.Reason = fmt.Sprintf("No validation rules configured for %s - manual review required", section.Name)
 	} else if rulesPassed {
 		result.Decision = shared.Approve
-		result.Reason = lastRuleReason
+		if lastRuleReason != "" {
+			result.Reason = lastRuleReason
+		}
 	}
 
 	return result
 }
 
+// isAdvisoryRule reports whether ruleName is configured with advisory severity for the section
+func isAdvisoryRule(ruleConfigs []config.RuleConfig, ruleName string) bool {
+	for _, rc := range ruleConfigs {
+		if rc.Name == ruleName {
+			return rc.IsAdvisory()
+		}
+	}
+	return false
+}
+
 // GetSectionDefinitions returns the section definitions for this parser
 func (p *YAMLSectionParser) GetSectionDefinitions() map[string]config.SectionDefinition {
 	return p.sectionDefinitions
@@ -1,11 +1,16 @@
 package rules
 
 import (
+	"fmt"
+	"sort"
 	"testing"
+	"time"
 
 	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
 	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // AutoApproveMockRule for testing auto-approve functionality
@@ -264,6 +269,39 @@ func TestYAMLSectionParser_ValidateSection_AuditLogging(t *testing.T) {
 	// For now, we just verify the decision logic works correctly
 }
 
+// slowMockRule approves after a fixed delay
+type slowMockRule struct {
+	AutoApproveMockRule
+	delay time.Duration
+}
+
+func (m *slowMockRule) ValidateLines(filePath string, fileContent string, lineRanges []shared.LineRange) (shared.DecisionType, string) {
+	time.Sleep(m.delay)
+	return m.decision, m.reason
+}
+
+func TestYAMLSectionParser_ValidateSection_RuleDurations(t *testing.T) {
+	section := &shared.Section{
+		Name:      "warehouses",
+		StartLine: 1,
+		EndLine:   3,
+		Content:   "warehouses:\n- type: user\n  size: SMALL",
+		FilePath:  "dataproducts/source/sales/prod/product.yaml",
+	}
+	rules := []shared.Rule{
+		&AutoApproveMockRule{name: "fast_rule", decision: shared.Approve, reason: "ok"},
+		&slowMockRule{AutoApproveMockRule: AutoApproveMockRule{name: "slow_rule", decision: shared.Approve, reason: "ok"}, delay: 5 * time.Millisecond},
+	}
+
+	result := NewYAMLSectionParser(map[string]config.SectionDefinition{}).ValidateSection(section, rules)
+
+	require.Len(t, result.RuleResults, 2)
+	assert.Equal(t, "fast_rule", result.RuleResults[0].RuleName)
+	assert.GreaterOrEqual(t, result.RuleResults[0].Duration, time.Duration(0))
+	assert.Equal(t, "slow_rule", result.RuleResults[1].RuleName)
+	assert.GreaterOrEqual(t, result.RuleResults[1].Duration, 5*time.Millisecond)
+}
+
 func TestAutoApproveConfiguration_Integration(t *testing.T) {
 	// Integration test to verify the complete auto-approve flow
 	yamlContent := `
@@ -335,3 +373,215 @@ changelog:
 	assert.Equal(t, 3, autoApprovedCount, "Expected 3 auto-approved sections (description, documentation_url, changelog)")
 	assert.Equal(t, 1, manualReviewCount, "Expected 1 manual review section (warehouses)")
 }
+
+const twoDocumentYAML = `name: first
+metadata:
+  owner: team-a
+warehouses:
+  - type: user
+    size: XSMALL
+---
+# Second service account
+name: second
+metadata:
+  owner: team-b
+warehouses:
+  - type: user
+    size: SMALL
+`
+
+func twoDocumentDefinitions() map[string]config.SectionDefinition {
+	return map[string]config.SectionDefinition{
+		"metadata":   {Name: "metadata", YAMLPath: "metadata", Required: true, AutoApprove: true},
+		"warehouses": {Name: "warehouses", YAMLPath: "warehouses"},
+		"full_file":  {Name: "full_file", YAMLPath: "."},
+	}
+}
+
+// sectionLines returns "start-end" for each section with the given name, in line order
+func sectionLines(sections []shared.Section, name string) []string {
+	sorted := append([]shared.Section(nil), sections...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].StartLine < sorted[j].StartLine })
+
+	var lines []string
+	for _, section := range sorted {
+		if section.Name == name {
+			lines = append(lines, fmt.Sprintf("%d-%d", section.StartLine, section.EndLine))
+		}
+	}
+	return lines
+}
+
+func TestYAMLSectionParser_MultiDocument(t *testing.T) {
+	parser := NewMultiDocumentYAMLSectionParser(twoDocumentDefinitions())
+
+	sections, err := parser.ParseSections("serviceaccounts/team_appuser.yaml", twoDocumentYAML)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"3-3", "11-11"}, sectionLines(sections, "metadata"))
+	assert.Equal(t, []string{"5-6", "13-14"}, sectionLines(sections, "warehouses"))
+	assert.Equal(t, []string{"1-6", "7-15"}, sectionLines(sections, "full_file"), "root path covers each document")
+
+	for _, section := range sections {
+		if section.Name == "metadata" && section.StartLine == 11 {
+			assert.Equal(t, "team-b", section.Fields["owner"])
+			assert.Equal(t, "  owner: team-b", section.Content)
+		}
+	}
+}
+
+func TestYAMLSectionParser_SingleDocumentIgnoresLaterDocuments(t *testing.T) {
+	parser := NewYAMLSectionParser(twoDocumentDefinitions())
+
+	sections, err := parser.ParseSections("serviceaccounts/team_appuser.yaml", twoDocumentYAML)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"3-3"}, sectionLines(sections, "metadata"))
+	assert.Equal(t, []string{"5-6"}, sectionLines(sections, "warehouses"))
+}
+
+func TestYAMLSectionParser_MultiDocumentRequiredSection(t *testing.T) {
+	definitions := map[string]config.SectionDefinition{
+		"owners": {Name: "owners", YAMLPath: "owners", Required: true},
+	}
+
+	_, err := NewMultiDocumentYAMLSectionParser(definitions).ParseSections("a.yaml", twoDocumentYAML)
+	assert.ErrorContains(t, err, "required section owners not found")
+
+	sections, err := NewMultiDocumentYAMLSectionParser(definitions).ParseSections("a.yaml", "name: a\n---\nowners:\n  - alice\n")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"4-4"}, sectionLines(sections, "owners"), "found in the second document only")
+}
+
+func TestSectionRuleManager_MultiDocumentFileFullyCovered(t *testing.T) {
+	ruleConfig := &config.GlobalRuleConfig{
+		Enabled: true,
+		Files: []config.FileRuleConfig{{
+			Name:          "service_accounts",
+			Path:          "serviceaccounts/",
+			Filename:      "*.yaml",
+			ParserType:    "yaml",
+			Enabled:       true,
+			MultiDocument: true,
+			Sections: []config.SectionDefinition{
+				{Name: "name", YAMLPath: "name", AutoApprove: true},
+				{Name: "metadata", YAMLPath: "metadata", AutoApprove: true},
+				{Name: "warehouses", YAMLPath: "warehouses", AutoApprove: true},
+			},
+		}},
+	}
+	client := &ignoreTestGitLabClient{
+		forkMRTestGitLabClient: &forkMRTestGitLabClient{},
+		files:                  map[string]string{"serviceaccounts/team_appuser.yaml": twoDocumentYAML},
+	}
+	manager := NewSectionRuleManager(ruleConfig, client)
+
+	result := manager.EvaluateAll(&shared.MRContext{
+		ProjectID: 123,
+		MRIID:     1,
+		Changes:   []gitlab.FileChange{{NewPath: "serviceaccounts/team_appuser.yaml", Diff: "@@ -13,2 +13,2 @@\n-    size: XSMALL\n+    size: SMALL"}},
+		MRInfo:    &gitlab.MRInfo{Author: "developer", SourceBranch: "feature"},
+	})
+
+	validation := result.FileValidations["serviceaccounts/team_appuser.yaml"]
+	require.NotNil(t, validation)
+	assert.Empty(t, validation.UncoveredLines, "second document's warehouses are covered")
+	assert.Equal(t, shared.Approve, validation.FileDecision)
+}
+
+const mixedTreatmentYAML = `name: marketing
+warehouses:
+  - type: user
+    size: XSMALL
+notes: |
+  Owned by the marketing team.
+  key: value lines here are prose, not YAML
+`
+
+func mixedTreatmentDefinitions() map[string]config.SectionDefinition {
+	return map[string]config.SectionDefinition{
+		"warehouses": {Name: "warehouses", YAMLPath: "warehouses"},
+		"notes":      {Name: "notes", YAMLPath: "notes", Treatment: "raw"},
+	}
+}
+
+func TestYAMLSectionParser_RawTreatment(t *testing.T) {
+	sections, err := NewYAMLSectionParser(mixedTreatmentDefinitions()).ParseSections("product.yaml", mixedTreatmentYAML)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"5-7"}, sectionLines(sections, "notes"), "a block scalar spans its text, not just the indicator line")
+
+	byName := make(map[string]shared.Section)
+	for _, section := range sections {
+		byName[section.Name] = section
+	}
+
+	warehouses := byName["warehouses"]
+	assert.Equal(t, shared.YAMLSection, warehouses.Type)
+	assert.NotEmpty(t, warehouses.Fields, "structured sections are decoded")
+
+	notes := byName["notes"]
+	assert.Equal(t, shared.TextSection, notes.Type)
+	assert.Nil(t, notes.Fields, "raw sections are passed through undecoded")
+	assert.Contains(t, notes.Content, "key: value lines here are prose")
+}
+
+// sectionTypeMockRule approves sections and records the type each section was handed over as
+type sectionTypeMockRule struct {
+	AutoApproveMockRule
+	seen map[string]shared.SectionType
+}
+
+func (m *sectionTypeMockRule) ValidateSectionLines(section *shared.Section, lineRanges []shared.LineRange) (shared.DecisionType, string) {
+	m.seen[section.Name] = section.Type
+	return shared.Approve, "recorded"
+}
+
+func TestSectionRuleManager_MixedSectionTreatments(t *testing.T) {
+	definitions := mixedTreatmentDefinitions()
+	ruleConfig := &config.GlobalRuleConfig{
+		Enabled: true,
+		Files: []config.FileRuleConfig{{
+			Name:       "product_configs",
+			Path:       "**/",
+			Filename:   "product.yaml",
+			ParserType: "yaml",
+			Enabled:    true,
+			Sections: []config.SectionDefinition{
+				{Name: "name", YAMLPath: "name", AutoApprove: true},
+				withRule(definitions["warehouses"], "section_type_rule"),
+				withRule(definitions["notes"], "section_type_rule"),
+			},
+		}},
+	}
+	client := &ignoreTestGitLabClient{
+		forkMRTestGitLabClient: &forkMRTestGitLabClient{},
+		files:                  map[string]string{"dataproducts/marketing/prod/product.yaml": mixedTreatmentYAML},
+	}
+	manager := NewSectionRuleManager(ruleConfig, client)
+	rule := &sectionTypeMockRule{AutoApproveMockRule: AutoApproveMockRule{name: "section_type_rule"}, seen: map[string]shared.SectionType{}}
+	manager.AddRule(rule)
+
+	result := manager.EvaluateAll(&shared.MRContext{
+		ProjectID: 123,
+		MRIID:     1,
+		Changes: []gitlab.FileChange{{
+			NewPath: "dataproducts/marketing/prod/product.yaml",
+			Diff:    "@@ -4,3 +4,3 @@\n-    size: SMALL\n+    size: XSMALL\n notes: |\n-  Owned by marketing.\n+  Owned by the marketing team.",
+		}},
+		MRInfo: &gitlab.MRInfo{Author: "developer", SourceBranch: "feature"},
+	})
+
+	validation := result.FileValidations["dataproducts/marketing/prod/product.yaml"]
+	require.NotNil(t, validation)
+	assert.Equal(t, shared.Approve, validation.FileDecision)
+	assert.Equal(t, map[string]shared.SectionType{
+		"warehouses": shared.YAMLSection,
+		"notes":      shared.TextSection,
+	}, rule.seen, "each section is handled with its own treatment within one file")
+}
+
+// withRule returns the section definition with one enabled rule
+func withRule(definition config.SectionDefinition, ruleName string) config.SectionDefinition {
+	definition.RuleConfigs = []config.RuleConfig{{Name: ruleName, Enabled: true}}
+	return definition
+}
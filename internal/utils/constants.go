@@ -6,6 +6,24 @@ const (
 	DefaultActionAutoApprove  = "auto_approve"
 )
 
+// Rule Severities - used in section rule configuration
+const (
+	RuleSeverityBlocking = "blocking" // Rule failures require manual review (default)
+	RuleSeverityAdvisory = "advisory" // Rule failures are reported as warnings only
+)
+
+// New File Policies - how data product files added by an MR are decided
+const (
+	NewFilePolicyValidate     = "validate"      // Validate new files section by section like any other change (default)
+	NewFilePolicyManualReview = "manual_review" // Always require manual review for new files
+)
+
+// Section Treatments - how a section's content is handed to its rules
+const (
+	SectionTreatmentStructured = "structured" // Parse the section as YAML into fields (default)
+	SectionTreatmentRaw        = "raw"        // Pass the section through as text, without decoding it
+)
+
 // MR States - used in webhook processing
 const (
 	MRStateOpened = "opened"
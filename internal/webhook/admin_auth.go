@@ -0,0 +1,32 @@
+package webhook
+
+import (
+	"crypto/subtle"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/logging"
+)
+
+// RequireAdminToken protects admin endpoints with the ADMIN_API_TOKEN bearer token.
+// Without a configured token the endpoints are disabled rather than left open.
+func RequireAdminToken(cfg *config.Config) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if cfg.Server.AdminToken == "" {
+			return c.Status(403).JSON(fiber.Map{
+				"error": "Admin API disabled: set ADMIN_API_TOKEN to enable it",
+			})
+		}
+
+		token := strings.TrimPrefix(c.Get("Authorization"), "Bearer ")
+		if subtle.ConstantTimeCompare([]byte(token), []byte(cfg.Server.AdminToken)) != 1 {
+			logging.Warn("Rejected admin request to %s from %s: invalid token", c.Path(), c.IP())
+			return c.Status(401).JSON(fiber.Map{
+				"error": "Invalid or missing admin token",
+			})
+		}
+
+		return c.Next()
+	}
+}
@@ -0,0 +1,82 @@
+package webhook
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/logging"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+	"go.uber.org/zap"
+)
+
+// approvedCommitMarkerFormat records in naysayer's approval comment which commit it approved
+const approvedCommitMarkerFormat = "<!-- naysayer-approved-commit: %s -->"
+
+// reapprovalSuppressedMarkerFormat identifies the note explaining a suppressed re-approval for a commit
+const reapprovalSuppressedMarkerFormat = "<!-- naysayer-comment-id: reapproval-suppressed %s -->"
+
+// withApprovedCommitMarker appends the approved commit to an approval comment so a later
+// evaluation can tell whether the MR changed since naysayer approved it
+func withApprovedCommitMarker(comment string, mrInfo *gitlab.MRInfo) string {
+	if mrInfo.LastCommit == "" {
+		return comment
+	}
+	return comment + "\n" + fmt.Sprintf(approvedCommitMarkerFormat, mrInfo.LastCommit)
+}
+
+// approvalRevoked reports whether naysayer approved the MR's current commit and a human has
+// since removed that approval. Re-approving would override the reviewer, so naysayer waits
+// for the next commit instead. Any lookup failure falls back to the normal approval path.
+func (h *DataProductConfigMrReviewHandler) approvalRevoked(mrInfo *gitlab.MRInfo) bool {
+	if mrInfo.LastCommit == "" {
+		return false
+	}
+
+	previous, decision := h.previousCommentDecision(mrInfo)
+	if previous == nil || decision != shared.Approve {
+		return false
+	}
+	// Commits pushed since naysayer's approval are a substantive change
+	if !strings.Contains(previous.Body, fmt.Sprintf(approvedCommitMarkerFormat, mrInfo.LastCommit)) {
+		return false
+	}
+
+	approvals, err := h.gitlabClient.GetMRApprovals(mrInfo.ProjectID, mrInfo.MRIID)
+	if err != nil {
+		logging.MRWarn(mrInfo.MRIID, "Failed to check MR approvals, re-approving", zap.Error(err))
+		return false
+	}
+	if approvals == nil {
+		return false
+	}
+	for _, approver := range approvals.ApprovedBy {
+		if h.gitlabClient.IsNaysayerBotAuthor(approver.User) {
+			return false
+		}
+	}
+	return true
+}
+
+// postReapprovalSuppressedNote explains once per commit why naysayer did not re-approve
+func (h *DataProductConfigMrReviewHandler) postReapprovalSuppressedNote(mrInfo *gitlab.MRInfo) {
+	if !h.config.Comments.EnableMRComments {
+		return
+	}
+
+	marker := fmt.Sprintf(reapprovalSuppressedMarkerFormat, mrInfo.LastCommit)
+	existing, err := h.gitlabClient.ListMRComments(mrInfo.ProjectID, mrInfo.MRIID)
+	if err != nil {
+		logging.MRWarn(mrInfo.MRIID, "Failed to list comments for re-approval note", zap.Error(err))
+		return
+	}
+	if hasCommentContaining(existing, marker) {
+		return
+	}
+
+	note := marker + "\n⏸️ **Re-approval suppressed**\n\n" +
+		"Naysayer's approval was removed by a reviewer, so naysayer will not approve this MR again until new commits are pushed."
+	if err := h.gitlabClient.AddMRComment(mrInfo.ProjectID, mrInfo.MRIID, note); err != nil {
+		logging.MRError(mrInfo.MRIID, "Failed to add re-approval suppressed note", err)
+	}
+}
@@ -0,0 +1,115 @@
+package webhook
+
+import (
+	"testing"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newRevocationTestHandler(mockClient *MockGitLabClient) *DataProductConfigMrReviewHandler {
+	return &DataProductConfigMrReviewHandler{
+		gitlabClient: mockClient,
+		config:       &config.Config{Comments: config.CommentsConfig{EnableMRComments: true}},
+	}
+}
+
+func approvalCommentFor(sha string) *gitlab.MRComment {
+	return &gitlab.MRComment{ID: 10, Body: "<!-- naysayer-comment-id: approval -->\n✅ **Auto-approved**\n<!-- naysayer-approved-commit: " + sha + " -->"}
+}
+
+func approveResult() *shared.RuleEvaluation {
+	return &shared.RuleEvaluation{
+		FinalDecision:   shared.Decision{Type: shared.Approve, Reason: "test decision"},
+		FileValidations: map[string]*shared.FileValidationSummary{},
+	}
+}
+
+func TestApplyDecision_SuppressesReapprovalAfterRevocation(t *testing.T) {
+	mockClient := &MockGitLabClient{
+		latestComments: map[string]*gitlab.MRComment{"approval": approvalCommentFor("abc123")},
+		approvals:      &gitlab.MRApprovals{ApprovedBy: []gitlab.MRApprover{{User: map[string]interface{}{"username": "alice"}}}},
+	}
+	handler := newRevocationTestHandler(mockClient)
+	mrInfo := &gitlab.MRInfo{ProjectID: 123, MRIID: 456, State: "opened", LastCommit: "abc123"}
+
+	approved, err := handler.applyDecision(approveResult(), mrInfo)
+
+	require.NoError(t, err)
+	assert.False(t, approved)
+	assert.Empty(t, mockClient.approvalMessages, "naysayer must not re-approve")
+	require.Len(t, mockClient.addedComments, 1)
+	assert.Contains(t, mockClient.addedComments[0], "<!-- naysayer-comment-id: reapproval-suppressed abc123 -->")
+	assert.Contains(t, mockClient.addedComments[0], "Re-approval suppressed")
+
+	// The note is posted once per commit
+	mockClient.existingComments = []gitlab.MRComment{{ID: 11, Body: mockClient.addedComments[0]}}
+	approved, err = handler.applyDecision(approveResult(), mrInfo)
+	require.NoError(t, err)
+	assert.False(t, approved)
+	assert.Len(t, mockClient.addedComments, 1)
+}
+
+func TestApplyDecision_Reapproves(t *testing.T) {
+	tests := []struct {
+		name       string
+		comments   map[string]*gitlab.MRComment
+		approvals  *gitlab.MRApprovals
+		lastCommit string
+	}{
+		{
+			name:       "new commit since naysayer approved",
+			comments:   map[string]*gitlab.MRComment{"approval": approvalCommentFor("abc123")},
+			approvals:  &gitlab.MRApprovals{},
+			lastCommit: "def456",
+		},
+		{
+			name:       "naysayer approval still present",
+			comments:   map[string]*gitlab.MRComment{"approval": approvalCommentFor("abc123")},
+			approvals:  &gitlab.MRApprovals{Approved: true, ApprovedBy: []gitlab.MRApprover{{User: map[string]interface{}{"username": "naysayer-bot"}}}},
+			lastCommit: "abc123",
+		},
+		{
+			name: "previous decision was manual review",
+			comments: map[string]*gitlab.MRComment{
+				"approval":      approvalCommentFor("abc123"),
+				"manual-review": {ID: 20, Body: "<!-- naysayer-comment-id: manual-review -->"},
+			},
+			approvals:  &gitlab.MRApprovals{},
+			lastCommit: "abc123",
+		},
+		{
+			name:       "first approval",
+			approvals:  &gitlab.MRApprovals{},
+			lastCommit: "abc123",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockGitLabClient{latestComments: tt.comments, approvals: tt.approvals}
+			handler := newRevocationTestHandler(mockClient)
+			mrInfo := &gitlab.MRInfo{ProjectID: 123, MRIID: 456, State: "opened", LastCommit: tt.lastCommit}
+
+			approved, err := handler.applyDecision(approveResult(), mrInfo)
+
+			require.NoError(t, err)
+			assert.True(t, approved)
+			assert.Len(t, mockClient.approvalMessages, 1)
+		})
+	}
+}
+
+func TestHandleApprovalWithComments_RecordsApprovedCommit(t *testing.T) {
+	mockClient := &MockGitLabClient{}
+	handler := newRevocationTestHandler(mockClient)
+	mrInfo := &gitlab.MRInfo{ProjectID: 123, MRIID: 456, State: "opened", LastCommit: "abc123"}
+
+	require.NoError(t, handler.handleApprovalWithComments(approveResult(), mrInfo))
+
+	require.Len(t, mockClient.addedComments, 1)
+	assert.Contains(t, mockClient.addedComments[0], "<!-- naysayer-approved-commit: abc123 -->")
+}
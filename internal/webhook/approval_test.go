@@ -8,12 +8,14 @@ import (
 	"strings"
 	"testing"
 	"time"
+	"unicode/utf8"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/redhat-data-and-ai/naysayer/internal/config"
 	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
 	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // MockRuleManagerForApproval creates a rule manager that returns approval decisions
@@ -414,6 +416,10 @@ func TestWebhookHandler_FullApprovalWorkflow(t *testing.T) {
 					}
 				]
 			}`))
+		} else if strings.Contains(r.URL.Path, "/notes") && r.Method == "GET" {
+			// Mock existing comments (none, so no manual review hold)
+			w.WriteHeader(200)
+			_, _ = w.Write([]byte(`[]`))
 		} else if strings.Contains(r.URL.Path, "/notes") {
 			// Mock comment creation
 			w.WriteHeader(201)
@@ -519,3 +525,290 @@ func TestWebhookHandler_FullApprovalWorkflow(t *testing.T) {
 	decision := response["decision"].(map[string]interface{})
 	assert.Equal(t, "approve", decision["type"])
 }
+
+func TestHandleDecisionFlip_RemovesOppositeComment(t *testing.T) {
+	cfg := &config.Config{
+		Comments: config.CommentsConfig{
+			EnableMRComments:       true,
+			UpdateExistingComments: true,
+			CommentVerbosity:       "basic",
+		},
+	}
+	mrInfo := &gitlab.MRInfo{ProjectID: 123, MRIID: 456, Author: "testuser", State: "opened"}
+	result := &shared.RuleEvaluation{
+		FinalDecision:   shared.Decision{Type: shared.Approve, Reason: "All rules passed"},
+		FileValidations: map[string]*shared.FileValidationSummary{},
+	}
+
+	t.Run("approval removes previous manual review comment", func(t *testing.T) {
+		mockClient := &MockGitLabClient{
+			latestComments: map[string]*gitlab.MRComment{
+				"manual-review": {ID: 11},
+				"approval":      {ID: 22},
+			},
+		}
+		handler := &DataProductConfigMrReviewHandler{gitlabClient: mockClient, config: cfg}
+
+		err := handler.handleApprovalWithComments(result, mrInfo)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []int{11}, mockClient.deletedCommentIDs)
+	})
+
+	t.Run("manual review removes previous approval comment", func(t *testing.T) {
+		mockClient := &MockGitLabClient{
+			latestComments: map[string]*gitlab.MRComment{
+				"approval": {ID: 22},
+			},
+		}
+		handler := &DataProductConfigMrReviewHandler{gitlabClient: mockClient, config: cfg}
+
+		err := handler.handleManualReviewWithComments(result, mrInfo)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []int{22}, mockClient.deletedCommentIDs)
+	})
+
+	t.Run("no opposite comment leaves comments untouched", func(t *testing.T) {
+		mockClient := &MockGitLabClient{
+			latestComments: map[string]*gitlab.MRComment{
+				"approval": {ID: 22},
+			},
+		}
+		handler := &DataProductConfigMrReviewHandler{gitlabClient: mockClient, config: cfg}
+
+		err := handler.handleApprovalWithComments(result, mrInfo)
+
+		assert.NoError(t, err)
+		assert.Empty(t, mockClient.deletedCommentIDs)
+	})
+}
+
+func TestApplyDecision_CommitStatus(t *testing.T) {
+	tests := []struct {
+		name              string
+		enabled           bool
+		manualReviewState string
+		decision          shared.DecisionType
+		lastCommit        string
+		expectedStatuses  []string
+	}{
+		{"approve reports success", true, "", shared.Approve, "abc123", []string{"abc123:success"}},
+		{"manual review reports pending", true, "pending", shared.ManualReview, "abc123", []string{"abc123:pending"}},
+		{"manual review reports failed when configured", true, "failed", shared.ManualReview, "abc123", []string{"abc123:failed"}},
+		{"disabled posts nothing", false, "", shared.Approve, "abc123", nil},
+		{"missing sha posts nothing", true, "", shared.Approve, "", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockGitLabClient{}
+			handler := &DataProductConfigMrReviewHandler{
+				gitlabClient: mockClient,
+				config: &config.Config{
+					CommitStatus: config.CommitStatusConfig{
+						Enabled:           tt.enabled,
+						ManualReviewState: tt.manualReviewState,
+					},
+				},
+			}
+			mrInfo := &gitlab.MRInfo{ProjectID: 123, MRIID: 456, State: "opened", LastCommit: tt.lastCommit}
+			result := &shared.RuleEvaluation{
+				FinalDecision:   shared.Decision{Type: tt.decision, Reason: "test decision"},
+				FileValidations: map[string]*shared.FileValidationSummary{},
+			}
+
+			approved, err := handler.applyDecision(result, mrInfo)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.decision == shared.Approve, approved)
+			assert.Equal(t, tt.expectedStatuses, mockClient.commitStatuses)
+		})
+	}
+}
+
+func TestReportCommitStatus_TruncatesOnCharacterBoundary(t *testing.T) {
+	mockClient := &MockGitLabClient{}
+	handler := &DataProductConfigMrReviewHandler{
+		gitlabClient: mockClient,
+		config:       &config.Config{CommitStatus: config.CommitStatusConfig{Enabled: true}},
+	}
+	mrInfo := &gitlab.MRInfo{ProjectID: 123, MRIID: 456, LastCommit: "abc123"}
+	result := &shared.RuleEvaluation{
+		FinalDecision: shared.Decision{Type: shared.ManualReview, Reason: "x" + strings.Repeat("ä", 300)},
+	}
+
+	handler.reportCommitStatus(result, mrInfo)
+
+	require.Len(t, mockClient.commitStatusDescs, 1)
+	description := mockClient.commitStatusDescs[0]
+	assert.True(t, utf8.ValidString(description), "multi-byte characters must not be split")
+	assert.Equal(t, 255, utf8.RuneCountInString(description))
+	assert.Equal(t, "x"+strings.Repeat("ä", 251)+"...", description)
+}
+
+func TestApplyDecision_CommitStatusFailureDoesNotBlockApproval(t *testing.T) {
+	mockClient := &MockGitLabClient{commitStatusErr: assert.AnError}
+	handler := &DataProductConfigMrReviewHandler{
+		gitlabClient: mockClient,
+		config:       &config.Config{CommitStatus: config.CommitStatusConfig{Enabled: true}},
+	}
+	mrInfo := &gitlab.MRInfo{ProjectID: 123, MRIID: 456, State: "opened", LastCommit: "abc123"}
+	result := &shared.RuleEvaluation{
+		FinalDecision:   shared.Decision{Type: shared.Approve, Reason: "All rules passed"},
+		FileValidations: map[string]*shared.FileValidationSummary{},
+	}
+
+	approved, err := handler.applyDecision(result, mrInfo)
+
+	assert.NoError(t, err)
+	assert.True(t, approved)
+	assert.Len(t, mockClient.approvalMessages, 1)
+}
+
+func TestApplyDecision_ReviewLabel(t *testing.T) {
+	tests := []struct {
+		name            string
+		enabled         bool
+		decision        shared.DecisionType
+		expectedAdded   []string
+		expectedRemoved []string
+	}{
+		{"manual review adds label", true, shared.ManualReview, []string{"naysayer:needs-review"}, nil},
+		{"approve removes label", true, shared.Approve, nil, []string{"naysayer:needs-review"}},
+		{"disabled leaves labels alone", false, shared.ManualReview, nil, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockGitLabClient{}
+			handler := &DataProductConfigMrReviewHandler{
+				gitlabClient: mockClient,
+				config: &config.Config{
+					ReviewLabel: config.ReviewLabelConfig{Enabled: tt.enabled, Name: "naysayer:needs-review"},
+				},
+			}
+			mrInfo := &gitlab.MRInfo{ProjectID: 123, MRIID: 456, State: "opened"}
+			result := &shared.RuleEvaluation{
+				FinalDecision:   shared.Decision{Type: tt.decision, Reason: "test decision"},
+				FileValidations: map[string]*shared.FileValidationSummary{},
+			}
+
+			approved, err := handler.applyDecision(result, mrInfo)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.decision == shared.Approve, approved)
+			assert.Equal(t, tt.expectedAdded, mockClient.addedLabels)
+			assert.Equal(t, tt.expectedRemoved, mockClient.removedLabels)
+		})
+	}
+}
+
+func TestApplyDecision_CommentOnChangeOnly(t *testing.T) {
+	approvalComment := &gitlab.MRComment{ID: 10, Body: "<!-- naysayer-comment-id: approval -->\n✅ **Auto-approved**"}
+	reviewComment := &gitlab.MRComment{ID: 20, Body: "<!-- naysayer-comment-id: manual-review -->\n⚠️ **Manual review required**"}
+
+	tests := []struct {
+		name            string
+		latestComments  map[string]*gitlab.MRComment
+		decision        shared.DecisionType
+		expectComment   bool
+		expectApprovals int
+		expectResets    int
+	}{
+		{"unchanged approval skips comment", map[string]*gitlab.MRComment{"approval": approvalComment}, shared.Approve, false, 1, 0},
+		{"unchanged manual review skips comment", map[string]*gitlab.MRComment{"approval": approvalComment, "manual-review": reviewComment}, shared.ManualReview, false, 0, 1},
+		{"changed to approval comments", map[string]*gitlab.MRComment{"approval": approvalComment, "manual-review": reviewComment}, shared.Approve, true, 1, 0},
+		{"changed to manual review comments", map[string]*gitlab.MRComment{"approval": approvalComment}, shared.ManualReview, true, 0, 1},
+		{"first decision comments", nil, shared.Approve, true, 1, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockGitLabClient{latestComments: tt.latestComments}
+			handler := &DataProductConfigMrReviewHandler{
+				gitlabClient: mockClient,
+				config: &config.Config{
+					Comments: config.CommentsConfig{EnableMRComments: true, CommentOnChangeOnly: true},
+				},
+			}
+			mrInfo := &gitlab.MRInfo{ProjectID: 123, MRIID: 456, State: "opened"}
+			result := &shared.RuleEvaluation{
+				FinalDecision:   shared.Decision{Type: tt.decision, Reason: "test decision"},
+				FileValidations: map[string]*shared.FileValidationSummary{},
+			}
+
+			approved, err := handler.applyDecision(result, mrInfo)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.decision == shared.Approve, approved)
+			if tt.expectComment {
+				assert.Len(t, mockClient.addedComments, 1)
+			} else {
+				assert.Empty(t, mockClient.addedComments)
+			}
+			assert.Len(t, mockClient.approvalMessages, tt.expectApprovals, "approval still happens when the comment is skipped")
+			assert.Equal(t, tt.expectResets, mockClient.approvalResets, "approval is still revoked when the comment is skipped")
+		})
+	}
+}
+
+func TestHandleManualReviewWithComments_InlineDiffNotes(t *testing.T) {
+	mrInfo := &gitlab.MRInfo{ProjectID: 123, MRIID: 456, Author: "testuser", State: "opened", LastCommit: "abc123"}
+	result := &shared.RuleEvaluation{
+		FinalDecision: shared.Decision{Type: shared.ManualReview, Reason: "Uncovered lines"},
+		FileValidations: map[string]*shared.FileValidationSummary{
+			"dataproducts/b/product.yaml": {
+				FileDecision:   shared.ManualReview,
+				UncoveredLines: []shared.LineRange{{StartLine: 8, EndLine: 9}, {StartLine: 20, EndLine: 20}},
+			},
+			"dataproducts/a/product.yaml": {
+				FileDecision:   shared.ManualReview,
+				UncoveredLines: []shared.LineRange{{StartLine: 3, EndLine: 5}},
+			},
+			"dataproducts/c/product.yaml": {FileDecision: shared.Approve},
+		},
+	}
+
+	tests := []struct {
+		name             string
+		enabled          bool
+		existingComments []gitlab.MRComment
+		expectedNotes    []string
+	}{
+		{
+			name:    "enabled posts a note on the first uncovered line of each file",
+			enabled: true,
+			expectedNotes: []string{
+				"abc123:dataproducts/a/product.yaml:3",
+				"abc123:dataproducts/b/product.yaml:8",
+			},
+		},
+		{
+			name:    "existing note for the same commit is not repeated",
+			enabled: true,
+			existingComments: []gitlab.MRComment{
+				{ID: 1, Body: "<!-- naysayer-comment-id: inline dataproducts/a/product.yaml:3@abc123 -->\nnote"},
+			},
+			expectedNotes: []string{"abc123:dataproducts/b/product.yaml:8"},
+		},
+		{
+			name:          "disabled posts no notes",
+			enabled:       false,
+			expectedNotes: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockGitLabClient{existingComments: tt.existingComments}
+			cfg := &config.Config{Comments: config.CommentsConfig{InlineDiffNotes: tt.enabled}}
+			handler := &DataProductConfigMrReviewHandler{gitlabClient: mockClient, config: cfg}
+
+			err := handler.handleManualReviewWithComments(result, mrInfo)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedNotes, mockClient.diffNotes)
+		})
+	}
+}
@@ -35,10 +35,12 @@ func NewAutoRebaseHandler(cfg *config.Config) *AutoRebaseHandler {
 
 	// Create a custom config with the appropriate token
 	gitlabConfig := config.GitLabConfig{
-		BaseURL:     cfg.GitLab.BaseURL,
-		Token:       token,
-		InsecureTLS: cfg.GitLab.InsecureTLS,
-		CACertPath:  cfg.GitLab.CACertPath,
+		BaseURL:        cfg.GitLab.BaseURL,
+		Token:          token,
+		InsecureTLS:    cfg.GitLab.InsecureTLS,
+		CACertPaths:    cfg.GitLab.CACertPaths,
+		ClientCertPath: cfg.GitLab.ClientCertPath,
+		ClientKeyPath:  cfg.GitLab.ClientKeyPath,
 	}
 
 	gitlabClient := gitlab.NewClient(gitlabConfig)
@@ -140,10 +142,7 @@ func (h *AutoRebaseHandler) HandleWebhook(c *fiber.Ctx) error {
 	}
 
 	// Unsupported event type
-	logging.Warn("Skipping unsupported event: %s", eventType)
-	return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-		"error": fmt.Sprintf("Unsupported event type: %s. Only push events are supported.", eventType),
-	})
+	return ignoredEvent(c, eventType, "Only push events are supported")
 }
 
 // handlePushToMain handles push events to main branch by rebasing all open MRs
@@ -210,6 +209,8 @@ func (h *AutoRebaseHandler) handlePushToMain(c *fiber.Ctx, payload map[string]in
 	successCount := 0
 	failureCount := 0
 	failures := make([]map[string]interface{}, 0)
+	rebased := make([]int, 0)
+	summaryMode := h.rebaseSummaryEnabled()
 
 	for _, mr := range eligibleMRs {
 		// Determine source project ID (handles fork MRs)
@@ -328,7 +329,7 @@ func (h *AutoRebaseHandler) handlePushToMain(c *fiber.Ctx, payload map[string]in
 				"error":  err.Error(),
 			})
 			// When rebase fails due to fork permissions (cannot push to source branch), comment on the MR so author knows to rebase manually
-			if isForkRebasePermissionError(err) {
+			if isForkRebasePermissionError(err) && !summaryMode {
 				forkComment := "🤖 **Auto-rebase attempted**\n\nThis merge request is from a fork. Automated rebase was attempted but cannot push to the fork's source branch (insufficient permissions). Please **rebase manually** to bring in the latest changes from the target branch.\n\n_This is an automated message._"
 				if commentErr := h.gitlabClient.AddMRComment(projectID, mr.IID, forkComment); commentErr != nil {
 					logging.Warn("Failed to add fork rebase comment to MR", zap.Int("mr_iid", mr.IID), zap.Error(commentErr))
@@ -337,6 +338,10 @@ func (h *AutoRebaseHandler) handlePushToMain(c *fiber.Ctx, payload map[string]in
 		} else if success {
 			logging.Info("Successfully rebased MR", zap.Int("mr_iid", mr.IID))
 			successCount++
+			rebased = append(rebased, mr.IID)
+			if summaryMode {
+				continue
+			}
 			commentBody := "🤖 **Automated Rebase**\n\nThis merge request has been automatically rebased with the latest changes from the target branch.\n\n_This is an automated action triggered by a push to the main branch._"
 			if commentErr := h.gitlabClient.AddMRComment(projectID, mr.IID, commentBody); commentErr != nil {
 				logging.Warn("Failed to add rebase comment to MR", zap.Int("mr_iid", mr.IID), zap.Error(commentErr))
@@ -344,6 +349,10 @@ func (h *AutoRebaseHandler) handlePushToMain(c *fiber.Ctx, payload map[string]in
 		}
 	}
 
+	if summaryMode {
+		h.postRebaseSummary(projectID, targetBranch, rebased, failures)
+	}
+
 	// Build response
 	response := fiber.Map{
 		"webhook_response": "processed",
@@ -0,0 +1,68 @@
+package webhook
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/logging"
+)
+
+// rebaseSummaryEnabled reports whether rebase results go to one tracking MR or issue
+// instead of a comment on every rebased MR
+func (h *AutoRebaseHandler) rebaseSummaryEnabled() bool {
+	return h.config.AutoRebase.SummaryMRIID > 0 || h.config.AutoRebase.SummaryIssueIID > 0
+}
+
+// postRebaseSummary posts the results of a rebase run to the tracking MR, or the tracking issue
+// when no MR is configured. Runs that neither rebased nor failed any MR post nothing.
+func (h *AutoRebaseHandler) postRebaseSummary(projectID int, targetBranch string, rebased []int, failures []map[string]interface{}) {
+	if len(rebased) == 0 && len(failures) == 0 {
+		return
+	}
+
+	summary := formatRebaseSummary(targetBranch, rebased, failures)
+	var err error
+	if mrIID := h.config.AutoRebase.SummaryMRIID; mrIID > 0 {
+		err = h.gitlabClient.AddMRComment(projectID, mrIID, summary)
+	} else {
+		err = h.gitlabClient.AddIssueComment(projectID, h.config.AutoRebase.SummaryIssueIID, summary)
+	}
+	if err != nil {
+		logging.Warn("Failed to post rebase summary",
+			zap.Int("project_id", projectID),
+			zap.Int("summary_mr_iid", h.config.AutoRebase.SummaryMRIID),
+			zap.Int("summary_issue_iid", h.config.AutoRebase.SummaryIssueIID),
+			zap.Error(err))
+	}
+}
+
+// formatRebaseSummary lists the MRs a push to targetBranch rebased and the ones that failed, with reasons
+func formatRebaseSummary(targetBranch string, rebased []int, failures []map[string]interface{}) string {
+	var b strings.Builder
+	b.WriteString("🤖 **Auto-rebase summary**\n\n")
+	fmt.Fprintf(&b, "A push to `%s` triggered an automated rebase: %d rebased, %d failed.\n", targetBranch, len(rebased), len(failures))
+
+	if len(rebased) > 0 {
+		b.WriteString("\n**✅ Rebased**\n")
+		for _, mrIID := range rebased {
+			fmt.Fprintf(&b, "- !%d\n", mrIID)
+		}
+	}
+
+	if len(failures) > 0 {
+		b.WriteString("\n**❌ Failed**\n")
+		for _, failure := range failures {
+			reason := fmt.Sprint(failure["error"])
+			if isForkRebasePermissionError(errors.New(reason)) {
+				reason += " (fork MR - please rebase manually)"
+			}
+			fmt.Fprintf(&b, "- !%v: %s\n", failure["mr_iid"], reason)
+		}
+	}
+
+	b.WriteString("\n_This is an automated message._")
+	return b.String()
+}
@@ -0,0 +1,121 @@
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func pushToMain(t *testing.T, handler *AutoRebaseHandler) {
+	t.Helper()
+
+	app := createTestApp()
+	app.Post("/rebase", handler.HandleWebhook)
+
+	payloadBytes, _ := json.Marshal(map[string]interface{}{
+		"object_kind": "push",
+		"ref":         "refs/heads/main",
+		"project":     map[string]interface{}{"id": 94023},
+	})
+	req := httptest.NewRequest("POST", "/rebase", bytes.NewReader(payloadBytes))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	require.Equal(t, 200, resp.StatusCode)
+}
+
+func TestFormatRebaseSummary(t *testing.T) {
+	summary := formatRebaseSummary("main", []int{11, 12}, []map[string]interface{}{
+		{"mr_iid": 13, "error": "failed to compare: 500 Internal Server Error"},
+		{"mr_iid": 14, "error": "rebase failed: 403 Forbidden - Cannot push to source branch"},
+	})
+
+	assert.Contains(t, summary, "A push to `main` triggered an automated rebase: 2 rebased, 2 failed.")
+	assert.Contains(t, summary, "**✅ Rebased**\n- !11\n- !12\n")
+	assert.Contains(t, summary, "- !13: failed to compare: 500 Internal Server Error\n")
+	assert.Contains(t, summary, "- !14: rebase failed: 403 Forbidden - Cannot push to source branch (fork MR - please rebase manually)\n")
+}
+
+func TestFormatRebaseSummary_OnlySuccesses(t *testing.T) {
+	summary := formatRebaseSummary("master", []int{21}, nil)
+
+	assert.Contains(t, summary, "1 rebased, 0 failed")
+	assert.Contains(t, summary, "- !21")
+	assert.NotContains(t, summary, "Failed")
+}
+
+func TestAutoRebase_SummaryMode(t *testing.T) {
+	tests := []struct {
+		name          string
+		summaryMRIID  int
+		summaryIssue  int
+		expectMRIIDs  []int
+		expectIssueID int
+	}{
+		{name: "tracking MR", summaryMRIID: 500, expectMRIIDs: []int{500}},
+		{name: "tracking issue", summaryIssue: 42, expectIssueID: 42},
+		{name: "tracking MR wins over issue", summaryMRIID: 500, summaryIssue: 42, expectMRIIDs: []int{500}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createTestConfig()
+			cfg.AutoRebase.SummaryMRIID = tt.summaryMRIID
+			cfg.AutoRebase.SummaryIssueIID = tt.summaryIssue
+			mockClient := &MockRebaseGitLabClient{
+				openMRs: []int{101, 102, 103},
+				rebaseErrors: map[int]error{
+					102: fmt.Errorf("rebase failed: 409 Conflict"),
+					103: fmt.Errorf("rebase failed: 403 Forbidden - Cannot push to source branch"),
+				},
+			}
+
+			pushToMain(t, NewAutoRebaseHandlerWithClient(cfg, mockClient))
+
+			// No per-MR comments, not even the fork permission hint
+			assert.Equal(t, tt.expectMRIIDs, mockClient.commentedMRs)
+			var summary string
+			if tt.expectIssueID > 0 {
+				require.Len(t, mockClient.issueComments[tt.expectIssueID], 1)
+				summary = mockClient.issueComments[tt.expectIssueID][0]
+			} else {
+				require.Len(t, mockClient.capturedComments, 1)
+				assert.Empty(t, mockClient.issueComments)
+				summary = mockClient.capturedComments[0]
+			}
+
+			assert.Contains(t, summary, "1 rebased, 2 failed")
+			assert.Contains(t, summary, "- !101\n")
+			assert.Contains(t, summary, "- !102: rebase failed: 409 Conflict\n")
+			assert.Contains(t, summary, "- !103: rebase failed: 403 Forbidden - Cannot push to source branch (fork MR - please rebase manually)")
+		})
+	}
+}
+
+func TestAutoRebase_DefaultCommentsPerMR(t *testing.T) {
+	mockClient := &MockRebaseGitLabClient{openMRs: []int{101, 102}}
+
+	pushToMain(t, NewAutoRebaseHandlerWithClient(createTestConfig(), mockClient))
+
+	assert.Equal(t, []int{101, 102}, mockClient.commentedMRs)
+	assert.Empty(t, mockClient.issueComments)
+	for _, comment := range mockClient.capturedComments {
+		assert.Contains(t, comment, "Automated Rebase")
+	}
+}
+
+func TestAutoRebase_SummarySkippedWhenNothingRebased(t *testing.T) {
+	cfg := createTestConfig()
+	cfg.AutoRebase.SummaryMRIID = 500
+	mockClient := &MockRebaseGitLabClient{}
+
+	pushToMain(t, NewAutoRebaseHandlerWithClient(cfg, mockClient))
+
+	assert.Empty(t, mockClient.capturedComments)
+}
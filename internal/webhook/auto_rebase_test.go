@@ -26,6 +26,9 @@ type MockRebaseGitLabClient struct {
 	openMRs           []int
 	openMRDetails     []gitlab.MRDetails
 	capturedComments  []string
+	commentedMRs      []int            // MR IIDs of capturedComments, in order
+	issueComments     map[int][]string // Comments added to issues, by issue IID
+	rebaseErrors      map[int]error    // Per-MR rebase errors, checked before rebaseError
 	capturedRebaseMRs []struct {
 		projectID int
 		mrIID     int
@@ -45,6 +48,13 @@ func (m *MockRebaseGitLabClient) CompareBranches(sourceProjectID int, sourceBran
 func (m *MockRebaseGitLabClient) GetBranchCommit(projectID int, branch string) (string, error) {
 	return "mock-main-sha", nil
 }
+func (m *MockRebaseGitLabClient) IsBranchProtected(projectID int, branch string) (bool, error) {
+	return true, nil
+}
+
+func (m *MockRebaseGitLabClient) GetMergeRefCommit(projectID, mrIID int) (string, error) {
+	return "", nil
+}
 
 func (m *MockRebaseGitLabClient) CompareCommits(projectID int, fromSHA, toSHA string) (*gitlab.CompareResult, error) {
 	return &gitlab.CompareResult{
@@ -59,6 +69,9 @@ func (m *MockRebaseGitLabClient) RebaseMR(projectID, mrIID int) (bool, error) {
 		projectID int
 		mrIID     int
 	}{projectID, mrIID})
+	if err := m.rebaseErrors[mrIID]; err != nil {
+		return false, err
+	}
 	if m.rebaseError != nil {
 		return false, m.rebaseError
 	}
@@ -67,6 +80,15 @@ func (m *MockRebaseGitLabClient) RebaseMR(projectID, mrIID int) (bool, error) {
 
 func (m *MockRebaseGitLabClient) AddMRComment(projectID, mrIID int, comment string) error {
 	m.capturedComments = append(m.capturedComments, comment)
+	m.commentedMRs = append(m.commentedMRs, mrIID)
+	return m.addCommentError
+}
+
+func (m *MockRebaseGitLabClient) AddIssueComment(projectID, issueIID int, comment string) error {
+	if m.issueComments == nil {
+		m.issueComments = make(map[int][]string)
+	}
+	m.issueComments[issueIID] = append(m.issueComments[issueIID], comment)
 	return m.addCommentError
 }
 
@@ -113,6 +135,10 @@ func (m *MockRebaseGitLabClient) AddOrUpdateMRComment(projectID, mrIID int, comm
 	return nil
 }
 
+func (m *MockRebaseGitLabClient) AddMRDiffNote(projectID, mrIID int, sha, filePath string, line int, body string) error {
+	return nil
+}
+
 func (m *MockRebaseGitLabClient) ListMRComments(projectID, mrIID int) ([]gitlab.MRComment, error) {
 	return []gitlab.MRComment{}, nil
 }
@@ -121,6 +147,10 @@ func (m *MockRebaseGitLabClient) UpdateMRComment(projectID, mrIID, commentID int
 	return nil
 }
 
+func (m *MockRebaseGitLabClient) DeleteMRComment(projectID, mrIID, commentID int) error {
+	return nil
+}
+
 func (m *MockRebaseGitLabClient) FindLatestNaysayerComment(projectID, mrIID int, commentType ...string) (*gitlab.MRComment, error) {
 	return nil, nil
 }
@@ -133,10 +163,26 @@ func (m *MockRebaseGitLabClient) ApproveMRWithMessage(projectID, mrIID int, mess
 	return nil
 }
 
+func (m *MockRebaseGitLabClient) SetCommitStatus(projectID int, sha, state, description string) error {
+	return nil
+}
+
+func (m *MockRebaseGitLabClient) GetMRHeadPipelineStatus(projectID, mrIID int) (string, error) {
+	return "", nil
+}
+
+func (m *MockRebaseGitLabClient) GetProjectIDByPath(pathWithNamespace string) (int, error) {
+	return 0, nil
+}
+
 func (m *MockRebaseGitLabClient) ResetNaysayerApproval(projectID, mrIID int) error {
 	return nil
 }
 
+func (m *MockRebaseGitLabClient) GetMRApprovals(projectID, mrIID int) (*gitlab.MRApprovals, error) {
+	return nil, nil
+}
+
 func (m *MockRebaseGitLabClient) GetCurrentBotUsername() (string, error) {
 	return "naysayer-bot", nil
 }
@@ -205,6 +251,12 @@ func (m *MockRebaseGitLabClient) CloseMR(projectID, mrIID int) error {
 	return nil
 }
 
+func (m *MockRebaseGitLabClient) AddMRLabels(projectID, mrIID int, labels []string) error { return nil }
+
+func (m *MockRebaseGitLabClient) RemoveMRLabels(projectID, mrIID int, labels []string) error {
+	return nil
+}
+
 // FindCommentByPattern checks if a comment with the pattern exists (mock implementation)
 func (m *MockRebaseGitLabClient) FindCommentByPattern(projectID, mrIID int, pattern string) (bool, error) {
 	// Mock implementation - check captured comments
@@ -548,13 +600,14 @@ func TestFivetranTerraformRebaseHandler_HandleWebhook_UnsupportedEventType(t *te
 
 	resp, err := app.Test(req)
 	assert.NoError(t, err)
-	assert.Equal(t, 400, resp.StatusCode)
+	assert.Equal(t, 200, resp.StatusCode)
 
 	body, _ := io.ReadAll(resp.Body)
 	var response map[string]interface{}
 	_ = json.Unmarshal(body, &response)
 
-	assert.Contains(t, response["error"].(string), "Unsupported event type")
+	assert.Equal(t, "ignored", response["status"])
+	assert.Equal(t, "merge_request", response["event_type"])
 }
 
 func TestFivetranTerraformRebaseHandler_HandleWebhook_MissingProject(t *testing.T) {
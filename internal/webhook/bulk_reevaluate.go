@@ -0,0 +1,163 @@
+package webhook
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/logging"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+	"go.uber.org/zap"
+)
+
+// BulkReevaluateHandler re-runs naysayer on every open MR of a project, e.g. after a rules.yaml change
+type BulkReevaluateHandler struct {
+	reviewHandler *DataProductConfigMrReviewHandler
+	concurrency   int
+}
+
+// MRReevaluation is the outcome of re-evaluating a single MR
+type MRReevaluation struct {
+	MRIID      int                 `json:"mr_iid"`
+	Decision   shared.DecisionType `json:"decision,omitempty"`
+	Reason     string              `json:"reason,omitempty"`
+	MRApproved bool                `json:"mr_approved"`
+	Skipped    bool                `json:"skipped,omitempty"`
+	Error      string              `json:"error,omitempty"`
+}
+
+// BulkReevaluateResponse summarizes a bulk re-evaluation
+type BulkReevaluateResponse struct {
+	Status       string           `json:"status"`
+	ProjectID    int              `json:"project_id"`
+	TotalMRs     int              `json:"total_mrs"`
+	Approved     int              `json:"approved"`
+	ManualReview int              `json:"manual_review"`
+	Skipped      int              `json:"skipped"`
+	Failed       int              `json:"failed"`
+	Results      []MRReevaluation `json:"results"`
+}
+
+// NewBulkReevaluateHandler creates a handler that applies decisions through reviewHandler
+func NewBulkReevaluateHandler(reviewHandler *DataProductConfigMrReviewHandler) *BulkReevaluateHandler {
+	concurrency := reviewHandler.config.Server.ReevaluateConcurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	return &BulkReevaluateHandler{reviewHandler: reviewHandler, concurrency: concurrency}
+}
+
+// HandleReevaluate evaluates every open MR in the project given by the :id route parameter.
+// A failure on one MR is reported in its result and doesn't stop the others.
+func (h *BulkReevaluateHandler) HandleReevaluate(c *fiber.Ctx) error {
+	projectID, err := c.ParamsInt("id")
+	if err != nil || projectID <= 0 {
+		return c.Status(400).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	// All open MRs, not just the last week's: older MRs are the ones most likely to predate the rule change
+	openMRs, err := h.reviewHandler.gitlabClient.ListAllOpenMRsWithDetails(projectID)
+	if err != nil {
+		logging.Error("Failed to list open MRs for project %d: %v", projectID, err)
+		return c.Status(502).JSON(fiber.Map{
+			"error": "Failed to list open MRs: " + err.Error(),
+		})
+	}
+
+	logging.Info("Re-evaluating %d open MRs in project %d", len(openMRs), projectID)
+
+	correlation := correlationID(c)
+	results := make([]MRReevaluation, len(openMRs))
+	semaphore := make(chan struct{}, h.concurrency)
+	var wg sync.WaitGroup
+	for i, mr := range openMRs {
+		wg.Add(1)
+		go func(i int, mr gitlab.MRDetails) {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+			results[i] = h.reevaluateMR(projectID, mr, correlation)
+		}(i, mr)
+	}
+	wg.Wait()
+
+	sort.Slice(results, func(i, j int) bool { return results[i].MRIID < results[j].MRIID })
+
+	response := BulkReevaluateResponse{
+		Status:    "completed",
+		ProjectID: projectID,
+		TotalMRs:  len(results),
+		Results:   results,
+	}
+	for _, result := range results {
+		switch {
+		case result.Error != "":
+			response.Failed++
+		case result.Skipped:
+			response.Skipped++
+		case result.MRApproved:
+			response.Approved++
+		default:
+			response.ManualReview++
+		}
+	}
+
+	logging.Info("Re-evaluated project %d: %d approved, %d manual review, %d skipped, %d failed",
+		projectID, response.Approved, response.ManualReview, response.Skipped, response.Failed)
+	return c.JSON(response)
+}
+
+// reevaluateMR evaluates one open MR and applies the decision, like a webhook for the MR would
+func (h *BulkReevaluateHandler) reevaluateMR(projectID int, mr gitlab.MRDetails, correlation string) (outcome MRReevaluation) {
+	outcome = MRReevaluation{MRIID: mr.IID}
+	defer func() {
+		if r := recover(); r != nil {
+			outcome.Error = fmt.Sprintf("re-evaluation panicked: %v", r)
+		}
+	}()
+
+	mrInfo := &gitlab.MRInfo{
+		ProjectID:    projectID,
+		MRIID:        mr.IID,
+		Title:        mr.Title,
+		Author:       mr.Author.Username,
+		SourceBranch: mr.SourceBranch,
+		TargetBranch: mr.TargetBranch,
+		State:        mr.State,
+		LastCommit:   mr.Sha,
+		Draft:        mr.Draft,
+	}
+
+	// Skip the MRs a webhook would skip: not open, drafts and non-protected targets
+	if reason := h.reviewHandler.evaluationSkipReason(mrInfo); reason != "" {
+		outcome.Skipped = true
+		outcome.Reason = reason
+		return outcome
+	}
+
+	unlock := evaluationLocks.lock(projectID, mr.IID)
+	defer unlock()
+
+	result, err := h.reviewHandler.evaluateRulesUncached(projectID, mr.IID, mrInfo)
+	if err != nil {
+		logging.MRError(mr.IID, "Rule evaluation failed during bulk re-evaluation", err)
+		outcome.Error = "Rule evaluation failed: " + err.Error()
+		return outcome
+	}
+	result.CorrelationID = correlation
+
+	approved, err := h.reviewHandler.applyDecision(result, mrInfo)
+	outcome.Decision = result.FinalDecision.Type
+	outcome.Reason = result.FinalDecision.Reason
+	if err != nil {
+		logging.MRWarn(mr.IID, "Failed to apply decision during bulk re-evaluation", zap.Error(err))
+		outcome.Error = "Failed to approve MR: " + err.Error()
+		return outcome
+	}
+	outcome.MRApproved = approved
+	return outcome
+}
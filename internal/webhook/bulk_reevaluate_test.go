@@ -0,0 +1,199 @@
+package webhook
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newBulkReevaluateTestServer serves four open MRs: !1 approves, !2 is a draft,
+// !3 needs manual review and !4 fails to approve
+func newBulkReevaluateTestServer(t *testing.T) (*httptest.Server, *[]string) {
+	t.Helper()
+	var mu sync.Mutex
+	var approved []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/api/v4/projects/123/merge_requests":
+			_, _ = w.Write([]byte(`[
+				{"iid": 1, "state": "opened", "title": "Shrink warehouse", "author": {"username": "alice"}, "sha": "aaa"},
+				{"iid": 2, "state": "opened", "title": "Draft: wip", "draft": true, "author": {"username": "bob"}},
+				{"iid": 3, "state": "opened", "title": "Grow warehouse", "author": {"username": "carol"}},
+				{"iid": 4, "state": "opened", "title": "Shrink other warehouse", "author": {"username": "dave"}}
+			]`))
+		case strings.HasSuffix(r.URL.Path, "/changes"):
+			_, _ = w.Write([]byte(`{"changes": [{"new_path": "dataproducts/agg/product.yaml", "diff": "@@ -1 +1 @@\n-size: LARGE\n+size: SMALL"}]}`))
+		case strings.HasSuffix(r.URL.Path, "/notes"):
+			_, _ = w.Write([]byte(`[]`))
+		case strings.HasSuffix(r.URL.Path, "/merge_requests/4/approve"):
+			w.WriteHeader(500)
+		case strings.HasSuffix(r.URL.Path, "/approve"):
+			mu.Lock()
+			approved = append(approved, r.URL.Path)
+			mu.Unlock()
+			w.WriteHeader(201)
+			_, _ = w.Write([]byte(`{"approved": true}`))
+		default:
+			w.WriteHeader(404)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server, &approved
+}
+
+func newBulkReevaluateTestHandler(serverURL string) *BulkReevaluateHandler {
+	cfg := &config.Config{
+		GitLab: config.GitLabConfig{BaseURL: serverURL, Token: "test-token"},
+		Server: config.ServerConfig{AdminToken: "admin-secret", ReevaluateConcurrency: 2},
+	}
+	reviewHandler := &DataProductConfigMrReviewHandler{
+		gitlabClient: gitlab.NewClientWithConfig(cfg),
+		config:       cfg,
+		ruleManager: &MockRuleManagerForApproval{
+			evaluateFunc: func(ctx *shared.MRContext) *shared.RuleEvaluation {
+				decision := shared.Approve
+				if ctx.MRIID == 3 {
+					decision = shared.ManualReview
+				}
+				return &shared.RuleEvaluation{
+					FinalDecision:   shared.Decision{Type: decision, Reason: "test decision"},
+					FileValidations: map[string]*shared.FileValidationSummary{},
+				}
+			},
+		},
+	}
+	return NewBulkReevaluateHandler(reviewHandler)
+}
+
+func TestBulkReevaluateHandler_HandleReevaluate(t *testing.T) {
+	server, approved := newBulkReevaluateTestServer(t)
+	handler := newBulkReevaluateTestHandler(server.URL)
+
+	app := createTestApp()
+	app.Post("/api/projects/:id/reevaluate", RequireAdminToken(handler.reviewHandler.config), handler.HandleReevaluate)
+	req := httptest.NewRequest("POST", "/api/projects/123/reevaluate", nil)
+	req.Header.Set("Authorization", "Bearer admin-secret")
+
+	resp, err := app.Test(req, -1)
+	require.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+
+	var response BulkReevaluateResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&response))
+	assert.Equal(t, 123, response.ProjectID)
+	assert.Equal(t, 4, response.TotalMRs)
+	assert.Equal(t, 1, response.Approved)
+	assert.Equal(t, 1, response.Skipped)
+	assert.Equal(t, 1, response.ManualReview)
+	assert.Equal(t, 1, response.Failed)
+
+	require.Len(t, response.Results, 4)
+	assert.True(t, response.Results[0].MRApproved)
+	assert.True(t, response.Results[1].Skipped)
+	assert.Equal(t, shared.ManualReview, response.Results[2].Decision)
+	assert.Contains(t, response.Results[3].Error, "Failed to approve MR")
+	assert.Equal(t, []string{"/api/v4/projects/123/merge_requests/1/approve"}, *approved)
+}
+
+func TestBulkReevaluateHandler_RequiresAdminToken(t *testing.T) {
+	tests := []struct {
+		name           string
+		adminToken     string
+		authorization  string
+		expectedStatus int
+	}{
+		{"no token configured", "", "Bearer anything", 403},
+		{"missing header", "admin-secret", "", 401},
+		{"wrong token", "admin-secret", "Bearer wrong", 401},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := newBulkReevaluateTestHandler("https://gitlab.example.com")
+			cfg := &config.Config{Server: config.ServerConfig{AdminToken: tt.adminToken}}
+
+			app := createTestApp()
+			app.Post("/api/projects/:id/reevaluate", RequireAdminToken(cfg), handler.HandleReevaluate)
+			req := httptest.NewRequest("POST", "/api/projects/123/reevaluate", nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+
+			resp, err := app.Test(req)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+		})
+	}
+}
+
+func TestBulkReevaluateHandler_InvalidProjectID(t *testing.T) {
+	handler := newBulkReevaluateTestHandler("https://gitlab.example.com")
+
+	app := createTestApp()
+	app.Post("/api/projects/:id/reevaluate", handler.HandleReevaluate)
+
+	resp, err := app.Test(httptest.NewRequest("POST", "/api/projects/abc/reevaluate", nil))
+	require.NoError(t, err)
+	assert.Equal(t, 400, resp.StatusCode)
+}
+
+func TestBulkReevaluateHandler_SimulatesWithoutToken(t *testing.T) {
+	setupTestRulesFile(t)
+	cfg := createTestConfig()
+	cfg.GitLab.Token = ""
+	cfg.GitLab.NoTokenMode = config.NoTokenModeSimulate
+	mockClient := &MockGitLabClient{changes: noteCommandTestChanges}
+	reviewHandler := NewDataProductConfigMrReviewHandlerWithClient(cfg, mockClient)
+	reviewHandler.ruleManager = &MockRuleManagerForApproval{}
+	handler := NewBulkReevaluateHandler(reviewHandler)
+
+	outcome := handler.reevaluateMR(123, gitlab.MRDetails{IID: 1, State: "opened", Sha: "aaa"}, "correlation")
+
+	assert.Equal(t, shared.ManualReview, outcome.Decision)
+	assert.Equal(t, "simulated: GITLAB_TOKEN not set", outcome.Reason)
+	assert.False(t, outcome.MRApproved)
+	assert.Zero(t, mockClient.fetchChangesCalls, "simulated decisions fetch nothing")
+	assert.Empty(t, mockClient.approvalMessages)
+}
+
+func TestBulkReevaluateHandler_SkipsLikeWebhooks(t *testing.T) {
+	tests := []struct {
+		name           string
+		mr             gitlab.MRDetails
+		expectedReason string
+	}{
+		{"draft", gitlab.MRDetails{IID: 1, State: "opened", Draft: true, TargetBranch: "main"}, "draft MR"},
+		{"non-protected target", gitlab.MRDetails{IID: 2, State: "opened", TargetBranch: "scratch"}, "target branch 'scratch' is not protected"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setupTestRulesFile(t)
+			cfg := createTestConfig()
+			cfg.Webhook.ProtectedTargetsOnly = true
+			mockClient := &MockGitLabClient{
+				changes:           noteCommandTestChanges,
+				protectedBranches: map[string]bool{"main": true},
+			}
+			reviewHandler := NewDataProductConfigMrReviewHandlerWithClient(cfg, mockClient)
+			reviewHandler.ruleManager = &MockRuleManagerForApproval{}
+			handler := NewBulkReevaluateHandler(reviewHandler)
+
+			outcome := handler.reevaluateMR(123, tt.mr, "correlation")
+
+			assert.True(t, outcome.Skipped)
+			assert.Equal(t, tt.expectedReason, outcome.Reason)
+			assert.Zero(t, mockClient.fetchChangesCalls)
+			assert.Empty(t, mockClient.approvalMessages)
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package webhook
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/logging"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+)
+
+// Names of the templates looked up in a custom comment template file.
+// A template file defines one or both with {{define "approval"}}...{{end}}.
+const (
+	approvalTemplateName     = "approval"
+	manualReviewTemplateName = "manual_review"
+)
+
+// CommentTemplateData is the data exposed to custom comment templates
+type CommentTemplateData struct {
+	Result *shared.RuleEvaluation
+	MRInfo *gitlab.MRInfo
+}
+
+// loadCommentTemplate parses the template file at path. It returns nil when
+// no path is configured or the file cannot be parsed, so callers fall back
+// to the built-in comment format.
+func (mb *MessageBuilder) loadCommentTemplate(path string) *template.Template {
+	if path == "" {
+		return nil
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		logging.Warn("Failed to read comment template %s, using built-in format: %v", path, err)
+		return nil
+	}
+
+	tmpl, err := template.New("comment").Funcs(mb.templateFuncs()).Parse(string(content))
+	if err != nil {
+		logging.Warn("Failed to parse comment template %s, using built-in format: %v", path, err)
+		return nil
+	}
+
+	return tmpl
+}
+
+// templateFuncs returns the helper functions available to comment templates
+func (mb *MessageBuilder) templateFuncs() template.FuncMap {
+	return template.FuncMap{
+		"ruleName": mb.formatRuleName,
+		"fileList": func(result *shared.RuleEvaluation) []string {
+			if result == nil {
+				return nil
+			}
+			files := make([]string, 0, len(result.FileValidations))
+			for filePath := range result.FileValidations {
+				files = append(files, filePath)
+			}
+			sort.Strings(files)
+			return files
+		},
+		"mentions": mb.escalationMentions,
+		"join":     strings.Join,
+	}
+}
+
+// renderTemplate executes the named template and reports whether it succeeded.
+// The hidden comment identifier is always prepended so comment tracking keeps working.
+func (mb *MessageBuilder) renderTemplate(name, commentID string, result *shared.RuleEvaluation, mrInfo *gitlab.MRInfo) (string, bool) {
+	if mb.template == nil || mb.template.Lookup(name) == nil {
+		return "", false
+	}
+
+	var buf bytes.Buffer
+	data := CommentTemplateData{Result: result, MRInfo: mrInfo}
+	if err := mb.template.ExecuteTemplate(&buf, name, data); err != nil {
+		logging.Warn("Failed to render %s comment template, using built-in format: %v", name, err)
+		return "", false
+	}
+
+	return fmt.Sprintf("<!-- naysayer-comment-id: %s -->\n%s", commentID, buf.String()), true
+}
@@ -0,0 +1,118 @@
+package webhook
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+	"github.com/stretchr/testify/assert"
+)
+
+func writeCommentTemplate(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "comment.tmpl")
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	return path
+}
+
+func templateTestEvaluation(decision shared.DecisionType) *shared.RuleEvaluation {
+	return &shared.RuleEvaluation{
+		FinalDecision: shared.Decision{Type: decision, Reason: "test reason"},
+		FileValidations: map[string]*shared.FileValidationSummary{
+			"b/product.yaml": {
+				FilePath:     "b/product.yaml",
+				FileDecision: decision,
+				RuleResults: []shared.LineValidationResult{
+					{RuleName: "warehouse_rule", Decision: decision, Reason: "warehouse checked", WasEvaluated: true},
+				},
+			},
+			"a/product.yaml": {FilePath: "a/product.yaml", FileDecision: decision},
+		},
+		TotalFiles: 2,
+	}
+}
+
+func TestBuildComment_CustomTemplate(t *testing.T) {
+	path := writeCommentTemplate(t, `{{define "approval"}}:rocket: Approved !{{.MRInfo.MRIID}} files={{join (fileList .Result) ","}}{{end}}`+
+		`{{define "manual_review"}}:eyes: {{.Result.FinalDecision.Reason}}{{range $f, $v := .Result.FileValidations}}{{range $v.RuleResults}} [{{ruleName .RuleName}}]{{end}}{{end}}{{end}}`)
+
+	cfg := &config.Config{Comments: config.CommentsConfig{TemplatePath: path}}
+	builder := NewMessageBuilder(cfg)
+	mrInfo := &gitlab.MRInfo{ProjectID: 1, MRIID: 42}
+
+	approval := builder.BuildApprovalComment(templateTestEvaluation(shared.Approve), mrInfo)
+	assert.Equal(t, "<!-- naysayer-comment-id: approval -->\n:rocket: Approved !42 files=a/product.yaml,b/product.yaml", approval)
+
+	review := builder.BuildManualReviewComment(templateTestEvaluation(shared.ManualReview), mrInfo)
+	assert.Equal(t, "<!-- naysayer-comment-id: manual-review -->\n:eyes: test reason [Warehouse configuration validated]", review)
+}
+
+func TestBuildComment_CustomTemplateMissingDefinitionFallsBack(t *testing.T) {
+	path := writeCommentTemplate(t, `{{define "approval"}}custom approval{{end}}`)
+
+	builder := NewMessageBuilder(&config.Config{Comments: config.CommentsConfig{TemplatePath: path}})
+	mrInfo := &gitlab.MRInfo{ProjectID: 1, MRIID: 42}
+
+	review := builder.BuildManualReviewComment(templateTestEvaluation(shared.ManualReview), mrInfo)
+	assert.Contains(t, review, "⚠️ **Manual review required**")
+}
+
+func TestBuildComment_BrokenTemplateFallsBack(t *testing.T) {
+	mrInfo := &gitlab.MRInfo{ProjectID: 1, MRIID: 42}
+
+	t.Run("parse error", func(t *testing.T) {
+		path := writeCommentTemplate(t, `{{define "approval"}}{{.Result.FinalDecision{{end}}`)
+		builder := NewMessageBuilder(&config.Config{Comments: config.CommentsConfig{TemplatePath: path}})
+
+		assert.Nil(t, builder.template)
+		comment := builder.BuildApprovalComment(templateTestEvaluation(shared.Approve), mrInfo)
+		assert.Contains(t, comment, "✅ **Auto-approved**")
+	})
+
+	t.Run("execution error", func(t *testing.T) {
+		path := writeCommentTemplate(t, `{{define "approval"}}{{.Result.DoesNotExist}}{{end}}`)
+		builder := NewMessageBuilder(&config.Config{Comments: config.CommentsConfig{TemplatePath: path}})
+
+		comment := builder.BuildApprovalComment(templateTestEvaluation(shared.Approve), mrInfo)
+		assert.Contains(t, comment, "<!-- naysayer-comment-id: approval -->")
+		assert.Contains(t, comment, "✅ **Auto-approved**")
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		builder := NewMessageBuilder(&config.Config{Comments: config.CommentsConfig{TemplatePath: "/nonexistent/comment.tmpl"}})
+
+		assert.Nil(t, builder.template)
+		comment := builder.BuildApprovalComment(templateTestEvaluation(shared.Approve), mrInfo)
+		assert.Contains(t, comment, "✅ **Auto-approved**")
+	})
+}
+
+func TestBuildComment_DefaultWithoutTemplate(t *testing.T) {
+	builder := NewMessageBuilder(&config.Config{})
+	mrInfo := &gitlab.MRInfo{ProjectID: 1, MRIID: 42}
+
+	assert.Nil(t, builder.template)
+	comment := builder.BuildApprovalComment(templateTestEvaluation(shared.Approve), mrInfo)
+	assert.Contains(t, comment, "<!-- naysayer-comment-id: approval -->")
+	assert.Contains(t, comment, "✅ **Auto-approved**")
+}
+
+func TestReviewHandler_CommentTemplateReadOnceUntilReload(t *testing.T) {
+	setupTestRulesFile(t)
+	path := writeCommentTemplate(t, `{{define "approval"}}first{{end}}`)
+	cfg := createTestConfig()
+	cfg.Comments.TemplatePath = path
+	handler := NewDataProductConfigMrReviewHandlerWithClient(cfg, &MockGitLabClient{})
+	mrInfo := &gitlab.MRInfo{ProjectID: 1, MRIID: 42}
+
+	assert.NoError(t, os.WriteFile(path, []byte(`{{define "approval"}}second{{end}}`), 0644))
+	assert.Same(t, handler.messages(), handler.messages(), "comments reuse the parsed template")
+	assert.Contains(t, handler.messages().BuildApprovalComment(templateTestEvaluation(shared.Approve), mrInfo), "first")
+
+	_, err := handler.ReloadRules()
+	assert.NoError(t, err)
+	assert.Contains(t, handler.messages().BuildApprovalComment(templateTestEvaluation(shared.Approve), mrInfo), "second")
+}
@@ -1,8 +1,14 @@
 package webhook
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"fmt"
+	"sort"
 	"strings"
+	"sync/atomic"
+	"time"
+	"unicode/utf8"
 
 	fiber "github.com/gofiber/fiber/v2"
 	"github.com/redhat-data-and-ai/naysayer/internal/config"
@@ -16,9 +22,17 @@ import (
 
 // DataProductConfigMrReviewHandler handles GitLab webhook requests
 type DataProductConfigMrReviewHandler struct {
-	gitlabClient gitlab.GitLabClient
-	ruleManager  shared.RuleManager
-	config       *config.Config
+	gitlabClient  gitlab.GitLabClient
+	ruleManager   shared.RuleManager
+	config        *config.Config
+	now           func() time.Time // Clock used for quiet hours; defaults to time.Now
+	decisionHooks []DecisionHook   // Run after every approve/manual review decision
+	dryRun        bool             // Evaluate and report decisions without writing anything to GitLab
+	rulesPath     string           // Rule configuration of a named review route; "" for the dataverse rules.yaml
+
+	updateDebouncer *updateDebouncer               // Coalesces bursts of update events; nil evaluates every event immediately
+	decisionCache   *decisionCache                 // Reuses the evaluation of an unchanged head commit; nil evaluates every event
+	messageBuilder  atomic.Pointer[MessageBuilder] // Comment template and texts, read once and again on rules reload
 }
 
 // NewDataProductConfigMrReviewHandler creates a new webhook handler
@@ -46,14 +60,99 @@ func NewDataProductConfigMrReviewHandlerWithClient(cfg *config.Config, client gi
 	}
 
 	// Log comments configuration
-	logging.Info("MR Comments: %t (verbosity: %s)",
-		cfg.Comments.EnableMRComments, cfg.Comments.CommentVerbosity)
+	logging.Info("MR Comments: %t (approval verbosity: %s, review verbosity: %s)",
+		cfg.Comments.EnableMRComments, cfg.Comments.ApprovalCommentVerbosity(), cfg.Comments.ReviewCommentVerbosity())
+
+	return newReviewHandler(cfg, client, manager)
+}
+
+// NewNamedConfigReviewHandler creates a webhook handler that validates MRs with the rule
+// configuration at rulesPath instead of rules.yaml, for a POST /review/<name> route
+func NewNamedConfigReviewHandler(cfg *config.Config, rulesPath string) (*DataProductConfigMrReviewHandler, error) {
+	return NewNamedConfigReviewHandlerWithClient(cfg, gitlab.NewClientWithConfig(cfg), rulesPath)
+}
+
+// NewNamedConfigReviewHandlerWithClient creates a named-config webhook handler with a custom GitLab client
+func NewNamedConfigReviewHandlerWithClient(cfg *config.Config, client gitlab.GitLabClient, rulesPath string) (*DataProductConfigMrReviewHandler, error) {
+	manager, err := rules.CreateSectionBasedManager(client, rulesPath)
+	if err != nil {
+		return nil, err
+	}
+
+	handler := newReviewHandler(cfg, client, manager)
+	handler.rulesPath = rulesPath
+	return handler, nil
+}
 
-	return &DataProductConfigMrReviewHandler{
+// newReviewHandler creates a webhook handler evaluating MRs with the given rule manager
+func newReviewHandler(cfg *config.Config, client gitlab.GitLabClient, manager shared.RuleManager) *DataProductConfigMrReviewHandler {
+	handler := &DataProductConfigMrReviewHandler{
 		gitlabClient: client,
 		ruleManager:  manager,
 		config:       cfg,
 	}
+	if cfg.Webhook.UpdateDebounceSeconds > 0 {
+		handler.updateDebouncer = newUpdateDebouncer(time.Duration(cfg.Webhook.UpdateDebounceSeconds) * time.Second)
+	}
+	if cfg.Webhook.DecisionCacheSeconds > 0 {
+		handler.decisionCache = newDecisionCache(time.Duration(cfg.Webhook.DecisionCacheSeconds) * time.Second)
+	}
+	handler.messageBuilder.Store(NewMessageBuilder(cfg))
+	return handler
+}
+
+// EnableDryRun makes the handler evaluate MRs and report the decision without approving,
+// commenting, labelling or archiving anything. Used to replay captured webhooks.
+func (h *DataProductConfigMrReviewHandler) EnableDryRun() {
+	h.dryRun = true
+}
+
+// ReloadRules re-reads rules.yaml, or the named route's rule configuration, into the handler's rule manager
+func (h *DataProductConfigMrReviewHandler) ReloadRules() (int, error) {
+	var count int
+	var err error
+	if h.rulesPath != "" {
+		count, err = rules.ReloadSectionBasedManager(h.ruleManager, h.gitlabClient, h.rulesPath)
+	} else {
+		count, err = rules.ReloadSectionBasedDataverseManager(h.ruleManager, h.gitlabClient)
+	}
+	if err == nil && h.decisionCache != nil {
+		// Evaluations made with the previous rules may no longer hold
+		h.decisionCache.clear()
+	}
+	if err == nil {
+		// Pick up edits to the comment template and texts along with the rules
+		h.messageBuilder.Store(NewMessageBuilder(h.config))
+	}
+	return count, err
+}
+
+// messages returns the handler's message builder, building it on first use, so the comment
+// template and texts are read and parsed once rather than for every comment
+func (h *DataProductConfigMrReviewHandler) messages() *MessageBuilder {
+	if mb := h.messageBuilder.Load(); mb != nil {
+		return mb
+	}
+	h.messageBuilder.CompareAndSwap(nil, NewMessageBuilder(h.config))
+	return h.messageBuilder.Load()
+}
+
+// CheckRules reports whether ReloadRules would succeed, without changing the loaded rules
+func (h *DataProductConfigMrReviewHandler) CheckRules() error {
+	if h.rulesPath != "" {
+		return rules.CheckSectionBasedReload(h.ruleManager, h.gitlabClient, h.rulesPath)
+	}
+	return rules.CheckSectionBasedDataverseReload(h.ruleManager, h.gitlabClient)
+}
+
+// RulesConfig returns the rules.yaml configuration currently loaded into the handler's rule manager
+func (h *DataProductConfigMrReviewHandler) RulesConfig() (*config.GlobalRuleConfig, error) {
+	return rules.DataverseRuleConfig(h.ruleManager)
+}
+
+// CoverageReport returns the file types that required manual review for lack of rules
+func (h *DataProductConfigMrReviewHandler) CoverageReport() (rules.CoverageReport, error) {
+	return rules.DataverseCoverageReport(h.ruleManager)
 }
 
 // HandleWebhook processes GitLab webhook requests with security validation
@@ -79,6 +178,13 @@ func (h *DataProductConfigMrReviewHandler) HandleWebhook(c *fiber.Ctx) error {
 		})
 	}
 
+	// Acknowledge well-formed events of other kinds before validating MR fields they don't have
+	if eventType, ok := payload["object_kind"].(string); ok && eventType != "" {
+		if _, supported := gitlabEventHeaders[eventType]; !supported {
+			return ignoredEvent(c, eventType, "Only merge_request and pipeline events are supported")
+		}
+	}
+
 	// Validate webhook payload structure
 	if err := h.validateWebhookPayload(payload); err != nil {
 		logging.Warn("Webhook validation failed: %v", err)
@@ -87,7 +193,7 @@ func (h *DataProductConfigMrReviewHandler) HandleWebhook(c *fiber.Ctx) error {
 		})
 	}
 
-	// Only support MR events
+	// Only support MR and pipeline events
 	eventType, ok := payload["object_kind"].(string)
 	if !ok {
 		logging.Warn("Missing object_kind in payload")
@@ -96,18 +202,92 @@ func (h *DataProductConfigMrReviewHandler) HandleWebhook(c *fiber.Ctx) error {
 		})
 	}
 
-	if eventType != "merge_request" {
-		logging.Warn("Skipping unsupported event: %s", eventType)
+	if err := h.validateEventHeader(c.Get(GitLabEventHeader), eventType); err != nil {
+		logging.Warn("Rejected webhook: %v", err)
 		return c.Status(400).JSON(fiber.Map{
-			"error": fmt.Sprintf("Unsupported event type: %s. Only merge_request events are supported.", eventType),
+			"error": err.Error(),
 		})
 	}
 
-	return h.handleMergeRequestEvent(c, payload)
+	switch eventType {
+	case "merge_request":
+		return h.handleMergeRequestEvent(c, payload)
+	case "pipeline":
+		return h.handlePipelineEvent(c, payload)
+	default:
+		return ignoredEvent(c, eventType, "Only merge_request and pipeline events are supported")
+	}
+}
+
+// ignoredEvent acknowledges a well-formed event of a kind the endpoint doesn't handle with 200, so
+// GitLab doesn't count the delivery as failed and disable the hook; 400 is kept for malformed requests
+func ignoredEvent(c *fiber.Ctx, eventType, reason string) error {
+	logging.Info("Ignoring unsupported event: %s", eventType)
+	return c.JSON(fiber.Map{
+		"webhook_response": "ignored",
+		"status":           "ignored",
+		"event_type":       eventType,
+		"reason":           reason,
+	})
+}
+
+// GitLabEventHeader names the GitLab event type of a webhook delivery (e.g. "Merge Request Hook")
+const GitLabEventHeader = "X-Gitlab-Event"
+
+// GitLabTokenHeader carries the secret token configured on a GitLab webhook
+const GitLabTokenHeader = "X-Gitlab-Token"
+
+// gitlabEventHeaders lists the X-Gitlab-Event values GitLab sends for each supported object_kind
+var gitlabEventHeaders = map[string][]string{
+	"merge_request": {"Merge Request Hook", "System Hook"},
+	"pipeline":      {"Pipeline Hook", "System Hook"},
+}
+
+// validateEventHeader checks that the X-Gitlab-Event header matches the payload's object_kind, so
+// e.g. a Push Hook sent to this endpoint is rejected. A missing header is only accepted while
+// webhook token verification is off (no WEBHOOK_SECRET), which test tooling relies on.
+func (h *DataProductConfigMrReviewHandler) validateEventHeader(header, eventType string) error {
+	expected, supported := gitlabEventHeaders[eventType]
+	if !supported {
+		return nil // Unsupported object kinds are rejected with their own message
+	}
+	if header == "" {
+		if h.config.HasWebhookSecret() {
+			return fmt.Errorf("missing %s header", GitLabEventHeader)
+		}
+		return nil
+	}
+	for _, value := range expected {
+		if header == value {
+			return nil
+		}
+	}
+	return fmt.Errorf("%s '%s' does not match %s payload (expected '%s')", GitLabEventHeader, header, eventType, expected[0])
 }
 
-// evaluateRules evaluates all rules and returns a decision with optimized error handling
+// evaluateRules evaluates all rules, reusing the cached evaluation of an unchanged head commit
 func (h *DataProductConfigMrReviewHandler) evaluateRules(projectID, mrID int, mrInfo *gitlab.MRInfo) (*shared.RuleEvaluation, error) {
+	if h.decisionCache != nil {
+		if cached := h.decisionCache.get(projectID, mrID, mrInfo.LastCommit); cached != nil {
+			logging.MRInfo(mrID, "Reusing rule evaluation of unchanged head commit", zap.String("head_sha", mrInfo.LastCommit))
+			return cached, nil
+		}
+	}
+	return h.evaluateRulesUncached(projectID, mrID, mrInfo)
+}
+
+// evaluateRulesUncached evaluates all rules and returns a decision with optimized error handling.
+// Explicit re-evaluations (/naysayer recheck, bulk re-evaluation) call it directly so they never
+// get a cached evaluation.
+func (h *DataProductConfigMrReviewHandler) evaluateRulesUncached(projectID, mrID int, mrInfo *gitlab.MRInfo) (*shared.RuleEvaluation, error) {
+	// Without a GitLab token nothing can be fetched, whichever path (webhook, debounce, bulk, recheck) asked
+	if h.config.SimulatesWithoutToken() {
+		return &shared.RuleEvaluation{
+			FinalDecision:   simulatedDecision(mrID),
+			FileValidations: make(map[string]*shared.FileValidationSummary),
+		}, nil
+	}
+
 	// Fetch MR changes from GitLab API with timeout handling
 	changes, err := h.gitlabClient.FetchMRChanges(projectID, mrID)
 	if err != nil {
@@ -172,6 +352,14 @@ func (h *DataProductConfigMrReviewHandler) evaluateRules(projectID, mrID int, mr
 
 	// Evaluate all rules using the simple rule manager
 	result := h.ruleManager.EvaluateAll(mrContext)
+	if h.decisionCache != nil {
+		if result.UsesLiveState {
+			// Approvals, the target branch or other MRs can change without a new head commit
+			h.decisionCache.drop(projectID, mrID)
+		} else {
+			h.decisionCache.put(projectID, mrID, mrInfo.LastCommit, result)
+		}
+	}
 
 	// Log rule evaluation completion
 	logging.MRInfo(mrID, "Rule evaluation completed",
@@ -182,16 +370,21 @@ func (h *DataProductConfigMrReviewHandler) evaluateRules(projectID, mrID int, mr
 
 // handleApprovalWithComments handles the approval process with meaningful comments and messages
 func (h *DataProductConfigMrReviewHandler) handleApprovalWithComments(result *shared.RuleEvaluation, mrInfo *gitlab.MRInfo) error {
-	messageBuilder := NewMessageBuilder(h.config)
+	messageBuilder := h.messages()
 
 	// Add detailed comment to MR if enabled
-	if h.config.Comments.EnableMRComments {
-		comment := messageBuilder.BuildApprovalComment(result, mrInfo)
+	if h.config.Comments.EnableMRComments && h.decisionUnchanged(result, mrInfo) {
+		logging.MRInfo(mrInfo.MRIID, "Skipping approval comment (decision unchanged since last comment)")
+	} else if h.config.Comments.EnableMRComments {
+		comment := withApprovedCommitMarker(messageBuilder.BuildApprovalComment(result, mrInfo), mrInfo)
 
 		logging.MRInfo(mrInfo.MRIID, "Adding/updating approval comment")
 
 		// Use smart comment handling (update existing or create new)
 		if h.config.Comments.UpdateExistingComments {
+			// Decision flipped to approval - the previous manual review comment is now outdated
+			h.removeStaleComment(mrInfo, "manual-review")
+
 			if err := h.gitlabClient.AddOrUpdateMRComment(mrInfo.ProjectID, mrInfo.MRIID, comment, "approval"); err != nil {
 				logging.MRError(mrInfo.MRIID, "Failed to add/update comment", err)
 				// Continue with approval even if comment fails - comment is nice-to-have
@@ -231,7 +424,7 @@ func (h *DataProductConfigMrReviewHandler) handleApprovalWithComments(result *sh
 
 // handleManualReviewWithComments handles manual review decisions with informational comments
 func (h *DataProductConfigMrReviewHandler) handleManualReviewWithComments(result *shared.RuleEvaluation, mrInfo *gitlab.MRInfo) error {
-	messageBuilder := NewMessageBuilder(h.config)
+	messageBuilder := h.messages()
 
 	// Reset any previous naysayer approval since manual review is now required
 	logging.MRInfo(mrInfo.MRIID, "Resetting any previous naysayer approval")
@@ -243,13 +436,18 @@ func (h *DataProductConfigMrReviewHandler) handleManualReviewWithComments(result
 	}
 
 	// Add informational comment to MR if enabled
-	if h.config.Comments.EnableMRComments {
+	if h.config.Comments.EnableMRComments && h.decisionUnchanged(result, mrInfo) {
+		logging.MRInfo(mrInfo.MRIID, "Skipping manual review comment (decision unchanged since last comment)")
+	} else if h.config.Comments.EnableMRComments {
 		comment := messageBuilder.BuildManualReviewComment(result, mrInfo)
 
 		logging.MRInfo(mrInfo.MRIID, "Adding/updating manual review comment")
 
 		// Use smart comment handling (update existing or create new)
 		if h.config.Comments.UpdateExistingComments {
+			// Decision flipped to manual review - the previous approval comment is now outdated
+			h.removeStaleComment(mrInfo, "approval")
+
 			if err := h.gitlabClient.AddOrUpdateMRComment(mrInfo.ProjectID, mrInfo.MRIID, comment, "manual-review"); err != nil {
 				logging.MRError(mrInfo.MRIID, "Failed to add/update manual review comment", err)
 				// Continue without error - comment is nice-to-have
@@ -269,13 +467,142 @@ func (h *DataProductConfigMrReviewHandler) handleManualReviewWithComments(result
 		logging.MRInfo(mrInfo.MRIID, "Skipping manual review comment (comments disabled)")
 	}
 
+	if h.config.Comments.InlineDiffNotes {
+		h.postInlineDiffNotes(result, mrInfo)
+	}
+
 	return nil
 }
 
+// postInlineDiffNotes points reviewers at the first uncovered line of each file that needs manual review.
+// Notes carry a hidden marker per file, line and commit so re-evaluations of the same commit don't repeat them.
+func (h *DataProductConfigMrReviewHandler) postInlineDiffNotes(result *shared.RuleEvaluation, mrInfo *gitlab.MRInfo) {
+	if mrInfo.LastCommit == "" {
+		logging.MRWarn(mrInfo.MRIID, "Cannot post inline notes: last commit SHA missing from webhook payload")
+		return
+	}
+
+	existing, err := h.gitlabClient.ListMRComments(mrInfo.ProjectID, mrInfo.MRIID)
+	if err != nil {
+		logging.MRWarn(mrInfo.MRIID, "Could not list comments, inline notes may be repeated", zap.Error(err))
+	}
+
+	filePaths := make([]string, 0, len(result.FileValidations))
+	for filePath := range result.FileValidations {
+		filePaths = append(filePaths, filePath)
+	}
+	sort.Strings(filePaths)
+
+	for _, filePath := range filePaths {
+		validation := result.FileValidations[filePath]
+		if validation.FileDecision != shared.ManualReview || len(validation.UncoveredLines) == 0 {
+			continue
+		}
+		uncovered := validation.UncoveredLines[0]
+		if uncovered.StartLine < 1 {
+			continue
+		}
+
+		marker := fmt.Sprintf("<!-- naysayer-comment-id: inline %s:%d@%s -->", filePath, uncovered.StartLine, mrInfo.LastCommit)
+		if hasCommentContaining(existing, marker) {
+			continue
+		}
+
+		body := fmt.Sprintf("%s\n⚠️ **Manual review required**: lines %d-%d are not covered by any validation rule.",
+			marker, uncovered.StartLine, uncovered.EndLine)
+		if err := h.gitlabClient.AddMRDiffNote(mrInfo.ProjectID, mrInfo.MRIID, mrInfo.LastCommit, filePath, uncovered.StartLine, body); err != nil {
+			logging.MRWarn(mrInfo.MRIID, "Failed to post inline note", zap.String("file", filePath), zap.Error(err))
+			continue
+		}
+		logging.MRInfo(mrInfo.MRIID, "Posted inline note", zap.String("file", filePath), zap.Int("line", uncovered.StartLine))
+	}
+}
+
+// hasCommentContaining reports whether any comment body contains text
+func hasCommentContaining(comments []gitlab.MRComment, text string) bool {
+	for _, comment := range comments {
+		if strings.Contains(comment.Body, text) {
+			return true
+		}
+	}
+	return false
+}
+
+// decisionUnchanged reports whether COMMENT_ON_CHANGE_ONLY is set and naysayer's latest
+// decision comment already carries the same decision, so posting another would only add noise
+func (h *DataProductConfigMrReviewHandler) decisionUnchanged(result *shared.RuleEvaluation, mrInfo *gitlab.MRInfo) bool {
+	if !h.config.Comments.CommentOnChangeOnly {
+		return false
+	}
+	previous, decision := h.previousCommentDecision(mrInfo)
+	return previous != nil && decision == result.FinalDecision.Type
+}
+
+// previousCommentDecision returns naysayer's latest approval or manual review comment and its
+// decision, read from the comment's hidden identifier. When both exist the newer one wins;
+// the comment is nil when there is none.
+func (h *DataProductConfigMrReviewHandler) previousCommentDecision(mrInfo *gitlab.MRInfo) (*gitlab.MRComment, shared.DecisionType) {
+	var latest *gitlab.MRComment
+	var decision shared.DecisionType
+	for commentType, commentDecision := range map[string]shared.DecisionType{"approval": shared.Approve, "manual-review": shared.ManualReview} {
+		comment, err := h.gitlabClient.FindLatestNaysayerComment(mrInfo.ProjectID, mrInfo.MRIID, commentType)
+		if err != nil {
+			logging.MRWarn(mrInfo.MRIID, "Failed to look up previous decision comment", zap.String("comment_type", commentType), zap.Error(err))
+			return nil, ""
+		}
+		// GitLab note IDs increase over time, so the higher ID is the newer comment
+		if comment != nil && (latest == nil || comment.ID > latest.ID) {
+			latest, decision = comment, commentDecision
+		}
+	}
+	return latest, decision
+}
+
+// removeStaleComment deletes the latest naysayer comment of the given type so that
+// comments from a previous, opposite decision don't linger on the MR
+func (h *DataProductConfigMrReviewHandler) removeStaleComment(mrInfo *gitlab.MRInfo, commentType string) {
+	staleComment, err := h.gitlabClient.FindLatestNaysayerComment(mrInfo.ProjectID, mrInfo.MRIID, commentType)
+	if err != nil {
+		logging.MRWarn(mrInfo.MRIID, "Could not search for stale comment", zap.String("comment_type", commentType), zap.Error(err))
+		return
+	}
+	if staleComment == nil {
+		return
+	}
+
+	if err := h.gitlabClient.DeleteMRComment(mrInfo.ProjectID, mrInfo.MRIID, staleComment.ID); err != nil {
+		logging.MRWarn(mrInfo.MRIID, "Could not delete stale comment", zap.String("comment_type", commentType), zap.Error(err))
+		return
+	}
+	logging.MRInfo(mrInfo.MRIID, "Deleted stale comment", zap.String("comment_type", commentType), zap.Int("comment_id", staleComment.ID))
+}
+
+// removeAllComments deletes every naysayer comment of the given type, newest first
+func (h *DataProductConfigMrReviewHandler) removeAllComments(mrInfo *gitlab.MRInfo, commentType string) error {
+	deleted := make(map[int]bool)
+	for {
+		comment, err := h.gitlabClient.FindLatestNaysayerComment(mrInfo.ProjectID, mrInfo.MRIID, commentType)
+		if err != nil {
+			return fmt.Errorf("could not search for %s comments: %w", commentType, err)
+		}
+		if comment == nil {
+			return nil
+		}
+		if deleted[comment.ID] {
+			return fmt.Errorf("%s comment %d is still present after deletion", commentType, comment.ID)
+		}
+		if err := h.gitlabClient.DeleteMRComment(mrInfo.ProjectID, mrInfo.MRIID, comment.ID); err != nil {
+			return fmt.Errorf("could not delete %s comment %d: %w", commentType, comment.ID, err)
+		}
+		deleted[comment.ID] = true
+		logging.MRInfo(mrInfo.MRIID, "Deleted comment", zap.String("comment_type", commentType), zap.Int("comment_id", comment.ID))
+	}
+}
+
 // handleMergeRequestEvent handles traditional MR events (immediate processing)
 func (h *DataProductConfigMrReviewHandler) handleMergeRequestEvent(c *fiber.Ctx, payload map[string]interface{}) error {
 	// Extract MR information
-	mrInfo, err := gitlab.ExtractMRInfo(payload)
+	mrInfo, err := gitlab.ExtractMRInfo(payload, h.gitlabClient)
 	if err != nil {
 		logging.Error("Failed to extract MR info: %v", err)
 		return c.Status(400).JSON(fiber.Map{
@@ -283,44 +610,200 @@ func (h *DataProductConfigMrReviewHandler) handleMergeRequestEvent(c *fiber.Ctx,
 		})
 	}
 
-	logging.MRInfo(mrInfo.MRIID, "Processing MR event",
-		zap.Int("project_id", mrInfo.ProjectID),
-		zap.String("author", mrInfo.Author),
-		zap.String("state", mrInfo.State))
+	// Merged MRs are never evaluated; optionally archive the decision comment so it doesn't go stale
+	if mrInfo.Action == mrActionMerge && h.config.Comments.ArchiveOnMerge && !h.dryRun {
+		return h.handleMergedMR(c, mrInfo)
+	}
 
-	// Skip rule evaluation if MR is not open
-	if mrInfo.State != utils.MRStateOpened {
-		logging.MRInfo(mrInfo.MRIID, "Skipping rule evaluation for non-open MR",
-			zap.String("state", mrInfo.State))
+	// Closed MRs are never evaluated; optionally clear the review state naysayer left on them
+	if mrInfo.State == utils.MRStateClosed && h.config.Webhook.CleanupOnClose && !h.dryRun {
+		return h.handleClosedMR(c, mrInfo)
+	}
+
+	// Actions like approved or a label-only update would re-run an unchanged evaluation
+	if !h.isTriggerAction(mrInfo.Action) {
+		logging.MRInfo(mrInfo.MRIID, "Skipping rule evaluation for non-triggering action",
+			zap.String("action", mrInfo.Action))
 
 		return c.JSON(fiber.Map{
 			"webhook_response": "processed",
 			"event_type":       "merge_request",
 			"decision":         "skipped",
-			"reason":           fmt.Sprintf("MR state is '%s', only processing open MRs", mrInfo.State),
+			"reason":           fmt.Sprintf("MR action '%s' does not trigger evaluation", mrInfo.Action),
 			"mr_approved":      false,
 			"project_id":       mrInfo.ProjectID,
 			"mr_iid":           mrInfo.MRIID,
 		})
 	}
 
-	// Skip rule evaluation for draft MRs - no comments, no approval, no processing
-	mrCtx := &shared.MRContext{MRInfo: mrInfo}
-	if shared.IsDraftMR(mrCtx) {
-		logging.MRInfo(mrInfo.MRIID, "Skipping rule evaluation for draft MR",
-			zap.String("title", mrInfo.Title))
+	// Dry runs replay a single delivery and report its decision, so they are never deferred
+	if mrInfo.Action == mrActionUpdate && h.updateDebouncer != nil && !h.dryRun {
+		return h.scheduleUpdate(c, mrInfo)
+	}
 
+	return h.reviewMR(c, mrInfo, "merge_request")
+}
+
+// isTriggerAction reports whether an MR webhook action triggers evaluation. Payloads without
+// an action, and configurations without trigger actions, always trigger evaluation.
+func (h *DataProductConfigMrReviewHandler) isTriggerAction(action string) bool {
+	if action == "" || len(h.config.Webhook.TriggerActions) == 0 {
+		return true
+	}
+	for _, triggerAction := range h.config.Webhook.TriggerActions {
+		if triggerAction == action {
+			return true
+		}
+	}
+	return false
+}
+
+// mrActionMerge is the object_attributes.action GitLab sends when an MR is merged
+const mrActionMerge = "merge"
+
+// archivedCommentBody replaces naysayer's decision comment once the MR has merged
+const archivedCommentBody = "<!-- naysayer-comment-id: archived -->\n🗄️ **MR merged** — validation archived."
+
+// handleMergedMR replaces the latest approval and manual review comments with a short archived note
+func (h *DataProductConfigMrReviewHandler) handleMergedMR(c *fiber.Ctx, mrInfo *gitlab.MRInfo) error {
+	archived := 0
+	for _, commentType := range []string{"approval", "manual-review"} {
+		comment, err := h.gitlabClient.FindLatestNaysayerComment(mrInfo.ProjectID, mrInfo.MRIID, commentType)
+		if err != nil {
+			logging.MRWarn(mrInfo.MRIID, "Could not search for comment to archive", zap.String("comment_type", commentType), zap.Error(err))
+			continue
+		}
+		if comment == nil {
+			continue
+		}
+
+		if err := h.gitlabClient.UpdateMRComment(mrInfo.ProjectID, mrInfo.MRIID, comment.ID, archivedCommentBody); err != nil {
+			logging.MRWarn(mrInfo.MRIID, "Could not archive comment", zap.String("comment_type", commentType), zap.Error(err))
+			continue
+		}
+		archived++
+		logging.MRInfo(mrInfo.MRIID, "Archived comment on merged MR", zap.String("comment_type", commentType), zap.Int("comment_id", comment.ID))
+	}
+
+	return c.JSON(fiber.Map{
+		"webhook_response":  "processed",
+		"event_type":        "merge_request",
+		"decision":          "skipped",
+		"reason":            "MR merged, validation archived",
+		"mr_approved":       false,
+		"comments_archived": archived,
+		"project_id":        mrInfo.ProjectID,
+		"mr_iid":            mrInfo.MRIID,
+	})
+}
+
+// handleClosedMR removes the review label and revokes naysayer's approval from an MR closed
+// without merging, so closed MRs don't linger in review queues. Rules are not evaluated.
+func (h *DataProductConfigMrReviewHandler) handleClosedMR(c *fiber.Ctx, mrInfo *gitlab.MRInfo) error {
+	labelRemoved := false
+	if h.config.ReviewLabel.Enabled && h.config.ReviewLabel.Name != "" {
+		if err := h.gitlabClient.RemoveMRLabels(mrInfo.ProjectID, mrInfo.MRIID, []string{h.config.ReviewLabel.Name}); err != nil {
+			logging.MRWarn(mrInfo.MRIID, "Failed to remove review label from closed MR", zap.String("label", h.config.ReviewLabel.Name), zap.Error(err))
+		} else {
+			labelRemoved = true
+		}
+	}
+
+	approvalReset := true
+	if err := h.gitlabClient.ResetNaysayerApproval(mrInfo.ProjectID, mrInfo.MRIID); err != nil {
+		logging.MRWarn(mrInfo.MRIID, "Could not reset naysayer approval on closed MR (may not have been approved)", zap.Error(err))
+		approvalReset = false
+	}
+	logging.MRInfo(mrInfo.MRIID, "Cleaned up closed MR",
+		zap.Bool("label_removed", labelRemoved),
+		zap.Bool("approval_reset", approvalReset))
+
+	return c.JSON(fiber.Map{
+		"webhook_response": "processed",
+		"event_type":       "merge_request",
+		"decision":         "skipped",
+		"reason":           "MR closed, review state cleaned up",
+		"mr_approved":      false,
+		"label_removed":    labelRemoved,
+		"approval_reset":   approvalReset,
+		"project_id":       mrInfo.ProjectID,
+		"mr_iid":           mrInfo.MRIID,
+	})
+}
+
+// handlePipelineEvent re-evaluates the MR when its pipeline succeeds, so MRs held back
+// by REQUIRE_PASSING_PIPELINE get approved once CI is green
+func (h *DataProductConfigMrReviewHandler) handlePipelineEvent(c *fiber.Ctx, payload map[string]interface{}) error {
+	objectAttrs, _ := payload["object_attributes"].(map[string]interface{})
+	status, _ := objectAttrs["status"].(string)
+	mergeRequest, hasMR := payload["merge_request"].(map[string]interface{})
+
+	var skipReason string
+	switch {
+	case !h.config.Approval.RequirePassingPipeline:
+		skipReason = "pipeline events are only processed when REQUIRE_PASSING_PIPELINE is enabled"
+	case !hasMR:
+		skipReason = "pipeline is not associated with a merge request"
+	case status != gitlab.PipelineStatusSuccess:
+		skipReason = fmt.Sprintf("pipeline status is '%s', only successful pipelines trigger re-evaluation", status)
+	}
+	if skipReason != "" {
 		return c.JSON(fiber.Map{
 			"webhook_response": "processed",
-			"event_type":       "merge_request",
+			"event_type":       "pipeline",
+			"decision":         "skipped",
+			"reason":           skipReason,
+			"mr_approved":      false,
+		})
+	}
+
+	// The pipeline payload carries the MR under "merge_request" rather than "object_attributes"
+	mrInfo, err := gitlab.ExtractMRInfo(map[string]interface{}{
+		"object_attributes": mergeRequest,
+		"project":           payload["project"],
+		"user":              payload["user"],
+	}, h.gitlabClient)
+	if err != nil {
+		logging.Error("Failed to extract MR info from pipeline event: %v", err)
+		return c.Status(400).JSON(fiber.Map{
+			"error": "Missing MR information: " + err.Error(),
+		})
+	}
+	if sha, ok := objectAttrs["sha"].(string); ok {
+		mrInfo.LastCommit = sha
+	}
+
+	return h.reviewMR(c, mrInfo, "pipeline")
+}
+
+// reviewMR evaluates the rules for an open, non-draft MR and applies the decision
+func (h *DataProductConfigMrReviewHandler) reviewMR(c *fiber.Ctx, mrInfo *gitlab.MRInfo, eventType string) error {
+	logging.MRInfo(mrInfo.MRIID, "Processing MR event",
+		zap.String("event_type", eventType),
+		zap.Int("project_id", mrInfo.ProjectID),
+		zap.String("author", mrInfo.Author),
+		zap.String("state", mrInfo.State))
+
+	// Without a GitLab token nothing can be fetched or applied, so the decision is only simulated
+	if h.config.SimulatesWithoutToken() {
+		return h.simulatedReview(c, mrInfo, eventType)
+	}
+
+	if reason := h.evaluationSkipReason(mrInfo); reason != "" {
+		return c.JSON(fiber.Map{
+			"webhook_response": "processed",
+			"event_type":       eventType,
 			"decision":         "skipped",
-			"reason":           "Draft MR - skipped processing to avoid bypassing validation rules",
+			"reason":           reason,
 			"mr_approved":      false,
 			"project_id":       mrInfo.ProjectID,
 			"mr_iid":           mrInfo.MRIID,
 		})
 	}
 
+	unlock := evaluationLocks.lock(mrInfo.ProjectID, mrInfo.MRIID)
+	defer unlock()
+
 	// Fast evaluation using rule manager
 	result, err := h.evaluateRules(mrInfo.ProjectID, mrInfo.MRIID, mrInfo)
 	if err != nil {
@@ -330,44 +813,329 @@ func (h *DataProductConfigMrReviewHandler) handleMergeRequestEvent(c *fiber.Ctx,
 		})
 	}
 
+	result.CorrelationID = correlationID(c)
+
 	// Log decision with execution time
 	logging.MRInfo(mrInfo.MRIID, "Decision",
+		zap.String("correlation_id", result.CorrelationID),
 		zap.String("type", string(result.FinalDecision.Type)),
 		zap.String("reason", result.FinalDecision.Reason),
 		zap.Duration("execution_time", result.ExecutionTime))
 
-	// Handle approval with comments if decision is to approve
 	approved := false
-	if result.FinalDecision.Type == shared.Approve {
-		if err := h.handleApprovalWithComments(result, mrInfo); err != nil {
-			logging.MRError(mrInfo.MRIID, "Failed to approve", err)
-			return c.Status(500).JSON(fiber.Map{
-				"error": "Failed to approve MR: " + err.Error(),
-			})
-		}
-		approved = true
-	} else {
-		// Handle manual review with informational comments
-		if err := h.handleManualReviewWithComments(result, mrInfo); err != nil {
-			logging.MRError(mrInfo.MRIID, "Failed to add manual review comment", err)
-			// Continue - comment failure shouldn't block the webhook response
-		}
-		logging.MRInfo(mrInfo.MRIID, "Manual review required", zap.String("reason", result.FinalDecision.Reason))
+	if h.dryRun {
+		logging.MRInfo(mrInfo.MRIID, "Dry run, decision not applied")
+	} else if approved, err = h.applyDecision(result, mrInfo); err != nil {
+		return c.Status(500).JSON(fiber.Map{
+			"error": "Failed to approve MR: " + err.Error(),
+		})
 	}
 
 	// Return structured response for GitLab webhook
-	return c.JSON(fiber.Map{
+	response := fiber.Map{
 		"webhook_response": "processed",
-		"event_type":       "merge_request",
+		"event_type":       eventType,
 		"decision":         result.FinalDecision,
 		"execution_time":   result.ExecutionTime.String(),
 		"rules_evaluated":  result.TotalFiles,
 		"mr_approved":      approved,
 		"project_id":       mrInfo.ProjectID,
 		"mr_iid":           mrInfo.MRIID,
+	}
+	if result.RulesMisconfigured {
+		response["rules_misconfigured"] = true
+	}
+	if h.dryRun {
+		response["dry_run"] = true
+	}
+	return c.JSON(response)
+}
+
+// simulatedReview reports a manual review decision without fetching files or writing to GitLab,
+// labelled as simulated so it isn't mistaken for a real evaluation (NO_TOKEN_MODE=simulate)
+func (h *DataProductConfigMrReviewHandler) simulatedReview(c *fiber.Ctx, mrInfo *gitlab.MRInfo, eventType string) error {
+	decision := simulatedDecision(mrInfo.MRIID)
+
+	return c.JSON(fiber.Map{
+		"webhook_response": "processed",
+		"event_type":       eventType,
+		"decision":         decision,
+		"mr_approved":      false,
+		"simulated":        true,
+		"project_id":       mrInfo.ProjectID,
+		"mr_iid":           mrInfo.MRIID,
 	})
 }
 
+// simulatedDecision is the manual review reported for every MR while GITLAB_TOKEN is missing
+func simulatedDecision(mrIID int) shared.Decision {
+	decision := shared.Decision{
+		Type:    shared.ManualReview,
+		Reason:  "simulated: GITLAB_TOKEN not set",
+		Summary: "🧪 Simulated decision - no GitLab token configured, files were not evaluated",
+	}
+	logging.MRWarn(mrIID, "Simulated decision, GITLAB_TOKEN not set",
+		zap.String("type", string(decision.Type)))
+	return decision
+}
+
+// evaluationSkipReason returns why an MR is not evaluated: it is not open, is a draft (no comments,
+// no approval, no processing) or targets a non-protected branch. It returns "" for MRs to evaluate.
+func (h *DataProductConfigMrReviewHandler) evaluationSkipReason(mrInfo *gitlab.MRInfo) string {
+	if mrInfo.State != utils.MRStateOpened {
+		logging.MRInfo(mrInfo.MRIID, "Skipping rule evaluation for non-open MR",
+			zap.String("state", mrInfo.State))
+		return fmt.Sprintf("MR state is '%s', only processing open MRs", mrInfo.State)
+	}
+
+	if shared.IsDraftMR(&shared.MRContext{MRInfo: mrInfo}) {
+		logging.MRInfo(mrInfo.MRIID, "Skipping rule evaluation for draft MR",
+			zap.String("title", mrInfo.Title),
+			zap.Bool("draft_flag", mrInfo.Draft))
+		return "draft MR"
+	}
+
+	// Skip MRs into scratch branches when only protected targets are auto-approved
+	if targetBranch, protected := h.targetBranchProtected(mrInfo); !protected {
+		logging.MRInfo(mrInfo.MRIID, "Skipping rule evaluation for non-protected target branch",
+			zap.String("target_branch", targetBranch))
+		return fmt.Sprintf("target branch '%s' is not protected", targetBranch)
+	}
+	return ""
+}
+
+// targetBranchProtected reports whether the MR's target branch is protected, along with the branch.
+// Without PROTECTED_TARGETS_ONLY every target counts as protected. PROTECTED_BRANCHES globs take
+// precedence over GitLab's protection settings; if the branch can't be checked the MR is evaluated.
+func (h *DataProductConfigMrReviewHandler) targetBranchProtected(mrInfo *gitlab.MRInfo) (string, bool) {
+	if !h.config.Webhook.ProtectedTargetsOnly {
+		return mrInfo.TargetBranch, true
+	}
+
+	targetBranch := mrInfo.TargetBranch
+	if targetBranch == "" {
+		branch, err := h.gitlabClient.GetMRTargetBranch(mrInfo.ProjectID, mrInfo.MRIID)
+		if err != nil {
+			logging.MRWarn(mrInfo.MRIID, "Could not resolve target branch, evaluating anyway", zap.Error(err))
+			return "", true
+		}
+		targetBranch = branch
+	}
+
+	if len(h.config.Webhook.ProtectedBranches) > 0 {
+		return targetBranch, shared.MatchesAnyPattern(targetBranch, h.config.Webhook.ProtectedBranches)
+	}
+
+	protected, err := h.gitlabClient.IsBranchProtected(mrInfo.ProjectID, targetBranch)
+	if err != nil {
+		logging.MRWarn(mrInfo.MRIID, "Could not check target branch protection, evaluating anyway",
+			zap.String("target_branch", targetBranch), zap.Error(err))
+		return targetBranch, true
+	}
+	return targetBranch, protected
+}
+
+// applyDecision approves the MR or requests manual review based on the evaluation result.
+// A manual-review hold set via `/naysayer hold`, a quiet hours change freeze or a head
+// pipeline that has not passed (with REQUIRE_PASSING_PIPELINE) turns an approval into
+// manual review. An approval that a reviewer removed from the current commit is not restored.
+func (h *DataProductConfigMrReviewHandler) applyDecision(result *shared.RuleEvaluation, mrInfo *gitlab.MRInfo) (bool, error) {
+	if h.config.SimulatesWithoutToken() {
+		logging.MRInfo(mrInfo.MRIID, "Simulated decision, not applied")
+		return false, nil
+	}
+
+	if result.FinalDecision.Type == shared.Approve {
+		if reason, held := h.hasManualReviewHold(mrInfo); held {
+			logging.MRInfo(mrInfo.MRIID, "Manual review hold is set, not auto-approving")
+			result.FinalDecision = shared.Decision{
+				Type:    shared.ManualReview,
+				Reason:  reason,
+				Summary: "Manual review hold",
+			}
+		}
+	}
+
+	if result.FinalDecision.Type == shared.Approve {
+		if reason, frozen := h.quietHoursFreeze(); frozen {
+			logging.MRInfo(mrInfo.MRIID, "Outside allowed approval window, not auto-approving", zap.String("reason", reason))
+			result.FinalDecision = shared.Decision{
+				Type:    shared.ManualReview,
+				Reason:  reason,
+				Summary: "Change freeze",
+			}
+		}
+	}
+
+	waitingOnPipeline := false
+	if result.FinalDecision.Type == shared.Approve {
+		if reason, waiting := h.pipelineNotPassed(mrInfo); waiting {
+			logging.MRInfo(mrInfo.MRIID, "Head pipeline has not passed, not auto-approving", zap.String("reason", reason))
+			result.FinalDecision = shared.Decision{
+				Type:    shared.ManualReview,
+				Reason:  reason,
+				Summary: "Waiting on pipeline",
+			}
+			waitingOnPipeline = true
+		}
+	}
+
+	if result.FinalDecision.Type == shared.Approve {
+		if reason, missing := h.independentApprovalMissing(result, mrInfo); missing {
+			logging.MRInfo(mrInfo.MRIID, "Naysayer would be the only approver, requesting human review", zap.String("reason", reason))
+			result.FinalDecision = shared.Decision{
+				Type:    shared.ManualReview,
+				Reason:  reason,
+				Summary: "Independent approval required",
+			}
+		}
+	}
+
+	if result.FinalDecision.Type == shared.Approve && h.approvalRevoked(mrInfo) {
+		logging.MRInfo(mrInfo.MRIID, "Naysayer approval was removed by a reviewer, not re-approving until new commits are pushed")
+		h.postReapprovalSuppressedNote(mrInfo)
+		return false, nil
+	}
+
+	// Handle approval with comments if decision is to approve
+	if result.FinalDecision.Type == shared.Approve {
+		if err := h.handleApprovalWithComments(result, mrInfo); err != nil {
+			logging.MRError(mrInfo.MRIID, "Failed to approve", err)
+			return false, err
+		}
+		h.reportCommitStatus(result, mrInfo)
+		h.syncReviewLabel(result, mrInfo)
+		h.runDecisionHooks(mrInfo, result)
+		return true, nil
+	}
+
+	// Handle manual review with informational comments
+	if err := h.handleManualReviewWithComments(result, mrInfo); err != nil {
+		logging.MRError(mrInfo.MRIID, "Failed to add manual review comment", err)
+		// Continue - comment failure shouldn't block the webhook response
+	}
+	logging.MRInfo(mrInfo.MRIID, "Manual review required", zap.String("reason", result.FinalDecision.Reason))
+	// A pending naysayer status would join the running pipeline and keep it from ever succeeding
+	if !waitingOnPipeline {
+		h.reportCommitStatus(result, mrInfo)
+	}
+	h.syncReviewLabel(result, mrInfo)
+	h.runDecisionHooks(mrInfo, result)
+	return false, nil
+}
+
+// syncReviewLabel adds the review label to MRs that need manual review and removes it
+// once they are approved, so reviewers can filter their MR list by it
+func (h *DataProductConfigMrReviewHandler) syncReviewLabel(result *shared.RuleEvaluation, mrInfo *gitlab.MRInfo) {
+	if !h.config.ReviewLabel.Enabled || h.config.ReviewLabel.Name == "" {
+		return
+	}
+
+	labels := []string{h.config.ReviewLabel.Name}
+	if result.FinalDecision.Type == shared.Approve {
+		if err := h.gitlabClient.RemoveMRLabels(mrInfo.ProjectID, mrInfo.MRIID, labels); err != nil {
+			logging.MRWarn(mrInfo.MRIID, "Failed to remove review label", zap.String("label", h.config.ReviewLabel.Name), zap.Error(err))
+		}
+		return
+	}
+
+	if err := h.gitlabClient.AddMRLabels(mrInfo.ProjectID, mrInfo.MRIID, labels); err != nil {
+		logging.MRWarn(mrInfo.MRIID, "Failed to add review label", zap.String("label", h.config.ReviewLabel.Name), zap.Error(err))
+	}
+}
+
+// reportCommitStatus posts the decision as a commit status on the MR's last commit when enabled
+func (h *DataProductConfigMrReviewHandler) reportCommitStatus(result *shared.RuleEvaluation, mrInfo *gitlab.MRInfo) {
+	if !h.config.CommitStatus.Enabled {
+		return
+	}
+	if mrInfo.LastCommit == "" {
+		logging.MRWarn(mrInfo.MRIID, "Cannot set commit status: last commit SHA missing from webhook payload")
+		return
+	}
+
+	state := gitlab.CommitStatusSuccess
+	if result.FinalDecision.Type != shared.Approve {
+		state = gitlab.CommitStatusPending
+		if h.config.CommitStatus.ManualReviewState == gitlab.CommitStatusFailed {
+			state = gitlab.CommitStatusFailed
+		}
+	}
+
+	// Keep the description within GitLab's 255 character limit, cutting between characters
+	description := result.FinalDecision.Reason
+	if utf8.RuneCountInString(description) > 255 {
+		description = string([]rune(description)[:252]) + "..."
+	}
+
+	if err := h.gitlabClient.SetCommitStatus(mrInfo.ProjectID, mrInfo.LastCommit, state, description); err != nil {
+		logging.MRWarn(mrInfo.MRIID, "Failed to set commit status", zap.Error(err))
+	}
+}
+
+// quietHoursFreeze reports whether auto-approval is currently frozen by the quiet hours
+// schedule, along with the reason shown in the manual review comment
+func (h *DataProductConfigMrReviewHandler) quietHoursFreeze() (string, bool) {
+	if !h.config.QuietHours.Enabled {
+		return "", false
+	}
+
+	window, err := newApprovalWindow(h.config.QuietHours)
+	if err != nil {
+		// Fail closed: a broken schedule must not allow approvals during a freeze
+		logging.Error("Invalid quiet hours configuration: %v", err)
+		return fmt.Sprintf("Change freeze: auto-approval is paused because the quiet hours configuration is invalid (%v)", err), true
+	}
+
+	now := time.Now
+	if h.now != nil {
+		now = h.now
+	}
+	if window.allows(now()) {
+		return "", false
+	}
+	return fmt.Sprintf("Change freeze: auto-approval is only allowed during %s. A reviewer can approve this MR manually.", window.summary), true
+}
+
+// pipelineNotPassed reports whether auto-approval must wait for the MR's head pipeline,
+// along with the reason shown in the manual review comment
+func (h *DataProductConfigMrReviewHandler) pipelineNotPassed(mrInfo *gitlab.MRInfo) (string, bool) {
+	if !h.config.Approval.RequirePassingPipeline {
+		return "", false
+	}
+
+	status, err := h.gitlabClient.GetMRHeadPipelineStatus(mrInfo.ProjectID, mrInfo.MRIID)
+	if err != nil {
+		// Fail closed: approve only once the pipeline is known to have passed
+		logging.MRWarn(mrInfo.MRIID, "Could not check head pipeline status", zap.Error(err))
+		return "Waiting on pipeline: could not determine the latest pipeline status. The MR will be re-evaluated when its pipeline succeeds.", true
+	}
+
+	switch status {
+	case gitlab.PipelineStatusSuccess:
+		return "", false
+	case "":
+		return "Waiting on pipeline: the MR has no pipeline yet. The MR will be re-evaluated when its pipeline succeeds.", true
+	default:
+		return fmt.Sprintf("Waiting on pipeline: the latest pipeline status is '%s'. The MR will be re-evaluated when its pipeline succeeds.", status), true
+	}
+}
+
+// hasManualReviewHold reports whether a `/naysayer hold` comment is active on the MR,
+// along with the reason shown in the manual review comment
+func (h *DataProductConfigMrReviewHandler) hasManualReviewHold(mrInfo *gitlab.MRInfo) (string, bool) {
+	holdComment, err := h.gitlabClient.FindLatestNaysayerComment(mrInfo.ProjectID, mrInfo.MRIID, holdCommentType)
+	if err != nil {
+		// Fail closed: a hold that cannot be ruled out is treated as set
+		logging.MRWarn(mrInfo.MRIID, "Could not check for manual review hold", zap.Error(err))
+		return "Manual review hold: could not check for a /naysayer hold comment. The MR will be re-evaluated on its next update.", true
+	}
+	if holdComment == nil {
+		return "", false
+	}
+	return "Manual review hold set via /naysayer hold", true
+}
+
 // validateWebhookPayload performs security validation on webhook payload
 func (h *DataProductConfigMrReviewHandler) validateWebhookPayload(payload map[string]interface{}) error {
 	// Check for required top-level fields
@@ -389,7 +1157,7 @@ func (h *DataProductConfigMrReviewHandler) validateWebhookPayload(payload map[st
 	// Validate state field if present
 	if state, exists := objectAttrsMap["state"]; exists {
 		if stateStr, ok := state.(string); ok {
-			if stateStr != utils.MRStateOpened {
+			if stateStr != utils.MRStateOpened && !h.isArchivableMerge(objectAttrsMap) && !h.isCleanableClose(stateStr) {
 				return fmt.Errorf("MR state: %s. Naysayer only processes Open MRs", stateStr)
 			}
 		} else {
@@ -399,3 +1167,30 @@ func (h *DataProductConfigMrReviewHandler) validateWebhookPayload(payload map[st
 
 	return nil
 }
+
+// isArchivableMerge reports whether the event is an MR merge whose comment should be archived
+func (h *DataProductConfigMrReviewHandler) isArchivableMerge(objectAttrs map[string]interface{}) bool {
+	action, _ := objectAttrs["action"].(string)
+	return action == mrActionMerge && h.config.Comments.ArchiveOnMerge
+}
+
+// isCleanableClose reports whether the event is for a closed MR whose review state should be cleaned up
+func (h *DataProductConfigMrReviewHandler) isCleanableClose(state string) bool {
+	return state == utils.MRStateClosed && h.config.Webhook.CleanupOnClose
+}
+
+// correlationID identifies the webhook delivery behind a decision: GitLab's event UUID
+// when present, otherwise the caller's request ID, otherwise a random ID
+func correlationID(c *fiber.Ctx) string {
+	for _, header := range []string{EventUUIDHeader, "X-Request-ID"} {
+		if id := c.Get(header); id != "" {
+			return id
+		}
+	}
+
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(buf)
+}
@@ -3,14 +3,18 @@ package webhook
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
 	"net/http/httptest"
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/redhat-data-and-ai/naysayer/internal/config"
 	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
@@ -120,6 +124,89 @@ func TestNewWebhookHandler(t *testing.T) {
 	assert.Equal(t, cfg, handler.config)
 }
 
+func TestWebhookHandler_HandleWebhook_DraftMRSkipped(t *testing.T) {
+	tests := []struct {
+		name            string
+		attributes      map[string]interface{}
+		expectedSkipped bool
+	}{
+		{
+			name:            "draft flag",
+			attributes:      map[string]interface{}{"title": "Update warehouse configuration", "draft": true},
+			expectedSkipped: true,
+		},
+		{
+			name:            "legacy work_in_progress flag",
+			attributes:      map[string]interface{}{"title": "Update warehouse configuration", "work_in_progress": true},
+			expectedSkipped: true,
+		},
+		{
+			name:            "draft title prefix",
+			attributes:      map[string]interface{}{"title": "Draft: Update warehouse configuration", "draft": false},
+			expectedSkipped: true,
+		},
+		{
+			name:            "WIP title prefix",
+			attributes:      map[string]interface{}{"title": "WIP: Update warehouse configuration"},
+			expectedSkipped: true,
+		},
+		{
+			name:            "normal open MR",
+			attributes:      map[string]interface{}{"title": "Update warehouse configuration", "draft": false, "work_in_progress": false},
+			expectedSkipped: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setupTestRulesFile(t)
+			mockClient := &MockGitLabClient{}
+			handler := NewDataProductConfigMrReviewHandlerWithClient(createTestConfig(), mockClient)
+
+			app := createTestApp()
+			app.Post("/webhook", handler.HandleWebhook)
+
+			attributes := map[string]interface{}{
+				"iid":           123,
+				"source_branch": "feature/update",
+				"target_branch": "main",
+				"state":         "opened",
+			}
+			for key, value := range tt.attributes {
+				attributes[key] = value
+			}
+			payload := map[string]interface{}{
+				"object_kind":       "merge_request",
+				"object_attributes": attributes,
+				"project":           map[string]interface{}{"id": 456},
+				"user":              map[string]interface{}{"username": "testuser"},
+			}
+
+			jsonData, _ := json.Marshal(payload)
+			req := httptest.NewRequest("POST", "/webhook", bytes.NewReader(jsonData))
+			req.Header.Set("Content-Type", "application/json")
+
+			resp, err := app.Test(req)
+			require.NoError(t, err)
+			assert.Equal(t, 200, resp.StatusCode)
+
+			var response map[string]interface{}
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&response))
+
+			if tt.expectedSkipped {
+				assert.Equal(t, "skipped", response["decision"])
+				assert.Equal(t, "draft MR", response["reason"])
+				assert.Equal(t, false, response["mr_approved"])
+				assert.Zero(t, mockClient.fetchChangesCalls, "draft MRs must not fetch changes")
+				return
+			}
+
+			assert.NotEqual(t, "skipped", response["decision"])
+			assert.Equal(t, 1, mockClient.fetchChangesCalls)
+		})
+	}
+}
+
 func TestWebhookHandler_HandleWebhook_Success(t *testing.T) {
 	setupTestRulesFile(t)
 	cfg := createTestConfig()
@@ -234,7 +321,37 @@ func TestWebhookHandler_HandleWebhook_NonMREvent(t *testing.T) {
 
 	resp, err := app.Test(req)
 	assert.NoError(t, err)
-	assert.Equal(t, 400, resp.StatusCode)
+	assert.Equal(t, 200, resp.StatusCode, "well-formed events of other kinds are acknowledged")
+
+	body, _ := io.ReadAll(resp.Body)
+	var response map[string]interface{}
+	_ = json.Unmarshal(body, &response)
+
+	assert.Equal(t, "ignored", response["status"])
+	assert.Equal(t, "push", response["event_type"])
+	assert.Nil(t, response["error"])
+}
+
+func TestWebhookHandler_HandleWebhook_MRMissingObjectAttributes(t *testing.T) {
+	setupTestRulesFile(t)
+	cfg := createTestConfig()
+	handler := NewDataProductConfigMrReviewHandler(cfg)
+
+	app := createTestApp()
+	app.Post("/webhook", handler.HandleWebhook)
+
+	payload := map[string]interface{}{
+		"object_kind": "merge_request",
+		"project":     map[string]interface{}{"id": 123},
+	}
+
+	jsonData, _ := json.Marshal(payload)
+	req := httptest.NewRequest("POST", "/webhook", bytes.NewReader(jsonData))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, 400, resp.StatusCode, "malformed MR events are still rejected")
 
 	body, _ := io.ReadAll(resp.Body)
 	var response map[string]interface{}
@@ -445,6 +562,31 @@ func TestWebhookHandler_ContentTypeVariations(t *testing.T) {
 type MockGitLabClient struct {
 	changes []gitlab.FileChange
 	err     error
+
+	latestComments    map[string]*gitlab.MRComment   // Latest naysayer comment by type
+	olderComments     map[string][]*gitlab.MRComment // Older naysayer comments by type, newest first
+	latestCommentErr  error
+	deletedCommentIDs []int
+	addedComments     []string
+	approvalMessages  []string
+	approvalResets    int
+	commitStatuses    []string // "sha:state" for each SetCommitStatus call
+	commitStatusDescs []string // Description of each SetCommitStatus call
+	commitStatusErr   error
+	fetchChangesCalls int
+	pipelineStatus    string // Head pipeline status returned by GetMRHeadPipelineStatus
+	pipelineErr       error
+	projectIDsByPath  map[string]int // Project IDs returned by GetProjectIDByPath
+	diffNotes         []string       // "sha:path:line" for each AddMRDiffNote call
+	existingComments  []gitlab.MRComment
+	updatedComments   map[int]string // New body by comment ID for each UpdateMRComment call
+	addedLabels       []string
+	removedLabels     []string
+	approvals         *gitlab.MRApprovals // Returned by GetMRApprovals
+	approvalsErr      error
+
+	protectedBranches  map[string]bool // Branches IsBranchProtected reports as protected (nil: all)
+	protectedBranchErr error
 }
 
 func (m *MockGitLabClient) FetchFileContent(projectID int, filePath, ref string) (*gitlab.FileContent, error) {
@@ -460,10 +602,16 @@ func (m *MockGitLabClient) GetMRDetails(projectID, mrIID int) (*gitlab.MRDetails
 }
 
 func (m *MockGitLabClient) FetchMRChanges(projectID, mrIID int) ([]gitlab.FileChange, error) {
+	m.fetchChangesCalls++
 	return m.changes, m.err
 }
 
 func (m *MockGitLabClient) AddMRComment(projectID, mrIID int, comment string) error {
+	m.addedComments = append(m.addedComments, comment)
+	return nil
+}
+
+func (m *MockGitLabClient) AddIssueComment(projectID, issueIID int, comment string) error {
 	return nil
 }
 
@@ -471,15 +619,46 @@ func (m *MockGitLabClient) AddOrUpdateMRComment(projectID, mrIID int, commentBod
 	return nil
 }
 
+func (m *MockGitLabClient) AddMRDiffNote(projectID, mrIID int, sha, filePath string, line int, body string) error {
+	m.diffNotes = append(m.diffNotes, fmt.Sprintf("%s:%s:%d", sha, filePath, line))
+	return nil
+}
+
 func (m *MockGitLabClient) ListMRComments(projectID, mrIID int) ([]gitlab.MRComment, error) {
-	return nil, nil
+	return m.existingComments, nil
 }
 
 func (m *MockGitLabClient) UpdateMRComment(projectID, mrIID, commentID int, newBody string) error {
+	if m.updatedComments == nil {
+		m.updatedComments = make(map[int]string)
+	}
+	m.updatedComments[commentID] = newBody
+	return nil
+}
+
+func (m *MockGitLabClient) DeleteMRComment(projectID, mrIID, commentID int) error {
+	m.deletedCommentIDs = append(m.deletedCommentIDs, commentID)
+	// The next older comment of the type becomes the latest
+	for commentType, comment := range m.latestComments {
+		if comment == nil || comment.ID != commentID {
+			continue
+		}
+		delete(m.latestComments, commentType)
+		if older := m.olderComments[commentType]; len(older) > 0 {
+			m.latestComments[commentType] = older[0]
+			m.olderComments[commentType] = older[1:]
+		}
+	}
 	return nil
 }
 
 func (m *MockGitLabClient) FindLatestNaysayerComment(projectID, mrIID int, commentType ...string) (*gitlab.MRComment, error) {
+	if m.latestCommentErr != nil {
+		return nil, m.latestCommentErr
+	}
+	if len(commentType) > 0 {
+		return m.latestComments[commentType[0]], nil
+	}
 	return nil, nil
 }
 
@@ -488,19 +667,42 @@ func (m *MockGitLabClient) ApproveMR(projectID, mrIID int) error {
 }
 
 func (m *MockGitLabClient) ApproveMRWithMessage(projectID, mrIID int, message string) error {
+	m.approvalMessages = append(m.approvalMessages, message)
 	return nil
 }
 
+func (m *MockGitLabClient) SetCommitStatus(projectID int, sha, state, description string) error {
+	m.commitStatuses = append(m.commitStatuses, sha+":"+state)
+	m.commitStatusDescs = append(m.commitStatusDescs, description)
+	return m.commitStatusErr
+}
+
+func (m *MockGitLabClient) GetMRHeadPipelineStatus(projectID, mrIID int) (string, error) {
+	return m.pipelineStatus, m.pipelineErr
+}
+
+func (m *MockGitLabClient) GetProjectIDByPath(pathWithNamespace string) (int, error) {
+	if id, ok := m.projectIDsByPath[pathWithNamespace]; ok {
+		return id, nil
+	}
+	return 0, gitlab.ErrNotFound
+}
+
 func (m *MockGitLabClient) ResetNaysayerApproval(projectID, mrIID int) error {
+	m.approvalResets++
 	return nil
 }
 
+func (m *MockGitLabClient) GetMRApprovals(projectID, mrIID int) (*gitlab.MRApprovals, error) {
+	return m.approvals, m.approvalsErr
+}
+
 func (m *MockGitLabClient) GetCurrentBotUsername() (string, error) {
 	return "naysayer-bot", nil
 }
 
 func (m *MockGitLabClient) IsNaysayerBotAuthor(author map[string]interface{}) bool {
-	return false
+	return author["username"] == "naysayer-bot"
 }
 
 func (m *MockGitLabClient) CompareBranches(sourceProjectID int, sourceBranch string, targetProjectID int, targetBranch string) (*gitlab.CompareResult, error) {
@@ -509,6 +711,15 @@ func (m *MockGitLabClient) CompareBranches(sourceProjectID int, sourceBranch str
 func (m *MockGitLabClient) GetBranchCommit(projectID int, branch string) (string, error) {
 	return "mock-sha", nil
 }
+func (m *MockGitLabClient) IsBranchProtected(projectID int, branch string) (bool, error) {
+	if m.protectedBranchErr != nil {
+		return false, m.protectedBranchErr
+	}
+	return m.protectedBranches == nil || m.protectedBranches[branch], nil
+}
+func (m *MockGitLabClient) GetMergeRefCommit(projectID, mrIID int) (string, error) {
+	return "", gitlab.ErrNotFound
+}
 func (m *MockGitLabClient) CompareCommits(projectID int, fromSHA, toSHA string) (*gitlab.CompareResult, error) {
 	return &gitlab.CompareResult{Commits: []gitlab.CompareCommit{}}, nil
 }
@@ -536,6 +747,16 @@ func (m *MockGitLabClient) CloseMR(projectID, mrIID int) error {
 	return nil
 }
 
+func (m *MockGitLabClient) AddMRLabels(projectID, mrIID int, labels []string) error {
+	m.addedLabels = append(m.addedLabels, labels...)
+	return nil
+}
+
+func (m *MockGitLabClient) RemoveMRLabels(projectID, mrIID int, labels []string) error {
+	m.removedLabels = append(m.removedLabels, labels...)
+	return nil
+}
+
 func (m *MockGitLabClient) FindCommentByPattern(projectID, mrIID int, pattern string) (bool, error) {
 	return false, nil
 }
@@ -630,3 +851,577 @@ func TestEvaluateRules_NetZeroChanges(t *testing.T) {
 	assert.Contains(t, result.FinalDecision.Reason, "no substantive changes")
 	assert.Equal(t, "Net-zero changes", result.FinalDecision.Summary)
 }
+
+func TestWebhookHandler_HandleWebhook_ProjectPathOnly(t *testing.T) {
+	setupTestRulesFile(t)
+	mockClient := &MockGitLabClient{
+		changes:          noteCommandTestChanges,
+		projectIDsByPath: map[string]int{"dataverse/configs/dataverse-config": 123},
+	}
+	handler := NewDataProductConfigMrReviewHandlerWithClient(createTestConfig(), mockClient)
+	handler.ruleManager = &MockRuleManagerForApproval{}
+
+	app := createTestApp()
+	app.Post("/webhook", handler.HandleWebhook)
+
+	payload := map[string]interface{}{
+		"object_kind": "merge_request",
+		"object_attributes": map[string]interface{}{
+			"iid":           456,
+			"source_branch": "feature/update",
+			"target_branch": "main",
+			"state":         "opened",
+		},
+		"project": map[string]interface{}{"path_with_namespace": "dataverse/configs/dataverse-config"},
+		"user":    map[string]interface{}{"username": "testuser"},
+	}
+	jsonData, _ := json.Marshal(payload)
+	req := httptest.NewRequest("POST", "/webhook", bytes.NewReader(jsonData))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+
+	var response map[string]interface{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&response))
+	assert.Equal(t, float64(123), response["project_id"])
+	assert.Equal(t, true, response["mr_approved"])
+}
+
+func TestWebhookHandler_HandleWebhook_RulesMisconfigured(t *testing.T) {
+	setupTestRulesFile(t)
+	mockClient := &MockGitLabClient{changes: noteCommandTestChanges}
+	handler := NewDataProductConfigMrReviewHandlerWithClient(createTestConfig(), mockClient)
+	handler.ruleManager = &MockRuleManagerForApproval{
+		evaluateFunc: func(ctx *shared.MRContext) *shared.RuleEvaluation {
+			return &shared.RuleEvaluation{
+				FinalDecision:      shared.Decision{Type: shared.ManualReview, Reason: "No rules cover the changes"},
+				FileValidations:    map[string]*shared.FileValidationSummary{},
+				RulesMisconfigured: true,
+			}
+		},
+	}
+
+	app := createTestApp()
+	app.Post("/webhook", handler.HandleWebhook)
+
+	payload := map[string]interface{}{
+		"object_kind": "merge_request",
+		"object_attributes": map[string]interface{}{
+			"iid":           456,
+			"source_branch": "feature/update",
+			"target_branch": "main",
+			"state":         "opened",
+		},
+		"project": map[string]interface{}{"id": 123},
+		"user":    map[string]interface{}{"username": "testuser"},
+	}
+	jsonData, _ := json.Marshal(payload)
+	req := httptest.NewRequest("POST", "/webhook", bytes.NewReader(jsonData))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+
+	var response map[string]interface{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&response))
+	assert.Equal(t, true, response["rules_misconfigured"])
+	assert.Equal(t, false, response["mr_approved"])
+}
+
+func TestWebhookHandler_HandleWebhook_MergeAction(t *testing.T) {
+	setupTestRulesFile(t)
+	payload := map[string]interface{}{
+		"object_kind": "merge_request",
+		"object_attributes": map[string]interface{}{
+			"iid":           456,
+			"source_branch": "feature/update",
+			"target_branch": "main",
+			"state":         "merged",
+			"action":        "merge",
+		},
+		"project": map[string]interface{}{"id": 123},
+		"user":    map[string]interface{}{"username": "testuser"},
+	}
+
+	tests := []struct {
+		name             string
+		archiveOnMerge   bool
+		expectedStatus   int
+		expectedComments map[int]string
+	}{
+		{
+			name:             "enabled archives the decision comment",
+			archiveOnMerge:   true,
+			expectedStatus:   200,
+			expectedComments: map[int]string{22: archivedCommentBody},
+		},
+		{
+			name:           "disabled skips the merged MR",
+			archiveOnMerge: false,
+			expectedStatus: 400,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockGitLabClient{
+				latestComments: map[string]*gitlab.MRComment{"approval": {ID: 22}},
+			}
+			cfg := createTestConfig()
+			cfg.Comments.ArchiveOnMerge = tt.archiveOnMerge
+			handler := NewDataProductConfigMrReviewHandlerWithClient(cfg, mockClient)
+			handler.ruleManager = &MockRuleManagerForApproval{}
+
+			app := createTestApp()
+			app.Post("/webhook", handler.HandleWebhook)
+			jsonData, _ := json.Marshal(payload)
+			req := httptest.NewRequest("POST", "/webhook", bytes.NewReader(jsonData))
+			req.Header.Set("Content-Type", "application/json")
+
+			resp, err := app.Test(req)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+
+			var response map[string]interface{}
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&response))
+			if tt.archiveOnMerge {
+				assert.Equal(t, "skipped", response["decision"])
+				assert.Equal(t, "MR merged, validation archived", response["reason"])
+				assert.Equal(t, float64(1), response["comments_archived"])
+			} else {
+				assert.Contains(t, response["error"], "Naysayer only processes Open MRs")
+			}
+			assert.Equal(t, tt.expectedComments, mockClient.updatedComments)
+			assert.Empty(t, mockClient.approvalMessages, "merged MRs are never approved")
+			assert.Zero(t, mockClient.fetchChangesCalls)
+		})
+	}
+}
+
+func TestWebhookHandler_HandleWebhook_ClosedMR(t *testing.T) {
+	setupTestRulesFile(t)
+	payload := map[string]interface{}{
+		"object_kind": "merge_request",
+		"object_attributes": map[string]interface{}{
+			"iid":           456,
+			"source_branch": "feature/update",
+			"target_branch": "main",
+			"state":         "closed",
+			"action":        "close",
+		},
+		"project": map[string]interface{}{"id": 123},
+		"user":    map[string]interface{}{"username": "testuser"},
+	}
+
+	tests := []struct {
+		name            string
+		cleanupOnClose  bool
+		expectedStatus  int
+		expectedRemoved []string
+		expectedResets  int
+	}{
+		{
+			name:            "enabled removes the review label and resets the approval",
+			cleanupOnClose:  true,
+			expectedStatus:  200,
+			expectedRemoved: []string{"naysayer:needs-review"},
+			expectedResets:  1,
+		},
+		{
+			name:           "disabled skips the closed MR",
+			cleanupOnClose: false,
+			expectedStatus: 400,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockGitLabClient{}
+			cfg := createTestConfig()
+			cfg.Webhook.CleanupOnClose = tt.cleanupOnClose
+			cfg.ReviewLabel = config.ReviewLabelConfig{Enabled: true, Name: "naysayer:needs-review"}
+			handler := NewDataProductConfigMrReviewHandlerWithClient(cfg, mockClient)
+			handler.ruleManager = &MockRuleManagerForApproval{}
+
+			app := createTestApp()
+			app.Post("/webhook", handler.HandleWebhook)
+			jsonData, _ := json.Marshal(payload)
+			req := httptest.NewRequest("POST", "/webhook", bytes.NewReader(jsonData))
+			req.Header.Set("Content-Type", "application/json")
+
+			resp, err := app.Test(req)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+
+			var response map[string]interface{}
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&response))
+			if tt.cleanupOnClose {
+				assert.Equal(t, "skipped", response["decision"])
+				assert.Equal(t, "MR closed, review state cleaned up", response["reason"])
+				assert.Equal(t, true, response["label_removed"])
+				assert.Equal(t, true, response["approval_reset"])
+			} else {
+				assert.Contains(t, response["error"], "Naysayer only processes Open MRs")
+			}
+			assert.Equal(t, tt.expectedRemoved, mockClient.removedLabels)
+			assert.Equal(t, tt.expectedResets, mockClient.approvalResets)
+			assert.Empty(t, mockClient.addedLabels)
+			assert.Empty(t, mockClient.approvalMessages, "closed MRs are never approved")
+			assert.Zero(t, mockClient.fetchChangesCalls, "rules are not run for closed MRs")
+		})
+	}
+}
+
+func TestWebhookHandler_HandleWebhook_TriggerActions(t *testing.T) {
+	setupTestRulesFile(t)
+
+	tests := []struct {
+		name             string
+		action           string
+		expectEvaluation bool
+	}{
+		{name: "triggering action", action: "update", expectEvaluation: true},
+		{name: "non-triggering action", action: "approved"},
+		{name: "missing action", expectEvaluation: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockGitLabClient{changes: noteCommandTestChanges}
+			cfg := createTestConfig()
+			cfg.Webhook.TriggerActions = []string{"open", "reopen", "update"}
+			handler := NewDataProductConfigMrReviewHandlerWithClient(cfg, mockClient)
+			handler.ruleManager = &MockRuleManagerForApproval{}
+
+			attributes := map[string]interface{}{
+				"iid":           456,
+				"title":         "Update product",
+				"source_branch": "feature/update",
+				"target_branch": "main",
+				"state":         "opened",
+			}
+			if tt.action != "" {
+				attributes["action"] = tt.action
+			}
+			payload := map[string]interface{}{
+				"object_kind":       "merge_request",
+				"object_attributes": attributes,
+				"project":           map[string]interface{}{"id": 123},
+				"user":              map[string]interface{}{"username": "testuser"},
+			}
+
+			app := createTestApp()
+			app.Post("/webhook", handler.HandleWebhook)
+			jsonData, _ := json.Marshal(payload)
+			req := httptest.NewRequest("POST", "/webhook", bytes.NewReader(jsonData))
+			req.Header.Set("Content-Type", "application/json")
+
+			resp, err := app.Test(req)
+			require.NoError(t, err)
+			assert.Equal(t, 200, resp.StatusCode)
+
+			var response map[string]interface{}
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&response))
+			if tt.expectEvaluation {
+				assert.Equal(t, 1, mockClient.fetchChangesCalls)
+				assert.Equal(t, true, response["mr_approved"])
+			} else {
+				assert.Equal(t, "skipped", response["decision"])
+				assert.Equal(t, "MR action 'approved' does not trigger evaluation", response["reason"])
+				assert.Zero(t, mockClient.fetchChangesCalls)
+				assert.Empty(t, mockClient.approvalMessages)
+			}
+		})
+	}
+}
+
+func TestWebhookHandler_HandleWebhook_ProtectedTargetsOnly(t *testing.T) {
+	setupTestRulesFile(t)
+
+	tests := []struct {
+		name               string
+		targetBranch       string
+		protectedTargets   bool
+		protectedGlobs     []string
+		protectedBranches  map[string]bool
+		protectedBranchErr error
+		expectEvaluation   bool
+	}{
+		{name: "disabled evaluates every target", targetBranch: "scratch", protectedBranches: map[string]bool{}, expectEvaluation: true},
+		{name: "protected target", targetBranch: "main", protectedTargets: true, protectedBranches: map[string]bool{"main": true}, expectEvaluation: true},
+		{name: "non-protected target", targetBranch: "scratch", protectedTargets: true, protectedBranches: map[string]bool{"main": true}},
+		{name: "glob match", targetBranch: "release/2024.1", protectedTargets: true, protectedGlobs: []string{"main", "release/*"}, protectedBranches: map[string]bool{}, expectEvaluation: true},
+		{name: "glob mismatch", targetBranch: "scratch", protectedTargets: true, protectedGlobs: []string{"main", "release/*"}, protectedBranches: map[string]bool{"scratch": true}},
+		{name: "lookup failure evaluates", targetBranch: "scratch", protectedTargets: true, protectedBranchErr: errors.New("gitlab unavailable"), expectEvaluation: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockGitLabClient{
+				changes:            noteCommandTestChanges,
+				protectedBranches:  tt.protectedBranches,
+				protectedBranchErr: tt.protectedBranchErr,
+			}
+			cfg := createTestConfig()
+			cfg.Webhook.ProtectedTargetsOnly = tt.protectedTargets
+			cfg.Webhook.ProtectedBranches = tt.protectedGlobs
+			handler := NewDataProductConfigMrReviewHandlerWithClient(cfg, mockClient)
+			handler.ruleManager = &MockRuleManagerForApproval{}
+
+			payload := map[string]interface{}{
+				"object_kind": "merge_request",
+				"object_attributes": map[string]interface{}{
+					"iid":           456,
+					"title":         "Update product",
+					"source_branch": "feature/update",
+					"target_branch": tt.targetBranch,
+					"state":         "opened",
+				},
+				"project": map[string]interface{}{"id": 123},
+				"user":    map[string]interface{}{"username": "testuser"},
+			}
+
+			app := createTestApp()
+			app.Post("/webhook", handler.HandleWebhook)
+			jsonData, _ := json.Marshal(payload)
+			req := httptest.NewRequest("POST", "/webhook", bytes.NewReader(jsonData))
+			req.Header.Set("Content-Type", "application/json")
+
+			resp, err := app.Test(req)
+			require.NoError(t, err)
+			assert.Equal(t, 200, resp.StatusCode)
+
+			var response map[string]interface{}
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&response))
+			if tt.expectEvaluation {
+				assert.Equal(t, 1, mockClient.fetchChangesCalls)
+				assert.Equal(t, true, response["mr_approved"])
+			} else {
+				assert.Equal(t, "skipped", response["decision"])
+				assert.Equal(t, fmt.Sprintf("target branch '%s' is not protected", tt.targetBranch), response["reason"])
+				assert.Zero(t, mockClient.fetchChangesCalls)
+				assert.Empty(t, mockClient.approvalMessages)
+			}
+		})
+	}
+}
+
+func TestWebhookHandler_HandleWebhook_EventHeader(t *testing.T) {
+	setupTestRulesFile(t)
+
+	tests := []struct {
+		name           string
+		secret         string
+		objectKind     string
+		header         string
+		expectedStatus int
+		expectedError  string
+	}{
+		{name: "matching header", objectKind: "merge_request", header: "Merge Request Hook", expectedStatus: 200},
+		{name: "matching header with verification", secret: "webhook-secret", objectKind: "merge_request", header: "Merge Request Hook", expectedStatus: 200},
+		{name: "system hook", objectKind: "merge_request", header: "System Hook", expectedStatus: 200},
+		{
+			name:           "wrong header",
+			objectKind:     "merge_request",
+			header:         "Push Hook",
+			expectedStatus: 400,
+			expectedError:  "X-Gitlab-Event 'Push Hook' does not match merge_request payload (expected 'Merge Request Hook')",
+		},
+		{
+			name:           "pipeline header on merge request payload",
+			secret:         "webhook-secret",
+			objectKind:     "merge_request",
+			header:         "Pipeline Hook",
+			expectedStatus: 400,
+			expectedError:  "X-Gitlab-Event 'Pipeline Hook' does not match merge_request payload (expected 'Merge Request Hook')",
+		},
+		{name: "missing header without verification", objectKind: "merge_request", expectedStatus: 200},
+		{
+			name:           "missing header with verification",
+			secret:         "webhook-secret",
+			objectKind:     "merge_request",
+			expectedStatus: 400,
+			expectedError:  "missing X-Gitlab-Event header",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createTestConfig()
+			cfg.Webhook.Secret = tt.secret
+			mockClient := &MockGitLabClient{changes: noteCommandTestChanges}
+			handler := NewDataProductConfigMrReviewHandlerWithClient(cfg, mockClient)
+			handler.ruleManager = &MockRuleManagerForApproval{}
+
+			payload := map[string]interface{}{
+				"object_kind": tt.objectKind,
+				"object_attributes": map[string]interface{}{
+					"iid":           456,
+					"source_branch": "feature/update",
+					"target_branch": "main",
+					"state":         "opened",
+				},
+				"project": map[string]interface{}{"id": 123},
+				"user":    map[string]interface{}{"username": "testuser"},
+			}
+
+			app := createTestApp()
+			app.Post("/webhook", handler.HandleWebhook)
+			jsonData, _ := json.Marshal(payload)
+			req := httptest.NewRequest("POST", "/webhook", bytes.NewReader(jsonData))
+			req.Header.Set("Content-Type", "application/json")
+			if tt.header != "" {
+				req.Header.Set(GitLabEventHeader, tt.header)
+			}
+
+			resp, err := app.Test(req)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+
+			var response map[string]interface{}
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&response))
+			if tt.expectedError != "" {
+				assert.Equal(t, tt.expectedError, response["error"])
+				assert.Zero(t, mockClient.fetchChangesCalls)
+			} else {
+				assert.Equal(t, true, response["mr_approved"])
+			}
+		})
+	}
+}
+
+func TestWebhookHandler_HandleWebhook_NoTokenMode(t *testing.T) {
+	tests := []struct {
+		name      string
+		mode      string
+		simulated bool
+	}{
+		{name: "simulate", mode: config.NoTokenModeSimulate, simulated: true},
+		{name: "fail", mode: config.NoTokenModeFail, simulated: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setupTestRulesFile(t)
+			cfg := createTestConfig()
+			cfg.GitLab.Token = ""
+			cfg.GitLab.NoTokenMode = tt.mode
+			mockClient := &MockGitLabClient{changes: noteCommandTestChanges}
+			handler := NewDataProductConfigMrReviewHandlerWithClient(cfg, mockClient)
+			handler.ruleManager = &MockRuleManagerForApproval{}
+
+			app := createTestApp()
+			app.Post("/webhook", handler.HandleWebhook)
+
+			payload := map[string]interface{}{
+				"object_kind": "merge_request",
+				"object_attributes": map[string]interface{}{
+					"iid":           456,
+					"source_branch": "feature/no-token",
+					"target_branch": "main",
+					"state":         "opened",
+				},
+				"project": map[string]interface{}{"id": 123},
+				"user":    map[string]interface{}{"username": "testuser"},
+			}
+			jsonData, _ := json.Marshal(payload)
+			req := httptest.NewRequest("POST", "/webhook", bytes.NewReader(jsonData))
+			req.Header.Set("Content-Type", "application/json")
+
+			resp, err := app.Test(req)
+			require.NoError(t, err)
+			assert.Equal(t, 200, resp.StatusCode)
+
+			var response map[string]interface{}
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&response))
+
+			if tt.simulated {
+				assert.Equal(t, true, response["simulated"])
+				assert.Equal(t, false, response["mr_approved"])
+				decision := response["decision"].(map[string]interface{})
+				assert.Equal(t, "manual_review", decision["type"])
+				assert.Contains(t, decision["reason"], "simulated")
+				assert.Equal(t, 0, mockClient.fetchChangesCalls, "simulated decisions fetch nothing")
+				assert.Empty(t, mockClient.approvalMessages)
+			} else {
+				// A fail-mode server never starts without a token; a handler built anyway evaluates normally
+				assert.Nil(t, response["simulated"])
+				assert.Equal(t, 1, mockClient.fetchChangesCalls)
+			}
+		})
+	}
+}
+
+func TestNamedConfigReviewHandler_RoutesUseOwnRules(t *testing.T) {
+	dir := t.TempDir()
+	docsRules := filepath.Join(dir, "docs.yaml")
+	strictRules := filepath.Join(dir, "strict.yaml")
+	strictContent := `enabled: true
+files:
+  - name: "product_configs"
+    path: "**/"
+    filename: "product.yaml"
+    parser_type: yaml
+    enabled: true
+    sections:
+      - name: warehouses
+        yaml_path: warehouses
+        rule_configs:
+          - name: warehouse_rule
+            enabled: true
+`
+	require.NoError(t, os.WriteFile(docsRules, []byte(strictContent+"safe_paths:\n  - \"docs/**\"\n"), 0644))
+	require.NoError(t, os.WriteFile(strictRules, []byte(strictContent), 0644))
+
+	cfg := createTestConfig()
+	mockClient := &MockGitLabClient{changes: []gitlab.FileChange{{NewPath: "docs/guide.txt", Diff: "+More docs"}}}
+	docsHandler, err := NewNamedConfigReviewHandlerWithClient(cfg, mockClient, docsRules)
+	require.NoError(t, err)
+	strictHandler, err := NewNamedConfigReviewHandlerWithClient(cfg, mockClient, strictRules)
+	require.NoError(t, err)
+
+	app := createTestApp()
+	app.Post("/review/docs", docsHandler.HandleWebhook)
+	app.Post("/review/strict", strictHandler.HandleWebhook)
+
+	review := func(route string) map[string]interface{} {
+		payload := map[string]interface{}{
+			"object_kind": "merge_request",
+			"object_attributes": map[string]interface{}{
+				"iid":           456,
+				"source_branch": "feature/docs",
+				"target_branch": "main",
+				"state":         "opened",
+			},
+			"project": map[string]interface{}{"id": 123},
+			"user":    map[string]interface{}{"username": "testuser"},
+		}
+		jsonData, _ := json.Marshal(payload)
+		req := httptest.NewRequest("POST", route, bytes.NewReader(jsonData))
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		require.Equal(t, 200, resp.StatusCode)
+		var response map[string]interface{}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&response))
+		return response["decision"].(map[string]interface{})
+	}
+
+	assert.Equal(t, "approve", review("/review/docs")["type"], "docs rules treat docs/** as safe")
+	assert.Equal(t, "manual_review", review("/review/strict")["type"], "strict rules cover nothing")
+
+	// Reloading re-reads the route's own rule configuration
+	require.NoError(t, os.WriteFile(docsRules, []byte(strictContent), 0644))
+	_, err = docsHandler.ReloadRules()
+	require.NoError(t, err)
+	assert.Equal(t, "manual_review", review("/review/docs")["type"])
+}
+
+func TestNamedConfigReviewHandler_InvalidConfig(t *testing.T) {
+	_, err := NewNamedConfigReviewHandlerWithClient(createTestConfig(), &MockGitLabClient{}, filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,99 @@
+package webhook
+
+import (
+	"sync"
+	"time"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+)
+
+// decisionCacheKey identifies an MR; each MR keeps the evaluation of its latest head commit only
+type decisionCacheKey struct {
+	projectID int
+	mrIID     int
+}
+
+type decisionCacheEntry struct {
+	headSHA   string
+	result    shared.RuleEvaluation
+	evaluated time.Time
+}
+
+// decisionCache remembers each MR's rule evaluation for its head commit, so duplicate or redelivered
+// events for an unchanged MR don't fetch and validate every file again. The decision is still applied
+// (approval, comments, labels) on every event; only the evaluation is reused.
+type decisionCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[decisionCacheKey]*decisionCacheEntry
+	now     func() time.Time // Clock used for TTL checks; defaults to time.Now
+}
+
+// newDecisionCache creates a cache whose evaluations expire after ttl
+func newDecisionCache(ttl time.Duration) *decisionCache {
+	return &decisionCache{
+		ttl:     ttl,
+		entries: make(map[decisionCacheKey]*decisionCacheEntry),
+		now:     time.Now,
+	}
+}
+
+// get returns a copy of the MR's evaluation at headSHA, or nil when there is none within the TTL.
+// A new head SHA drops the MR's previous evaluation.
+func (dc *decisionCache) get(projectID, mrIID int, headSHA string) *shared.RuleEvaluation {
+	if headSHA == "" {
+		return nil
+	}
+	dc.mu.Lock()
+	defer dc.mu.Unlock()
+
+	key := decisionCacheKey{projectID: projectID, mrIID: mrIID}
+	entry, ok := dc.entries[key]
+	if !ok {
+		return nil
+	}
+	if entry.headSHA != headSHA || dc.now().Sub(entry.evaluated) >= dc.ttl {
+		delete(dc.entries, key)
+		return nil
+	}
+	// Callers adjust the final decision (holds, quiet hours, pipelines), so never hand out the cached value
+	result := entry.result
+	return &result
+}
+
+// put stores a copy of the MR's evaluation at headSHA, replacing any earlier head commit's
+func (dc *decisionCache) put(projectID, mrIID int, headSHA string, result *shared.RuleEvaluation) {
+	if headSHA == "" || result == nil {
+		return
+	}
+	dc.mu.Lock()
+	defer dc.mu.Unlock()
+
+	now := dc.now()
+	for key, entry := range dc.entries {
+		if now.Sub(entry.evaluated) >= dc.ttl {
+			delete(dc.entries, key)
+		}
+	}
+	dc.entries[decisionCacheKey{projectID: projectID, mrIID: mrIID}] = &decisionCacheEntry{
+		headSHA:   headSHA,
+		result:    *result,
+		evaluated: now,
+	}
+}
+
+// drop forgets the MR's cached evaluation, e.g. once a newer one must not be reused
+func (dc *decisionCache) drop(projectID, mrIID int) {
+	dc.mu.Lock()
+	defer dc.mu.Unlock()
+
+	delete(dc.entries, decisionCacheKey{projectID: projectID, mrIID: mrIID})
+}
+
+// clear drops every cached evaluation, e.g. once new rules may decide differently
+func (dc *decisionCache) clear() {
+	dc.mu.Lock()
+	defer dc.mu.Unlock()
+
+	dc.entries = make(map[decisionCacheKey]*decisionCacheEntry)
+}
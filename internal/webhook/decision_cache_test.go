@@ -0,0 +1,156 @@
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+)
+
+func TestDecisionCache_ReusesSameHeadSHA(t *testing.T) {
+	cache := newDecisionCache(time.Minute)
+	cache.put(123, 456, "abc", &shared.RuleEvaluation{FinalDecision: shared.Decision{Type: shared.Approve}})
+
+	cached := cache.get(123, 456, "abc")
+	require.NotNil(t, cached)
+	assert.Equal(t, shared.Approve, cached.FinalDecision.Type)
+
+	// Callers adjusting the decision don't change the cached evaluation
+	cached.FinalDecision.Type = shared.ManualReview
+	assert.Equal(t, shared.Approve, cache.get(123, 456, "abc").FinalDecision.Type)
+
+	assert.Nil(t, cache.get(123, 789, "abc"), "other MRs have their own evaluations")
+	assert.Nil(t, cache.get(123, 456, ""), "events without a head SHA are always evaluated")
+}
+
+func TestDecisionCache_NewHeadSHAInvalidates(t *testing.T) {
+	cache := newDecisionCache(time.Minute)
+	cache.put(123, 456, "abc", &shared.RuleEvaluation{})
+
+	assert.Nil(t, cache.get(123, 456, "def"))
+	assert.Nil(t, cache.get(123, 456, "abc"), "the previous head commit's evaluation is dropped")
+}
+
+func TestDecisionCache_Expires(t *testing.T) {
+	now := time.Now()
+	cache := newDecisionCache(time.Minute)
+	cache.now = func() time.Time { return now }
+	cache.put(123, 456, "abc", &shared.RuleEvaluation{})
+
+	now = now.Add(time.Minute)
+	assert.Nil(t, cache.get(123, 456, "abc"))
+}
+
+func TestDecisionCache_Drop(t *testing.T) {
+	cache := newDecisionCache(time.Minute)
+	cache.put(123, 456, "abc", &shared.RuleEvaluation{})
+	cache.put(123, 789, "abc", &shared.RuleEvaluation{})
+
+	cache.drop(123, 456)
+
+	assert.Nil(t, cache.get(123, 456, "abc"))
+	assert.NotNil(t, cache.get(123, 789, "abc"))
+}
+
+func TestEvaluateRules_LiveStateNotCached(t *testing.T) {
+	setupTestRulesFile(t)
+	cfg := createTestConfig()
+	cfg.Webhook.DecisionCacheSeconds = 60
+	mockClient := &MockGitLabClient{changes: noteCommandTestChanges}
+	handler := NewDataProductConfigMrReviewHandlerWithClient(cfg, mockClient)
+	handler.ruleManager = &MockRuleManagerForApproval{
+		evaluateFunc: func(ctx *shared.MRContext) *shared.RuleEvaluation {
+			return &shared.RuleEvaluation{
+				FinalDecision: shared.Decision{Type: shared.Approve},
+				UsesLiveState: true,
+			}
+		},
+	}
+	mrInfo := &gitlab.MRInfo{ProjectID: 123, MRIID: 456, LastCommit: "abc123"}
+
+	for i := 0; i < 2; i++ {
+		_, err := handler.evaluateRules(123, 456, mrInfo)
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, 2, mockClient.fetchChangesCalls, "evaluations that read approvals or the target branch are not reused")
+}
+
+func TestNoteCommand_RecheckBypassesDecisionCache(t *testing.T) {
+	client := &MockGitLabClient{changes: noteCommandTestChanges}
+	handler := createNoteCommandTestHandler(t, client)
+	handler.reviewHandler.ruleManager = &MockRuleManagerForApproval{}
+	handler.reviewHandler.decisionCache = newDecisionCache(time.Minute)
+
+	_, err := handler.reviewHandler.evaluateRules(123, 456, &gitlab.MRInfo{ProjectID: 123, MRIID: 456, LastCommit: "abc123"})
+	require.NoError(t, err)
+	require.Equal(t, 1, client.fetchChangesCalls)
+
+	payload := createNotePayload("/naysayer recheck", "reviewer")
+	payload["merge_request"].(map[string]interface{})["last_commit"] = map[string]interface{}{"id": "abc123"}
+	status, _ := postNote(t, handler, payload)
+
+	assert.Equal(t, 200, status)
+	assert.Equal(t, 2, client.fetchChangesCalls, "recheck evaluates the MR again")
+}
+
+func TestWebhookHandler_HandleWebhook_DecisionCache(t *testing.T) {
+	setupTestRulesFile(t)
+	cfg := createTestConfig()
+	cfg.Webhook.DecisionCacheSeconds = 60
+	mockClient := &MockGitLabClient{changes: noteCommandTestChanges}
+	handler := NewDataProductConfigMrReviewHandlerWithClient(cfg, mockClient)
+	handler.ruleManager = &MockRuleManagerForApproval{}
+	require.NotNil(t, handler.decisionCache)
+
+	app := createTestApp()
+	app.Post("/webhook", handler.HandleWebhook)
+
+	send := func(headSHA string) map[string]interface{} {
+		payload := map[string]interface{}{
+			"object_kind": "merge_request",
+			"object_attributes": map[string]interface{}{
+				"iid":           456,
+				"action":        "update",
+				"source_branch": "feature/cache",
+				"target_branch": "main",
+				"state":         "opened",
+				"last_commit":   map[string]interface{}{"id": headSHA},
+			},
+			"project": map[string]interface{}{"id": 123},
+			"user":    map[string]interface{}{"username": "testuser"},
+		}
+		jsonData, _ := json.Marshal(payload)
+		req := httptest.NewRequest("POST", "/webhook", bytes.NewReader(jsonData))
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		assert.Equal(t, 200, resp.StatusCode)
+		var response map[string]interface{}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&response))
+		return response
+	}
+
+	first := send("abc123")
+	assert.Equal(t, true, first["mr_approved"])
+	assert.Equal(t, 1, mockClient.fetchChangesCalls)
+
+	// A duplicate event for the same head commit reuses the evaluation but still applies it
+	second := send("abc123")
+	assert.Equal(t, true, second["mr_approved"])
+	assert.Equal(t, 1, mockClient.fetchChangesCalls, "same head SHA is not evaluated again")
+	assert.Len(t, mockClient.approvalMessages, 2)
+
+	// A new head commit is evaluated again
+	third := send("def456")
+	assert.Equal(t, true, third["mr_approved"])
+	assert.Equal(t, 2, mockClient.fetchChangesCalls, "new head SHA is evaluated")
+}
@@ -0,0 +1,162 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/logging"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+	"go.uber.org/zap"
+)
+
+// callbackDecisionHookName identifies CallbackDecisionHook in logs
+const callbackDecisionHookName = "callback"
+
+// decisionCodeManualReview is the callback decision code for MRs that need manual review
+const decisionCodeManualReview = "MANUAL_REVIEW"
+
+// DecisionCallbackSignatureHeader carries the hex HMAC-SHA256 of the callback body, keyed by DECISION_CALLBACK_SECRET
+const DecisionCallbackSignatureHeader = "X-Naysayer-Signature"
+
+// decisionCallbackTimeout bounds each callback attempt
+const decisionCallbackTimeout = 5 * time.Second
+
+// DecisionCallbackPayload is the JSON body POSTed to DECISION_CALLBACK_URL
+type DecisionCallbackPayload struct {
+	ProjectID     int                    `json:"project_id"`
+	MRIID         int                    `json:"mr_iid"`
+	Decision      shared.DecisionType    `json:"decision"`
+	DecisionCode  string                 `json:"decision_code"`
+	Reason        string                 `json:"reason"`
+	CorrelationID string                 `json:"correlation_id,omitempty"`
+	Files         []DecisionCallbackFile `json:"files"`
+}
+
+// DecisionCallbackFile is a changed file and its decision
+type DecisionCallbackFile struct {
+	Path     string              `json:"path"`
+	Decision shared.DecisionType `json:"decision"`
+}
+
+// CallbackDecisionHook POSTs every decision to an external URL. The request is sent in the
+// background with one retry, so a slow or failing receiver never delays the webhook response.
+type CallbackDecisionHook struct {
+	url      string
+	secret   string
+	client   *http.Client
+	messages *MessageBuilder
+	pending  sync.WaitGroup
+}
+
+// NewCallbackDecisionHook creates a hook posting decisions to cfg.Webhook.DecisionCallbackURL
+func NewCallbackDecisionHook(cfg *config.Config) *CallbackDecisionHook {
+	return &CallbackDecisionHook{
+		url:      cfg.Webhook.DecisionCallbackURL,
+		secret:   cfg.Webhook.DecisionCallbackSecret,
+		client:   &http.Client{Timeout: decisionCallbackTimeout},
+		messages: NewMessageBuilder(cfg),
+	}
+}
+
+// Name returns the hook's identifier
+func (h *CallbackDecisionHook) Name() string {
+	return callbackDecisionHookName
+}
+
+// OnDecision queues the callback and returns immediately; delivery failures are logged
+func (h *CallbackDecisionHook) OnDecision(ctx context.Context, mrInfo *gitlab.MRInfo, result *shared.RuleEvaluation) error {
+	body, err := json.Marshal(h.buildPayload(mrInfo, result))
+	if err != nil {
+		return fmt.Errorf("failed to encode decision callback: %w", err)
+	}
+
+	h.pending.Add(1)
+	go func() {
+		defer h.pending.Done()
+		if err := h.send(body); err != nil {
+			logging.MRWarn(mrInfo.MRIID, "Decision callback failed", zap.String("url", h.url), zap.Error(err))
+		}
+	}()
+	return nil
+}
+
+// wait blocks until queued callbacks have finished
+func (h *CallbackDecisionHook) wait() {
+	h.pending.Wait()
+}
+
+// send POSTs body, retrying once when the first attempt fails
+func (h *CallbackDecisionHook) send(body []byte) error {
+	err := h.post(body)
+	if err == nil {
+		return nil
+	}
+	logging.Warn("Decision callback to %s failed, retrying once: %v", h.url, err)
+	return h.post(body)
+}
+
+// post makes a single signed callback request; any non-2xx status is an error
+func (h *CallbackDecisionHook) post(body []byte) error {
+	req, err := http.NewRequest("POST", h.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create callback request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if h.secret != "" {
+		req.Header.Set(DecisionCallbackSignatureHeader, signDecisionCallback(h.secret, body))
+	}
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("callback returned status %d: %s", resp.StatusCode, string(respBody))
+	}
+	return nil
+}
+
+// buildPayload summarizes the decision and each file's outcome, sorted by path
+func (h *CallbackDecisionHook) buildPayload(mrInfo *gitlab.MRInfo, result *shared.RuleEvaluation) DecisionCallbackPayload {
+	code := decisionCodeManualReview
+	if result.FinalDecision.Type == shared.Approve {
+		code, _ = h.messages.approvalDecision(result)
+	}
+
+	files := make([]DecisionCallbackFile, 0, len(result.FileValidations))
+	for path, validation := range result.FileValidations {
+		files = append(files, DecisionCallbackFile{Path: path, Decision: validation.FileDecision})
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].Path < files[j].Path })
+
+	return DecisionCallbackPayload{
+		ProjectID:     mrInfo.ProjectID,
+		MRIID:         mrInfo.MRIID,
+		Decision:      result.FinalDecision.Type,
+		DecisionCode:  code,
+		Reason:        result.FinalDecision.Reason,
+		CorrelationID: result.CorrelationID,
+		Files:         files,
+	}
+}
+
+// signDecisionCallback returns the hex-encoded HMAC-SHA256 of body
+func signDecisionCallback(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
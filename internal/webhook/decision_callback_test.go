@@ -0,0 +1,136 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// callbackReceiver records callback requests and answers with the next status in statuses (200 once exhausted)
+type callbackReceiver struct {
+	mu         sync.Mutex
+	statuses   []int
+	bodies     [][]byte
+	signatures []string
+}
+
+func (r *callbackReceiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, _ := io.ReadAll(req.Body)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.bodies = append(r.bodies, body)
+	r.signatures = append(r.signatures, req.Header.Get(DecisionCallbackSignatureHeader))
+	status := http.StatusOK
+	if len(r.statuses) > 0 {
+		status, r.statuses = r.statuses[0], r.statuses[1:]
+	}
+	w.WriteHeader(status)
+}
+
+func newCallbackHook(t *testing.T, receiver *callbackReceiver) *CallbackDecisionHook {
+	t.Helper()
+	server := httptest.NewServer(receiver)
+	t.Cleanup(server.Close)
+
+	cfg := createTestConfig()
+	cfg.Webhook.DecisionCallbackURL = server.URL
+	cfg.Webhook.DecisionCallbackSecret = "callback-secret"
+	return NewCallbackDecisionHook(cfg)
+}
+
+func TestCallbackDecisionHook_Success(t *testing.T) {
+	receiver := &callbackReceiver{}
+	hook := newCallbackHook(t, receiver)
+
+	err := hook.OnDecision(context.Background(), &gitlab.MRInfo{ProjectID: 123, MRIID: 456}, &shared.RuleEvaluation{
+		FinalDecision: shared.Decision{Type: shared.ManualReview, Reason: "Warehouse size increased"},
+		CorrelationID: "delivery-1",
+		FileValidations: map[string]*shared.FileValidationSummary{
+			"dataproducts/b/prod/product.yaml": {FileDecision: shared.ManualReview},
+			"dataproducts/a/prod/README.md":    {FileDecision: shared.Approve},
+		},
+	})
+	require.NoError(t, err)
+	hook.wait()
+
+	require.Len(t, receiver.bodies, 1)
+	var payload DecisionCallbackPayload
+	require.NoError(t, json.Unmarshal(receiver.bodies[0], &payload))
+	assert.Equal(t, DecisionCallbackPayload{
+		ProjectID:     123,
+		MRIID:         456,
+		Decision:      shared.ManualReview,
+		DecisionCode:  "MANUAL_REVIEW",
+		Reason:        "Warehouse size increased",
+		CorrelationID: "delivery-1",
+		Files: []DecisionCallbackFile{
+			{Path: "dataproducts/a/prod/README.md", Decision: shared.Approve},
+			{Path: "dataproducts/b/prod/product.yaml", Decision: shared.ManualReview},
+		},
+	}, payload)
+	assert.Equal(t, signDecisionCallback("callback-secret", receiver.bodies[0]), receiver.signatures[0])
+}
+
+func TestCallbackDecisionHook_RetriesOnce(t *testing.T) {
+	receiver := &callbackReceiver{statuses: []int{http.StatusBadGateway}}
+	hook := newCallbackHook(t, receiver)
+
+	require.NoError(t, hook.OnDecision(context.Background(), &gitlab.MRInfo{ProjectID: 123, MRIID: 456}, &shared.RuleEvaluation{
+		FinalDecision: shared.Decision{Type: shared.Approve},
+	}))
+	hook.wait()
+
+	require.Len(t, receiver.bodies, 2)
+	assert.Equal(t, receiver.bodies[0], receiver.bodies[1])
+}
+
+func TestCallbackDecisionHook_FailingCallbackDoesNotBreakWebhook(t *testing.T) {
+	setupTestRulesFile(t)
+	receiver := &callbackReceiver{statuses: []int{http.StatusInternalServerError, http.StatusInternalServerError}}
+	hook := newCallbackHook(t, receiver)
+	handler := NewDataProductConfigMrReviewHandlerWithClient(createTestConfig(), &MockGitLabClient{changes: noteCommandTestChanges})
+	handler.ruleManager = &MockRuleManagerForApproval{}
+	handler.AddDecisionHook(hook)
+
+	app := createTestApp()
+	app.Post("/webhook", handler.HandleWebhook)
+	payload := map[string]interface{}{
+		"object_kind": "merge_request",
+		"object_attributes": map[string]interface{}{
+			"iid":           456,
+			"source_branch": "feature/update",
+			"target_branch": "main",
+			"state":         "opened",
+		},
+		"project": map[string]interface{}{"id": 123},
+		"user":    map[string]interface{}{"username": "testuser"},
+	}
+	jsonData, _ := json.Marshal(payload)
+	req := httptest.NewRequest("POST", "/webhook", bytes.NewReader(jsonData))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+
+	var response map[string]interface{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&response))
+	assert.Equal(t, true, response["mr_approved"])
+
+	hook.wait()
+	require.Len(t, receiver.bodies, 2, "failed callback is retried once")
+	var callback DecisionCallbackPayload
+	require.NoError(t, json.Unmarshal(receiver.bodies[0], &callback))
+	assert.Equal(t, shared.Approve, callback.Decision)
+	assert.Equal(t, DecisionCodeWarehouseDecrease, callback.DecisionCode, "the mock rule manager approves a warehouse decrease")
+}
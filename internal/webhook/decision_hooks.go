@@ -0,0 +1,86 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/logging"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+	"go.uber.org/zap"
+)
+
+// DecisionHook runs custom behavior (notifications, ticket updates) after naysayer has
+// approved an MR or requested manual review. Errors are logged and never change the decision.
+type DecisionHook interface {
+	Name() string
+	OnDecision(ctx context.Context, mrInfo *gitlab.MRInfo, result *shared.RuleEvaluation) error
+}
+
+// logDecisionHookName is the DECISION_HOOKS entry for LogDecisionHook
+const logDecisionHookName = "log"
+
+// NewDecisionHook creates the built-in hook registered under name in DECISION_HOOKS
+func NewDecisionHook(name string) (DecisionHook, error) {
+	switch name {
+	case logDecisionHookName:
+		return NewLogDecisionHook(), nil
+	default:
+		return nil, fmt.Errorf("unknown decision hook: %s", name)
+	}
+}
+
+// LogDecisionHook writes each decision as a single structured log entry for log-based alerting
+type LogDecisionHook struct{}
+
+// NewLogDecisionHook creates a hook that logs every decision
+func NewLogDecisionHook() *LogDecisionHook {
+	return &LogDecisionHook{}
+}
+
+// Name returns the hook's DECISION_HOOKS identifier
+func (h *LogDecisionHook) Name() string {
+	return logDecisionHookName
+}
+
+// OnDecision logs the decision with the MR and file counts
+func (h *LogDecisionHook) OnDecision(ctx context.Context, mrInfo *gitlab.MRInfo, result *shared.RuleEvaluation) error {
+	logging.MRInfo(mrInfo.MRIID, "Decision hook",
+		zap.Int("project_id", mrInfo.ProjectID),
+		zap.String("author", mrInfo.Author),
+		zap.String("decision", string(result.FinalDecision.Type)),
+		zap.String("reason", result.FinalDecision.Reason),
+		zap.String("correlation_id", result.CorrelationID),
+		zap.Int("total_files", result.TotalFiles),
+		zap.Int("review_files", result.ReviewFiles))
+	return nil
+}
+
+// AddDecisionHook registers a hook to run after every decision
+func (h *DataProductConfigMrReviewHandler) AddDecisionHook(hook DecisionHook) {
+	h.decisionHooks = append(h.decisionHooks, hook)
+}
+
+// runDecisionHooks calls every registered hook in order; a failing hook doesn't stop the others
+func (h *DataProductConfigMrReviewHandler) runDecisionHooks(mrInfo *gitlab.MRInfo, result *shared.RuleEvaluation) {
+	for _, hook := range h.decisionHooks {
+		if err := runDecisionHook(hook, mrInfo, result); err != nil {
+			logging.MRWarn(mrInfo.MRIID, "Decision hook failed", zap.String("hook", hook.Name()), zap.Error(err))
+		}
+	}
+}
+
+// runDecisionHook calls a single hook, turning a panic into an error so it can't take down the webhook
+func runDecisionHook(hook DecisionHook, mrInfo *gitlab.MRInfo, result *shared.RuleEvaluation) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("hook panicked: %v", r)
+		}
+	}()
+	return hook.OnDecision(context.Background(), mrInfo, result)
+}
+
+// AddDecisionHook registers a hook to run after decisions made by `/naysayer recheck`
+func (h *NoteCommandHandler) AddDecisionHook(hook DecisionHook) {
+	h.reviewHandler.AddDecisionHook(hook)
+}
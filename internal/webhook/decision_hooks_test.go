@@ -0,0 +1,106 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingDecisionHook records the decisions it sees and optionally fails or panics
+type recordingDecisionHook struct {
+	err       error
+	panics    bool
+	decisions []shared.DecisionType
+}
+
+func (h *recordingDecisionHook) Name() string {
+	return "recording"
+}
+
+func (h *recordingDecisionHook) OnDecision(ctx context.Context, mrInfo *gitlab.MRInfo, result *shared.RuleEvaluation) error {
+	h.decisions = append(h.decisions, result.FinalDecision.Type)
+	if h.panics {
+		panic("hook exploded")
+	}
+	return h.err
+}
+
+func TestApplyDecision_RunsDecisionHooks(t *testing.T) {
+	mrInfo := &gitlab.MRInfo{ProjectID: 123, MRIID: 456, Author: "testuser", State: "opened"}
+
+	for _, decision := range []shared.DecisionType{shared.Approve, shared.ManualReview} {
+		t.Run(string(decision), func(t *testing.T) {
+			failing := &recordingDecisionHook{err: errors.New("slack unavailable")}
+			panicking := &recordingDecisionHook{panics: true}
+			recorder := &recordingDecisionHook{}
+			handler := &DataProductConfigMrReviewHandler{gitlabClient: &MockGitLabClient{}, config: &config.Config{}}
+			handler.AddDecisionHook(failing)
+			handler.AddDecisionHook(panicking)
+			handler.AddDecisionHook(recorder)
+
+			approved, err := handler.applyDecision(&shared.RuleEvaluation{
+				FinalDecision:   shared.Decision{Type: decision, Reason: "test"},
+				FileValidations: map[string]*shared.FileValidationSummary{},
+			}, mrInfo)
+
+			require.NoError(t, err)
+			assert.Equal(t, decision == shared.Approve, approved)
+			assert.Equal(t, []shared.DecisionType{decision}, failing.decisions)
+			assert.Equal(t, []shared.DecisionType{decision}, panicking.decisions)
+			assert.Equal(t, []shared.DecisionType{decision}, recorder.decisions, "hooks after a failing hook still run")
+		})
+	}
+}
+
+func TestDecisionHooks_FailingHookDoesNotBreakResponse(t *testing.T) {
+	setupTestRulesFile(t)
+	handler := NewDataProductConfigMrReviewHandlerWithClient(createTestConfig(), &MockGitLabClient{changes: noteCommandTestChanges})
+	handler.ruleManager = &MockRuleManagerForApproval{}
+	hook := &recordingDecisionHook{err: errors.New("jira unavailable")}
+	handler.AddDecisionHook(hook)
+
+	app := createTestApp()
+	app.Post("/webhook", handler.HandleWebhook)
+	payload := map[string]interface{}{
+		"object_kind": "merge_request",
+		"object_attributes": map[string]interface{}{
+			"iid":           456,
+			"source_branch": "feature/update",
+			"target_branch": "main",
+			"state":         "opened",
+		},
+		"project": map[string]interface{}{"id": 123},
+		"user":    map[string]interface{}{"username": "testuser"},
+	}
+	jsonData, _ := json.Marshal(payload)
+	req := httptest.NewRequest("POST", "/webhook", bytes.NewReader(jsonData))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+
+	var response map[string]interface{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&response))
+	assert.Equal(t, true, response["mr_approved"])
+	assert.Equal(t, []shared.DecisionType{shared.Approve}, hook.decisions)
+}
+
+func TestNewDecisionHook(t *testing.T) {
+	hook, err := NewDecisionHook("log")
+	require.NoError(t, err)
+	assert.Equal(t, "log", hook.Name())
+	assert.NoError(t, hook.OnDecision(context.Background(), &gitlab.MRInfo{ProjectID: 1, MRIID: 2}, &shared.RuleEvaluation{}))
+
+	_, err = NewDecisionHook("slack")
+	assert.Error(t, err)
+}
@@ -0,0 +1,31 @@
+package webhook
+
+import (
+	fiber "github.com/gofiber/fiber/v2"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+)
+
+// ConfigHandler reports the configuration the service is running with. Secrets are never
+// returned; the response only says whether each one is set.
+type ConfigHandler struct {
+	cfg *config.Config
+}
+
+// NewConfigHandler creates a handler reporting cfg
+func NewConfigHandler(cfg *config.Config) *ConfigHandler {
+	return &ConfigHandler{cfg: cfg}
+}
+
+// HandleConfig returns the effective configuration with secrets redacted
+func (h *ConfigHandler) HandleConfig(c *fiber.Ctx) error {
+	return c.JSON(fiber.Map{
+		"gitlab_base_url":            h.cfg.GitLab.BaseURL,
+		"analysis_mode":              h.cfg.AnalysisMode(),
+		"webhook_security_mode":      h.cfg.WebhookSecurityMode(),
+		"approval_comment_verbosity": h.cfg.Comments.ApprovalCommentVerbosity(),
+		"review_comment_verbosity":   h.cfg.Comments.ReviewCommentVerbosity(),
+		"secrets":                    h.cfg.SecretsSet(),
+		"config":                     h.cfg.Redacted(),
+	})
+}
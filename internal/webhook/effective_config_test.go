@@ -0,0 +1,103 @@
+package webhook
+
+import (
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigHandler_RedactsSecrets(t *testing.T) {
+	cfg := createTestConfig()
+	cfg.GitLab.Token = "glpat-very-secret"
+	cfg.GitLab.ApprovalToken = "glpat-approval-secret"
+	cfg.Webhook.Secret = "webhook-secret-value"
+	cfg.Webhook.DecisionCallbackURL = "https://hooks.example.com/naysayer"
+	cfg.Server.AdminToken = "admin-token-value"
+	cfg.Comments.CommentVerbosity = "detailed"
+	cfg.Comments.ReviewVerbosity = "basic"
+
+	app := createTestApp()
+	app.Get("/api/config", RequireAdminToken(cfg), NewConfigHandler(cfg).HandleConfig)
+
+	req := httptest.NewRequest("GET", "/api/config", nil)
+	req.Header.Set("Authorization", "Bearer admin-token-value")
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	for _, secret := range []string{"glpat-very-secret", "glpat-approval-secret", "webhook-secret-value", "admin-token-value"} {
+		assert.NotContains(t, string(body), secret)
+	}
+
+	var response struct {
+		GitLabBaseURL            string          `json:"gitlab_base_url"`
+		AnalysisMode             string          `json:"analysis_mode"`
+		WebhookSecurityMode      string          `json:"webhook_security_mode"`
+		ApprovalCommentVerbosity string          `json:"approval_comment_verbosity"`
+		ReviewCommentVerbosity   string          `json:"review_comment_verbosity"`
+		Secrets                  map[string]bool `json:"secrets"`
+		Config                   struct {
+			Webhook struct {
+				DecisionCallbackURL string
+			}
+		} `json:"config"`
+	}
+	require.NoError(t, json.Unmarshal(body, &response))
+	assert.Equal(t, "https://gitlab.example.com", response.GitLabBaseURL)
+	assert.Equal(t, "Full YAML analysis", response.AnalysisMode)
+	assert.Equal(t, "Token verification available", response.WebhookSecurityMode)
+	assert.Equal(t, "detailed", response.ApprovalCommentVerbosity)
+	assert.Equal(t, "basic", response.ReviewCommentVerbosity)
+	assert.Equal(t, "https://hooks.example.com/naysayer", response.Config.Webhook.DecisionCallbackURL)
+
+	assert.True(t, response.Secrets["GITLAB_TOKEN"])
+	assert.True(t, response.Secrets["GITLAB_TOKEN_APPROVAL"])
+	assert.True(t, response.Secrets["WEBHOOK_SECRET"])
+	assert.True(t, response.Secrets["ADMIN_API_TOKEN"])
+	assert.False(t, response.Secrets["GITLAB_TOKEN_STALE_MR"])
+	assert.False(t, response.Secrets["DECISION_CALLBACK_SECRET"])
+	assert.Equal(t, "glpat-very-secret", cfg.GitLab.Token, "redaction does not modify the running configuration")
+}
+
+func TestConfigHandler_UnsetSecrets(t *testing.T) {
+	cfg := createTestConfig()
+	cfg.GitLab.Token = ""
+
+	var response struct {
+		AnalysisMode        string          `json:"analysis_mode"`
+		WebhookSecurityMode string          `json:"webhook_security_mode"`
+		Secrets             map[string]bool `json:"secrets"`
+	}
+	app := createTestApp()
+	app.Get("/api/config", NewConfigHandler(cfg).HandleConfig)
+	resp, err := app.Test(httptest.NewRequest("GET", "/api/config", nil))
+	require.NoError(t, err)
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&response))
+
+	assert.Equal(t, "Limited (no GitLab token)", response.AnalysisMode)
+	assert.Equal(t, "No secret configured", response.WebhookSecurityMode)
+	assert.NotEmpty(t, response.Secrets)
+	for name, set := range response.Secrets {
+		assert.False(t, set, name)
+	}
+}
+
+func TestConfigHandler_RequiresAdminToken(t *testing.T) {
+	cfg := createTestConfig()
+	cfg.Server.AdminToken = "admin-token-value"
+
+	app := createTestApp()
+	app.Get("/api/config", RequireAdminToken(cfg), NewConfigHandler(cfg).HandleConfig)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/api/config", nil))
+	require.NoError(t, err)
+	assert.Equal(t, 401, resp.StatusCode)
+	body, _ := io.ReadAll(resp.Body)
+	assert.NotContains(t, string(body), "test-token")
+}
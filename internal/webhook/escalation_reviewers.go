@@ -0,0 +1,97 @@
+package webhook
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/logging"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+)
+
+// EscalationReviewers maps a file path glob to the users or groups responsible for it
+type EscalationReviewers struct {
+	Path      string   `yaml:"path"`      // Glob such as "dataproducts/analytics/**"
+	Reviewers []string `yaml:"reviewers"` // GitLab usernames or group paths, with or without a leading '@'
+}
+
+// loadEscalationReviewers parses the YAML file at path, a list of EscalationReviewers.
+// No file, or a missing or invalid one, means manual review comments mention nobody.
+func loadEscalationReviewers(path string) []EscalationReviewers {
+	if path == "" {
+		return nil
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		logging.Warn("Failed to read escalation reviewers %s, mentioning nobody: %v", path, err)
+		return nil
+	}
+	var mappings []EscalationReviewers
+	if err := yaml.Unmarshal(content, &mappings); err != nil {
+		logging.Warn("Failed to parse escalation reviewers %s, mentioning nobody: invalid YAML: %v", path, err)
+		return nil
+	}
+	logging.Info("Loaded %d escalation reviewer mappings from %s", len(mappings), path)
+	return mappings
+}
+
+// escalationMentions returns the @-mentions of the reviewers mapped to the files needing manual
+// review, in mapping order without duplicates. When no single file needs review (e.g. an MR-level
+// check failed) every file in the MR is considered.
+func (mb *MessageBuilder) escalationMentions(result *shared.RuleEvaluation) []string {
+	if len(mb.escalation) == 0 || result == nil {
+		return nil
+	}
+
+	var reviewFiles, allFiles []string
+	for filePath, fileValidation := range result.FileValidations {
+		allFiles = append(allFiles, filePath)
+		if fileValidation != nil && fileValidation.FileDecision == shared.ManualReview {
+			reviewFiles = append(reviewFiles, filePath)
+		}
+	}
+	if len(reviewFiles) == 0 {
+		reviewFiles = allFiles
+	}
+	sort.Strings(reviewFiles)
+
+	var mentions []string
+	seen := make(map[string]bool)
+	for _, mapping := range mb.escalation {
+		if !matchesAnyFile(reviewFiles, mapping.Path) {
+			continue
+		}
+		for _, reviewer := range mapping.Reviewers {
+			name := strings.TrimPrefix(strings.TrimSpace(reviewer), "@")
+			if name == "" || seen[name] {
+				continue
+			}
+			seen[name] = true
+			mentions = append(mentions, "@"+name)
+		}
+	}
+	return mentions
+}
+
+// matchesAnyFile reports whether any of the files matches the glob
+func matchesAnyFile(files []string, pattern string) bool {
+	for _, filePath := range files {
+		if shared.MatchesPattern(filePath, pattern) {
+			return true
+		}
+	}
+	return false
+}
+
+// buildEscalationLine returns the line mentioning the mapped reviewers, or "" when none are mapped
+func (mb *MessageBuilder) buildEscalationLine(result *shared.RuleEvaluation) string {
+	mentions := mb.escalationMentions(result)
+	if len(mentions) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("👥 **Reviewers:** %s\n\n", strings.Join(mentions, " "))
+}
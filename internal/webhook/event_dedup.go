@@ -0,0 +1,105 @@
+package webhook
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	fiber "github.com/gofiber/fiber/v2"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/logging"
+)
+
+// EventUUIDHeader carries GitLab's unique ID for a webhook event; redeliveries reuse it
+const EventUUIDHeader = "X-Gitlab-Event-UUID"
+
+// EventDeduplicator skips webhook redeliveries using a bounded LRU of recently seen event UUIDs.
+// GitLab redelivers on timeout, and reprocessing would approve or comment twice.
+type EventDeduplicator struct {
+	mu       sync.Mutex
+	capacity int
+	ttl      time.Duration
+	entries  map[string]*list.Element
+	order    *list.List       // Most recently seen at the front
+	now      func() time.Time // Clock used for TTL checks; defaults to time.Now
+}
+
+type dedupEntry struct {
+	key    string
+	seenAt time.Time
+}
+
+// NewEventDeduplicator creates a deduplicator sized from the webhook configuration
+func NewEventDeduplicator(cfg *config.Config) *EventDeduplicator {
+	return &EventDeduplicator{
+		capacity: cfg.Webhook.DedupCacheSize,
+		ttl:      time.Duration(cfg.Webhook.DedupTTLSeconds) * time.Second,
+		entries:  make(map[string]*list.Element),
+		order:    list.New(),
+		now:      time.Now,
+	}
+}
+
+// Handle is a route middleware that answers duplicate deliveries without calling the next handler.
+// Deliveries that fail are forgotten so GitLab's retry is processed.
+func (d *EventDeduplicator) Handle(c *fiber.Ctx) error {
+	eventUUID := c.Get(EventUUIDHeader)
+	if eventUUID == "" || d.capacity <= 0 {
+		return c.Next()
+	}
+
+	// The same event is delivered to every hook configured on a project, so key by route too
+	key := c.Path() + "|" + eventUUID
+	if !d.claim(key) {
+		logging.Info("Skipping duplicate webhook delivery %s for %s", eventUUID, c.Path())
+		return c.JSON(fiber.Map{
+			"webhook_response": "processed",
+			"decision":         "duplicate",
+			"event_uuid":       eventUUID,
+		})
+	}
+
+	err := c.Next()
+	if err != nil || c.Response().StatusCode() >= fiber.StatusBadRequest {
+		d.release(key)
+	}
+	return err
+}
+
+// claim records key as seen and reports whether it was not already seen within the TTL.
+// Claiming before processing also catches a redelivery that arrives while the original is still running.
+func (d *EventDeduplicator) claim(key string) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	now := d.now()
+	if element, ok := d.entries[key]; ok {
+		entry := element.Value.(*dedupEntry)
+		d.order.MoveToFront(element)
+		if now.Sub(entry.seenAt) < d.ttl {
+			return false
+		}
+		entry.seenAt = now
+		return true
+	}
+
+	d.entries[key] = d.order.PushFront(&dedupEntry{key: key, seenAt: now})
+	for d.order.Len() > d.capacity {
+		oldest := d.order.Back()
+		d.order.Remove(oldest)
+		delete(d.entries, oldest.Value.(*dedupEntry).key)
+	}
+	return true
+}
+
+// release forgets key so the next delivery of the event is processed
+func (d *EventDeduplicator) release(key string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if element, ok := d.entries[key]; ok {
+		d.order.Remove(element)
+		delete(d.entries, key)
+	}
+}
@@ -0,0 +1,187 @@
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	fiber "github.com/gofiber/fiber/v2"
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestDeduplicator(size, ttlSeconds int) *EventDeduplicator {
+	return NewEventDeduplicator(&config.Config{
+		Webhook: config.WebhookConfig{DedupCacheSize: size, DedupTTLSeconds: ttlSeconds},
+	})
+}
+
+func deliverWebhook(t *testing.T, app *fiber.App, path, eventUUID string, payload map[string]interface{}) map[string]interface{} {
+	t.Helper()
+	jsonData, _ := json.Marshal(payload)
+	req := httptest.NewRequest("POST", path, bytes.NewReader(jsonData))
+	req.Header.Set("Content-Type", "application/json")
+	if eventUUID != "" {
+		req.Header.Set(EventUUIDHeader, eventUUID)
+	}
+
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+
+	var response map[string]interface{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&response))
+	return response
+}
+
+func TestEventDeduplicator_MRReviewRedelivery(t *testing.T) {
+	setupTestRulesFile(t)
+	mockClient := &MockGitLabClient{changes: noteCommandTestChanges}
+	handler := NewDataProductConfigMrReviewHandlerWithClient(createTestConfig(), mockClient)
+	handler.ruleManager = &MockRuleManagerForApproval{}
+	dedup := newTestDeduplicator(10, 3600)
+
+	app := createTestApp()
+	app.Post("/webhook", dedup.Handle, handler.HandleWebhook)
+	payload := map[string]interface{}{
+		"object_kind": "merge_request",
+		"object_attributes": map[string]interface{}{
+			"iid":           456,
+			"title":         "Update product",
+			"source_branch": "feature/update",
+			"target_branch": "main",
+			"state":         "opened",
+		},
+		"project": map[string]interface{}{"id": 123},
+		"user":    map[string]interface{}{"username": "testuser"},
+	}
+
+	t.Run("first delivery is processed", func(t *testing.T) {
+		response := deliverWebhook(t, app, "/webhook", "event-1", payload)
+
+		assert.Equal(t, true, response["mr_approved"])
+		assert.Len(t, mockClient.approvalMessages, 1)
+	})
+
+	t.Run("duplicate delivery is skipped", func(t *testing.T) {
+		fetchCalls := mockClient.fetchChangesCalls
+		response := deliverWebhook(t, app, "/webhook", "event-1", payload)
+
+		assert.Equal(t, "duplicate", response["decision"])
+		assert.Equal(t, "event-1", response["event_uuid"])
+		assert.Equal(t, fetchCalls, mockClient.fetchChangesCalls)
+		assert.Len(t, mockClient.approvalMessages, 1, "a redelivery must not approve again")
+	})
+
+	t.Run("a new event is processed", func(t *testing.T) {
+		response := deliverWebhook(t, app, "/webhook", "event-2", payload)
+
+		assert.Equal(t, true, response["mr_approved"])
+		assert.Len(t, mockClient.approvalMessages, 2)
+	})
+
+	t.Run("deliveries without a UUID are always processed", func(t *testing.T) {
+		deliverWebhook(t, app, "/webhook", "", payload)
+		deliverWebhook(t, app, "/webhook", "", payload)
+
+		assert.Len(t, mockClient.approvalMessages, 4)
+	})
+}
+
+func TestEventDeduplicator_Handle(t *testing.T) {
+	tests := []struct {
+		name          string
+		cacheSize     int
+		firstStatus   int
+		secondPath    string
+		expectedCalls int
+	}{
+		{name: "duplicate skipped", cacheSize: 10, firstStatus: 200, secondPath: "/a", expectedCalls: 1},
+		{name: "failed delivery is retried", cacheSize: 10, firstStatus: 500, secondPath: "/a", expectedCalls: 2},
+		{name: "same event on another route is processed", cacheSize: 10, firstStatus: 200, secondPath: "/b", expectedCalls: 2},
+		{name: "disabled when cache size is zero", cacheSize: 0, firstStatus: 200, secondPath: "/a", expectedCalls: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dedup := newTestDeduplicator(tt.cacheSize, 3600)
+			calls := 0
+			status := tt.firstStatus
+			next := func(c *fiber.Ctx) error {
+				calls++
+				code := status
+				status = 200
+				return c.Status(code).JSON(fiber.Map{"decision": "processed"})
+			}
+
+			app := fiber.New()
+			app.Post("/a", dedup.Handle, next)
+			app.Post("/b", dedup.Handle, next)
+
+			for _, path := range []string{"/a", tt.secondPath} {
+				req := httptest.NewRequest("POST", path, nil)
+				req.Header.Set(EventUUIDHeader, "event-1")
+				_, err := app.Test(req)
+				require.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.expectedCalls, calls)
+		})
+	}
+}
+
+func TestEventDeduplicator_Claim(t *testing.T) {
+	t.Run("expired entries are processed again", func(t *testing.T) {
+		dedup := newTestDeduplicator(10, 60)
+		now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+		dedup.now = func() time.Time { return now }
+
+		assert.True(t, dedup.claim("event-1"))
+		now = now.Add(59 * time.Second)
+		assert.False(t, dedup.claim("event-1"))
+		now = now.Add(time.Second)
+		assert.True(t, dedup.claim("event-1"))
+	})
+
+	t.Run("least recently seen entry is evicted", func(t *testing.T) {
+		dedup := newTestDeduplicator(2, 3600)
+
+		assert.True(t, dedup.claim("event-1"))
+		assert.True(t, dedup.claim("event-2"))
+		assert.False(t, dedup.claim("event-1"))
+		assert.True(t, dedup.claim("event-3"))
+
+		assert.Equal(t, 2, dedup.order.Len())
+		assert.False(t, dedup.claim("event-1"))
+		assert.True(t, dedup.claim("event-2"), "oldest entry should have been evicted")
+	})
+}
+
+func TestMRReview_ApprovalMessageCarriesEventUUID(t *testing.T) {
+	setupTestRulesFile(t)
+	mockClient := &MockGitLabClient{changes: noteCommandTestChanges}
+	cfg := createTestConfig()
+	cfg.Approval.MessageSuffixEnabled = true
+	handler := NewDataProductConfigMrReviewHandlerWithClient(cfg, mockClient)
+	handler.ruleManager = &MockRuleManagerForApproval{}
+
+	app := createTestApp()
+	app.Post("/webhook", handler.HandleWebhook)
+	deliverWebhook(t, app, "/webhook", "event-7", map[string]interface{}{
+		"object_kind": "merge_request",
+		"object_attributes": map[string]interface{}{
+			"iid":           456,
+			"source_branch": "feature/update",
+			"target_branch": "main",
+			"state":         "opened",
+		},
+		"project": map[string]interface{}{"id": 123},
+		"user":    map[string]interface{}{"username": "testuser"},
+	})
+
+	require.Len(t, mockClient.approvalMessages, 1)
+	assert.Regexp(t, `^Auto-approved: .+ \[naysayer:APPROVE_[A-Z_]+:event-7\]$`, mockClient.approvalMessages[0])
+}
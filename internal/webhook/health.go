@@ -5,12 +5,14 @@ import (
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
 )
 
 // HealthHandler handles health check requests
 type HealthHandler struct {
 	config    *config.Config
 	startTime time.Time
+	gitlab    *gitlab.Client // Reports the last successful GitLab call made by any handler's client
 }
 
 // NewHealthHandler creates a new health handler
@@ -18,6 +20,7 @@ func NewHealthHandler(cfg *config.Config) *HealthHandler {
 	return &HealthHandler{
 		config:    cfg,
 		startTime: time.Now(),
+		gitlab:    gitlab.NewClientWithConfig(cfg),
 	}
 }
 
@@ -37,6 +40,12 @@ func (h *HealthHandler) HandleHealth(c *fiber.Ctx) error {
 		"webhook_secret": h.config.HasWebhookSecret(),
 	}
 
+	// null until GitLab has answered once; a stale value means naysayer is up but cannot reach GitLab
+	health["last_gitlab_success"] = nil
+	if lastSuccess := h.gitlab.LastSuccessfulCall(); !lastSuccess.IsZero() {
+		health["last_gitlab_success"] = lastSuccess.UTC().Format(time.RFC3339)
+	}
+
 	return c.JSON(health)
 }
 
@@ -3,12 +3,15 @@ package webhook
 import (
 	"encoding/json"
 	"io"
+	"net/http"
 	"net/http/httptest"
 	"testing"
 	"time"
 
 	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNewHealthHandler(t *testing.T) {
@@ -343,3 +346,36 @@ func TestHealthHandler_HTTPMethods(t *testing.T) {
 		})
 	}
 }
+
+func TestHealthHandler_HandleHealth_LastGitLabSuccess(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"changes": []}`))
+	}))
+	defer server.Close()
+
+	cfg := createTestConfig()
+	cfg.GitLab.BaseURL = server.URL
+	handler := NewHealthHandler(cfg)
+	app := createTestApp()
+	app.Get("/health", handler.HandleHealth)
+
+	getHealth := func() map[string]interface{} {
+		resp, err := app.Test(httptest.NewRequest("GET", "/health", nil))
+		require.NoError(t, err)
+		var health map[string]interface{}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&health))
+		return health
+	}
+
+	health := getHealth()
+	assert.Contains(t, health, "last_gitlab_success")
+	assert.Nil(t, health["last_gitlab_success"], "GitLab has not answered yet")
+
+	// Any client for the same GitLab instance updates the timestamp
+	_, err := gitlab.NewClientWithConfig(cfg).FetchMRChanges(123, 456)
+	require.NoError(t, err)
+
+	lastSuccess, err := time.Parse(time.RFC3339, getHealth()["last_gitlab_success"].(string))
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now(), lastSuccess, time.Minute)
+}
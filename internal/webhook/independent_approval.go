@@ -0,0 +1,62 @@
+package webhook
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/logging"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+	"go.uber.org/zap"
+)
+
+// independentApprovalMissing reports whether the MR changes a path listed in INDEPENDENT_APPROVAL_PATHS
+// and no human other than its author has approved it yet. Naysayer's approval would then be the only one, which could satisfy
+// a required approval (e.g. as a code owner) that should come from an independent reviewer.
+// If the approvals can't be checked, a human review is requested.
+func (h *DataProductConfigMrReviewHandler) independentApprovalMissing(result *shared.RuleEvaluation, mrInfo *gitlab.MRInfo) (string, bool) {
+	paths := h.independentApprovalFiles(result)
+	if len(paths) == 0 {
+		return "", false
+	}
+
+	approvals, err := h.gitlabClient.GetMRApprovals(mrInfo.ProjectID, mrInfo.MRIID)
+	if err != nil {
+		logging.MRWarn(mrInfo.MRIID, "Failed to check MR approvals for independent approval", zap.Error(err))
+		return fmt.Sprintf("Independent approval required: `%s` needs a human approval, and the MR's approvals could not be checked", paths[0]), true
+	}
+	if approvals != nil {
+		for _, approver := range approvals.ApprovedBy {
+			if h.isIndependentApprover(approver, mrInfo) {
+				return "", false
+			}
+		}
+	}
+	return fmt.Sprintf("Independent approval required: `%s` needs a human approval other than the author's before naysayer approves", paths[0]), true
+}
+
+// isIndependentApprover reports whether an approval comes from someone other than naysayer and the MR author,
+// who could approve their own MR where the project allows it
+func (h *DataProductConfigMrReviewHandler) isIndependentApprover(approver gitlab.MRApprover, mrInfo *gitlab.MRInfo) bool {
+	if h.gitlabClient.IsNaysayerBotAuthor(approver.User) {
+		return false
+	}
+	username, _ := approver.User["username"].(string)
+	return mrInfo.Author == "" || !strings.EqualFold(username, mrInfo.Author)
+}
+
+// independentApprovalFiles returns the evaluated files matching INDEPENDENT_APPROVAL_PATHS, sorted
+func (h *DataProductConfigMrReviewHandler) independentApprovalFiles(result *shared.RuleEvaluation) []string {
+	var paths []string
+	for filePath := range result.FileValidations {
+		for _, pattern := range h.config.Approval.IndependentApprovalPaths {
+			if shared.MatchesPattern(filePath, pattern) {
+				paths = append(paths, filePath)
+				break
+			}
+		}
+	}
+	sort.Strings(paths)
+	return paths
+}
@@ -0,0 +1,94 @@
+package webhook
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyDecision_IndependentApproval(t *testing.T) {
+	const ownersFile = "dataproducts/agg/test/prod/developers.yaml"
+	human := gitlab.MRApprover{User: map[string]interface{}{"username": "alice"}}
+	bot := gitlab.MRApprover{User: map[string]interface{}{"username": "naysayer-bot"}}
+	author := gitlab.MRApprover{User: map[string]interface{}{"username": "Bob"}}
+
+	tests := []struct {
+		name         string
+		changedFile  string
+		approvals    *gitlab.MRApprovals
+		approvalsErr error
+		wantApproved bool
+		wantReason   string
+	}{
+		{
+			name:        "only naysayer would approve",
+			changedFile: ownersFile,
+			approvals:   &gitlab.MRApprovals{},
+			wantReason:  "Independent approval required: `" + ownersFile + "` needs a human approval other than the author's before naysayer approves",
+		},
+		{
+			name:        "naysayer's own earlier approval doesn't count",
+			changedFile: ownersFile,
+			approvals:   &gitlab.MRApprovals{ApprovedBy: []gitlab.MRApprover{bot}},
+			wantReason:  "needs a human approval other than the author's before naysayer approves",
+		},
+		{
+			name:        "the author's own approval doesn't count",
+			changedFile: ownersFile,
+			approvals:   &gitlab.MRApprovals{Approved: true, ApprovedBy: []gitlab.MRApprover{author}},
+			wantReason:  "needs a human approval other than the author's before naysayer approves",
+		},
+		{
+			name:         "human already approved",
+			changedFile:  ownersFile,
+			approvals:    &gitlab.MRApprovals{Approved: true, ApprovedBy: []gitlab.MRApprover{bot, human}},
+			wantApproved: true,
+		},
+		{
+			name:         "approvals unavailable",
+			changedFile:  ownersFile,
+			approvalsErr: errors.New("GitLab API error 500"),
+			wantReason:   "could not be checked",
+		},
+		{
+			name:         "path not configured",
+			changedFile:  "dataproducts/agg/test/prod/product.yaml",
+			approvals:    &gitlab.MRApprovals{},
+			wantApproved: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockGitLabClient{approvals: tt.approvals, approvalsErr: tt.approvalsErr}
+			handler := &DataProductConfigMrReviewHandler{
+				gitlabClient: mockClient,
+				config: &config.Config{
+					Comments: config.CommentsConfig{EnableMRComments: true},
+					Approval: config.ApprovalConfig{IndependentApprovalPaths: []string{"**/developers.yaml"}},
+				},
+			}
+			result := approveResult()
+			result.FileValidations[tt.changedFile] = &shared.FileValidationSummary{FilePath: tt.changedFile}
+
+			approved, err := handler.applyDecision(result, &gitlab.MRInfo{ProjectID: 123, MRIID: 456, State: "opened", Author: "bob"})
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantApproved, approved)
+			if tt.wantApproved {
+				assert.Len(t, mockClient.approvalMessages, 1)
+				return
+			}
+			assert.Empty(t, mockClient.approvalMessages, "naysayer must not be the only approver")
+			assert.Equal(t, shared.ManualReview, result.FinalDecision.Type)
+			assert.Contains(t, result.FinalDecision.Reason, tt.wantReason)
+			require.NotEmpty(t, mockClient.addedComments, "a human review is requested in a comment")
+			assert.Contains(t, mockClient.addedComments[len(mockClient.addedComments)-1], "Independent approval required")
+		})
+	}
+}
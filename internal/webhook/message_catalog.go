@@ -0,0 +1,88 @@
+package webhook
+
+import (
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/logging"
+)
+
+// defaultLocale is the locale of the built-in message catalog
+const defaultLocale = "en"
+
+// Message keys for comment headers and reasons that aren't decision codes
+const (
+	MessageKeyApprovalHeader      = "HEADER_APPROVAL"
+	MessageKeyManualReviewHeader  = "HEADER_MANUAL_REVIEW"
+	MessageKeyWhyManualReview     = "HEADER_WHY_MANUAL_REVIEW"
+	MessageKeyWhatWasChecked      = "HEADER_WHAT_WAS_CHECKED"
+	MessageKeyUncoveredFilesCause = "REVIEW_UNCOVERED_FILES"
+)
+
+// defaultMessageCatalog is the English text of each message key. Locales in the
+// MESSAGE_CATALOG_PATH file override these key by key; missing keys stay English.
+var defaultMessageCatalog = map[string]string{
+	MessageKeyApprovalHeader:      "✅ **Auto-approved**",
+	MessageKeyManualReviewHeader:  "⚠️ **Manual review required**",
+	MessageKeyWhyManualReview:     "**Why manual review is needed:**",
+	MessageKeyWhatWasChecked:      "**What was checked:**",
+	MessageKeyUncoveredFilesCause: "One or more files require manual review",
+	DecisionCodeWarehouseDecrease: "Auto-approved: Warehouse changes are safe (decreases only)",
+	DecisionCodeAutomatedUser:     "Auto-approved: Automated user with passing CI",
+	DecisionCodeDataverseSafe:     "Auto-approved: Only dataverse-safe files modified",
+	DecisionCodeAllCovered:        "Auto-approved: All rules passed",
+}
+
+// loadMessageCatalog returns the built-in English messages overridden by the locale's entries in
+// the YAML file at path, a map from locale to message key to text. English is used when no file is
+// configured, the file is missing or invalid, or it has no entries for the locale.
+func loadMessageCatalog(path, locale string) map[string]string {
+	messages := make(map[string]string, len(defaultMessageCatalog))
+	for key, text := range defaultMessageCatalog {
+		messages[key] = text
+	}
+	if path == "" || locale == "" || locale == defaultLocale {
+		return messages
+	}
+
+	catalogs, err := readMessageCatalog(path)
+	if err != nil {
+		logging.Warn("Failed to load message catalog %s, using English: %v", path, err)
+		return messages
+	}
+	localized, ok := catalogs[locale]
+	if !ok {
+		logging.Warn("Message catalog %s has no locale %s, using English", path, locale)
+		return messages
+	}
+	for key, text := range localized {
+		if text != "" {
+			messages[key] = text
+		}
+	}
+	logging.Info("Loaded %d %s messages from %s", len(localized), locale, path)
+	return messages
+}
+
+// readMessageCatalog parses a message catalog file
+func readMessageCatalog(path string) (map[string]map[string]string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var catalogs map[string]map[string]string
+	if err := yaml.Unmarshal(content, &catalogs); err != nil {
+		return nil, fmt.Errorf("invalid YAML: %w", err)
+	}
+	return catalogs, nil
+}
+
+// text returns the configured locale's message for key
+func (mb *MessageBuilder) text(key string) string {
+	if text, ok := mb.messages[key]; ok {
+		return text
+	}
+	return defaultMessageCatalog[key]
+}
@@ -4,72 +4,127 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"text/template"
+	"time"
+	"unicode/utf8"
 
 	"github.com/redhat-data-and-ai/naysayer/internal/config"
 	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/logging"
 	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
 )
 
+// codeFenceCloser closes a markdown code block left open by truncation
+const codeFenceCloser = "\n```"
+
 // MessageBuilder handles creation of MR comments and approval messages
 type MessageBuilder struct {
-	config *config.Config
+	config      *config.Config
+	template    *template.Template         // Optional custom comment template (nil uses built-in format)
+	displayText map[string]RuleDisplayText // Friendly names and approval explanations by rule name
+	messages    map[string]string          // Headers and reasons by message key, in the configured locale
+	escalation  []EscalationReviewers      // Reviewers @-mentioned on manual review comments by file path
 }
 
 // NewMessageBuilder creates a new message builder
 func NewMessageBuilder(cfg *config.Config) *MessageBuilder {
-	return &MessageBuilder{config: cfg}
+	mb := &MessageBuilder{config: cfg}
+	mb.template = mb.loadCommentTemplate(cfg.Comments.TemplatePath)
+	mb.displayText = loadRuleDisplayText(cfg.Comments.RuleDisplayTextPath)
+	mb.messages = loadMessageCatalog(cfg.Comments.MessageCatalogPath, cfg.Comments.Locale)
+	mb.escalation = loadEscalationReviewers(cfg.Comments.ReviewerMentionsPath)
+	return mb
 }
 
 // BuildApprovalComment creates a detailed comment for the MR explaining the approval decision
 func (mb *MessageBuilder) BuildApprovalComment(result *shared.RuleEvaluation, mrInfo *gitlab.MRInfo) string {
+	if rendered, ok := mb.renderTemplate(approvalTemplateName, "approval", result, mrInfo); ok {
+		return mb.truncateComment(rendered)
+	}
+
 	var comment strings.Builder
 
 	// Hidden identifier for comment tracking
 	comment.WriteString("<!-- naysayer-comment-id: approval -->\n")
 
 	// Header
-	comment.WriteString("✅ **Auto-approved**\n\n")
+	comment.WriteString(mb.text(MessageKeyApprovalHeader) + "\n\n")
 
 	// Analysis results based on verbosity
-	switch mb.config.Comments.CommentVerbosity {
+	switch mb.config.Comments.ApprovalCommentVerbosity() {
 	case "basic":
 		comment.WriteString(mb.buildBasicSummary(result))
+	case "summary":
+		comment.WriteString(mb.buildAggregatedSummary(result))
 	case "debug":
 		comment.WriteString(mb.buildDebugSummary(result, mrInfo))
 	default: // "detailed"
 		comment.WriteString(mb.buildDetailedSummary(result))
 	}
 
-	return comment.String()
+	return mb.truncateComment(comment.String())
 }
 
 // BuildManualReviewComment creates a detailed comment for MRs requiring manual review
 func (mb *MessageBuilder) BuildManualReviewComment(result *shared.RuleEvaluation, mrInfo *gitlab.MRInfo) string {
+	if rendered, ok := mb.renderTemplate(manualReviewTemplateName, "manual-review", result, mrInfo); ok {
+		return mb.truncateComment(rendered)
+	}
+
 	var comment strings.Builder
 
 	// Hidden identifier for comment tracking
 	comment.WriteString("<!-- naysayer-comment-id: manual-review -->\n")
 
 	// Header
-	comment.WriteString("⚠️ **Manual review required**\n\n")
+	comment.WriteString(mb.text(MessageKeyManualReviewHeader) + "\n\n")
+	comment.WriteString(mb.buildEscalationLine(result))
 
 	// Analysis results based on verbosity
-	switch mb.config.Comments.CommentVerbosity {
+	switch mb.config.Comments.ReviewCommentVerbosity() {
 	case "basic":
 		comment.WriteString(mb.buildBasicManualReviewSummary(result))
+	case "summary":
+		comment.WriteString(mb.buildAggregatedSummary(result))
 	case "debug":
 		comment.WriteString(mb.buildDebugManualReviewSummary(result, mrInfo))
 	default: // "detailed"
 		comment.WriteString(mb.buildDetailedManualReviewSummary(result))
 	}
-	return comment.String()
+	return mb.truncateComment(comment.String())
+}
+
+// truncateComment cuts comments longer than MaxCommentBytes so GitLab accepts the note,
+// keeping the hidden identifier at the top and pointing readers at the logs for the rest
+func (mb *MessageBuilder) truncateComment(comment string) string {
+	limit := mb.config.Comments.MaxCommentBytes
+	if limit <= 0 || len(comment) <= limit {
+		return comment
+	}
+
+	notice := fmt.Sprintf("\n\n---\n✂️ **Comment truncated**: the full analysis is %d bytes, over the %d byte limit. See naysayer's logs for the complete details.\n", len(comment), limit)
+	cut := limit - len(notice) - len(codeFenceCloser)
+	if cut < 0 {
+		cut = 0
+	}
+	for cut > 0 && !utf8.RuneStart(comment[cut]) {
+		cut--
+	}
+
+	truncated := comment[:cut]
+	if strings.Count(truncated, "```")%2 == 1 {
+		truncated += codeFenceCloser
+	}
+
+	logging.Warn("Comment truncated from %d to %d bytes (MAX_COMMENT_BYTES=%d)", len(comment), len(truncated)+len(notice), limit)
+	return truncated + notice
 }
 
 // buildBasicSummary creates a basic approval summary
 func (mb *MessageBuilder) buildBasicSummary(result *shared.RuleEvaluation) string {
 	var summary strings.Builder
 
-	summary.WriteString("**What was checked:**\n")
+	summary.WriteString(mb.text(MessageKeyWhatWasChecked) + "\n")
 	summary.WriteString(mb.buildRulesSummary(result.FileValidations))
 
 	return summary.String()
@@ -92,8 +147,10 @@ func (mb *MessageBuilder) buildDetailedSummary(result *shared.RuleEvaluation) st
 		}
 	}
 
+	summary.WriteString(mb.buildChangesSummary(result))
+
 	// What was checked
-	summary.WriteString("**What was checked:**\n")
+	summary.WriteString(mb.text(MessageKeyWhatWasChecked) + "\n")
 	summary.WriteString(mb.buildRulesSummary(result.FileValidations))
 
 	summary.WriteString("\n</details>")
@@ -101,6 +158,121 @@ func (mb *MessageBuilder) buildDetailedSummary(result *shared.RuleEvaluation) st
 	return summary.String()
 }
 
+// changesSummaryMaxFiles caps the files listed under "What changed" so many-file MRs stay readable
+const changesSummaryMaxFiles = 10
+
+// buildChangesSummary lists, per changed file, the added and removed line counts and the
+// sections touched. Returns "" when no file has a diff summary.
+func (mb *MessageBuilder) buildChangesSummary(result *shared.RuleEvaluation) string {
+	var filePaths []string
+	for filePath, fileValidation := range result.FileValidations {
+		if fileValidation.DiffSummary != nil {
+			filePaths = append(filePaths, filePath)
+		}
+	}
+	if len(filePaths) == 0 {
+		return ""
+	}
+	sort.Strings(filePaths)
+
+	var summary strings.Builder
+	summary.WriteString("**What changed:**\n")
+	for i, filePath := range filePaths {
+		if i == changesSummaryMaxFiles {
+			summary.WriteString(fmt.Sprintf("• …and %d more files\n", len(filePaths)-changesSummaryMaxFiles))
+			break
+		}
+		diffSummary := result.FileValidations[filePath].DiffSummary
+		summary.WriteString(fmt.Sprintf("• `%s` +%d/-%d", filePath, diffSummary.AddedLines, diffSummary.RemovedLines))
+		if len(diffSummary.Sections) > 0 {
+			summary.WriteString(": " + strings.Join(diffSummary.Sections, ", "))
+		}
+		summary.WriteString("\n")
+	}
+	summary.WriteString("\n")
+	return summary.String()
+}
+
+// summaryTopReasons is the number of manual review reasons listed in summary mode
+const summaryTopReasons = 3
+
+// buildAggregatedSummary creates a compact summary for MRs touching many files:
+// approved vs review counts, the most common manual review reasons and a collapsed file list
+func (mb *MessageBuilder) buildAggregatedSummary(result *shared.RuleEvaluation) string {
+	var summary strings.Builder
+
+	approved, review := 0, 0
+	reasonCounts := make(map[string]int)
+	for filePath, fileValidation := range result.FileValidations {
+		if fileValidation.FileDecision != shared.ManualReview {
+			approved++
+			continue
+		}
+		review++
+		for _, reason := range mb.manualReviewReasons(filePath, fileValidation) {
+			reasonCounts[reason]++
+		}
+	}
+
+	summary.WriteString("| Files | Count |\n")
+	summary.WriteString("|-------|-------|\n")
+	summary.WriteString(fmt.Sprintf("| ✅ Auto-approved | %d |\n", approved))
+	summary.WriteString(fmt.Sprintf("| 🚫 Manual review | %d |\n\n", review))
+
+	if len(reasonCounts) > 0 {
+		// Most common reasons first, alphabetical within the same count
+		var reasons []string
+		for reason := range reasonCounts {
+			reasons = append(reasons, reason)
+		}
+		sort.Slice(reasons, func(i, j int) bool {
+			if reasonCounts[reasons[i]] != reasonCounts[reasons[j]] {
+				return reasonCounts[reasons[i]] > reasonCounts[reasons[j]]
+			}
+			return reasons[i] < reasons[j]
+		})
+		if len(reasons) > summaryTopReasons {
+			reasons = reasons[:summaryTopReasons]
+		}
+
+		summary.WriteString("**Top reasons for manual review:**\n")
+		for _, reason := range reasons {
+			unit := "files"
+			if reasonCounts[reason] == 1 {
+				unit = "file"
+			}
+			summary.WriteString(fmt.Sprintf("• %s (%d %s)\n", reason, reasonCounts[reason], unit))
+		}
+		summary.WriteString("\n")
+	}
+
+	summary.WriteString("<details>\n")
+	summary.WriteString(fmt.Sprintf("<summary>📄 <strong>Files in this MR</strong> (%d)</summary>\n\n", len(result.FileValidations)))
+	summary.WriteString(mb.buildFilesSummary(result))
+	summary.WriteString("\n</details>")
+
+	return summary.String()
+}
+
+// manualReviewReasons returns the distinct reasons a file needs manual review
+func (mb *MessageBuilder) manualReviewReasons(filePath string, fileValidation *shared.FileValidationSummary) []string {
+	seen := make(map[string]bool)
+	var reasons []string
+	for _, ruleResult := range fileValidation.RuleResults {
+		if ruleResult.Decision == shared.ManualReview && !ruleResult.Advisory && !seen[ruleResult.Reason] {
+			seen[ruleResult.Reason] = true
+			reasons = append(reasons, ruleResult.Reason)
+		}
+	}
+	if len(reasons) > 0 {
+		return reasons
+	}
+	if len(fileValidation.RuleResults) == 0 {
+		return []string{mb.getUncoveredReason(filePath)}
+	}
+	return []string{"Changed lines not covered by any validation rule"}
+}
+
 // buildDebugSummary creates a verbose debug summary
 func (mb *MessageBuilder) buildDebugSummary(result *shared.RuleEvaluation, mrInfo *gitlab.MRInfo) string {
 	var summary strings.Builder
@@ -124,6 +296,53 @@ func (mb *MessageBuilder) buildDebugSummary(result *shared.RuleEvaluation, mrInf
 	summary.WriteString("📊 **Detailed Analysis Results:**\n")
 	summary.WriteString(mb.buildDetailedRulesSummary(result.FileValidations))
 
+	if timings := mb.buildRuleTimingsSummary(result.FileValidations); timings != "" {
+		summary.WriteString("\n⏱️ **Rule Timings:**\n")
+		summary.WriteString(timings)
+	}
+
+	return summary.String()
+}
+
+// buildRuleTimingsSummary lists the total validation time of each evaluated rule, slowest first
+func (mb *MessageBuilder) buildRuleTimingsSummary(fileValidations map[string]*shared.FileValidationSummary) string {
+	type ruleTiming struct {
+		name  string
+		total time.Duration
+		runs  int
+	}
+
+	timings := make(map[string]*ruleTiming)
+	for _, fileValidation := range fileValidations {
+		for _, ruleResult := range fileValidation.RuleResults {
+			if !ruleResult.WasEvaluated || ruleResult.Duration <= 0 {
+				continue
+			}
+			timing, ok := timings[ruleResult.RuleName]
+			if !ok {
+				timing = &ruleTiming{name: ruleResult.RuleName}
+				timings[ruleResult.RuleName] = timing
+			}
+			timing.total += ruleResult.Duration
+			timing.runs++
+		}
+	}
+
+	sorted := make([]*ruleTiming, 0, len(timings))
+	for _, timing := range timings {
+		sorted = append(sorted, timing)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].total != sorted[j].total {
+			return sorted[i].total > sorted[j].total
+		}
+		return sorted[i].name < sorted[j].name
+	})
+
+	var summary strings.Builder
+	for _, timing := range sorted {
+		summary.WriteString(fmt.Sprintf("• %s: %v (%d run(s))\n", timing.name, timing.total, timing.runs))
+	}
 	return summary.String()
 }
 
@@ -175,6 +394,13 @@ func (mb *MessageBuilder) buildRulesSummary(fileValidations map[string]*shared.F
 					}
 				}
 			case shared.ManualReview:
+				if ruleResult.Advisory {
+					// Advisory warnings override approvals but never a blocking failure
+					if existing, exists := ruleMessages[ruleKey]; !exists || !strings.HasPrefix(existing, "🚫") {
+						ruleMessages[ruleKey] = fmt.Sprintf("⚠️ %s (advisory)", ruleResult.Reason)
+					}
+					continue
+				}
 				// Manual review messages always override, use actual reason
 				ruleMessages[ruleKey] = fmt.Sprintf("🚫 %s", ruleResult.Reason)
 			}
@@ -224,18 +450,11 @@ func (mb *MessageBuilder) isNoiseMessage(message string) bool {
 	return false
 }
 
-// formatRuleName converts internal rule names to user-friendly descriptions (used only in debug mode)
+// formatRuleName converts internal rule names to user-friendly descriptions (used only in debug mode).
+// Rules without display text keep their raw name.
 func (mb *MessageBuilder) formatRuleName(ruleName string) string {
-	friendlyNames := map[string]string{
-		"warehouse_rule":            "Warehouse configuration validated",
-		"service_account_rule":      "Service account validated",
-		"toc_approval_rule":         "TOC approval check",
-		"metadata_rule":             "Metadata validated",
-		"dataproduct_consumer_rule": "Consumer access changes validated",
-	}
-
-	if friendly, ok := friendlyNames[ruleName]; ok {
-		return friendly
+	if text := mb.displayText[ruleName]; text.Name != "" {
+		return text.Name
 	}
 	return ruleName
 }
@@ -259,20 +478,11 @@ func (mb *MessageBuilder) shouldUseGenericMessage(ruleName string) bool {
 
 // getGenericRuleMessage returns a generic message when a rule validates multiple files
 func (mb *MessageBuilder) getGenericRuleMessage(ruleName string, fileCount int) string {
-	switch ruleName {
-	case "metadata_rule":
-		return fmt.Sprintf("✅ Metadata changes validated across %d files", fileCount)
-	case "warehouse_rule":
-		return fmt.Sprintf("✅ Warehouse configuration validated across %d files", fileCount)
-	case "toc_approval_rule":
-		return fmt.Sprintf("✅ TOC approval validated across %d files", fileCount)
-	case "dataproduct_consumer_rule":
-		return fmt.Sprintf("✅ Consumer access validated across %d files", fileCount)
-	case "service_account_rule":
-		return fmt.Sprintf("✅ Service account validated across %d files", fileCount)
-	default:
-		return fmt.Sprintf("✅ Changes validated across %d files", fileCount)
+	explanation := mb.displayText[ruleName].Approval
+	if explanation == "" {
+		explanation = "Changes validated"
 	}
+	return fmt.Sprintf("✅ %s across %d files", explanation, fileCount)
 }
 
 // hasUncoveredFiles checks if there are files without validation rules
@@ -338,7 +548,11 @@ func (mb *MessageBuilder) buildDetailedRulesSummary(fileValidations map[string]*
 		case shared.Approve:
 			summary.WriteString(fmt.Sprintf("• ✅ **%s**\n", friendlyName))
 		case shared.ManualReview:
-			summary.WriteString(fmt.Sprintf("• 🚫 **%s**: %s\n", friendlyName, result.Reason))
+			if result.Advisory {
+				summary.WriteString(fmt.Sprintf("• ⚠️ **%s** (advisory): %s\n", friendlyName, result.Reason))
+			} else {
+				summary.WriteString(fmt.Sprintf("• 🚫 **%s**: %s\n", friendlyName, result.Reason))
+			}
 		}
 	}
 
@@ -410,18 +624,39 @@ func (mb *MessageBuilder) buildDetailedFilesSummary(result *shared.RuleEvaluatio
 	return summary.String()
 }
 
-// BuildApprovalMessage creates a short message for the approval API
+// Decision codes identifying why an MR was auto-approved, stable for auditing
+const (
+	DecisionCodeWarehouseDecrease = "APPROVE_WAREHOUSE_DECREASE"
+	DecisionCodeAutomatedUser     = "APPROVE_AUTOMATED_USER"
+	DecisionCodeDataverseSafe     = "APPROVE_DATAVERSE_SAFE_FILES"
+	DecisionCodeAllCovered        = "APPROVE_ALL_COVERED"
+)
+
+// BuildApprovalMessage creates a short message for the approval API.
+// With Approval.MessageSuffixEnabled, a greppable [naysayer:<code>:<correlation id>] token follows the sentence.
 func (mb *MessageBuilder) BuildApprovalMessage(result *shared.RuleEvaluation) string {
+	code, message := mb.approvalDecision(result)
+	if !mb.config.Approval.MessageSuffixEnabled {
+		return message
+	}
+	if result.CorrelationID == "" {
+		return fmt.Sprintf("%s [naysayer:%s]", message, code)
+	}
+	return fmt.Sprintf("%s [naysayer:%s:%s]", message, code, result.CorrelationID)
+}
+
+// approvalDecision returns the decision code and human-readable sentence for an approval
+func (mb *MessageBuilder) approvalDecision(result *shared.RuleEvaluation) (string, string) {
 	// Analyze the results to create a meaningful short message
 	switch {
 	case mb.hasWarehouseChanges(result):
-		return "Auto-approved: Warehouse changes are safe (decreases only)"
+		return DecisionCodeWarehouseDecrease, mb.text(DecisionCodeWarehouseDecrease)
 	case mb.isAutomatedUser(result):
-		return "Auto-approved: Automated user with passing CI"
+		return DecisionCodeAutomatedUser, mb.text(DecisionCodeAutomatedUser)
 	case mb.hasOnlyDataverseFiles(result):
-		return "Auto-approved: Only dataverse-safe files modified"
+		return DecisionCodeDataverseSafe, mb.text(DecisionCodeDataverseSafe)
 	default:
-		return "Auto-approved: All rules passed"
+		return DecisionCodeAllCovered, mb.text(DecisionCodeAllCovered)
 	}
 }
 
@@ -451,9 +686,9 @@ func (mb *MessageBuilder) hasOnlyDataverseFiles(result *shared.RuleEvaluation) b
 func (mb *MessageBuilder) buildBasicManualReviewSummary(result *shared.RuleEvaluation) string {
 	var summary strings.Builder
 
-	summary.WriteString(fmt.Sprintf("**Why manual review is needed:**\n%s\n\n", result.FinalDecision.Reason))
+	summary.WriteString(fmt.Sprintf("%s\n%s\n\n", mb.text(MessageKeyWhyManualReview), result.FinalDecision.Reason))
 
-	summary.WriteString("**What was checked:**\n")
+	summary.WriteString(mb.text(MessageKeyWhatWasChecked) + "\n")
 	summary.WriteString(mb.buildRulesSummary(result.FileValidations))
 
 	return summary.String()
@@ -467,10 +702,10 @@ func (mb *MessageBuilder) buildDetailedManualReviewSummary(result *shared.RuleEv
 	hasUncovered := mb.hasUncoveredFiles(result)
 
 	if hasUncovered {
-		summary.WriteString("**Why manual review is needed:**\n")
-		summary.WriteString("One or more files require manual review\n\n")
+		summary.WriteString(mb.text(MessageKeyWhyManualReview) + "\n")
+		summary.WriteString(mb.text(MessageKeyUncoveredFilesCause) + "\n\n")
 	} else {
-		summary.WriteString(fmt.Sprintf("**Why manual review is needed:**\n%s\n\n", result.FinalDecision.Reason))
+		summary.WriteString(fmt.Sprintf("%s\n%s\n\n", mb.text(MessageKeyWhyManualReview), result.FinalDecision.Reason))
 	}
 
 	// Collapsible section for analysis details
@@ -484,8 +719,10 @@ func (mb *MessageBuilder) buildDetailedManualReviewSummary(result *shared.RuleEv
 		summary.WriteString("\n")
 	}
 
+	summary.WriteString(mb.buildChangesSummary(result))
+
 	// Always show what was checked (rule results)
-	summary.WriteString("**What was checked:**\n")
+	summary.WriteString(mb.text(MessageKeyWhatWasChecked) + "\n")
 	summary.WriteString(mb.buildRulesSummary(result.FileValidations))
 
 	// If there are uncovered files, show them in a separate section
@@ -548,6 +785,12 @@ func (mb *MessageBuilder) buildDebugManualReviewSummary(result *shared.RuleEvalu
 	summary.WriteString(mb.buildDetailedRulesSummary(result.FileValidations))
 	summary.WriteString("\n")
 
+	if timings := mb.buildRuleTimingsSummary(result.FileValidations); timings != "" {
+		summary.WriteString("⏱️ **Rule Timings:**\n")
+		summary.WriteString(timings)
+		summary.WriteString("\n")
+	}
+
 	// System information (debug mode keeps some details)
 	summary.WriteString("⚙️ **System Details:**\n")
 	summary.WriteString(fmt.Sprintf("• Rule evaluation time: %v\n", result.ExecutionTime))
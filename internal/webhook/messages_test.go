@@ -1,13 +1,19 @@
 package webhook
 
 import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
+	"unicode/utf8"
 
 	"github.com/redhat-data-and-ai/naysayer/internal/config"
 	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
 	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestBuildApprovalComment_BasicVerbosity(t *testing.T) {
@@ -184,6 +190,44 @@ func TestBuildApprovalComment_DetailedVerbosity(t *testing.T) {
 	// Note: Files section only appears when there are 3+ files
 }
 
+func TestMessageBuilder_ChangesSummary(t *testing.T) {
+	builder := NewMessageBuilder(&config.Config{Comments: config.CommentsConfig{CommentVerbosity: "detailed"}})
+	result := &shared.RuleEvaluation{
+		FinalDecision: shared.Decision{Type: shared.ManualReview, Reason: "Needs review"},
+		FileValidations: map[string]*shared.FileValidationSummary{
+			"dataproducts/marketing/prod/product.yaml": {
+				FileDecision: shared.ManualReview,
+				RuleResults:  []shared.LineValidationResult{{RuleName: "warehouse_rule", Decision: shared.ManualReview, Reason: "Warehouse increase"}},
+				DiffSummary:  &shared.DiffSummary{AddedLines: 3, RemovedLines: 2, Sections: []string{"name", "warehouses"}},
+			},
+			"README.md": {
+				FileDecision: shared.Approve,
+				DiffSummary:  &shared.DiffSummary{AddedLines: 1},
+			},
+			"docs/no-diff.md": {FileDecision: shared.Approve},
+		},
+		TotalFiles: 3,
+	}
+
+	comment := builder.BuildManualReviewComment(result, &gitlab.MRInfo{})
+	assert.Contains(t, comment, "**What changed:**\n• `README.md` +1/-0\n• `dataproducts/marketing/prod/product.yaml` +3/-2: name, warehouses\n\n")
+	assert.NotContains(t, comment, "`docs/no-diff.md` +")
+
+	approval := builder.BuildApprovalComment(result, &gitlab.MRInfo{})
+	assert.Contains(t, approval, "`dataproducts/marketing/prod/product.yaml` +3/-2: name, warehouses")
+
+	// Many-file MRs list the first files and count the rest
+	for i := 0; i < changesSummaryMaxFiles+2; i++ {
+		result.FileValidations[fmt.Sprintf("docs/page%02d.md", i)] = &shared.FileValidationSummary{
+			FileDecision: shared.Approve,
+			DiffSummary:  &shared.DiffSummary{AddedLines: 1},
+		}
+	}
+	comment = builder.BuildManualReviewComment(result, &gitlab.MRInfo{})
+	assert.Equal(t, changesSummaryMaxFiles, strings.Count(comment, " +1/-0")+strings.Count(comment, " +3/-2"))
+	assert.Contains(t, comment, "• …and 4 more files\n")
+}
+
 func TestBuildManualReviewComment(t *testing.T) {
 	cfg := &config.Config{
 		Comments: config.CommentsConfig{
@@ -287,3 +331,495 @@ func TestBuildManualReviewComment_IncludesNonEvaluatedManualReviewReason(t *test
 	assert.Contains(t, comment, "**What was checked:**")
 	assert.Contains(t, comment, fallbackReason)
 }
+
+func TestBuildManualReviewComment_SummaryVerbosity(t *testing.T) {
+	cfg := &config.Config{
+		Comments: config.CommentsConfig{
+			CommentVerbosity: "summary",
+		},
+	}
+	builder := NewMessageBuilder(cfg)
+
+	warehouseReason := "Warehouse size increase detected: user warehouse: SMALL → LARGE"
+	approvedFile := func(path string) *shared.FileValidationSummary {
+		return &shared.FileValidationSummary{
+			FilePath:     path,
+			FileDecision: shared.Approve,
+			RuleResults: []shared.LineValidationResult{
+				{RuleName: "metadata_rule", Decision: shared.Approve, Reason: "Metadata valid", WasEvaluated: true},
+			},
+		}
+	}
+	reviewFile := func(path string, reasons ...string) *shared.FileValidationSummary {
+		validation := &shared.FileValidationSummary{FilePath: path, FileDecision: shared.ManualReview}
+		for _, reason := range reasons {
+			validation.RuleResults = append(validation.RuleResults, shared.LineValidationResult{
+				RuleName: "warehouse_rule", Decision: shared.ManualReview, Reason: reason, WasEvaluated: true,
+			})
+		}
+		return validation
+	}
+
+	result := &shared.RuleEvaluation{
+		FinalDecision: shared.Decision{Type: shared.ManualReview, Reason: "One or more files require manual review"},
+		FileValidations: map[string]*shared.FileValidationSummary{
+			"dataproducts/a/prod/product.yaml": reviewFile("dataproducts/a/prod/product.yaml", warehouseReason),
+			"dataproducts/b/prod/product.yaml": reviewFile("dataproducts/b/prod/product.yaml", warehouseReason),
+			"dataproducts/c/prod/product.yaml": approvedFile("dataproducts/c/prod/product.yaml"),
+			"dataproducts/d/prod/product.yaml": approvedFile("dataproducts/d/prod/product.yaml"),
+			"dataproducts/e/prod/product.yaml": approvedFile("dataproducts/e/prod/product.yaml"),
+			"scripts/migrate.sql":              reviewFile("scripts/migrate.sql"),
+		},
+		TotalFiles: 6,
+	}
+
+	comment := builder.BuildManualReviewComment(result, &gitlab.MRInfo{ProjectID: 1, MRIID: 2})
+
+	assert.Contains(t, comment, "<!-- naysayer-comment-id: manual-review -->")
+	assert.Contains(t, comment, "| ✅ Auto-approved | 3 |")
+	assert.Contains(t, comment, "| 🚫 Manual review | 3 |")
+	assert.Contains(t, comment, "**Top reasons for manual review:**\n"+
+		"• "+warehouseReason+" (2 files)\n"+
+		"• No validation rules configured for SQL migrations (1 file)\n")
+	assert.Contains(t, comment, "<summary>📄 <strong>Files in this MR</strong> (6)</summary>")
+	assert.Contains(t, comment, "• `dataproducts/a/prod/product.yaml` 🚫")
+	assert.Contains(t, comment, "• `dataproducts/c/prod/product.yaml` ✅")
+	assert.NotContains(t, comment, "What was checked")
+}
+
+func TestBuildApprovalComment_SummaryVerbosity(t *testing.T) {
+	cfg := &config.Config{
+		Comments: config.CommentsConfig{
+			CommentVerbosity: "summary",
+		},
+	}
+	builder := NewMessageBuilder(cfg)
+
+	result := &shared.RuleEvaluation{
+		FinalDecision: shared.Decision{Type: shared.Approve, Reason: "All rules passed"},
+		FileValidations: map[string]*shared.FileValidationSummary{
+			"dataproducts/a/prod/product.yaml": {FilePath: "dataproducts/a/prod/product.yaml", FileDecision: shared.Approve},
+			"dataproducts/b/prod/product.yaml": {FilePath: "dataproducts/b/prod/product.yaml", FileDecision: shared.Approve},
+		},
+		TotalFiles: 2,
+	}
+
+	comment := builder.BuildApprovalComment(result, &gitlab.MRInfo{ProjectID: 1, MRIID: 2})
+
+	assert.Contains(t, comment, "| ✅ Auto-approved | 2 |")
+	assert.Contains(t, comment, "| 🚫 Manual review | 0 |")
+	assert.NotContains(t, comment, "Top reasons for manual review")
+}
+
+func TestBuildApprovalComment_AdvisoryWarnings(t *testing.T) {
+	builder := NewMessageBuilder(&config.Config{
+		Comments: config.CommentsConfig{CommentVerbosity: "basic"},
+	})
+
+	result := &shared.RuleEvaluation{
+		FinalDecision: shared.Decision{Type: shared.Approve, Reason: "All files passed validation"},
+		FileValidations: map[string]*shared.FileValidationSummary{
+			"test/product.yaml": {
+				FilePath: "test/product.yaml",
+				RuleResults: []shared.LineValidationResult{
+					{
+						RuleName:     "warehouse_rule",
+						Decision:     shared.Approve,
+						Reason:       "Warehouse decreases detected",
+						LineRanges:   []shared.LineRange{{StartLine: 1, EndLine: 10}},
+						WasEvaluated: true,
+					},
+					{
+						RuleName:     "style_rule",
+						Decision:     shared.ManualReview,
+						Reason:       "description should be a full sentence",
+						LineRanges:   []shared.LineRange{{StartLine: 1, EndLine: 10}},
+						WasEvaluated: true,
+						Advisory:     true,
+					},
+				},
+				FileDecision: shared.Approve,
+			},
+		},
+		TotalFiles:    1,
+		ApprovedFiles: 1,
+	}
+
+	comment := builder.BuildApprovalComment(result, &gitlab.MRInfo{ProjectID: 123, MRIID: 456})
+
+	assert.Contains(t, comment, "✅ **Auto-approved**")
+	assert.Contains(t, comment, "• ⚠️ description should be a full sentence (advisory)")
+	assert.NotContains(t, comment, "🚫")
+}
+
+func TestMessageBuilder_VerbosityPerDecisionType(t *testing.T) {
+	cfg := &config.Config{
+		Comments: config.CommentsConfig{
+			CommentVerbosity:  "summary",
+			ApprovalVerbosity: "basic",
+			ReviewVerbosity:   "detailed",
+		},
+	}
+	builder := NewMessageBuilder(cfg)
+	mrInfo := &gitlab.MRInfo{ProjectID: 123, MRIID: 456, Author: "test-user"}
+	fileValidations := map[string]*shared.FileValidationSummary{
+		"test/product.yaml": {
+			FilePath: "test/product.yaml",
+			RuleResults: []shared.LineValidationResult{
+				{RuleName: "warehouse_rule", Decision: shared.Approve, Reason: "Warehouse decreases detected", WasEvaluated: true},
+			},
+			FileDecision: shared.Approve,
+		},
+	}
+
+	approval := builder.BuildApprovalComment(&shared.RuleEvaluation{
+		FinalDecision:   shared.Decision{Type: shared.Approve, Reason: "All changes approved"},
+		FileValidations: fileValidations,
+		TotalFiles:      1,
+		ApprovedFiles:   1,
+	}, mrInfo)
+	review := builder.BuildManualReviewComment(&shared.RuleEvaluation{
+		FinalDecision:   shared.Decision{Type: shared.ManualReview, Reason: "Warehouse size increase"},
+		FileValidations: fileValidations,
+		TotalFiles:      1,
+		ReviewFiles:     1,
+	}, mrInfo)
+
+	assert.Contains(t, approval, "**What was checked:**")
+	assert.NotContains(t, approval, "<details>", "approval comments use the basic format")
+	assert.Contains(t, review, "<details>", "manual review comments use the detailed format")
+}
+
+func TestMessageBuilder_VerbosityFallsBackToCommentVerbosity(t *testing.T) {
+	cfg := &config.Config{
+		Comments: config.CommentsConfig{
+			CommentVerbosity: "basic",
+			ReviewVerbosity:  "detailed",
+		},
+	}
+	builder := NewMessageBuilder(cfg)
+
+	approval := builder.BuildApprovalComment(&shared.RuleEvaluation{
+		FinalDecision:   shared.Decision{Type: shared.Approve, Reason: "All changes approved"},
+		FileValidations: map[string]*shared.FileValidationSummary{},
+		TotalFiles:      1,
+		ApprovedFiles:   1,
+	}, &gitlab.MRInfo{ProjectID: 123, MRIID: 456})
+
+	assert.Contains(t, approval, "**What was checked:**")
+	assert.NotContains(t, approval, "<details>")
+}
+
+func TestBuildApprovalMessage_Suffix(t *testing.T) {
+	result := &shared.RuleEvaluation{
+		FinalDecision:   shared.Decision{Type: shared.Approve, Reason: "All changes approved"},
+		FileValidations: map[string]*shared.FileValidationSummary{},
+		CorrelationID:   "3f1c9a2e",
+	}
+
+	tests := []struct {
+		name          string
+		suffixEnabled bool
+		correlationID string
+		expected      string
+	}{
+		{
+			name:     "suffix disabled",
+			expected: "Auto-approved: All rules passed",
+		},
+		{
+			name:          "suffix enabled",
+			suffixEnabled: true,
+			correlationID: "3f1c9a2e",
+			expected:      "Auto-approved: All rules passed [naysayer:APPROVE_ALL_COVERED:3f1c9a2e]",
+		},
+		{
+			name:          "suffix enabled without correlation id",
+			suffixEnabled: true,
+			expected:      "Auto-approved: All rules passed [naysayer:APPROVE_ALL_COVERED]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := NewMessageBuilder(&config.Config{
+				Approval: config.ApprovalConfig{MessageSuffixEnabled: tt.suffixEnabled},
+			})
+			result.CorrelationID = tt.correlationID
+
+			assert.Equal(t, tt.expected, builder.BuildApprovalMessage(result))
+		})
+	}
+}
+
+func TestBuildApprovalMessage_SuffixDecisionCode(t *testing.T) {
+	builder := NewMessageBuilder(&config.Config{
+		Approval: config.ApprovalConfig{MessageSuffixEnabled: true},
+	})
+	result := &shared.RuleEvaluation{
+		FinalDecision: shared.Decision{Type: shared.Approve},
+		FileValidations: map[string]*shared.FileValidationSummary{
+			"dataproducts/agg/product.yaml": {
+				RuleResults: []shared.LineValidationResult{
+					{RuleName: "warehouse_rule", Decision: shared.Approve},
+				},
+			},
+		},
+		CorrelationID: "evt-42",
+	}
+
+	message := builder.BuildApprovalMessage(result)
+
+	assert.True(t, strings.HasPrefix(message, "Auto-approved: Warehouse changes are safe"), "human sentence comes first")
+	assert.True(t, strings.HasSuffix(message, "[naysayer:APPROVE_WAREHOUSE_DECREASE:evt-42]"))
+}
+
+func TestMessageBuilder_MaxCommentBytes(t *testing.T) {
+	fileValidations := make(map[string]*shared.FileValidationSummary)
+	for i := 0; i < 200; i++ {
+		filePath := fmt.Sprintf("dataproducts/agg/product%03d/prod/product.yaml", i)
+		fileValidations[filePath] = &shared.FileValidationSummary{
+			FilePath:     filePath,
+			FileDecision: shared.ManualReview,
+			RuleResults: []shared.LineValidationResult{
+				{RuleName: "warehouse_rule", Decision: shared.ManualReview, Reason: "Warehouse size increased from XSMALL to LARGE", WasEvaluated: true},
+			},
+		}
+	}
+	result := &shared.RuleEvaluation{
+		FinalDecision:   shared.Decision{Type: shared.ManualReview, Reason: "Warehouse size increase"},
+		FileValidations: fileValidations,
+		TotalFiles:      len(fileValidations),
+		ReviewFiles:     len(fileValidations),
+	}
+	mrInfo := &gitlab.MRInfo{ProjectID: 123, MRIID: 456, Author: "test-user"}
+
+	full := NewMessageBuilder(&config.Config{Comments: config.CommentsConfig{CommentVerbosity: "debug"}}).BuildManualReviewComment(result, mrInfo)
+	require.Greater(t, len(full), 2000)
+
+	t.Run("over the limit is truncated with a logs note", func(t *testing.T) {
+		builder := NewMessageBuilder(&config.Config{Comments: config.CommentsConfig{CommentVerbosity: "debug", MaxCommentBytes: 2000}})
+
+		review := builder.BuildManualReviewComment(result, mrInfo)
+		approval := builder.BuildApprovalComment(result, mrInfo)
+
+		for _, comment := range []string{review, approval} {
+			assert.LessOrEqual(t, len(comment), 2000)
+			assert.True(t, utf8.ValidString(comment))
+			assert.Contains(t, comment, "**Comment truncated**")
+			assert.Contains(t, comment, "See naysayer's logs")
+		}
+		assert.True(t, strings.HasPrefix(review, "<!-- naysayer-comment-id: manual-review -->"), "comment identifier must survive truncation")
+		assert.True(t, strings.HasPrefix(approval, "<!-- naysayer-comment-id: approval -->"))
+	})
+
+	t.Run("under the limit is left intact", func(t *testing.T) {
+		builder := NewMessageBuilder(&config.Config{Comments: config.CommentsConfig{CommentVerbosity: "debug", MaxCommentBytes: len(full)}})
+
+		assert.Equal(t, full, builder.BuildManualReviewComment(result, mrInfo))
+	})
+
+	t.Run("zero disables the limit", func(t *testing.T) {
+		builder := NewMessageBuilder(&config.Config{Comments: config.CommentsConfig{CommentVerbosity: "debug"}})
+
+		assert.NotContains(t, builder.BuildManualReviewComment(result, mrInfo), "**Comment truncated**")
+	})
+}
+
+func TestMessageBuilder_TruncateCommentClosesCodeFence(t *testing.T) {
+	builder := NewMessageBuilder(&config.Config{Comments: config.CommentsConfig{MaxCommentBytes: 300}})
+	comment := "header\n```\n" + strings.Repeat("log line\n", 100) + "```\n"
+
+	truncated := builder.truncateComment(comment)
+
+	assert.LessOrEqual(t, len(truncated), 300)
+	assert.Equal(t, 0, strings.Count(truncated, "```")%2, "open code block must be closed before the note")
+}
+
+func TestMessageBuilder_DebugRuleTimings(t *testing.T) {
+	builder := NewMessageBuilder(&config.Config{Comments: config.CommentsConfig{CommentVerbosity: "debug"}})
+
+	result := &shared.RuleEvaluation{
+		FinalDecision: shared.Decision{Type: shared.ManualReview, Reason: "Warehouse increase"},
+		FileValidations: map[string]*shared.FileValidationSummary{
+			"dataproducts/source/sales/prod/product.yaml": {
+				FilePath:     "dataproducts/source/sales/prod/product.yaml",
+				FileDecision: shared.ManualReview,
+				RuleResults: []shared.LineValidationResult{
+					{RuleName: "metadata_rule", Decision: shared.Approve, Reason: "ok", WasEvaluated: true, Duration: time.Millisecond},
+					{RuleName: "warehouse_rule", Decision: shared.ManualReview, Reason: "Warehouse increase", WasEvaluated: true, Duration: 40 * time.Millisecond},
+				},
+			},
+			"dataproducts/source/sales/dev/product.yaml": {
+				FilePath:     "dataproducts/source/sales/dev/product.yaml",
+				FileDecision: shared.Approve,
+				RuleResults: []shared.LineValidationResult{
+					{RuleName: "metadata_rule", Decision: shared.Approve, Reason: "ok", WasEvaluated: true, Duration: 2 * time.Millisecond},
+					{RuleName: "coverage_fallback", Decision: shared.ManualReview, Reason: "not evaluated"},
+				},
+			},
+		},
+		TotalFiles: 2,
+	}
+	mrInfo := &gitlab.MRInfo{ProjectID: 456, MRIID: 789, Author: "developer", Title: "Resize warehouses"}
+
+	comment := builder.BuildManualReviewComment(result, mrInfo)
+
+	assert.Contains(t, comment, "⏱️ **Rule Timings:**\n• warehouse_rule: 40ms (1 run(s))\n• metadata_rule: 3ms (2 run(s))\n")
+	assert.NotContains(t, comment, "coverage_fallback: ")
+	assert.Contains(t, builder.BuildApprovalComment(result, mrInfo), "⏱️ **Rule Timings:**")
+}
+
+func TestMessageBuilder_RuleDisplayText(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rule_display_text.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`warehouse_rule:
+  name: Warehouse sizing approved
+custom_policy_rule:
+  name: Custom policy validated
+  approval: Custom policy changes validated
+`), 0600))
+
+	cfg := &config.Config{Comments: config.CommentsConfig{RuleDisplayTextPath: path}}
+	mb := NewMessageBuilder(cfg)
+
+	// A config-provided name overrides the default; fields left out keep the default
+	assert.Equal(t, "Warehouse sizing approved", mb.formatRuleName("warehouse_rule"))
+	assert.Equal(t, "✅ Warehouse configuration validated across 3 files", mb.getGenericRuleMessage("warehouse_rule", 3))
+
+	// Custom rules get display text without code changes
+	assert.Equal(t, "Custom policy validated", mb.formatRuleName("custom_policy_rule"))
+	assert.Equal(t, "✅ Custom policy changes validated across 2 files", mb.getGenericRuleMessage("custom_policy_rule", 2))
+
+	// Unknown rules fall back to the raw name
+	assert.Equal(t, "unknown_rule", mb.formatRuleName("unknown_rule"))
+	assert.Equal(t, "✅ Changes validated across 2 files", mb.getGenericRuleMessage("unknown_rule", 2))
+
+	// Built-ins are untouched for builders without a display text file
+	assert.Equal(t, "Warehouse configuration validated", NewMessageBuilder(&config.Config{}).formatRuleName("warehouse_rule"))
+}
+
+func TestMessageBuilder_RuleDisplayTextInvalidFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rule_display_text.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("warehouse_rule: [not, a, mapping"), 0600))
+
+	mb := NewMessageBuilder(&config.Config{Comments: config.CommentsConfig{RuleDisplayTextPath: path}})
+
+	assert.Equal(t, "Metadata validated", mb.formatRuleName("metadata_rule"))
+}
+
+func TestMessageBuilder_Locale(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "messages.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`de:
+  HEADER_APPROVAL: "✅ **Automatisch genehmigt**"
+  HEADER_MANUAL_REVIEW: "⚠️ **Manuelle Prüfung erforderlich**"
+  HEADER_WHY_MANUAL_REVIEW: "**Warum eine manuelle Prüfung nötig ist:**"
+  APPROVE_ALL_COVERED: "Automatisch genehmigt: Alle Regeln bestanden"
+`), 0600))
+
+	mb := NewMessageBuilder(&config.Config{Comments: config.CommentsConfig{
+		CommentVerbosity:   "detailed",
+		Locale:             "de",
+		MessageCatalogPath: path,
+	}})
+
+	approved := &shared.RuleEvaluation{
+		FinalDecision:   shared.Decision{Type: shared.Approve, Reason: "All rules passed"},
+		FileValidations: map[string]*shared.FileValidationSummary{},
+	}
+	assert.Contains(t, mb.BuildApprovalComment(approved, &gitlab.MRInfo{}), "✅ **Automatisch genehmigt**")
+	assert.Equal(t, "Automatisch genehmigt: Alle Regeln bestanden", mb.BuildApprovalMessage(approved))
+
+	review := &shared.RuleEvaluation{
+		FinalDecision:   shared.Decision{Type: shared.ManualReview, Reason: "Warehouse increase"},
+		FileValidations: map[string]*shared.FileValidationSummary{},
+	}
+	comment := mb.BuildManualReviewComment(review, &gitlab.MRInfo{})
+	assert.Contains(t, comment, "⚠️ **Manuelle Prüfung erforderlich**")
+	assert.Contains(t, comment, "**Warum eine manuelle Prüfung nötig ist:**\nWarehouse increase")
+	// Keys the locale leaves out fall back to English
+	assert.Contains(t, comment, "**What was checked:**")
+}
+
+func TestMessageBuilder_LocaleFallsBackToEnglish(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "messages.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("de:\n  HEADER_APPROVAL: \"✅ **Automatisch genehmigt**\"\n"), 0600))
+
+	tests := []struct {
+		name   string
+		locale string
+		path   string
+	}{
+		{name: "locale missing from the catalog", locale: "fr", path: path},
+		{name: "no catalog configured", locale: "de"},
+		{name: "unreadable catalog", locale: "de", path: filepath.Join(t.TempDir(), "missing.yaml")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mb := NewMessageBuilder(&config.Config{Comments: config.CommentsConfig{Locale: tt.locale, MessageCatalogPath: tt.path}})
+
+			assert.Equal(t, "✅ **Auto-approved**", mb.text(MessageKeyApprovalHeader))
+			assert.Equal(t, "Auto-approved: All rules passed", mb.text(DecisionCodeAllCovered))
+		})
+	}
+}
+
+func TestMessageBuilder_EscalationReviewers(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "reviewers.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`- path: "dataproducts/analytics/**"
+  reviewers: ["@analytics-team", alice]
+- path: "dataproducts/**/prod/*"
+  reviewers: [prod-oncall, "@alice"]
+`), 0600))
+	mb := NewMessageBuilder(&config.Config{Comments: config.CommentsConfig{
+		CommentVerbosity:     "detailed",
+		ReviewerMentionsPath: path,
+	}})
+
+	review := func(files map[string]shared.DecisionType) *shared.RuleEvaluation {
+		result := &shared.RuleEvaluation{
+			FinalDecision:   shared.Decision{Type: shared.ManualReview, Reason: "Warehouse increase"},
+			FileValidations: map[string]*shared.FileValidationSummary{},
+		}
+		for filePath, decision := range files {
+			result.FileValidations[filePath] = &shared.FileValidationSummary{FilePath: filePath, FileDecision: decision}
+		}
+		return result
+	}
+
+	t.Run("mapped path mentions its reviewers", func(t *testing.T) {
+		comment := mb.BuildManualReviewComment(review(map[string]shared.DecisionType{
+			"dataproducts/analytics/prod/product.yaml":   shared.ManualReview,
+			"dataproducts/marketing/dev/developers.yaml": shared.Approve,
+		}), &gitlab.MRInfo{})
+
+		assert.Contains(t, comment, "👥 **Reviewers:** @analytics-team @alice @prod-oncall\n")
+	})
+
+	t.Run("only files needing review are escalated", func(t *testing.T) {
+		comment := mb.BuildManualReviewComment(review(map[string]shared.DecisionType{
+			"dataproducts/analytics/dev/product.yaml":  shared.Approve,
+			"dataproducts/marketing/prod/product.yaml": shared.ManualReview,
+		}), &gitlab.MRInfo{})
+
+		assert.Contains(t, comment, "👥 **Reviewers:** @prod-oncall @alice\n")
+		assert.NotContains(t, comment, "@analytics-team")
+	})
+
+	t.Run("unmapped path mentions nobody", func(t *testing.T) {
+		comment := mb.BuildManualReviewComment(review(map[string]shared.DecisionType{
+			"dataproducts/marketing/dev/product.yaml": shared.ManualReview,
+		}), &gitlab.MRInfo{})
+
+		assert.NotContains(t, comment, "**Reviewers:**")
+		assert.NotContains(t, comment, "@")
+	})
+
+	t.Run("no mapping file mentions nobody", func(t *testing.T) {
+		comment := NewMessageBuilder(&config.Config{}).BuildManualReviewComment(review(map[string]shared.DecisionType{
+			"dataproducts/analytics/prod/product.yaml": shared.ManualReview,
+		}), &gitlab.MRInfo{})
+
+		assert.NotContains(t, comment, "**Reviewers:**")
+	})
+}
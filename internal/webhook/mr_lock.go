@@ -0,0 +1,60 @@
+package webhook
+
+import "sync"
+
+// mrLockKey identifies the MR an evaluation lock belongs to
+type mrLockKey struct {
+	projectID int
+	mrIID     int
+}
+
+// evaluationLock serializes one MR's evaluations; holders counts the evaluations holding or
+// waiting for it, so it can be dropped once none are left
+type evaluationLock struct {
+	mu      sync.Mutex
+	holders int
+}
+
+// mrLocks keeps evaluations of the same MR from overlapping while evaluations of different MRs
+// run concurrently
+type mrLocks struct {
+	mu    sync.Mutex
+	locks map[mrLockKey]*evaluationLock
+}
+
+// evaluationLocks is shared by every handler and evaluation path (webhooks, debounced updates,
+// note commands and bulk re-evaluation), since they evaluate and approve the same MRs
+var evaluationLocks = &mrLocks{locks: make(map[mrLockKey]*evaluationLock)}
+
+// lock blocks until no other evaluation of the MR is running and returns the function that releases it
+func (l *mrLocks) lock(projectID, mrIID int) func() {
+	key := mrLockKey{projectID, mrIID}
+
+	l.mu.Lock()
+	lock, ok := l.locks[key]
+	if !ok {
+		lock = &evaluationLock{}
+		l.locks[key] = lock
+	}
+	lock.holders++
+	l.mu.Unlock()
+
+	lock.mu.Lock()
+	return func() {
+		lock.mu.Unlock()
+
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		lock.holders--
+		if lock.holders == 0 {
+			delete(l.locks, key)
+		}
+	}
+}
+
+// held returns the number of MRs with an evaluation running or waiting
+func (l *mrLocks) held() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return len(l.locks)
+}
@@ -0,0 +1,55 @@
+package webhook
+
+import (
+	"testing"
+	"time"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvaluationLocks_RecheckWaitsForRunningEvaluation(t *testing.T) {
+	client := &MockGitLabClient{changes: noteCommandTestChanges}
+	handler := createNoteCommandTestHandler(t, client)
+	evaluated := make(chan int, 2)
+	handler.reviewHandler.ruleManager = &MockRuleManagerForApproval{evaluateFunc: func(mrCtx *shared.MRContext) *shared.RuleEvaluation {
+		evaluated <- mrCtx.MRIID
+		return approveResult()
+	}}
+
+	// A webhook, debounced or bulk evaluation of the MR is running
+	unlock := evaluationLocks.lock(123, 456)
+	done := make(chan int)
+	go func() {
+		status, _ := postNote(t, handler, createNotePayload("/naysayer recheck", "reviewer"))
+		done <- status
+	}()
+
+	select {
+	case <-evaluated:
+		t.Fatal("recheck evaluated the MR while another evaluation of it was running")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	unlock()
+	assert.Equal(t, 200, <-done)
+	assert.Equal(t, 456, <-evaluated)
+	assert.Zero(t, evaluationLocks.held())
+}
+
+func TestEvaluationLocks_DifferentMRsDoNotBlock(t *testing.T) {
+	unlock := evaluationLocks.lock(123, 456)
+	defer unlock()
+
+	acquired := make(chan struct{})
+	go func() {
+		evaluationLocks.lock(123, 789)()
+		close(acquired)
+	}()
+
+	select {
+	case <-acquired:
+	case <-time.After(time.Second):
+		t.Fatal("one MR's evaluation blocked another MR's")
+	}
+}
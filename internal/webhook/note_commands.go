@@ -0,0 +1,314 @@
+package webhook
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"strings"
+
+	fiber "github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/logging"
+	"github.com/redhat-data-and-ai/naysayer/internal/utils"
+)
+
+// Slash commands supported in MR comments
+const (
+	commandPrefix   = "/naysayer"
+	commandRecheck  = "recheck"
+	commandApprove  = "approve"
+	commandHold     = "hold"
+	holdCommentType = "hold"
+)
+
+// NoteCommandHandler handles GitLab note (comment) events carrying /naysayer commands
+type NoteCommandHandler struct {
+	gitlabClient  gitlab.GitLabClient
+	reviewHandler *DataProductConfigMrReviewHandler
+	config        *config.Config
+}
+
+// NewNoteCommandHandler creates a new note command handler
+func NewNoteCommandHandler(cfg *config.Config) *NoteCommandHandler {
+	gitlabClient := gitlab.NewClientWithConfig(cfg)
+	return NewNoteCommandHandlerWithClient(cfg, gitlabClient)
+}
+
+// NewNoteCommandHandlerWithClient creates a note command handler with a custom GitLab client
+// This is primarily used for testing with mock clients
+func NewNoteCommandHandlerWithClient(cfg *config.Config, client gitlab.GitLabClient) *NoteCommandHandler {
+	logging.Info("Naysayer commands: %t (permitted users: %d)", cfg.Commands.Enabled, len(cfg.Commands.AllowedUsers))
+
+	return &NoteCommandHandler{
+		gitlabClient:  client,
+		reviewHandler: NewDataProductConfigMrReviewHandlerWithClient(cfg, client),
+		config:        cfg,
+	}
+}
+
+// ReloadRules re-reads rules.yaml into the rule manager used by /naysayer recheck
+func (h *NoteCommandHandler) ReloadRules() (int, error) {
+	return h.reviewHandler.ReloadRules()
+}
+
+// CheckRules reports whether ReloadRules would succeed, without changing the loaded rules
+func (h *NoteCommandHandler) CheckRules() error {
+	return h.reviewHandler.CheckRules()
+}
+
+// HandleWebhook processes GitLab note events
+func (h *NoteCommandHandler) HandleWebhook(c *fiber.Ctx) error {
+	c.Set("Content-Type", "application/json")
+
+	contentType := c.Get("Content-Type")
+	if !strings.Contains(contentType, "application/json") {
+		logging.Warn("Invalid content type: %s", contentType)
+		return c.Status(400).JSON(fiber.Map{
+			"error": "Content-Type must be application/json",
+		})
+	}
+
+	var payload map[string]interface{}
+	if err := c.BodyParser(&payload); err != nil {
+		logging.Error("Failed to parse payload: %v", err)
+		return c.Status(400).JSON(fiber.Map{
+			"error": "Invalid JSON payload",
+		})
+	}
+
+	eventType, _ := payload["object_kind"].(string)
+	if eventType == "" {
+		logging.Warn("Missing object_kind in payload")
+		return c.Status(400).JSON(fiber.Map{
+			"error": "Missing object_kind",
+		})
+	}
+	if eventType != "note" {
+		return ignoredEvent(c, eventType, "Only note events are supported")
+	}
+
+	if !h.config.Commands.Enabled {
+		return h.ignored(c, "Naysayer commands are disabled")
+	}
+
+	// The command author comes from the payload, so only deliveries signed with the webhook secret are trusted
+	if !h.config.HasWebhookSecret() {
+		logging.Warn("Refusing naysayer command: WEBHOOK_SECRET is not configured")
+		return c.Status(403).JSON(fiber.Map{
+			"error": "Naysayer commands require WEBHOOK_SECRET to be configured",
+		})
+	}
+	if !h.validWebhookToken(c.Get(GitLabTokenHeader)) {
+		logging.Warn("Rejected naysayer command from %s: invalid %s", c.IP(), GitLabTokenHeader)
+		return c.Status(401).JSON(fiber.Map{
+			"error": "Invalid or missing " + GitLabTokenHeader,
+		})
+	}
+
+	objectAttrs, _ := payload["object_attributes"].(map[string]interface{})
+	if noteableType, _ := objectAttrs["noteable_type"].(string); noteableType != "MergeRequest" {
+		return h.ignored(c, "Note is not on a merge request")
+	}
+
+	noteBody, _ := objectAttrs["note"].(string)
+	command, ok := parseNaysayerCommand(noteBody)
+	if !ok {
+		return h.ignored(c, "Note does not contain a /naysayer command")
+	}
+
+	user, _ := payload["user"].(map[string]interface{})
+	if h.gitlabClient.IsNaysayerBotAuthor(user) {
+		return h.ignored(c, "Note was written by naysayer")
+	}
+
+	username, _ := user["username"].(string)
+	if !h.isPermittedUser(username) {
+		logging.Warn("User %s is not permitted to run /naysayer %s", username, command)
+		return h.ignored(c, fmt.Sprintf("User '%s' is not permitted to run naysayer commands", username))
+	}
+
+	mrInfo, err := extractNoteMRInfo(payload, h.gitlabClient)
+	if err != nil {
+		logging.Error("Failed to extract MR info from note: %v", err)
+		return c.Status(400).JSON(fiber.Map{
+			"error": "Missing MR information: " + err.Error(),
+		})
+	}
+
+	logging.MRInfo(mrInfo.MRIID, "Processing naysayer command",
+		zap.String("command", command),
+		zap.String("user", username))
+
+	switch command {
+	case commandRecheck:
+		return h.handleRecheck(c, mrInfo)
+	case commandApprove:
+		return h.handleApprove(c, mrInfo, username)
+	case commandHold:
+		return h.handleHold(c, mrInfo, username)
+	default:
+		return h.ignored(c, fmt.Sprintf("Unknown naysayer command: %s", command))
+	}
+}
+
+// handleRecheck re-runs rule evaluation for the MR
+func (h *NoteCommandHandler) handleRecheck(c *fiber.Ctx, mrInfo *gitlab.MRInfo) error {
+	if mrInfo.State != utils.MRStateOpened {
+		return h.ignored(c, fmt.Sprintf("MR state is '%s', only processing open MRs", mrInfo.State))
+	}
+
+	unlock := evaluationLocks.lock(mrInfo.ProjectID, mrInfo.MRIID)
+	defer unlock()
+
+	result, err := h.reviewHandler.evaluateRulesUncached(mrInfo.ProjectID, mrInfo.MRIID, mrInfo)
+	if err != nil {
+		logging.MRError(mrInfo.MRIID, "Rule evaluation failed", err)
+		return c.Status(500).JSON(fiber.Map{
+			"error": "Rule evaluation failed: " + err.Error(),
+		})
+	}
+
+	result.CorrelationID = correlationID(c)
+
+	approved, err := h.reviewHandler.applyDecision(result, mrInfo)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{
+			"error": "Failed to approve MR: " + err.Error(),
+		})
+	}
+
+	response := fiber.Map{
+		"webhook_response": "processed",
+		"event_type":       "note",
+		"command":          commandRecheck,
+		"decision":         result.FinalDecision,
+		"mr_approved":      approved,
+		"project_id":       mrInfo.ProjectID,
+		"mr_iid":           mrInfo.MRIID,
+	}
+	if result.RulesMisconfigured {
+		response["rules_misconfigured"] = true
+	}
+	return c.JSON(response)
+}
+
+// handleApprove lifts any manual-review hold and approves the MR
+func (h *NoteCommandHandler) handleApprove(c *fiber.Ctx, mrInfo *gitlab.MRInfo, username string) error {
+	if mrInfo.State != utils.MRStateOpened {
+		return h.ignored(c, fmt.Sprintf("MR state is '%s', only processing open MRs", mrInfo.State))
+	}
+
+	// An evaluation that already read the hold must not finish after the approval
+	unlock := evaluationLocks.lock(mrInfo.ProjectID, mrInfo.MRIID)
+	defer unlock()
+
+	// Every hold comment must go: the hold check finds the latest remaining one
+	if err := h.reviewHandler.removeAllComments(mrInfo, holdCommentType); err != nil {
+		logging.MRError(mrInfo.MRIID, "Failed to lift manual review hold", err)
+		return c.Status(500).JSON(fiber.Map{
+			"error": "Failed to lift manual review hold: " + err.Error(),
+		})
+	}
+
+	message := fmt.Sprintf("Approved via /naysayer approve by @%s", username)
+	if err := h.gitlabClient.ApproveMRWithMessage(mrInfo.ProjectID, mrInfo.MRIID, message); err != nil {
+		logging.MRError(mrInfo.MRIID, "Failed to approve MR via command", err)
+		return c.Status(500).JSON(fiber.Map{
+			"error": "Failed to approve MR: " + err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"webhook_response": "processed",
+		"event_type":       "note",
+		"command":          commandApprove,
+		"mr_approved":      true,
+		"project_id":       mrInfo.ProjectID,
+		"mr_iid":           mrInfo.MRIID,
+	})
+}
+
+// handleHold revokes naysayer's approval and records a manual-review hold comment
+func (h *NoteCommandHandler) handleHold(c *fiber.Ctx, mrInfo *gitlab.MRInfo, username string) error {
+	// An evaluation running alongside could otherwise approve the MR after the approval is reset
+	unlock := evaluationLocks.lock(mrInfo.ProjectID, mrInfo.MRIID)
+	defer unlock()
+
+	if err := h.gitlabClient.ResetNaysayerApproval(mrInfo.ProjectID, mrInfo.MRIID); err != nil {
+		logging.MRWarn(mrInfo.MRIID, "Could not reset previous naysayer approval (may not have been approved)", zap.Error(err))
+	}
+
+	comment := fmt.Sprintf("<!-- naysayer-comment-id: %s -->\n⏸️ **Manual review hold** set by @%s\n\n"+
+		"Naysayer will not auto-approve this MR until a permitted user comments `%s %s`.",
+		holdCommentType, username, commandPrefix, commandApprove)
+	if err := h.gitlabClient.AddMRComment(mrInfo.ProjectID, mrInfo.MRIID, comment); err != nil {
+		logging.MRError(mrInfo.MRIID, "Failed to add hold comment", err)
+		return c.Status(500).JSON(fiber.Map{
+			"error": "Failed to set manual review hold: " + err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"webhook_response": "processed",
+		"event_type":       "note",
+		"command":          commandHold,
+		"mr_approved":      false,
+		"project_id":       mrInfo.ProjectID,
+		"mr_iid":           mrInfo.MRIID,
+	})
+}
+
+// ignored returns a successful response for notes that require no action
+func (h *NoteCommandHandler) ignored(c *fiber.Ctx, reason string) error {
+	return c.JSON(fiber.Map{
+		"webhook_response": "ignored",
+		"event_type":       "note",
+		"reason":           reason,
+	})
+}
+
+// validWebhookToken checks the delivery's token against the configured webhook secret
+func (h *NoteCommandHandler) validWebhookToken(token string) bool {
+	return subtle.ConstantTimeCompare([]byte(token), []byte(h.config.Webhook.Secret)) == 1
+}
+
+// isPermittedUser checks if the user may run naysayer commands
+func (h *NoteCommandHandler) isPermittedUser(username string) bool {
+	if username == "" {
+		return false
+	}
+	for _, allowed := range h.config.Commands.AllowedUsers {
+		if strings.EqualFold(allowed, username) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseNaysayerCommand extracts the command from a note whose first line is `/naysayer <command>`
+func parseNaysayerCommand(note string) (string, bool) {
+	firstLine := strings.TrimSpace(strings.SplitN(strings.TrimSpace(note), "\n", 2)[0])
+	fields := strings.Fields(firstLine)
+	if len(fields) == 0 || fields[0] != commandPrefix {
+		return "", false
+	}
+	if len(fields) < 2 {
+		return "", true
+	}
+	return strings.ToLower(fields[1]), true
+}
+
+// extractNoteMRInfo builds MR info from the merge_request object embedded in a note event
+func extractNoteMRInfo(payload map[string]interface{}, resolver gitlab.ProjectIDResolver) (*gitlab.MRInfo, error) {
+	mergeRequest, ok := payload["merge_request"].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("missing merge_request")
+	}
+
+	return gitlab.ExtractMRInfo(map[string]interface{}{
+		"object_attributes": mergeRequest,
+		"project":           payload["project"],
+	}, resolver)
+}
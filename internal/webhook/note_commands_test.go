@@ -0,0 +1,317 @@
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createNoteCommandTestHandler(t *testing.T, client *MockGitLabClient) *NoteCommandHandler {
+	setupTestRulesFile(t)
+	cfg := createTestConfig()
+	cfg.Webhook.Secret = "note-secret"
+	cfg.Commands = config.CommandsConfig{
+		Enabled:      true,
+		AllowedUsers: []string{"reviewer"},
+	}
+	return NewNoteCommandHandlerWithClient(cfg, client)
+}
+
+func createNotePayload(note, username string) map[string]interface{} {
+	return map[string]interface{}{
+		"object_kind": "note",
+		"user": map[string]interface{}{
+			"username": username,
+		},
+		"project": map[string]interface{}{
+			"id": float64(123),
+		},
+		"object_attributes": map[string]interface{}{
+			"noteable_type": "MergeRequest",
+			"note":          note,
+		},
+		"merge_request": map[string]interface{}{
+			"iid":           float64(456),
+			"title":         "Update product config",
+			"source_branch": "feature",
+			"target_branch": "main",
+			"state":         "opened",
+			"author": map[string]interface{}{
+				"username": "author",
+			},
+		},
+	}
+}
+
+func postNote(t *testing.T, handler *NoteCommandHandler, payload map[string]interface{}) (int, map[string]interface{}) {
+	return postNoteWithToken(t, handler, payload, handler.config.Webhook.Secret)
+}
+
+func postNoteWithToken(t *testing.T, handler *NoteCommandHandler, payload map[string]interface{}, token string) (int, map[string]interface{}) {
+	app := createTestApp()
+	app.Post("/naysayer-commands", handler.HandleWebhook)
+
+	body, _ := json.Marshal(payload)
+	req := httptest.NewRequest("POST", "/naysayer-commands", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set(GitLabTokenHeader, token)
+	}
+
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+
+	var response map[string]interface{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&response))
+	return resp.StatusCode, response
+}
+
+var noteCommandTestChanges = []gitlab.FileChange{
+	{NewPath: "dataproducts/agg/test/prod/product.yaml", Diff: "+name: test"},
+}
+
+func TestNoteCommand_Recheck(t *testing.T) {
+	client := &MockGitLabClient{changes: noteCommandTestChanges}
+	handler := createNoteCommandTestHandler(t, client)
+	handler.reviewHandler.ruleManager = &MockRuleManagerForApproval{}
+
+	status, response := postNote(t, handler, createNotePayload("/naysayer recheck", "reviewer"))
+
+	assert.Equal(t, 200, status)
+	assert.Equal(t, "processed", response["webhook_response"])
+	assert.Equal(t, "recheck", response["command"])
+	assert.Equal(t, true, response["mr_approved"])
+	assert.Len(t, client.approvalMessages, 1)
+}
+
+func TestNoteCommand_RecheckRespectsHold(t *testing.T) {
+	client := &MockGitLabClient{
+		changes: noteCommandTestChanges,
+		latestComments: map[string]*gitlab.MRComment{
+			holdCommentType: {ID: 42},
+		},
+	}
+	handler := createNoteCommandTestHandler(t, client)
+	handler.reviewHandler.ruleManager = &MockRuleManagerForApproval{}
+
+	status, response := postNote(t, handler, createNotePayload("/naysayer recheck", "reviewer"))
+
+	assert.Equal(t, 200, status)
+	decision, _ := response["decision"].(map[string]interface{})
+	assert.Equal(t, "manual_review", decision["type"])
+	assert.Equal(t, "Manual review hold set via /naysayer hold", decision["reason"])
+	assert.Equal(t, false, response["mr_approved"])
+	assert.Empty(t, client.approvalMessages)
+}
+
+func TestNoteCommand_RecheckHoldLookupFails(t *testing.T) {
+	client := &MockGitLabClient{
+		changes:          noteCommandTestChanges,
+		latestCommentErr: fmt.Errorf("gitlab unavailable"),
+	}
+	handler := createNoteCommandTestHandler(t, client)
+	handler.reviewHandler.ruleManager = &MockRuleManagerForApproval{}
+
+	status, response := postNote(t, handler, createNotePayload("/naysayer recheck", "reviewer"))
+
+	assert.Equal(t, 200, status)
+	decision, _ := response["decision"].(map[string]interface{})
+	assert.Equal(t, "manual_review", decision["type"])
+	assert.Contains(t, decision["reason"], "could not check for a /naysayer hold comment")
+	assert.Equal(t, false, response["mr_approved"])
+	assert.Empty(t, client.approvalMessages)
+}
+
+func TestNoteCommand_Approve(t *testing.T) {
+	client := &MockGitLabClient{
+		latestComments: map[string]*gitlab.MRComment{
+			holdCommentType: {ID: 42},
+		},
+	}
+	handler := createNoteCommandTestHandler(t, client)
+
+	status, response := postNote(t, handler, createNotePayload("/naysayer approve", "Reviewer"))
+
+	assert.Equal(t, 200, status)
+	assert.Equal(t, "approve", response["command"])
+	assert.Equal(t, true, response["mr_approved"])
+	assert.Equal(t, []int{42}, client.deletedCommentIDs)
+	require.Len(t, client.approvalMessages, 1)
+	assert.Contains(t, client.approvalMessages[0], "@Reviewer")
+}
+
+func TestNoteCommand_ApproveLiftsEveryHold(t *testing.T) {
+	client := &MockGitLabClient{
+		changes: noteCommandTestChanges,
+		latestComments: map[string]*gitlab.MRComment{
+			holdCommentType: {ID: 43},
+		},
+		olderComments: map[string][]*gitlab.MRComment{
+			holdCommentType: {{ID: 42}},
+		},
+	}
+	handler := createNoteCommandTestHandler(t, client)
+	handler.reviewHandler.ruleManager = &MockRuleManagerForApproval{}
+
+	status, response := postNote(t, handler, createNotePayload("/naysayer approve", "reviewer"))
+	require.Equal(t, 200, status)
+	assert.Equal(t, true, response["mr_approved"])
+	assert.Equal(t, []int{43, 42}, client.deletedCommentIDs)
+
+	// With both holds gone, later evaluations approve again
+	status, response = postNote(t, handler, createNotePayload("/naysayer recheck", "reviewer"))
+	require.Equal(t, 200, status)
+	assert.Equal(t, true, response["mr_approved"])
+}
+
+func TestNoteCommand_ApproveHoldRemovalFails(t *testing.T) {
+	client := &MockGitLabClient{latestCommentErr: fmt.Errorf("gitlab unavailable")}
+	handler := createNoteCommandTestHandler(t, client)
+
+	status, response := postNote(t, handler, createNotePayload("/naysayer approve", "reviewer"))
+
+	assert.Equal(t, 500, status)
+	assert.Contains(t, response["error"], "Failed to lift manual review hold")
+	assert.Empty(t, client.approvalMessages)
+}
+
+func TestNoteCommand_ApproveClosedMR(t *testing.T) {
+	for _, state := range []string{"merged", "closed"} {
+		t.Run(state, func(t *testing.T) {
+			client := &MockGitLabClient{}
+			handler := createNoteCommandTestHandler(t, client)
+
+			payload := createNotePayload("/naysayer approve", "reviewer")
+			payload["merge_request"].(map[string]interface{})["state"] = state
+			status, response := postNote(t, handler, payload)
+
+			assert.Equal(t, 200, status)
+			assert.Equal(t, "ignored", response["webhook_response"])
+			assert.Equal(t, "MR state is '"+state+"', only processing open MRs", response["reason"])
+			assert.Empty(t, client.approvalMessages)
+		})
+	}
+}
+
+func TestNoteCommand_WebhookToken(t *testing.T) {
+	tests := []struct {
+		name           string
+		secret         string
+		token          string
+		expectedStatus int
+	}{
+		{"no secret configured", "", "", 403},
+		{"missing token", "note-secret", "", 401},
+		{"wrong token", "note-secret", "guess", 401},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &MockGitLabClient{}
+			handler := createNoteCommandTestHandler(t, client)
+			handler.config.Webhook.Secret = tt.secret
+
+			status, response := postNoteWithToken(t, handler, createNotePayload("/naysayer approve", "reviewer"), tt.token)
+
+			assert.Equal(t, tt.expectedStatus, status)
+			assert.NotEmpty(t, response["error"])
+			assert.Empty(t, client.approvalMessages)
+		})
+	}
+}
+
+func TestNoteCommand_Hold(t *testing.T) {
+	client := &MockGitLabClient{}
+	handler := createNoteCommandTestHandler(t, client)
+
+	status, response := postNote(t, handler, createNotePayload("/naysayer hold\nneeds a closer look", "reviewer"))
+
+	assert.Equal(t, 200, status)
+	assert.Equal(t, "hold", response["command"])
+	assert.Equal(t, false, response["mr_approved"])
+	assert.Equal(t, 1, client.approvalResets)
+	require.Len(t, client.addedComments, 1)
+	assert.Contains(t, client.addedComments[0], "<!-- naysayer-comment-id: hold -->")
+}
+
+func TestNoteCommand_Ignored(t *testing.T) {
+	tests := []struct {
+		name     string
+		note     string
+		username string
+		reason   string
+	}{
+		{"unknown command", "/naysayer merge", "reviewer", "Unknown naysayer command: merge"},
+		{"unauthorized user", "/naysayer approve", "someone", "User 'someone' is not permitted to run naysayer commands"},
+		{"not a command", "LGTM", "reviewer", "Note does not contain a /naysayer command"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &MockGitLabClient{}
+			handler := createNoteCommandTestHandler(t, client)
+
+			status, response := postNote(t, handler, createNotePayload(tt.note, tt.username))
+
+			assert.Equal(t, 200, status)
+			assert.Equal(t, "ignored", response["webhook_response"])
+			assert.Equal(t, tt.reason, response["reason"])
+			assert.Empty(t, client.approvalMessages)
+			assert.Empty(t, client.addedComments)
+		})
+	}
+}
+
+func TestNoteCommand_DisabledCommands(t *testing.T) {
+	client := &MockGitLabClient{}
+	handler := createNoteCommandTestHandler(t, client)
+	handler.config.Commands.Enabled = false
+
+	status, response := postNote(t, handler, createNotePayload("/naysayer approve", "reviewer"))
+
+	assert.Equal(t, 200, status)
+	assert.Equal(t, "ignored", response["webhook_response"])
+	assert.Empty(t, client.approvalMessages)
+}
+
+func TestNoteCommand_UnsupportedEvent(t *testing.T) {
+	handler := createNoteCommandTestHandler(t, &MockGitLabClient{})
+
+	payload := createNotePayload("/naysayer approve", "reviewer")
+	payload["object_kind"] = "merge_request"
+	status, response := postNote(t, handler, payload)
+
+	assert.Equal(t, 200, status)
+	assert.Equal(t, "ignored", response["status"])
+	assert.Empty(t, handler.gitlabClient.(*MockGitLabClient).approvalMessages)
+}
+
+func TestParseNaysayerCommand(t *testing.T) {
+	tests := []struct {
+		note     string
+		command  string
+		expected bool
+	}{
+		{"/naysayer recheck", "recheck", true},
+		{"  /naysayer APPROVE  ", "approve", true},
+		{"/naysayer hold\nwaiting on security", "hold", true},
+		{"/naysayer", "", true},
+		{"please /naysayer approve", "", false},
+		{"/naysayerx approve", "", false},
+		{"", "", false},
+	}
+
+	for _, tt := range tests {
+		command, ok := parseNaysayerCommand(tt.note)
+		assert.Equal(t, tt.expected, ok, tt.note)
+		assert.Equal(t, tt.command, command, tt.note)
+	}
+}
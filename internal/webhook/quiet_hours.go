@@ -0,0 +1,156 @@
+package webhook
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	_ "time/tzdata" // Embed zone data so quiet hours timezones resolve in minimal images
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+)
+
+var weekdaysByName = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+// minuteRange is a daily time range in minutes since midnight, end exclusive.
+// A range whose end is not after its start wraps past midnight.
+type minuteRange struct {
+	start int
+	end   int
+}
+
+func (r minuteRange) contains(minute int) bool {
+	if r.start < r.end {
+		return minute >= r.start && minute < r.end
+	}
+	return minute >= r.start || minute < r.end
+}
+
+// approvalWindow is the parsed form of config.QuietHoursConfig
+type approvalWindow struct {
+	location *time.Location
+	days     map[time.Weekday]bool
+	hours    []minuteRange
+	summary  string
+}
+
+// newApprovalWindow parses the quiet hours configuration into an approval window
+func newApprovalWindow(cfg config.QuietHoursConfig) (*approvalWindow, error) {
+	timezone := cfg.Timezone
+	if timezone == "" {
+		timezone = "UTC"
+	}
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timezone %q: %w", timezone, err)
+	}
+
+	window := &approvalWindow{
+		location: location,
+		days:     make(map[time.Weekday]bool),
+		summary:  fmt.Sprintf("%s %s (%s)", strings.Join(cfg.AllowedDays, ","), strings.Join(cfg.AllowedHours, ","), timezone),
+	}
+
+	for _, spec := range cfg.AllowedDays {
+		if err := window.addDays(spec); err != nil {
+			return nil, err
+		}
+	}
+	for _, spec := range cfg.AllowedHours {
+		hours, err := parseMinuteRange(spec)
+		if err != nil {
+			return nil, err
+		}
+		window.hours = append(window.hours, hours)
+	}
+
+	if len(window.days) == 0 || len(window.hours) == 0 {
+		return nil, fmt.Errorf("quiet hours require at least one allowed day and hour range")
+	}
+	return window, nil
+}
+
+// addDays adds a single weekday ("sat") or an inclusive range ("mon-fri", "fri-mon")
+func (w *approvalWindow) addDays(spec string) error {
+	from, to, isRange := strings.Cut(strings.ToLower(strings.TrimSpace(spec)), "-")
+	if !isRange {
+		to = from
+	}
+
+	start, ok := weekdaysByName[strings.TrimSpace(from)]
+	if !ok {
+		return fmt.Errorf("invalid weekday %q in %q", from, spec)
+	}
+	end, ok := weekdaysByName[strings.TrimSpace(to)]
+	if !ok {
+		return fmt.Errorf("invalid weekday %q in %q", to, spec)
+	}
+
+	for day := start; ; day = (day + 1) % 7 {
+		w.days[day] = true
+		if day == end {
+			return nil
+		}
+	}
+}
+
+// parseMinuteRange parses "HH:MM-HH:MM"; "24:00" is accepted as an end of day
+func parseMinuteRange(spec string) (minuteRange, error) {
+	from, to, ok := strings.Cut(strings.TrimSpace(spec), "-")
+	if !ok {
+		return minuteRange{}, fmt.Errorf("invalid hour range %q: expected HH:MM-HH:MM", spec)
+	}
+
+	start, err := parseClockMinutes(from)
+	if err != nil || start == 24*60 {
+		return minuteRange{}, fmt.Errorf("invalid start time in hour range %q", spec)
+	}
+	end, err := parseClockMinutes(to)
+	if err != nil {
+		return minuteRange{}, fmt.Errorf("invalid end time in hour range %q", spec)
+	}
+	return minuteRange{start: start, end: end}, nil
+}
+
+func parseClockMinutes(value string) (int, error) {
+	hour, minute, ok := strings.Cut(strings.TrimSpace(value), ":")
+	if !ok {
+		return 0, fmt.Errorf("expected HH:MM, got %q", value)
+	}
+	h, err := strconv.Atoi(hour)
+	if err != nil {
+		return 0, err
+	}
+	m, err := strconv.Atoi(minute)
+	if err != nil {
+		return 0, err
+	}
+	if h < 0 || h > 24 || m < 0 || m > 59 || (h == 24 && m != 0) {
+		return 0, fmt.Errorf("time %q out of range", value)
+	}
+	return h*60 + m, nil
+}
+
+// allows reports whether auto-approval is permitted at the given instant
+func (w *approvalWindow) allows(t time.Time) bool {
+	local := t.In(w.location)
+	if !w.days[local.Weekday()] {
+		return false
+	}
+
+	minute := local.Hour()*60 + local.Minute()
+	for _, hours := range w.hours {
+		if hours.contains(minute) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,188 @@
+package webhook
+
+import (
+	"testing"
+	"time"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func weekdayBusinessHours(timezone string) config.QuietHoursConfig {
+	return config.QuietHoursConfig{
+		Enabled:      true,
+		Timezone:     timezone,
+		AllowedDays:  []string{"mon-fri"},
+		AllowedHours: []string{"09:00-17:00"},
+	}
+}
+
+func TestApprovalWindow_Allows(t *testing.T) {
+	tests := []struct {
+		name     string
+		cfg      config.QuietHoursConfig
+		at       time.Time
+		expected bool
+	}{
+		{
+			name:     "weekday inside business hours",
+			cfg:      weekdayBusinessHours("UTC"),
+			at:       time.Date(2026, 10, 14, 10, 30, 0, 0, time.UTC), // Wednesday
+			expected: true,
+		},
+		{
+			name:     "weekday before opening",
+			cfg:      weekdayBusinessHours("UTC"),
+			at:       time.Date(2026, 10, 14, 8, 59, 0, 0, time.UTC),
+			expected: false,
+		},
+		{
+			name:     "end of range is exclusive",
+			cfg:      weekdayBusinessHours("UTC"),
+			at:       time.Date(2026, 10, 14, 17, 0, 0, 0, time.UTC),
+			expected: false,
+		},
+		{
+			name:     "weekend during business hours",
+			cfg:      weekdayBusinessHours("UTC"),
+			at:       time.Date(2026, 10, 17, 10, 0, 0, 0, time.UTC), // Saturday
+			expected: false,
+		},
+		{
+			name:     "UTC Friday evening is Saturday morning in Tokyo",
+			cfg:      weekdayBusinessHours("Asia/Tokyo"),
+			at:       time.Date(2026, 10, 16, 23, 30, 0, 0, time.UTC), // Sat 08:30 JST
+			expected: false,
+		},
+		{
+			name:     "UTC early morning is inside New York business hours the previous evening",
+			cfg:      config.QuietHoursConfig{Timezone: "America/New_York", AllowedDays: []string{"mon-fri"}, AllowedHours: []string{"09:00-22:00"}},
+			at:       time.Date(2026, 10, 15, 1, 0, 0, 0, time.UTC), // Wed 21:00 EDT
+			expected: true,
+		},
+		{
+			name:     "UTC Monday morning is still Sunday in Los Angeles",
+			cfg:      config.QuietHoursConfig{Timezone: "America/Los_Angeles", AllowedDays: []string{"mon-fri"}, AllowedHours: []string{"00:00-24:00"}},
+			at:       time.Date(2026, 10, 12, 5, 0, 0, 0, time.UTC), // Sun 22:00 PDT
+			expected: false,
+		},
+		{
+			name:     "overnight range wraps past midnight",
+			cfg:      config.QuietHoursConfig{Timezone: "UTC", AllowedDays: []string{"mon-sun"}, AllowedHours: []string{"22:00-06:00"}},
+			at:       time.Date(2026, 10, 14, 2, 0, 0, 0, time.UTC),
+			expected: true,
+		},
+		{
+			name:     "wrapping day range includes weekend",
+			cfg:      config.QuietHoursConfig{Timezone: "UTC", AllowedDays: []string{"fri-mon"}, AllowedHours: []string{"09:00-17:00"}},
+			at:       time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC), // Sunday
+			expected: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			window, err := newApprovalWindow(tt.cfg)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, window.allows(tt.at))
+		})
+	}
+}
+
+func TestNewApprovalWindow_InvalidConfig(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  config.QuietHoursConfig
+	}{
+		{"unknown timezone", config.QuietHoursConfig{Timezone: "Mars/Olympus", AllowedDays: []string{"mon"}, AllowedHours: []string{"09:00-17:00"}}},
+		{"unknown weekday", config.QuietHoursConfig{AllowedDays: []string{"funday"}, AllowedHours: []string{"09:00-17:00"}}},
+		{"malformed hour range", config.QuietHoursConfig{AllowedDays: []string{"mon"}, AllowedHours: []string{"9-17"}}},
+		{"hour out of range", config.QuietHoursConfig{AllowedDays: []string{"mon"}, AllowedHours: []string{"09:00-25:00"}}},
+		{"no allowed hours", config.QuietHoursConfig{AllowedDays: []string{"mon"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newApprovalWindow(tt.cfg)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestApplyDecision_QuietHours(t *testing.T) {
+	tests := []struct {
+		name             string
+		cfg              config.QuietHoursConfig
+		now              time.Time
+		expectedApproved bool
+	}{
+		{
+			name:             "inside window approves",
+			cfg:              weekdayBusinessHours("Europe/Prague"),
+			now:              time.Date(2026, 10, 14, 10, 0, 0, 0, time.UTC), // Wed 12:00 CEST
+			expectedApproved: true,
+		},
+		{
+			name:             "outside window downgrades to manual review",
+			cfg:              weekdayBusinessHours("Europe/Prague"),
+			now:              time.Date(2026, 10, 17, 10, 0, 0, 0, time.UTC), // Saturday
+			expectedApproved: false,
+		},
+		{
+			name:             "overnight in configured timezone downgrades",
+			cfg:              weekdayBusinessHours("Europe/Prague"),
+			now:              time.Date(2026, 10, 14, 6, 30, 0, 0, time.UTC), // Wed 08:30 CEST
+			expectedApproved: false,
+		},
+		{
+			name:             "invalid configuration fails closed",
+			cfg:              config.QuietHoursConfig{Enabled: true, Timezone: "Mars/Olympus"},
+			now:              time.Date(2026, 10, 14, 10, 0, 0, 0, time.UTC),
+			expectedApproved: false,
+		},
+		{
+			name:             "disabled ignores schedule",
+			cfg:              config.QuietHoursConfig{Enabled: false},
+			now:              time.Date(2026, 10, 17, 3, 0, 0, 0, time.UTC),
+			expectedApproved: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockGitLabClient{}
+			handler := &DataProductConfigMrReviewHandler{
+				gitlabClient: mockClient,
+				config: &config.Config{
+					Comments:   config.CommentsConfig{EnableMRComments: true, CommentVerbosity: "basic"},
+					QuietHours: tt.cfg,
+				},
+				now: func() time.Time { return tt.now },
+			}
+			mrInfo := &gitlab.MRInfo{ProjectID: 123, MRIID: 456, State: "opened"}
+			result := &shared.RuleEvaluation{
+				FinalDecision:   shared.Decision{Type: shared.Approve, Reason: "All rules passed"},
+				FileValidations: map[string]*shared.FileValidationSummary{},
+			}
+
+			approved, err := handler.applyDecision(result, mrInfo)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedApproved, approved)
+			if tt.expectedApproved {
+				assert.Equal(t, shared.Approve, result.FinalDecision.Type)
+				assert.Len(t, mockClient.approvalMessages, 1)
+				return
+			}
+
+			assert.Equal(t, shared.ManualReview, result.FinalDecision.Type)
+			assert.Contains(t, result.FinalDecision.Reason, "Change freeze")
+			assert.Empty(t, mockClient.approvalMessages)
+			require.Len(t, mockClient.addedComments, 1)
+			assert.Contains(t, mockClient.addedComments[0], "Change freeze")
+		})
+	}
+}
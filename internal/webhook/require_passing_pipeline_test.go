@@ -0,0 +1,200 @@
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules/shared"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyDecision_RequirePassingPipeline(t *testing.T) {
+	tests := []struct {
+		name             string
+		required         bool
+		pipelineStatus   string
+		pipelineErr      error
+		expectedApproved bool
+		expectedReason   string
+	}{
+		{
+			name:             "successful pipeline approves",
+			required:         true,
+			pipelineStatus:   "success",
+			expectedApproved: true,
+		},
+		{
+			name:             "failed pipeline waits",
+			required:         true,
+			pipelineStatus:   "failed",
+			expectedApproved: false,
+			expectedReason:   "latest pipeline status is 'failed'",
+		},
+		{
+			name:             "running pipeline waits",
+			required:         true,
+			pipelineStatus:   "running",
+			expectedApproved: false,
+			expectedReason:   "latest pipeline status is 'running'",
+		},
+		{
+			name:             "no pipeline waits",
+			required:         true,
+			pipelineStatus:   "",
+			expectedApproved: false,
+			expectedReason:   "the MR has no pipeline yet",
+		},
+		{
+			name:             "status lookup error fails closed",
+			required:         true,
+			pipelineErr:      errors.New("connection refused"),
+			expectedApproved: false,
+			expectedReason:   "could not determine the latest pipeline status",
+		},
+		{
+			name:             "disabled ignores pipeline",
+			required:         false,
+			pipelineStatus:   "failed",
+			expectedApproved: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockGitLabClient{pipelineStatus: tt.pipelineStatus, pipelineErr: tt.pipelineErr}
+			handler := &DataProductConfigMrReviewHandler{
+				gitlabClient: mockClient,
+				config: &config.Config{
+					Comments:     config.CommentsConfig{EnableMRComments: true, CommentVerbosity: "basic"},
+					Approval:     config.ApprovalConfig{RequirePassingPipeline: tt.required},
+					CommitStatus: config.CommitStatusConfig{Enabled: true, ManualReviewState: "pending"},
+				},
+			}
+			mrInfo := &gitlab.MRInfo{ProjectID: 123, MRIID: 456, State: "opened", LastCommit: "abc123"}
+			result := &shared.RuleEvaluation{
+				FinalDecision:   shared.Decision{Type: shared.Approve, Reason: "All rules passed"},
+				FileValidations: map[string]*shared.FileValidationSummary{},
+			}
+
+			approved, err := handler.applyDecision(result, mrInfo)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedApproved, approved)
+			if tt.expectedApproved {
+				assert.Equal(t, shared.Approve, result.FinalDecision.Type)
+				assert.Len(t, mockClient.approvalMessages, 1)
+				assert.Equal(t, []string{"abc123:success"}, mockClient.commitStatuses)
+				return
+			}
+
+			assert.Equal(t, shared.ManualReview, result.FinalDecision.Type)
+			assert.Equal(t, "Waiting on pipeline", result.FinalDecision.Summary)
+			assert.Contains(t, result.FinalDecision.Reason, tt.expectedReason)
+			assert.Empty(t, mockClient.approvalMessages)
+			require.Len(t, mockClient.addedComments, 1)
+			assert.Contains(t, mockClient.addedComments[0], "Waiting on pipeline")
+			assert.Empty(t, mockClient.commitStatuses, "a pending status would keep the pipeline from succeeding")
+		})
+	}
+}
+
+func createPipelinePayload(status string, mergeRequest map[string]interface{}) map[string]interface{} {
+	payload := map[string]interface{}{
+		"object_kind": "pipeline",
+		"object_attributes": map[string]interface{}{
+			"id":     789,
+			"status": status,
+			"sha":    "def456",
+		},
+		"project": map[string]interface{}{"id": 123},
+		"user":    map[string]interface{}{"username": "ci-bot"},
+	}
+	if mergeRequest != nil {
+		payload["merge_request"] = mergeRequest
+	}
+	return payload
+}
+
+func TestWebhookHandler_HandleWebhook_PipelineEvent(t *testing.T) {
+	openMR := map[string]interface{}{
+		"iid":           456,
+		"title":         "Update product",
+		"source_branch": "feature/update",
+		"target_branch": "main",
+		"state":         "opened",
+	}
+
+	tests := []struct {
+		name             string
+		required         bool
+		payload          map[string]interface{}
+		expectedDecision interface{}
+		expectedApproved bool
+	}{
+		{
+			name:             "successful pipeline re-evaluates and approves",
+			required:         true,
+			payload:          createPipelinePayload("success", openMR),
+			expectedApproved: true,
+		},
+		{
+			name:             "failed pipeline is skipped",
+			required:         true,
+			payload:          createPipelinePayload("failed", openMR),
+			expectedDecision: "skipped",
+		},
+		{
+			name:             "pipeline without merge request is skipped",
+			required:         true,
+			payload:          createPipelinePayload("success", nil),
+			expectedDecision: "skipped",
+		},
+		{
+			name:             "pipeline events ignored when not required",
+			required:         false,
+			payload:          createPipelinePayload("success", openMR),
+			expectedDecision: "skipped",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setupTestRulesFile(t)
+			mockClient := &MockGitLabClient{changes: noteCommandTestChanges, pipelineStatus: "success"}
+			cfg := createTestConfig()
+			cfg.Approval.RequirePassingPipeline = tt.required
+			handler := NewDataProductConfigMrReviewHandlerWithClient(cfg, mockClient)
+			handler.ruleManager = &MockRuleManagerForApproval{}
+
+			app := createTestApp()
+			app.Post("/webhook", handler.HandleWebhook)
+
+			jsonData, _ := json.Marshal(tt.payload)
+			req := httptest.NewRequest("POST", "/webhook", bytes.NewReader(jsonData))
+			req.Header.Set("Content-Type", "application/json")
+
+			resp, err := app.Test(req)
+			require.NoError(t, err)
+			assert.Equal(t, 200, resp.StatusCode)
+
+			var response map[string]interface{}
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&response))
+
+			assert.Equal(t, "pipeline", response["event_type"])
+			assert.Equal(t, tt.expectedApproved, response["mr_approved"])
+			if tt.expectedApproved {
+				assert.Len(t, mockClient.approvalMessages, 1)
+				return
+			}
+			assert.Equal(t, tt.expectedDecision, response["decision"])
+			assert.Zero(t, mockClient.fetchChangesCalls)
+			assert.Empty(t, mockClient.approvalMessages)
+		})
+	}
+}
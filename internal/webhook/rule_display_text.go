@@ -0,0 +1,69 @@
+package webhook
+
+import (
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/logging"
+)
+
+// RuleDisplayText is how a rule is presented in MR comments
+type RuleDisplayText struct {
+	Name     string `yaml:"name"`     // Friendly name shown instead of the raw rule name
+	Approval string `yaml:"approval"` // Explanation when the rule approves several files, followed by " across N files"
+}
+
+// defaultRuleDisplayText is the display text of built-in rules. Entries in the
+// RULE_DISPLAY_TEXT_PATH file override these field by field.
+var defaultRuleDisplayText = map[string]RuleDisplayText{
+	"warehouse_rule":            {Name: "Warehouse configuration validated", Approval: "Warehouse configuration validated"},
+	"service_account_rule":      {Name: "Service account validated", Approval: "Service account validated"},
+	"toc_approval_rule":         {Name: "TOC approval check", Approval: "TOC approval validated"},
+	"metadata_rule":             {Name: "Metadata validated", Approval: "Metadata changes validated"},
+	"dataproduct_consumer_rule": {Name: "Consumer access changes validated", Approval: "Consumer access validated"},
+}
+
+// loadRuleDisplayText returns the built-in display text merged with the YAML file at path,
+// a map from rule name to RuleDisplayText. A missing or invalid file keeps the built-ins.
+func loadRuleDisplayText(path string) map[string]RuleDisplayText {
+	displayText := make(map[string]RuleDisplayText, len(defaultRuleDisplayText))
+	for ruleName, text := range defaultRuleDisplayText {
+		displayText[ruleName] = text
+	}
+	if path == "" {
+		return displayText
+	}
+
+	overrides, err := readRuleDisplayText(path)
+	if err != nil {
+		logging.Warn("Failed to load rule display text %s, using built-in names: %v", path, err)
+		return displayText
+	}
+	for ruleName, override := range overrides {
+		text := displayText[ruleName]
+		if override.Name != "" {
+			text.Name = override.Name
+		}
+		if override.Approval != "" {
+			text.Approval = override.Approval
+		}
+		displayText[ruleName] = text
+	}
+	logging.Info("Loaded display text for %d rules from %s", len(overrides), path)
+	return displayText
+}
+
+// readRuleDisplayText parses a rule display text file
+func readRuleDisplayText(path string) (map[string]RuleDisplayText, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var overrides map[string]RuleDisplayText
+	if err := yaml.Unmarshal(content, &overrides); err != nil {
+		return nil, fmt.Errorf("invalid YAML: %w", err)
+	}
+	return overrides, nil
+}
@@ -0,0 +1,44 @@
+package webhook
+
+import (
+	fiber "github.com/gofiber/fiber/v2"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/logging"
+	"github.com/redhat-data-and-ai/naysayer/internal/rules"
+)
+
+// RulesCoverageReporter is implemented by handlers that record which file types their rules don't cover
+type RulesCoverageReporter interface {
+	CoverageReport() (rules.CoverageReport, error)
+}
+
+// RulesCoverageHandler reports the file types in recent MRs that no rules.yaml file configuration covers
+type RulesCoverageHandler struct {
+	reporter RulesCoverageReporter
+}
+
+// NewRulesCoverageHandler creates a handler reporting the coverage recorded by reporter
+func NewRulesCoverageHandler(reporter RulesCoverageReporter) *RulesCoverageHandler {
+	return &RulesCoverageHandler{reporter: reporter}
+}
+
+// HandleCoverage returns the uncovered file types with how often each required manual review
+func (h *RulesCoverageHandler) HandleCoverage(c *fiber.Ctx) error {
+	report, err := h.reporter.CoverageReport()
+	if err != nil {
+		logging.Error("Failed to read rule coverage: %v", err)
+		return c.Status(500).JSON(fiber.Map{
+			"error": "Failed to read rule coverage: " + err.Error(),
+		})
+	}
+
+	uncoveredFiles := 0
+	for _, fileType := range report.FileTypes {
+		uncoveredFiles += fileType.Count
+	}
+	return c.JSON(fiber.Map{
+		"since":           report.Since,
+		"uncovered_files": uncoveredFiles,
+		"file_types":      report.FileTypes,
+	})
+}
@@ -0,0 +1,45 @@
+package webhook
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRulesCoverageHandler_ReportsUncoveredFileTypes(t *testing.T) {
+	setupTestRulesFile(t)
+	mockClient := &MockGitLabClient{changes: []gitlab.FileChange{
+		{NewPath: "scripts/deploy.sh", Diff: "@@ -1 +1 @@\n-a\n+b"},
+		{NewPath: "scripts/cleanup.sh", Diff: "@@ -1 +1 @@\n-a\n+b"},
+		{NewPath: "Makefile", Diff: "@@ -1 +1 @@\n-a\n+b"},
+	}}
+	reviewHandler := NewDataProductConfigMrReviewHandlerWithClient(createTestConfig(), mockClient)
+	_, err := reviewHandler.evaluateRules(123, 456, nil)
+	require.NoError(t, err)
+
+	app := createTestApp()
+	app.Get("/api/rules/coverage", NewRulesCoverageHandler(reviewHandler).HandleCoverage)
+	resp, err := app.Test(httptest.NewRequest("GET", "/api/rules/coverage", nil))
+	require.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+
+	var body struct {
+		UncoveredFiles int `json:"uncovered_files"`
+		FileTypes      []struct {
+			Pattern     string `json:"pattern"`
+			Count       int    `json:"count"`
+			ExamplePath string `json:"example_path"`
+		} `json:"file_types"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.Equal(t, 3, body.UncoveredFiles)
+	require.Len(t, body.FileTypes, 2)
+	assert.Equal(t, "*.sh", body.FileTypes[0].Pattern)
+	assert.Equal(t, 2, body.FileTypes[0].Count)
+	assert.Equal(t, "Makefile", body.FileTypes[1].Pattern)
+	assert.Equal(t, 1, body.FileTypes[1].Count)
+}
@@ -0,0 +1,106 @@
+package webhook
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	fiber "github.com/gofiber/fiber/v2"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/logging"
+)
+
+// RulesReloader is implemented by handlers that hold a rule manager built from rules.yaml
+type RulesReloader interface {
+	// CheckRules reports whether ReloadRules would succeed, without changing the loaded rules
+	CheckRules() error
+	ReloadRules() (int, error)
+}
+
+// NamedRulesReloader labels a reloader in the reload response
+type NamedRulesReloader struct {
+	Name     string
+	Reloader RulesReloader
+}
+
+// RulesReloadHandler re-reads rules.yaml for running handlers without a restart
+type RulesReloadHandler struct {
+	reloaders []NamedRulesReloader
+	mu        sync.Mutex // Serializes concurrent reload requests
+}
+
+// NewRulesReloadHandler creates a reload handler for the given rule-holding handlers
+func NewRulesReloadHandler(reloaders ...NamedRulesReloader) *RulesReloadHandler {
+	return &RulesReloadHandler{reloaders: reloaders}
+}
+
+// HandleReload reloads rules.yaml into every registered handler. Every configuration is
+// checked before any is swapped in, so an invalid one leaves all handlers on their previous rules.
+func (h *RulesReloadHandler) HandleReload(c *fiber.Ctx) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, named := range h.reloaders {
+		if err := named.Reloader.CheckRules(); err != nil {
+			logging.Error("Rules reload failed for %s, keeping previous configuration: %v", named.Name, err)
+			return c.Status(400).JSON(fiber.Map{
+				"error": fmt.Sprintf("Failed to reload rules for %s: %v", named.Name, err),
+			})
+		}
+	}
+
+	ruleCounts := make(map[string]int, len(h.reloaders))
+	for _, named := range h.reloaders {
+		count, err := named.Reloader.ReloadRules()
+		if err != nil {
+			// The configuration changed on disk after it was checked
+			logging.Error("Rules reload failed for %s after the configuration was checked: %v", named.Name, err)
+			return c.Status(500).JSON(fiber.Map{
+				"error":      fmt.Sprintf("Failed to reload rules for %s: %v", named.Name, err),
+				"rule_count": ruleCounts,
+			})
+		}
+		ruleCounts[named.Name] = count
+	}
+
+	logging.Info("Rules reloaded (%v)", ruleCounts)
+	return c.JSON(fiber.Map{
+		"status":     "reloaded",
+		"rule_count": ruleCounts,
+	})
+}
+
+// RulesConfigReporter is implemented by handlers that can report the rules.yaml they loaded
+type RulesConfigReporter interface {
+	RulesConfig() (*config.GlobalRuleConfig, error)
+}
+
+// RulesConfigHandler reports which rules.yaml is active, to confirm a deploy or reload took effect
+type RulesConfigHandler struct {
+	reporter RulesConfigReporter
+}
+
+// NewRulesConfigHandler creates a handler reporting the configuration loaded by reporter
+func NewRulesConfigHandler(reporter RulesConfigReporter) *RulesConfigHandler {
+	return &RulesConfigHandler{reporter: reporter}
+}
+
+// HandleConfig returns the path, modification time, content hash and size of the loaded rules.yaml
+func (h *RulesConfigHandler) HandleConfig(c *fiber.Ctx) error {
+	ruleConfig, err := h.reporter.RulesConfig()
+	if err != nil {
+		logging.Error("Failed to read loaded rules configuration: %v", err)
+		return c.Status(500).JSON(fiber.Map{
+			"error": "Failed to read rules configuration: " + err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"path":          ruleConfig.Source.Path,
+		"modified_at":   ruleConfig.Source.ModTime.UTC().Format(time.RFC3339),
+		"sha256":        ruleConfig.Source.SHA256,
+		"file_count":    len(ruleConfig.Files),
+		"section_count": ruleConfig.SectionCount(),
+	})
+}
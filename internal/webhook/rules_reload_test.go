@@ -0,0 +1,132 @@
+package webhook
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func postRulesReload(t *testing.T, handler *RulesReloadHandler) (int, map[string]interface{}) {
+	app := createTestApp()
+	app.Post("/api/rules/reload", handler.HandleReload)
+
+	resp, err := app.Test(httptest.NewRequest("POST", "/api/rules/reload", nil))
+	require.NoError(t, err)
+
+	var body map[string]interface{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	return resp.StatusCode, body
+}
+
+func TestRulesReloadHandler_Success(t *testing.T) {
+	setupTestRulesFile(t)
+	reviewHandler := NewDataProductConfigMrReviewHandlerWithClient(createTestConfig(), &MockGitLabClient{})
+	noteHandler := NewNoteCommandHandlerWithClient(createTestConfig(), &MockGitLabClient{})
+
+	status, body := postRulesReload(t, NewRulesReloadHandler(
+		NamedRulesReloader{Name: "review", Reloader: reviewHandler},
+		NamedRulesReloader{Name: "commands", Reloader: noteHandler},
+	))
+
+	assert.Equal(t, 200, status)
+	assert.Equal(t, "reloaded", body["status"])
+	ruleCounts, ok := body["rule_count"].(map[string]interface{})
+	require.True(t, ok, "rule_count is reported per handler")
+	assert.Greater(t, ruleCounts["review"], float64(0))
+	assert.Greater(t, ruleCounts["commands"], float64(0))
+}
+
+func TestRulesReloadHandler_MalformedYAMLKeepsPreviousConfig(t *testing.T) {
+	setupTestRulesFile(t)
+	mockClient := &MockGitLabClient{changes: noteCommandTestChanges}
+	reviewHandler := NewDataProductConfigMrReviewHandlerWithClient(createTestConfig(), mockClient)
+
+	require.NoError(t, os.WriteFile("rules.yaml", []byte("enabled: true\nfiles: [unclosed"), 0644))
+
+	status, body := postRulesReload(t, NewRulesReloadHandler(NamedRulesReloader{Name: "review", Reloader: reviewHandler}))
+
+	assert.Equal(t, 400, status)
+	assert.Contains(t, body["error"], "Failed to reload rules")
+
+	// The previous configuration still evaluates MRs
+	result, err := reviewHandler.evaluateRules(123, 456, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.TotalFiles)
+}
+
+func TestRulesReloadHandler_InvalidNamedConfigKeepsEveryHandler(t *testing.T) {
+	setupTestRulesFile(t)
+	mainHandler := NewDataProductConfigMrReviewHandlerWithClient(createTestConfig(), &MockGitLabClient{})
+	namedRulesPath := filepath.Join(t.TempDir(), "named.yaml")
+	original, err := os.ReadFile("rules.yaml")
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(namedRulesPath, original, 0644))
+	namedHandler, err := NewNamedConfigReviewHandlerWithClient(createTestConfig(), &MockGitLabClient{}, namedRulesPath)
+	require.NoError(t, err)
+	before, err := mainHandler.RulesConfig()
+	require.NoError(t, err)
+
+	// rules.yaml gets a valid edit while the named route's configuration breaks
+	require.NoError(t, os.WriteFile("rules.yaml", append(original, []byte("\n# edited\n")...), 0644))
+	require.NoError(t, os.WriteFile(namedRulesPath, []byte("enabled: true\nfiles: [unclosed"), 0644))
+
+	status, body := postRulesReload(t, NewRulesReloadHandler(
+		NamedRulesReloader{Name: "dataverse-product-config-review", Reloader: mainHandler},
+		NamedRulesReloader{Name: "review/named", Reloader: namedHandler},
+	))
+
+	assert.Equal(t, 400, status)
+	assert.Contains(t, body["error"], "Failed to reload rules for review/named")
+	after, err := mainHandler.RulesConfig()
+	require.NoError(t, err)
+	assert.Equal(t, before.Source.SHA256, after.Source.SHA256, "the main handler must keep its previous rules")
+}
+
+func getRulesConfig(t *testing.T, handler *RulesConfigHandler) (int, map[string]interface{}) {
+	app := createTestApp()
+	app.Get("/api/rules/config", handler.HandleConfig)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/api/rules/config", nil))
+	require.NoError(t, err)
+
+	var body map[string]interface{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	return resp.StatusCode, body
+}
+
+func TestRulesConfigHandler_ReportsLoadedFile(t *testing.T) {
+	setupTestRulesFile(t)
+	reviewHandler := NewDataProductConfigMrReviewHandlerWithClient(createTestConfig(), &MockGitLabClient{})
+
+	status, body := getRulesConfig(t, NewRulesConfigHandler(reviewHandler))
+
+	expectedPath, err := filepath.Abs("rules.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, 200, status)
+	assert.Equal(t, expectedPath, body["path"])
+	assert.NotEmpty(t, body["sha256"])
+	assert.NotEmpty(t, body["modified_at"])
+	assert.Equal(t, float64(2), body["file_count"])
+	assert.Equal(t, float64(2), body["section_count"])
+}
+
+func TestRulesConfigHandler_HashChangesAfterReload(t *testing.T) {
+	setupTestRulesFile(t)
+	reviewHandler := NewDataProductConfigMrReviewHandlerWithClient(createTestConfig(), &MockGitLabClient{})
+	configHandler := NewRulesConfigHandler(reviewHandler)
+	_, before := getRulesConfig(t, configHandler)
+
+	original, err := os.ReadFile("rules.yaml")
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile("rules.yaml", append(original, []byte("\n# edited\n")...), 0644))
+	_, err = reviewHandler.ReloadRules()
+	require.NoError(t, err)
+
+	_, after := getRulesConfig(t, configHandler)
+	assert.NotEqual(t, before["sha256"], after["sha256"])
+}
@@ -42,6 +42,10 @@ func (m *MockStaleMRClient) CloseMR(projectID, mrIID int) error {
 	return nil
 }
 
+func (m *MockStaleMRClient) AddMRLabels(projectID, mrIID int, labels []string) error { return nil }
+
+func (m *MockStaleMRClient) RemoveMRLabels(projectID, mrIID int, labels []string) error { return nil }
+
 func (m *MockStaleMRClient) AddMRComment(projectID, mrIID int, comment string) error {
 	if m.addCommentError != nil {
 		return m.addCommentError
@@ -50,6 +54,10 @@ func (m *MockStaleMRClient) AddMRComment(projectID, mrIID int, comment string) e
 	return nil
 }
 
+func (m *MockStaleMRClient) AddIssueComment(projectID, issueIID int, comment string) error {
+	return nil
+}
+
 func (m *MockStaleMRClient) FindCommentByPattern(projectID, mrIID int, pattern string) (bool, error) {
 	if m.findPatternError != nil {
 		return false, m.findPatternError
@@ -74,12 +82,20 @@ func (m *MockStaleMRClient) FetchMRChanges(projectID, mrIID int) ([]gitlab.FileC
 func (m *MockStaleMRClient) AddOrUpdateMRComment(projectID, mrIID int, commentBody, commentType string) error {
 	return nil
 }
+
+func (m *MockStaleMRClient) AddMRDiffNote(projectID, mrIID int, sha, filePath string, line int, body string) error {
+	return nil
+}
 func (m *MockStaleMRClient) ListMRComments(projectID, mrIID int) ([]gitlab.MRComment, error) {
 	return nil, nil
 }
 func (m *MockStaleMRClient) UpdateMRComment(projectID, mrIID, commentID int, newBody string) error {
 	return nil
 }
+
+func (m *MockStaleMRClient) DeleteMRComment(projectID, mrIID, commentID int) error {
+	return nil
+}
 func (m *MockStaleMRClient) FindLatestNaysayerComment(projectID, mrIID int, commentType ...string) (*gitlab.MRComment, error) {
 	return nil, nil
 }
@@ -87,17 +103,40 @@ func (m *MockStaleMRClient) ApproveMR(projectID, mrIID int) error { return nil }
 func (m *MockStaleMRClient) ApproveMRWithMessage(projectID, mrIID int, message string) error {
 	return nil
 }
+
+func (m *MockStaleMRClient) SetCommitStatus(projectID int, sha, state, description string) error {
+	return nil
+}
+
+func (m *MockStaleMRClient) GetMRHeadPipelineStatus(projectID, mrIID int) (string, error) {
+	return "", nil
+}
+
+func (m *MockStaleMRClient) GetProjectIDByPath(pathWithNamespace string) (int, error) {
+	return 0, nil
+}
 func (m *MockStaleMRClient) ResetNaysayerApproval(projectID, mrIID int) error { return nil }
-func (m *MockStaleMRClient) GetCurrentBotUsername() (string, error)           { return "naysayer-bot", nil }
+func (m *MockStaleMRClient) GetMRApprovals(projectID, mrIID int) (*gitlab.MRApprovals, error) {
+	return nil, nil
+}
+func (m *MockStaleMRClient) GetCurrentBotUsername() (string, error) { return "naysayer-bot", nil }
 func (m *MockStaleMRClient) IsNaysayerBotAuthor(author map[string]interface{}) bool {
 	return false
 }
+
 func (m *MockStaleMRClient) CompareBranches(sourceProjectID int, sourceBranch string, targetProjectID int, targetBranch string) (*gitlab.CompareResult, error) {
 	return &gitlab.CompareResult{Commits: []gitlab.CompareCommit{}}, nil
 }
 func (m *MockStaleMRClient) GetBranchCommit(projectID int, branch string) (string, error) {
 	return "mock-sha", nil
 }
+func (m *MockStaleMRClient) IsBranchProtected(projectID int, branch string) (bool, error) {
+	return true, nil
+}
+
+func (m *MockStaleMRClient) GetMergeRefCommit(projectID, mrIID int) (string, error) {
+	return "", nil
+}
 func (m *MockStaleMRClient) CompareCommits(projectID int, fromSHA, toSHA string) (*gitlab.CompareResult, error) {
 	return &gitlab.CompareResult{Commits: []gitlab.CompareCommit{}}, nil
 }
@@ -0,0 +1,158 @@
+package webhook
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	fiber "github.com/gofiber/fiber/v2"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+	"github.com/redhat-data-and-ai/naysayer/internal/logging"
+	"go.uber.org/zap"
+)
+
+// mrActionUpdate is the object_attributes.action GitLab sends when commits are pushed to an MR
+const mrActionUpdate = "update"
+
+// debounceKey identifies the MR a scheduled evaluation belongs to
+type debounceKey struct {
+	projectID int
+	mrIID     int
+}
+
+// scheduledUpdate is an evaluation waiting for its MR's debounce window to close
+type scheduledUpdate struct {
+	timer    *time.Timer
+	evaluate func()
+}
+
+// updateDebouncer coalesces bursts of update events: each event (re)starts its MR's timer, and
+// only the evaluation scheduled by the last event of a burst runs. Scheduled evaluations live in
+// memory, so the window only coalesces events delivered to the same instance.
+type updateDebouncer struct {
+	mu        sync.Mutex
+	delay     time.Duration
+	scheduled map[debounceKey]*scheduledUpdate // The latest update of each MR awaiting evaluation
+	pending   sync.WaitGroup                   // Scheduled and running evaluations
+}
+
+// newUpdateDebouncer creates a debouncer that waits delay after the last update of an MR
+func newUpdateDebouncer(delay time.Duration) *updateDebouncer {
+	return &updateDebouncer{
+		delay:     delay,
+		scheduled: make(map[debounceKey]*scheduledUpdate),
+	}
+}
+
+// schedule runs evaluate once no further update for the MR arrives within the delay,
+// cancelling the evaluation scheduled by the MR's previous update
+func (d *updateDebouncer) schedule(projectID, mrIID int, evaluate func()) {
+	key := debounceKey{projectID, mrIID}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if previous, ok := d.scheduled[key]; ok && previous.timer.Stop() {
+		d.pending.Done()
+		logging.MRInfo(mrIID, "Replaced scheduled evaluation with a newer update")
+	}
+	update := &scheduledUpdate{evaluate: evaluate}
+	d.pending.Add(1)
+	update.timer = time.AfterFunc(d.delay, func() { d.run(key, update) })
+	d.scheduled[key] = update
+}
+
+// run evaluates the MR unless a newer update was scheduled while this one was waiting to run.
+// It holds the MR's evaluation lock, so it never overlaps another evaluation of the MR.
+func (d *updateDebouncer) run(key debounceKey, update *scheduledUpdate) {
+	defer d.pending.Done()
+
+	unlock := evaluationLocks.lock(key.projectID, key.mrIID)
+	defer unlock()
+
+	d.mu.Lock()
+	latest := d.scheduled[key] == update
+	if latest {
+		delete(d.scheduled, key)
+	}
+	d.mu.Unlock()
+
+	if latest {
+		update.evaluate()
+	}
+}
+
+// wait blocks until scheduled evaluations have run
+func (d *updateDebouncer) wait() {
+	d.pending.Wait()
+}
+
+// flush runs every scheduled evaluation now instead of at the end of its window, and waits for them
+func (d *updateDebouncer) flush() {
+	d.mu.Lock()
+	for key, update := range d.scheduled {
+		if update.timer.Stop() {
+			go d.run(key, update)
+		}
+	}
+	d.mu.Unlock()
+
+	d.wait()
+}
+
+// evaluateScheduled evaluates an MR after its debounce window and applies the decision,
+// like reviewMR does for events evaluated immediately. The debouncer holds the MR's evaluation lock.
+func (h *DataProductConfigMrReviewHandler) evaluateScheduled(mrInfo *gitlab.MRInfo, correlation string) {
+	if h.evaluationSkipReason(mrInfo) != "" {
+		return
+	}
+
+	result, err := h.evaluateRules(mrInfo.ProjectID, mrInfo.MRIID, mrInfo)
+	if err != nil {
+		logging.MRError(mrInfo.MRIID, "Scheduled rule evaluation failed", err)
+		return
+	}
+	result.CorrelationID = correlation
+
+	logging.MRInfo(mrInfo.MRIID, "Decision",
+		zap.String("correlation_id", result.CorrelationID),
+		zap.String("type", string(result.FinalDecision.Type)),
+		zap.String("reason", result.FinalDecision.Reason),
+		zap.Duration("execution_time", result.ExecutionTime))
+
+	if _, err := h.applyDecision(result, mrInfo); err != nil {
+		logging.MRWarn(mrInfo.MRIID, "Failed to apply scheduled decision", zap.Error(err))
+	}
+}
+
+// DrainScheduledEvaluations runs the debounced evaluations still waiting for their window and
+// waits for them, so updates received just before shutdown are not dropped
+func (h *DataProductConfigMrReviewHandler) DrainScheduledEvaluations() {
+	if h.updateDebouncer == nil {
+		return
+	}
+	logging.Info("Running scheduled evaluations before shutdown")
+	h.updateDebouncer.flush()
+}
+
+// scheduleUpdate defers evaluation of an update event until the MR's debounce window closes
+func (h *DataProductConfigMrReviewHandler) scheduleUpdate(c *fiber.Ctx, mrInfo *gitlab.MRInfo) error {
+	// Header values point into fiber's request buffer, which is reused after the handler returns
+	correlation := strings.Clone(correlationID(c))
+	h.updateDebouncer.schedule(mrInfo.ProjectID, mrInfo.MRIID, func() {
+		h.evaluateScheduled(mrInfo, correlation)
+	})
+	logging.MRInfo(mrInfo.MRIID, "Scheduled evaluation after debounce window",
+		zap.Duration("delay", h.updateDebouncer.delay))
+
+	return c.JSON(fiber.Map{
+		"webhook_response": "processed",
+		"event_type":       "merge_request",
+		"decision":         "scheduled",
+		"reason":           "evaluation scheduled in " + h.updateDebouncer.delay.String() + "; later updates to the MR replace it",
+		"mr_approved":      false,
+		"project_id":       mrInfo.ProjectID,
+		"mr_iid":           mrInfo.MRIID,
+	})
+}
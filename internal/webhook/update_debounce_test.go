@@ -0,0 +1,198 @@
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/gitlab"
+)
+
+func TestUpdateDebouncer_CoalescesBurst(t *testing.T) {
+	debouncer := newUpdateDebouncer(20 * time.Millisecond)
+	var mu sync.Mutex
+	var evaluated []int
+
+	for i := 1; i <= 5; i++ {
+		update := i
+		debouncer.schedule(123, 456, func() {
+			mu.Lock()
+			defer mu.Unlock()
+			evaluated = append(evaluated, update)
+		})
+	}
+	debouncer.schedule(123, 789, func() {
+		mu.Lock()
+		defer mu.Unlock()
+		evaluated = append(evaluated, 789)
+	})
+	debouncer.wait()
+
+	assert.ElementsMatch(t, []int{5, 789}, evaluated, "only the last update of each MR is evaluated")
+	assert.Empty(t, debouncer.scheduled)
+}
+
+func TestUpdateDebouncer_UpdatesAfterWindowEvaluateAgain(t *testing.T) {
+	debouncer := newUpdateDebouncer(time.Millisecond)
+	evaluations := 0
+
+	debouncer.schedule(123, 456, func() { evaluations++ })
+	debouncer.wait()
+	debouncer.schedule(123, 456, func() { evaluations++ })
+	debouncer.wait()
+
+	assert.Equal(t, 2, evaluations)
+}
+
+func TestUpdateDebouncer_FlushRunsScheduledEvaluations(t *testing.T) {
+	debouncer := newUpdateDebouncer(time.Hour)
+	var mu sync.Mutex
+	var evaluated []int
+
+	for _, mrIID := range []int{456, 789} {
+		mrIID := mrIID
+		debouncer.schedule(123, mrIID, func() {
+			mu.Lock()
+			defer mu.Unlock()
+			evaluated = append(evaluated, mrIID)
+		})
+	}
+	debouncer.flush()
+
+	assert.ElementsMatch(t, []int{456, 789}, evaluated, "flush doesn't wait for the window to close")
+	assert.Empty(t, debouncer.scheduled)
+}
+
+func TestUpdateDebouncer_DifferentMRsEvaluateConcurrently(t *testing.T) {
+	debouncer := newUpdateDebouncer(time.Millisecond)
+	otherStarted := make(chan struct{})
+	var overlapped bool
+
+	debouncer.schedule(123, 456, func() {
+		select {
+		case <-otherStarted:
+			overlapped = true
+		case <-time.After(time.Second):
+		}
+	})
+	debouncer.schedule(123, 789, func() { close(otherStarted) })
+	debouncer.wait()
+
+	assert.True(t, overlapped, "one MR's evaluation must not block another MR's")
+	assert.Zero(t, evaluationLocks.held())
+}
+
+func TestUpdateDebouncer_SameMREvaluationsDoNotOverlap(t *testing.T) {
+	debouncer := newUpdateDebouncer(time.Hour)
+	var running, maxRunning int
+	var mu sync.Mutex
+	evaluate := func() {
+		mu.Lock()
+		running++
+		if running > maxRunning {
+			maxRunning = running
+		}
+		mu.Unlock()
+		time.Sleep(20 * time.Millisecond)
+		mu.Lock()
+		running--
+		mu.Unlock()
+	}
+
+	// Two evaluations of the same MR, e.g. one started by its timer while the next was flushed
+	key := debounceKey{123, 456}
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		update := &scheduledUpdate{evaluate: evaluate}
+		debouncer.mu.Lock()
+		debouncer.scheduled[key] = update
+		debouncer.mu.Unlock()
+		debouncer.pending.Add(1)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			debouncer.run(key, update)
+		}()
+		time.Sleep(5 * time.Millisecond)
+	}
+	wg.Wait()
+
+	assert.Equal(t, 1, maxRunning)
+}
+
+func TestWebhookHandler_HandleWebhook_UpdateDebounce(t *testing.T) {
+	setupTestRulesFile(t)
+	cfg := createTestConfig()
+	cfg.Webhook.UpdateDebounceSeconds = 1
+	mockClient := &MockGitLabClient{changes: noteCommandTestChanges}
+	handler := NewDataProductConfigMrReviewHandlerWithClient(cfg, mockClient)
+	handler.ruleManager = &MockRuleManagerForApproval{}
+	require.NotNil(t, handler.updateDebouncer)
+	handler.updateDebouncer.delay = 200 * time.Millisecond
+
+	app := createTestApp()
+	app.Post("/webhook", handler.HandleWebhook)
+
+	send := func(action string) map[string]interface{} {
+		payload := map[string]interface{}{
+			"object_kind": "merge_request",
+			"object_attributes": map[string]interface{}{
+				"iid":           456,
+				"action":        action,
+				"source_branch": "feature/update",
+				"target_branch": "main",
+				"state":         "opened",
+			},
+			"project": map[string]interface{}{"id": 123},
+			"user":    map[string]interface{}{"username": "testuser"},
+		}
+		jsonData, _ := json.Marshal(payload)
+		req := httptest.NewRequest("POST", "/webhook", bytes.NewReader(jsonData))
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		assert.Equal(t, 200, resp.StatusCode)
+		var response map[string]interface{}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&response))
+		return response
+	}
+
+	for i := 0; i < 5; i++ {
+		response := send("update")
+		assert.Equal(t, "scheduled", response["decision"])
+		assert.Equal(t, false, response["mr_approved"])
+	}
+
+	handler.updateDebouncer.wait()
+	assert.Equal(t, 1, mockClient.fetchChangesCalls, "a burst of updates is evaluated once")
+	assert.Len(t, mockClient.approvalMessages, 1)
+
+	// Other actions are evaluated immediately
+	response := send("open")
+	assert.Equal(t, true, response["mr_approved"])
+	assert.Equal(t, 2, mockClient.fetchChangesCalls)
+}
+
+func TestEvaluateScheduled_SimulatesWithoutToken(t *testing.T) {
+	setupTestRulesFile(t)
+	cfg := createTestConfig()
+	cfg.GitLab.Token = ""
+	cfg.GitLab.NoTokenMode = config.NoTokenModeSimulate
+	mockClient := &MockGitLabClient{changes: noteCommandTestChanges}
+	handler := NewDataProductConfigMrReviewHandlerWithClient(cfg, mockClient)
+	handler.ruleManager = &MockRuleManagerForApproval{}
+
+	handler.evaluateScheduled(&gitlab.MRInfo{ProjectID: 123, MRIID: 456, State: "opened"}, "correlation")
+
+	assert.Zero(t, mockClient.fetchChangesCalls, "simulated decisions fetch nothing")
+	assert.Empty(t, mockClient.approvalMessages)
+	assert.Empty(t, mockClient.addedComments)
+}
@@ -0,0 +1,146 @@
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	fiber "github.com/gofiber/fiber/v2"
+
+	"github.com/redhat-data-and-ai/naysayer/internal/config"
+	"github.com/redhat-data-and-ai/naysayer/internal/logging"
+)
+
+// redactedHeaders are replaced in captures so secrets never reach the capture directory
+var redactedHeaders = map[string]bool{
+	"X-Gitlab-Token": true,
+	"Authorization":  true,
+}
+
+// redactedValue replaces the value of redacted headers
+const redactedValue = "[REDACTED]"
+
+// WebhookCapture is a webhook delivery as written to WEBHOOK_CAPTURE_DIR
+type WebhookCapture struct {
+	CapturedAt time.Time         `json:"captured_at"`
+	Path       string            `json:"path"`
+	Headers    map[string]string `json:"headers"`
+	Body       json.RawMessage   `json:"body"`
+}
+
+// WebhookCapturer writes the raw body and headers of every webhook delivery to a directory
+// so a questionable decision can be replayed against a dev instance
+type WebhookCapturer struct {
+	dir string
+	now func() time.Time // Clock used for capture timestamps; defaults to time.Now
+}
+
+// NewWebhookCapturer creates a capturer from the webhook configuration; an empty capture dir disables it
+func NewWebhookCapturer(cfg *config.Config) *WebhookCapturer {
+	return &WebhookCapturer{
+		dir: cfg.Webhook.CaptureDir,
+		now: time.Now,
+	}
+}
+
+// Handle is a route middleware that captures the delivery and passes it on.
+// Capture failures are logged and never fail the webhook.
+func (w *WebhookCapturer) Handle(c *fiber.Ctx) error {
+	if w.dir == "" {
+		return c.Next()
+	}
+
+	capture := &WebhookCapture{
+		CapturedAt: w.now().UTC(),
+		Path:       c.Path(),
+		Headers:    make(map[string]string),
+		Body:       captureBody(c.Body()),
+	}
+	c.Request().Header.VisitAll(func(key, value []byte) {
+		name := http.CanonicalHeaderKey(string(key))
+		if redactedHeaders[name] {
+			capture.Headers[name] = redactedValue
+			return
+		}
+		capture.Headers[name] = string(value)
+	})
+
+	if path, err := w.write(capture); err != nil {
+		logging.Warn("Failed to capture webhook for %s: %v", c.Path(), err)
+	} else {
+		logging.Info("Captured webhook for %s to %s", c.Path(), path)
+	}
+	return c.Next()
+}
+
+// write stores capture as <timestamp>-<route>.json in the capture directory
+func (w *WebhookCapturer) write(capture *WebhookCapture) (string, error) {
+	data, err := json.MarshalIndent(capture, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(w.dir, 0750); err != nil {
+		return "", err
+	}
+
+	route := strings.ReplaceAll(strings.Trim(capture.Path, "/"), "/", "_")
+	name := fmt.Sprintf("%s-%s.json", capture.CapturedAt.Format("20060102T150405.000000000Z"), route)
+	path := filepath.Join(w.dir, name)
+	return path, os.WriteFile(path, data, 0600)
+}
+
+// captureBody keeps JSON bodies as-is and stores anything else as a JSON string
+func captureBody(body []byte) json.RawMessage {
+	if json.Valid(body) {
+		return append(json.RawMessage(nil), body...)
+	}
+	quoted, _ := json.Marshal(string(body))
+	return quoted
+}
+
+// LoadWebhookCapture reads a capture written by WebhookCapturer
+func LoadWebhookCapture(path string) (*WebhookCapture, error) {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read capture: %w", err)
+	}
+	var capture WebhookCapture
+	if err := json.Unmarshal(data, &capture); err != nil {
+		return nil, fmt.Errorf("failed to parse capture %s: %w", path, err)
+	}
+	if capture.Path == "" || len(capture.Body) == 0 {
+		return nil, fmt.Errorf("capture %s has no path or body", path)
+	}
+	return &capture, nil
+}
+
+// ReplayCapture feeds a captured delivery through handler and returns the response status and body.
+// Redacted headers are not sent, so handlers that check them must not be replayed.
+func ReplayCapture(handler fiber.Handler, capture *WebhookCapture) (int, []byte, error) {
+	app := fiber.New(fiber.Config{DisableStartupMessage: true})
+	app.Post(capture.Path, handler)
+
+	req, err := http.NewRequest(http.MethodPost, capture.Path, bytes.NewReader(capture.Body))
+	if err != nil {
+		return 0, nil, err
+	}
+	for name, value := range capture.Headers {
+		if value != redactedValue {
+			req.Header.Set(name, value)
+		}
+	}
+
+	resp, err := app.Test(req, -1)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	body, err := io.ReadAll(resp.Body)
+	return resp.StatusCode, body, err
+}
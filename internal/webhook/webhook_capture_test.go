@@ -0,0 +1,106 @@
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookCapturer_WritesFile(t *testing.T) {
+	cfg := createTestConfig()
+	cfg.Webhook.CaptureDir = filepath.Join(t.TempDir(), "captures")
+	capturer := NewWebhookCapturer(cfg)
+	capturer.now = func() time.Time { return time.Date(2026, 3, 4, 10, 30, 0, 0, time.UTC) }
+
+	nextCalled := false
+	app := createTestApp()
+	app.Post("/dataverse-product-config-review", capturer.Handle, func(c *fiber.Ctx) error {
+		nextCalled = true
+		return c.SendStatus(200)
+	})
+
+	body := []byte(`{"object_kind":"merge_request","object_attributes":{"iid":456}}`)
+	req := httptest.NewRequest("POST", "/dataverse-product-config-review", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Gitlab-Token", "super-secret")
+	req.Header.Set(EventUUIDHeader, "event-1")
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.True(t, nextCalled)
+
+	path := filepath.Join(cfg.Webhook.CaptureDir, "20260304T103000.000000000Z-dataverse-product-config-review.json")
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "super-secret")
+
+	capture, err := LoadWebhookCapture(path)
+	require.NoError(t, err)
+	assert.Equal(t, "/dataverse-product-config-review", capture.Path)
+	assert.JSONEq(t, string(body), string(capture.Body))
+	assert.Equal(t, "[REDACTED]", capture.Headers["X-Gitlab-Token"])
+	assert.Equal(t, "event-1", capture.Headers["X-Gitlab-Event-Uuid"])
+	assert.Equal(t, "application/json", capture.Headers["Content-Type"])
+}
+
+func TestWebhookCapturer_DisabledWritesNothing(t *testing.T) {
+	capturer := NewWebhookCapturer(createTestConfig())
+
+	app := createTestApp()
+	app.Post("/webhook", capturer.Handle, func(c *fiber.Ctx) error { return c.SendStatus(200) })
+	resp, err := app.Test(httptest.NewRequest("POST", "/webhook", bytes.NewReader([]byte(`{}`))))
+
+	require.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+}
+
+func TestReplayCapture_DryRunProducesDecision(t *testing.T) {
+	setupTestRulesFile(t)
+	mockClient := &MockGitLabClient{changes: noteCommandTestChanges}
+	handler := NewDataProductConfigMrReviewHandlerWithClient(createTestConfig(), mockClient)
+	handler.ruleManager = &MockRuleManagerForApproval{}
+	handler.EnableDryRun()
+
+	body, _ := json.Marshal(map[string]interface{}{
+		"object_kind": "merge_request",
+		"object_attributes": map[string]interface{}{
+			"iid":           456,
+			"title":         "Update product",
+			"source_branch": "feature/update",
+			"target_branch": "main",
+			"state":         "opened",
+		},
+		"project": map[string]interface{}{"id": 123},
+		"user":    map[string]interface{}{"username": "testuser"},
+	})
+	capture := &WebhookCapture{
+		Path:    "/dataverse-product-config-review",
+		Headers: map[string]string{"Content-Type": "application/json", "X-Gitlab-Token": "[REDACTED]"},
+		Body:    body,
+	}
+
+	status, responseBody, err := ReplayCapture(handler.HandleWebhook, capture)
+	require.NoError(t, err)
+	assert.Equal(t, 200, status)
+
+	var response map[string]interface{}
+	require.NoError(t, json.Unmarshal(responseBody, &response))
+	assert.Equal(t, true, response["dry_run"])
+	assert.Equal(t, false, response["mr_approved"])
+	decision, _ := response["decision"].(map[string]interface{})
+	assert.Equal(t, "approve", decision["type"])
+
+	// Dry run reads the MR but never writes to GitLab
+	assert.Equal(t, 1, mockClient.fetchChangesCalls)
+	assert.Empty(t, mockClient.approvalMessages)
+	assert.Empty(t, mockClient.addedComments)
+	assert.Empty(t, mockClient.addedLabels)
+}